@@ -685,6 +685,13 @@ func decodeUTXO(raw interface{}, expectedOutPoint *OutPoint) (*UTXO, error) {
 		for k, v := range outputRaw {
 			output[k] = v
 		}
+	} else {
+		// wes_getUTXO 按地址查询时返回扁平格式（amount / tokenID / height 与 outpoint 同级）
+		for _, k := range []string{"amount", "tokenID", "token_id", "height", "owner"} {
+			if v, ok := rawMap[k]; ok {
+				output[k] = v
+			}
+		}
 	}
 
 	// 解析 lockingCondition
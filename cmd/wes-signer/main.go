@@ -0,0 +1,181 @@
+// wes-signer 独立签名守护进程
+//
+// 从 Keystore 目录加载密钥，以策略钱包（wallet/policy）包装后在 Unix socket 或本机回环地址上
+// 提供签名 API（见 wallet/remote）。业务进程使用 remote.Client.Wallet 获取 wallet.Wallet，无需持有私钥。
+//
+// 策略设置原生币限额时需用 -node 指定节点，用于查询输入金额（隐含手续费计入外流）。
+//
+//	WES_SIGNER_PASSWORD=... wes-signer -keystore ./keystore -listen unix:/run/wes-signer.sock \
+//	    -token-file /etc/wes-signer/token -policy /etc/wes-signer/policy.json -node http://localhost:28680/jsonrpc
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/wallet"
+	"github.com/weisyn/client-sdk-go/wallet/policy"
+	"github.com/weisyn/client-sdk-go/wallet/remote"
+)
+
+func main() {
+	keystoreDir := flag.String("keystore", "", "Keystore 目录（必填）")
+	addresses := flag.String("address", "", "要加载的地址，逗号分隔（默认加载目录中全部 Keystore）")
+	listen := flag.String("listen", "127.0.0.1:7750", `监听地址："unix:/path/to.sock" 或本机回环 "host:port"`)
+	tokenFile := flag.String("token-file", "", "认证令牌文件（未指定时读取环境变量 WES_SIGNER_TOKEN）")
+	passwordFile := flag.String("password-file", "", "Keystore 密码文件（未指定时读取环境变量 WES_SIGNER_PASSWORD）")
+	policyFile := flag.String("policy", "", "签名策略 JSON 文件（未指定时不限制）")
+	auditLogPath := flag.String("audit-log", "wes-signer-audit.jsonl", "审计日志文件（JSON Lines）")
+	nodeEndpoint := flag.String("node", "", "节点 JSON-RPC 地址（策略设置原生币限额时必填，用于查询输入金额）")
+	flag.Parse()
+
+	if err := run(*keystoreDir, *addresses, *listen, *tokenFile, *passwordFile, *policyFile, *auditLogPath, *nodeEndpoint); err != nil {
+		log.Fatalf("wes-signer: %v", err)
+	}
+}
+
+func run(keystoreDir, addresses, listen, tokenFile, passwordFile, policyFile, auditLogPath, nodeEndpoint string) error {
+	if keystoreDir == "" {
+		return fmt.Errorf("-keystore is required")
+	}
+	token, err := secret(tokenFile, "WES_SIGNER_TOKEN")
+	if err != nil {
+		return fmt.Errorf("read token: %w", err)
+	}
+	password, err := secret(passwordFile, "WES_SIGNER_PASSWORD")
+	if err != nil {
+		return fmt.Errorf("read password: %w", err)
+	}
+
+	p := &policy.Policy{}
+	if policyFile != "" {
+		data, err := os.ReadFile(policyFile)
+		if err != nil {
+			return fmt.Errorf("read policy: %w", err)
+		}
+		if err := json.Unmarshal(data, p); err != nil {
+			return fmt.Errorf("parse policy: %w", err)
+		}
+	}
+	if p.RequiredConfirmations > 0 {
+		return fmt.Errorf("policy requires confirmations, but wes-signer has no approvers configured")
+	}
+
+	var node client.Client
+	if nodeEndpoint != "" {
+		if node, err = client.NewClient(&client.Config{Endpoint: nodeEndpoint, Protocol: client.ProtocolHTTP, Timeout: 30}); err != nil {
+			return fmt.Errorf("connect node: %w", err)
+		}
+		defer node.Close()
+	}
+
+	auditLog, err := policy.OpenFileAuditLog(auditLogPath)
+	if err != nil {
+		return err
+	}
+	defer auditLog.Close()
+
+	signers, err := loadSigners(keystoreDir, addresses, password, p, policy.Options{AuditLog: auditLog, Client: node})
+	if err != nil {
+		return err
+	}
+	server, err := remote.NewServer(token, signers...)
+	if err != nil {
+		return err
+	}
+
+	l, err := remote.Listen(listen)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	for _, k := range server.Keys() {
+		log.Printf("loaded key %s", k.Address)
+	}
+	log.Printf("listening on %s", listen)
+	if err := httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// loadSigners 解锁 Keystore 并以策略钱包包装（所有密钥共用同一策略、审计日志与节点客户端）
+func loadSigners(keystoreDir, addresses, password string, p *policy.Policy, opts policy.Options) ([]*policy.Wallet, error) {
+	km, err := wallet.NewKeystoreManager(keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	var list []string
+	if addresses != "" {
+		for _, a := range strings.Split(addresses, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				list = append(list, a)
+			}
+		}
+	} else {
+		infos, err := km.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			list = append(list, info.Address)
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("no keystore found in %s", keystoreDir)
+	}
+
+	signers := make([]*policy.Wallet, 0, len(list))
+	for _, address := range list {
+		w, err := km.Unlock(address, password, 0)
+		if err != nil {
+			return nil, fmt.Errorf("unlock %s: %w", address, err)
+		}
+		pw, err := policy.NewWallet(w, p, opts)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, pw)
+	}
+	return signers, nil
+}
+
+// secret 从文件（去除首尾空白）或环境变量读取密钥类配置
+func secret(path, env string) (string, error) {
+	if path == "" {
+		if v := os.Getenv(env); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("neither a file nor %s is set", env)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	v := strings.TrimSpace(string(data))
+	if v == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return v, nil
+}
@@ -41,18 +41,20 @@ func main() {
 	tokenService := token.NewService(httpClient)
 
 	// 4. 准备转账参数
-	fromAddr := wallet.Address()
-	toAddr := make([]byte, 20)
-	// 示例：设置接收地址（实际应该从用户输入获取）
-	copy(toAddr, []byte("recipient_address_here"))
+	fromAddr := types.MustAddressFromBytes(wallet.Address())
+	// 示例：设置接收地址（实际应该从用户输入获取，支持 Base58 / hex 格式）
+	toAddr, err := types.ParseAddress("0x0000000000000000000000000000000000000001")
+	if err != nil {
+		log.Fatalf("解析接收地址失败: %v", err)
+	}
 
 	// 5. 执行转账
 	ctx := context.Background()
 	result, err := tokenService.Transfer(ctx, &token.TransferRequest{
 		From:    fromAddr,
 		To:      toAddr,
-		Amount:  1000, // 转账金额
-		TokenID: nil,  // nil表示原生币
+		Amount:  types.NewAmount(1000), // 转账金额
+		TokenID: nil,                   // nil表示原生币
 	}, wallet)
 
 	if err != nil {
@@ -0,0 +1,101 @@
+// Package testnode 提供测试用的模拟节点逻辑：按交易草稿构造未签名交易并计算签名哈希
+//
+// 仅供各包测试中的 mock client 使用，实现的是节点行为的最小子集。
+package testnode
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// DraftToTx 按草稿构造未签名交易
+//
+// 输出按 type / output_type 构造：state 为状态输出，resource 为资源输出，其余为资产输出
+// （给出 token_id 时为合约代币，否则为原生币）。锁定条件由 utils.DraftLockingConditions 按草稿补全。
+func DraftToTx(draftJSON []byte) (*txcodec.Transaction, error) {
+	var draft struct {
+		Inputs []struct {
+			TxHash          string `json:"tx_hash"`
+			OutputIndex     uint32 `json:"output_index"`
+			IsReferenceOnly bool   `json:"is_reference_only"`
+		} `json:"inputs"`
+		Outputs []map[string]interface{} `json:"outputs"`
+	}
+	if err := json.Unmarshal(draftJSON, &draft); err != nil {
+		return nil, err
+	}
+
+	tx := &txcodec.Transaction{Version: 1}
+	for _, in := range draft.Inputs {
+		txID, err := decodeHex(in.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		tx.Inputs = append(tx.Inputs, &txcodec.TxInput{
+			PreviousOutput:  txcodec.OutPoint{TxID: txID, OutputIndex: in.OutputIndex},
+			IsReferenceOnly: in.IsReferenceOnly,
+		})
+	}
+	for i, out := range draft.Outputs {
+		owner, _ := decodeHex(stringField(out, "owner"))
+		o := &txcodec.TxOutput{Owner: owner}
+		kind := stringField(out, "type")
+		if kind == "" {
+			kind = stringField(out, "output_type")
+		}
+		switch kind {
+		case "state":
+			stateID, _ := decodeHex(stringField(out, "state_id"))
+			result, _ := decodeHex(stringField(out, "execution_result_hash"))
+			o.State = &txcodec.StateOutput{StateID: stateID, ExecutionResultHash: result}
+		case "resource":
+			o.Resource = &txcodec.ResourceOutput{}
+		default:
+			amount := stringField(out, "amount")
+			o.Asset = &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: amount}}
+			if tokenIDHex := stringField(out, "token_id"); tokenIDHex != "" {
+				tokenID, _ := decodeHex(tokenIDHex)
+				o.Asset = &txcodec.AssetOutput{ContractToken: &txcodec.ContractTokenAsset{FungibleClassID: tokenID, Amount: amount}}
+			}
+		}
+		locks, err := utils.DraftLockingConditions(owner, out)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		o.LockingConditions = locks
+		tx.Outputs = append(tx.Outputs, o)
+	}
+	return tx, nil
+}
+
+// ComputeSignatureHashFromDraft 模拟 wes_computeSignatureHashFromDraft（SIGHASH_ALL）
+func ComputeSignatureHashFromDraft(params interface{}) (interface{}, error) {
+	p := params.(map[string]interface{})
+	tx, err := DraftToTx(p["draft"].(json.RawMessage))
+	if err != nil {
+		return nil, err
+	}
+	hash, err := txcodec.ComputeSighash(tx, p["input_index"].(uint32), txcodec.SighashAll)
+	if err != nil {
+		return nil, err
+	}
+	txHex, err := txcodec.EncodeHex(tx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"hash": hex.EncodeToString(hash), "unsignedTx": txHex}, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
@@ -0,0 +1,381 @@
+// Package outbox 提供交易提交的持久化发件箱（Outbox Journal）
+//
+// **问题**：
+// 进程在 wes_finalizeTransactionFromDraft 与 SendRawTransaction 之间、
+// 或刚发送完毕时崩溃，会丢失"交易是否已广播"的信息。
+//
+// **方案**：
+// Outbox 包装 client.Client，在广播前先将已签名交易及本地计算的交易哈希写入持久化 Store，
+// 广播后更新状态。进程重启后调用 Recover 按交易哈希对账（GetTransaction），
+// 未上链的交易重新广播，从而实现至少一次（at-least-once）且幂等的提交。
+//
+// **使用示例**：
+//
+//	store, _ := outbox.NewFileStore("./data/outbox")
+//	ob := outbox.New(cli, store)
+//	_, _ = ob.Recover(ctx)                   // 启动时对账
+//	tokenService := token.NewService(ob)     // 业务服务透明地经过 Outbox 广播
+package outbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+// Status 发件箱条目状态
+type Status string
+
+const (
+	// StatusPending 已写入，尚未确认广播结果（可能未发送，也可能发送后崩溃）
+	StatusPending Status = "pending"
+	// StatusBroadcast 节点已接受，尚未确认上链
+	StatusBroadcast Status = "broadcast"
+	// StatusConfirmed 已上链确认
+	StatusConfirmed Status = "confirmed"
+	// StatusRejected 节点拒绝接受交易
+	StatusRejected Status = "rejected"
+	// StatusFailed 交易上链但执行失败
+	StatusFailed Status = "failed"
+)
+
+// IsFinal 是否为终态（终态条目不再重新广播）
+func (s Status) IsFinal() bool {
+	return s == StatusConfirmed || s == StatusRejected || s == StatusFailed
+}
+
+// ErrNotFound 条目不存在
+var ErrNotFound = errors.New("outbox entry not found")
+
+// Intent 交易意图（供对账与审计展示）
+type Intent struct {
+	// Operation 业务操作，如 "token.transfer"
+	Operation string `json:"operation,omitempty"`
+	// Description 人类可读描述
+	Description string `json:"description,omitempty"`
+	// Metadata 附加信息
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Entry 发件箱条目
+type Entry struct {
+	// ID 幂等键：SHA-256(已签名交易字节) 的 hex
+	ID string `json:"id"`
+	// SignedTx 已签名交易 hex
+	SignedTx string `json:"signed_tx"`
+	// TxHash 交易哈希（写入时由 TxHasher 本地计算，广播成功后以节点返回值为准）
+	TxHash string `json:"tx_hash,omitempty"`
+	// Intent 交易意图
+	Intent *Intent `json:"intent,omitempty"`
+	// Status 当前状态
+	Status Status `json:"status"`
+	// Attempts 广播次数
+	Attempts int `json:"attempts"`
+	// LastError 最近一次错误或拒绝原因
+	LastError string `json:"last_error,omitempty"`
+	// CreatedAt 创建时间
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store 发件箱持久化存储
+//
+// 实现必须是并发安全的，且 Put 返回前数据已落盘。
+type Store interface {
+	// Put 写入或覆盖条目
+	Put(ctx context.Context, entry *Entry) error
+	// Get 按 ID 查询条目，不存在时返回 ErrNotFound
+	Get(ctx context.Context, id string) (*Entry, error)
+	// List 列出指定状态的条目（未指定状态时返回全部），按创建时间升序
+	List(ctx context.Context, statuses ...Status) ([]*Entry, error)
+	// Delete 删除条目，不存在时不报错
+	Delete(ctx context.Context, id string) error
+}
+
+// EntryID 计算已签名交易的幂等键
+func EntryID(signedTxHex string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(signedTxHex, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("decode signed tx hex: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// TxHasher 本地计算已签名交易的交易哈希（须与节点 wes_getTransactionByHash 使用的哈希一致）
+type TxHasher func(signedTxHex string) (string, error)
+
+// DefaultTxHasher 使用 txcodec.ComputeTxHash 计算交易哈希（0x 前缀 hex）
+func DefaultTxHasher(signedTxHex string) (string, error) {
+	tx, err := txcodec.DecodeHex(signedTxHex)
+	if err != nil {
+		return "", fmt.Errorf("decode signed tx: %w", err)
+	}
+	hash, err := txcodec.ComputeTxHash(tx)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(hash), nil
+}
+
+type intentKey struct{}
+
+// WithIntent 为后续经 Outbox 广播的交易附加意图
+func WithIntent(ctx context.Context, intent *Intent) context.Context {
+	return context.WithValue(ctx, intentKey{}, intent)
+}
+
+func intentFromContext(ctx context.Context) *Intent {
+	intent, _ := ctx.Value(intentKey{}).(*Intent)
+	return intent
+}
+
+// Outbox 带持久化发件箱的 Client 包装
+//
+// 除 SendRawTransaction 外的方法直接透传给底层 Client。
+type Outbox struct {
+	client client.Client
+	store  Store
+	hasher TxHasher
+	now    func() time.Time
+}
+
+// New 创建 Outbox（使用 DefaultTxHasher）
+func New(c client.Client, store Store) *Outbox {
+	return &Outbox{
+		client: c,
+		store:  store,
+		hasher: DefaultTxHasher,
+		now:    time.Now,
+	}
+}
+
+// WithTxHasher 替换交易哈希算法（节点哈希算法与 DefaultTxHasher 不同时使用）
+func (o *Outbox) WithTxHasher(h TxHasher) *Outbox {
+	if h != nil {
+		o.hasher = h
+	}
+	return o
+}
+
+// Store 返回底层存储
+func (o *Outbox) Store() Store {
+	return o.store
+}
+
+// Call 透传 JSON-RPC 调用
+func (o *Outbox) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	return o.client.Call(ctx, method, params)
+}
+
+// Subscribe 透传事件订阅
+func (o *Outbox) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return o.client.Subscribe(ctx, filter)
+}
+
+// Close 关闭底层 Client
+func (o *Outbox) Close() error {
+	return o.client.Close()
+}
+
+// SendRawTransaction 先写入发件箱（含本地计算的交易哈希），再广播交易
+//
+// **幂等性**：同一笔已签名交易重复提交时复用已有条目；
+// 已确认上链的交易直接返回记录的结果，不再广播。
+// 无法计算交易哈希的交易不会写入也不会广播。
+func (o *Outbox) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	id, err := EntryID(signedTxHex)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := o.store.Get(ctx, id)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		txHash, err := o.hasher(signedTxHex)
+		if err != nil {
+			return nil, fmt.Errorf("compute tx hash failed: %w", err)
+		}
+		now := o.now()
+		entry = &Entry{
+			ID:        id,
+			SignedTx:  signedTxHex,
+			TxHash:    txHash,
+			Intent:    intentFromContext(ctx),
+			Status:    StatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		// 广播前必须先落盘
+		if err := o.store.Put(ctx, entry); err != nil {
+			return nil, fmt.Errorf("write outbox entry failed: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("read outbox entry failed: %w", err)
+	case entry.Status == StatusConfirmed:
+		return &client.SendTxResult{TxHash: entry.TxHash, Accepted: true}, nil
+	}
+
+	return o.broadcast(ctx, entry)
+}
+
+// broadcast 广播条目并更新状态
+func (o *Outbox) broadcast(ctx context.Context, entry *Entry) (*client.SendTxResult, error) {
+	entry.Attempts++
+	result, sendErr := o.client.SendRawTransaction(ctx, entry.SignedTx)
+
+	switch {
+	case sendErr != nil:
+		// 结果未知（可能已到达节点），保持 pending 等待对账
+		entry.LastError = sendErr.Error()
+	case result.Accepted:
+		entry.Status = StatusBroadcast
+		if result.TxHash != "" {
+			entry.TxHash = result.TxHash
+		}
+		entry.LastError = ""
+	default:
+		entry.Status = StatusRejected
+		if result.TxHash != "" {
+			entry.TxHash = result.TxHash
+		}
+		entry.LastError = result.Reason
+	}
+	entry.UpdatedAt = o.now()
+
+	if err := o.store.Put(ctx, entry); err != nil {
+		if sendErr != nil {
+			return nil, sendErr
+		}
+		return result, fmt.Errorf("update outbox entry failed: %w", err)
+	}
+	return result, sendErr
+}
+
+// RecoverResult 对账结果
+type RecoverResult struct {
+	// Confirmed 已确认上链的条目
+	Confirmed []*Entry
+	// Failed 上链但执行失败的条目
+	Failed []*Entry
+	// Rebroadcast 重新广播且被节点接受的条目
+	Rebroadcast []*Entry
+	// Rejected 重新广播被节点拒绝的条目
+	Rejected []*Entry
+	// Pending 仍未得到结果的条目（如网络错误），下次对账继续处理
+	Pending []*Entry
+}
+
+// Recover 对所有未终结的条目进行对账
+//
+// **流程**：
+//  1. 按交易哈希调用 GetTransaction 查询（旧条目没有哈希时先本地计算），
+//     已确认 / 已失败的条目直接更新状态；节点仍在处理中的条目保持原状态
+//  2. 查询不到：重新广播同一笔已签名交易（幂等）
+//  3. 重新广播被拒绝时再查询一次，期间已上链的交易按上链结果处理，不标记为 rejected
+//
+// 单个条目出错不会中断对账，仍未处理的条目归入 Pending。
+func (o *Outbox) Recover(ctx context.Context) (*RecoverResult, error) {
+	entries, err := o.store.List(ctx, StatusPending, StatusBroadcast)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox entries failed: %w", err)
+	}
+
+	wes := client.NewWESClientFromClient(o.client)
+	result := &RecoverResult{}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if entry.TxHash == "" {
+			txHash, err := o.hasher(entry.SignedTx)
+			if err != nil {
+				entry.LastError = fmt.Sprintf("compute tx hash failed: %v", err)
+				result.Pending = append(result.Pending, entry)
+				continue
+			}
+			entry.TxHash = txHash
+		}
+		if o.reconcile(ctx, wes, entry, result) {
+			continue
+		}
+
+		sendResult, err := o.broadcast(ctx, entry)
+		switch {
+		case err != nil:
+			result.Pending = append(result.Pending, entry)
+		case sendResult.Accepted:
+			result.Rebroadcast = append(result.Rebroadcast, entry)
+		case o.reconcile(ctx, wes, entry, result):
+		default:
+			result.Rejected = append(result.Rejected, entry)
+		}
+	}
+
+	return result, nil
+}
+
+// reconcile 按交易哈希查询上链状态并归类条目；节点查询不到交易时返回 false
+func (o *Outbox) reconcile(ctx context.Context, wes client.WESClient, entry *Entry, result *RecoverResult) bool {
+	info, err := wes.GetTransaction(ctx, entry.TxHash)
+	if err != nil || info == nil {
+		return false
+	}
+	switch info.Status {
+	case client.TransactionStatusConfirmed:
+		o.finalize(ctx, entry, StatusConfirmed, "")
+		result.Confirmed = append(result.Confirmed, entry)
+	case client.TransactionStatusFailed:
+		o.finalize(ctx, entry, StatusFailed, "transaction failed on chain")
+		result.Failed = append(result.Failed, entry)
+	default:
+		// 节点已知但未确认，无需重新广播；被拒绝的重播恢复为 broadcast
+		if entry.Status == StatusRejected {
+			o.finalize(ctx, entry, StatusBroadcast, "")
+		}
+		result.Pending = append(result.Pending, entry)
+	}
+	return true
+}
+
+// finalize 更新条目状态（持久化失败时保持原状态，下次对账重试）
+func (o *Outbox) finalize(ctx context.Context, entry *Entry, status Status, reason string) {
+	prev := entry.Status
+	entry.Status = status
+	entry.LastError = reason
+	entry.UpdatedAt = o.now()
+	if err := o.store.Put(ctx, entry); err != nil {
+		entry.Status = prev
+		entry.LastError = err.Error()
+	}
+}
+
+// Prune 删除早于 before 的终态条目
+func (o *Outbox) Prune(ctx context.Context, before time.Time) (int, error) {
+	entries, err := o.store.List(ctx, StatusConfirmed, StatusRejected, StatusFailed)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.UpdatedAt.Before(before) {
+			if err := o.store.Delete(ctx, entry.ID); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+var _ client.Client = (*Outbox)(nil)
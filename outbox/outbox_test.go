@@ -0,0 +1,231 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+)
+
+// mockClient 模拟节点广播与交易查询
+type mockClient struct {
+	sendErr  error
+	reject   string
+	sent     []string
+	onChain  map[string]string // txHash -> status
+	mined    string            // 非空时广播的交易以该状态按本地哈希上链
+	getCalls int
+}
+
+func (m *mockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if method != "wes_getTransactionByHash" {
+		return nil, fmt.Errorf("unexpected method %s", method)
+	}
+	m.getCalls++
+	hash := params.([]interface{})[0].(string)
+	status, ok := m.onChain[hash]
+	if !ok {
+		return nil, nil
+	}
+	return map[string]interface{}{"hash": hash, "status": status}, nil
+}
+
+func (m *mockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent = append(m.sent, signedTxHex)
+	if m.mined != "" {
+		hash, _ := DefaultTxHasher(signedTxHex)
+		m.onChain[hash] = m.mined
+	}
+	if m.sendErr != nil {
+		return nil, m.sendErr
+	}
+	if m.reject != "" {
+		return &client.SendTxResult{Accepted: false, Reason: m.reject}, nil
+	}
+	return &client.SendTxResult{TxHash: "0xhash-" + signedTxHex, Accepted: true}, nil
+}
+
+func (m *mockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockClient) Close() error { return nil }
+
+func TestSendRawTransactionJournals(t *testing.T) {
+	ctx := context.Background()
+	mock := &mockClient{}
+	store := NewMemoryStore()
+	ob := New(mock, store)
+
+	intent := &Intent{Operation: "token.transfer", Metadata: map[string]string{"to": "abc"}}
+	result, err := ob.SendRawTransaction(WithIntent(ctx, intent), "0801")
+	if err != nil || !result.Accepted {
+		t.Fatalf("SendRawTransaction() = %+v, %v", result, err)
+	}
+
+	id, _ := EntryID("0801")
+	entry, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("store.Get() error = %v", err)
+	}
+	if entry.Status != StatusBroadcast || entry.TxHash != "0xhash-0801" || entry.Attempts != 1 {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Intent == nil || entry.Intent.Operation != "token.transfer" {
+		t.Errorf("entry.Intent = %+v", entry.Intent)
+	}
+}
+
+func TestSendRawTransactionIdempotent(t *testing.T) {
+	ctx := context.Background()
+	mock := &mockClient{onChain: map[string]string{}}
+	ob := New(mock, NewMemoryStore())
+
+	if _, err := ob.SendRawTransaction(ctx, "0802"); err != nil {
+		t.Fatalf("SendRawTransaction() error = %v", err)
+	}
+	mock.onChain["0xhash-0802"] = "confirmed"
+	if _, err := ob.Recover(ctx); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+
+	// 已确认的交易再次提交不会重新广播
+	result, err := ob.SendRawTransaction(ctx, "0802")
+	if err != nil || result.TxHash != "0xhash-0802" {
+		t.Fatalf("SendRawTransaction() = %+v, %v", result, err)
+	}
+	if len(mock.sent) != 1 {
+		t.Errorf("broadcast count = %d, want 1", len(mock.sent))
+	}
+}
+
+func TestRecoverAfterCrash(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	// 第一次运行：广播时网络中断，结果未知
+	crashed := &mockClient{sendErr: errors.New("connection reset")}
+	if _, err := New(crashed, store).SendRawTransaction(ctx, "0803"); err == nil {
+		t.Fatalf("SendRawTransaction() error = nil, want network error")
+	}
+	// 另一笔交易已被接受并已上链
+	if _, err := New(&mockClient{}, store).SendRawTransaction(ctx, "0804"); err != nil {
+		t.Fatalf("SendRawTransaction() error = %v", err)
+	}
+
+	// 重启后对账
+	mock := &mockClient{onChain: map[string]string{"0xhash-0804": "confirmed"}}
+	result, err := New(mock, store).Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if len(result.Rebroadcast) != 1 || result.Rebroadcast[0].SignedTx != "0803" {
+		t.Errorf("Rebroadcast = %+v", result.Rebroadcast)
+	}
+	if len(result.Confirmed) != 1 || result.Confirmed[0].SignedTx != "0804" {
+		t.Errorf("Confirmed = %+v", result.Confirmed)
+	}
+	if len(mock.sent) != 1 || mock.sent[0] != "0803" {
+		t.Errorf("sent = %v, want only 0803", mock.sent)
+	}
+
+	id, _ := EntryID("0803")
+	entry, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("store.Get() error = %v", err)
+	}
+	if entry.Status != StatusBroadcast || entry.Attempts != 2 {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	// 终态条目可被清理
+	removed, err := New(mock, store).Prune(ctx, time.Now().Add(time.Minute))
+	if err != nil || removed != 1 {
+		t.Errorf("Prune() = %d, %v; want 1", removed, err)
+	}
+}
+
+func TestRecoverRejected(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if _, err := New(&mockClient{sendErr: errors.New("timeout")}, store).SendRawTransaction(ctx, "0805"); err == nil {
+		t.Fatalf("SendRawTransaction() error = nil")
+	}
+
+	result, err := New(&mockClient{reject: "double spend"}, store).Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].LastError != "double spend" {
+		t.Errorf("Rejected = %+v", result.Rejected)
+	}
+
+	pending, _ := store.List(ctx, StatusPending, StatusBroadcast)
+	if len(pending) != 0 {
+		t.Errorf("pending entries = %d, want 0", len(pending))
+	}
+}
+
+func TestRecoverMinedWithoutNodeHash(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	// 广播后连接中断：交易实际已上链，但没有拿到节点返回的哈希
+	mock := &mockClient{sendErr: errors.New("connection reset"), mined: "confirmed", onChain: map[string]string{}}
+	if _, err := New(mock, store).SendRawTransaction(ctx, "0806"); err == nil {
+		t.Fatalf("SendRawTransaction() error = nil")
+	}
+	id, _ := EntryID("0806")
+	entry, _ := store.Get(ctx, id)
+	localHash, _ := DefaultTxHasher("0806")
+	if entry.TxHash != localHash {
+		t.Fatalf("entry.TxHash = %q, want local hash %q", entry.TxHash, localHash)
+	}
+
+	mock.sendErr, mock.mined = nil, ""
+	result, err := New(mock, store).Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if len(result.Confirmed) != 1 || len(mock.sent) != 1 {
+		t.Errorf("Confirmed = %+v, sent = %v; want confirmed without rebroadcast", result.Confirmed, mock.sent)
+	}
+
+	// 重播被拒绝（节点已收录）时按上链结果处理，不标记为 rejected
+	if _, err := New(&mockClient{sendErr: errors.New("timeout")}, store).SendRawTransaction(ctx, "0807"); err == nil {
+		t.Fatalf("SendRawTransaction() error = nil")
+	}
+	mock = &mockClient{reject: "already exists", mined: "pending", onChain: map[string]string{}}
+	result, err = New(mock, store).Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	id, _ = EntryID("0807")
+	entry, _ = store.Get(ctx, id)
+	if len(result.Rejected) != 0 || len(result.Pending) != 1 || entry.Status != StatusBroadcast {
+		t.Errorf("result = %+v, entry = %+v", result, entry)
+	}
+
+	if _, err := New(mock, store).SendRawTransaction(ctx, "0a01"); err == nil {
+		t.Errorf("SendRawTransaction() error = nil for undecodable tx")
+	}
+}
+
+func TestFileStoreRejectsInvalidID(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := store.Put(context.Background(), &Entry{ID: "../escape"}); err == nil {
+		t.Errorf("Put() error = nil for path traversal id")
+	}
+	if _, err := store.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
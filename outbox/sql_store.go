@@ -0,0 +1,168 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SQLStore 基于 database/sql 的 SQLite 存储
+//
+// SDK 不内置数据库驱动，由调用方注册驱动并传入 *sql.DB，例如：
+//
+//	import _ "modernc.org/sqlite"
+//	db, _ := sql.Open("sqlite", "outbox.db")
+//	store, _ := outbox.NewSQLStore(ctx, db, "")
+//
+// 表结构与写入语句（TEXT 主键、CREATE INDEX IF NOT EXISTS、INSERT OR REPLACE）为 SQLite 方言，
+// 不适用于 MySQL / PostgreSQL；其他数据库请自行实现 Store 接口。
+type SQLStore struct {
+	db    *sql.DB
+	table string
+}
+
+// DefaultSQLTable 默认表名
+const DefaultSQLTable = "wes_outbox"
+
+var sqlIdentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewSQLStore 创建 SQL 存储并确保表结构存在
+// table 为空时使用 DefaultSQLTable
+func NewSQLStore(ctx context.Context, db *sql.DB, table string) (*SQLStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is nil")
+	}
+	if table == "" {
+		table = DefaultSQLTable
+	}
+	if !sqlIdentPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %q", table)
+	}
+
+	s := &SQLStore{db: db, table: table}
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	signed_tx TEXT NOT NULL,
+	tx_hash TEXT NOT NULL DEFAULT '',
+	intent TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL
+)`, table)
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return nil, fmt.Errorf("create outbox table: %w", err)
+	}
+	index := fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_status ON %s (status)`, table, table)
+	if _, err := db.ExecContext(ctx, index); err != nil {
+		return nil, fmt.Errorf("create outbox index: %w", err)
+	}
+	return s, nil
+}
+
+// Put 写入或覆盖条目
+func (s *SQLStore) Put(ctx context.Context, entry *Entry) error {
+	intent := ""
+	if entry.Intent != nil {
+		data, err := json.Marshal(entry.Intent)
+		if err != nil {
+			return fmt.Errorf("marshal intent: %w", err)
+		}
+		intent = string(data)
+	}
+
+	query := fmt.Sprintf(`INSERT OR REPLACE INTO %s
+	(id, signed_tx, tx_hash, intent, status, attempts, last_error, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, s.table)
+	_, err := s.db.ExecContext(ctx, query,
+		entry.ID, entry.SignedTx, entry.TxHash, intent, string(entry.Status),
+		entry.Attempts, entry.LastError, entry.CreatedAt.UnixNano(), entry.UpdatedAt.UnixNano())
+	if err != nil {
+		return fmt.Errorf("put outbox entry: %w", err)
+	}
+	return nil
+}
+
+// Get 按 ID 查询条目
+func (s *SQLStore) Get(ctx context.Context, id string) (*Entry, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = ?`, sqlColumns, s.table)
+	entry, err := scanEntry(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return entry, err
+}
+
+// List 列出指定状态的条目
+func (s *SQLStore) List(ctx context.Context, statuses ...Status) ([]*Entry, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s`, sqlColumns, s.table)
+	args := make([]interface{}, 0, len(statuses))
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, st := range statuses {
+			placeholders[i] = "?"
+			args = append(args, string(st))
+		}
+		query += ` WHERE status IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	query += ` ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entry)
+	}
+	return out, rows.Err()
+}
+
+// Delete 删除条目
+func (s *SQLStore) Delete(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, s.table)
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete outbox entry: %w", err)
+	}
+	return nil
+}
+
+const sqlColumns = `id, signed_tx, tx_hash, intent, status, attempts, last_error, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEntry(row rowScanner) (*Entry, error) {
+	var (
+		entry              Entry
+		intent, status     string
+		createdAt, updated int64
+	)
+	if err := row.Scan(&entry.ID, &entry.SignedTx, &entry.TxHash, &intent, &status,
+		&entry.Attempts, &entry.LastError, &createdAt, &updated); err != nil {
+		return nil, err
+	}
+	if intent != "" {
+		entry.Intent = &Intent{}
+		if err := json.Unmarshal([]byte(intent), entry.Intent); err != nil {
+			return nil, fmt.Errorf("decode intent: %w", err)
+		}
+	}
+	entry.Status = Status(status)
+	entry.CreatedAt = time.Unix(0, createdAt)
+	entry.UpdatedAt = time.Unix(0, updated)
+	return &entry, nil
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestSQLStore(t *testing.T) *SQLStore {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	// 每个连接都是独立的内存数据库，限制为单连接
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLStore(context.Background(), db, "")
+	if err != nil {
+		t.Fatalf("NewSQLStore() error = %v", err)
+	}
+	return store
+}
+
+func TestSQLStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLStore(t)
+
+	created := time.Date(2024, 5, 1, 8, 30, 0, 123456789, time.UTC)
+	entry := &Entry{
+		ID:       "aa01",
+		SignedTx: "0a01",
+		TxHash:   "0xhash",
+		Intent: &Intent{
+			Operation:   "token.transfer",
+			Description: "pay invoice #42",
+			Metadata:    map[string]string{"invoice": "42"},
+		},
+		Status:    StatusBroadcast,
+		Attempts:  2,
+		LastError: "timeout",
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Minute),
+	}
+	if err := store.Put(ctx, entry); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, err := store.Get(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(entry.CreatedAt) || !got.UpdatedAt.Equal(entry.UpdatedAt) {
+		t.Errorf("timestamps = %v / %v, want %v / %v", got.CreatedAt, got.UpdatedAt, entry.CreatedAt, entry.UpdatedAt)
+	}
+	got.CreatedAt, got.UpdatedAt = entry.CreatedAt, entry.UpdatedAt
+	if !reflect.DeepEqual(got, entry) {
+		t.Errorf("Get() = %+v, want %+v", got, entry)
+	}
+
+	// 覆盖写入：无意图的条目读回 Intent 为 nil
+	entry.Intent = nil
+	entry.Status = StatusConfirmed
+	if err := store.Put(ctx, entry); err != nil {
+		t.Fatalf("Put() overwrite error = %v", err)
+	}
+	if got, _ := store.Get(ctx, entry.ID); got.Intent != nil || got.Status != StatusConfirmed {
+		t.Errorf("Get() after overwrite = %+v", got)
+	}
+
+	if err := store.Delete(ctx, entry.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, entry.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLStoreListByStatus(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLStore(t)
+
+	base := time.Unix(1700000000, 0)
+	entries := []struct {
+		id     string
+		status Status
+		offset time.Duration
+	}{
+		{id: "c", status: StatusPending, offset: 2 * time.Second},
+		{id: "a", status: StatusBroadcast, offset: time.Second},
+		{id: "b", status: StatusPending, offset: 0},
+		{id: "d", status: StatusRejected, offset: 3 * time.Second},
+	}
+	for _, e := range entries {
+		at := base.Add(e.offset)
+		if err := store.Put(ctx, &Entry{ID: e.id, SignedTx: "0a01", Status: e.status, CreatedAt: at, UpdatedAt: at}); err != nil {
+			t.Fatalf("Put(%s) error = %v", e.id, err)
+		}
+	}
+
+	ids := func(list []*Entry) []string {
+		out := make([]string, len(list))
+		for i, e := range list {
+			out[i] = e.ID
+		}
+		return out
+	}
+	tests := []struct {
+		name     string
+		statuses []Status
+		want     []string
+	}{
+		{name: "all", want: []string{"b", "a", "c", "d"}},
+		{name: "pending", statuses: []Status{StatusPending}, want: []string{"b", "c"}},
+		{name: "pending or broadcast", statuses: []Status{StatusPending, StatusBroadcast}, want: []string{"b", "a", "c"}},
+		{name: "none", statuses: []Status{StatusConfirmed}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := store.List(ctx, tt.statuses...)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := ids(list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSQLStoreRejectsInvalidTable(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := NewSQLStore(context.Background(), db, "outbox; DROP TABLE x"); err == nil {
+		t.Errorf("NewSQLStore() error = nil for invalid table name")
+	}
+}
@@ -0,0 +1,238 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore 内存存储（仅用于测试，进程退出后数据丢失）
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*Entry)}
+}
+
+// Put 写入或覆盖条目
+func (s *MemoryStore) Put(ctx context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = cloneEntry(entry)
+	return nil
+}
+
+// Get 按 ID 查询条目
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cloneEntry(entry), nil
+}
+
+// List 列出指定状态的条目
+func (s *MemoryStore) List(ctx context.Context, statuses ...Status) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*Entry
+	for _, entry := range s.entries {
+		if matchStatus(entry.Status, statuses) {
+			out = append(out, cloneEntry(entry))
+		}
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+// Delete 删除条目
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// FileStore 文件存储：每个条目一个 JSON 文件（<id>.json）
+//
+// 写入采用"临时文件 + fsync + rename"，保证崩溃时不会留下半写的条目。
+type FileStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFileStore 创建文件存储，目录不存在时自动创建
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create outbox directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put 写入或覆盖条目
+func (s *FileStore) Put(ctx context.Context, entry *Entry) error {
+	if err := validateEntryID(entry.ID); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal outbox entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-"+entry.ID+"-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // rename 成功后为空操作
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, s.path(entry.ID)); err != nil {
+		return fmt.Errorf("rename outbox entry: %w", err)
+	}
+	return syncDir(s.dir)
+}
+
+// Get 按 ID 查询条目
+func (s *FileStore) Get(ctx context.Context, id string) (*Entry, error) {
+	if err := validateEntryID(id); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(s.path(id))
+}
+
+// List 列出指定状态的条目
+func (s *FileStore) List(ctx context.Context, statuses ...Status) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []*Entry
+	for _, file := range files {
+		entry, err := s.read(file)
+		if err != nil {
+			return nil, err
+		}
+		if matchStatus(entry.Status, statuses) {
+			out = append(out, entry)
+		}
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+// Delete 删除条目
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	if err := validateEntryID(id); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete outbox entry: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *FileStore) read(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("read outbox entry: %w", err)
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("decode outbox entry %s: %w", filepath.Base(path), err)
+	}
+	return &entry, nil
+}
+
+// syncDir 同步目录元数据，确保 rename 持久化
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	// 部分平台（如 Windows）不支持目录 fsync，忽略该错误
+	_ = d.Sync()
+	return nil
+}
+
+// validateEntryID 防止 ID 中包含路径分隔符
+func validateEntryID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return fmt.Errorf("invalid outbox entry id: %q", id)
+	}
+	return nil
+}
+
+func matchStatus(status Status, statuses []Status) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func sortEntries(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+}
+
+func cloneEntry(entry *Entry) *Entry {
+	cp := *entry
+	if entry.Intent != nil {
+		intent := *entry.Intent
+		if entry.Intent.Metadata != nil {
+			intent.Metadata = make(map[string]string, len(entry.Intent.Metadata))
+			for k, v := range entry.Intent.Metadata {
+				intent.Metadata[k] = v
+			}
+		}
+		cp.Intent = &intent
+	}
+	return &cp
+}
@@ -0,0 +1,249 @@
+// Package payout 提供批量发放（工资 / 空投）引擎
+//
+// **流程**：
+//  1. ReadCSV / ReadJSON 读取收款列表，Prepare 校验地址（types.ParseAddress）并去重
+//  2. Engine.Run 将收款人分组，每组通过 token.Service.BatchTransfer 提交一笔（或多笔）交易
+//  3. 每组提交前后将进度写入 StateStore，进程崩溃后重新 Run 会从中断处继续
+//  4. Reconcile 按交易回执逐一核对，生成对账报告
+//
+// **重复支付保护**：
+// 只有确定未广播的失败（Transferer 返回满足 errors.Is(err, token.ErrNotSubmitted) 的错误）
+// 标记为 ItemFailed，可通过 Config.RetryFailed 重试。提交请求出错（网络错误、超时）、
+// 结果缺少交易哈希或提交过程中崩溃的分组无法确定是否已上链，标记为 ItemUnknown，
+// Reconcile 报告为 unknown，RetryFailed 也不会重试。需人工核对付款地址的链上记录，
+// 确认未上链后调用 State.MarkNotSubmitted 并保存进度，再设置 Config.RetryFailed 重试。
+//
+// **使用示例**：
+//
+//	recipients, _ := payout.ReadCSV(file)
+//	plan := payout.Prepare(recipients, payout.DedupeSkip)
+//	store, _ := payout.NewFileStateStore("./data/payout")
+//	engine := payout.New(tokenService, payout.Config{From: addr, Store: store})
+//	state, err := engine.Run(ctx, plan)
+//	report, _ := payout.Reconcile(ctx, cli, plan, state)
+//	_ = report.WriteCSV(os.Stdout)
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/services/token"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// DefaultChunkSize 每组默认收款人数
+const DefaultChunkSize = 100
+
+// Transferer 批量转账接口（token.Service 满足该接口）
+type Transferer interface {
+	BatchTransfer(ctx context.Context, req *token.BatchTransferRequest, wallets ...wallet.Wallet) (*token.BatchTransferResult, error)
+}
+
+// Config 发放引擎配置
+type Config struct {
+	From            types.Address // 付款地址
+	Wallet          wallet.Wallet // 签名 Wallet（可选，nil 时使用 Transferer 的默认 Wallet）
+	ChunkSize       int           // 每组收款人数（0 表示 DefaultChunkSize）
+	MaxOutputsPerTx int           // 透传给 BatchTransferRequest.MaxOutputsPerTx
+	MaxInputsPerTx  int           // 透传给 BatchTransferRequest.MaxInputsPerTx
+	Store           StateStore    // 进度存储（nil 时使用内存存储，无法断点续发）
+	RetryFailed     bool          // 是否重试 ItemFailed 的收款人（ItemUnknown 不会重试）
+
+	// OnProgress 进度回调（每组处理完成后调用）
+	OnProgress func(progress utils.BatchProgress)
+}
+
+// Engine 发放引擎
+type Engine struct {
+	transferer Transferer
+	cfg        Config
+	now        func() time.Time
+}
+
+// New 创建发放引擎
+func New(transferer Transferer, cfg Config) *Engine {
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStateStore()
+	}
+	return &Engine{transferer: transferer, cfg: cfg, now: time.Now}
+}
+
+// Run 执行发放计划，返回最新进度
+//
+// 已提交的收款人不会重复发放；某组失败时记录错误并继续处理后续分组。
+// 全部完成且无失败时返回 nil 错误，否则返回汇总错误（进度已持久化）。
+func (e *Engine) Run(ctx context.Context, plan *Plan) (*State, error) {
+	// 1. 参数验证
+	if plan == nil || len(plan.Recipients) == 0 {
+		return nil, fmt.Errorf("plan has no recipients")
+	}
+	if e.cfg.From.IsZero() {
+		return nil, fmt.Errorf("from address is required")
+	}
+
+	// 2. 加载或初始化进度
+	state, err := e.loadState(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 分组处理待发放的收款人
+	total := len(plan.Recipients)
+	for _, chunk := range e.pendingChunks(state) {
+		if err := ctx.Err(); err != nil {
+			return state, err
+		}
+		if err := e.runChunk(ctx, plan, state, chunk); err != nil {
+			return state, err
+		}
+
+		if e.cfg.OnProgress != nil {
+			submitted := state.Count(ItemSubmitted)
+			failed := state.Count(ItemFailed) + state.Count(ItemUnknown)
+			e.cfg.OnProgress(utils.BatchProgress{
+				Completed:  submitted + failed,
+				Total:      total,
+				Percentage: (submitted + failed) * 100 / total,
+				Success:    submitted,
+				Failed:     failed,
+			})
+		}
+	}
+
+	// 4. 汇总结果
+	failed, unknown := state.Count(ItemFailed), state.Count(ItemUnknown)
+	if unknown > 0 {
+		return state, fmt.Errorf("%d of %d recipients failed, %d in unknown state after interruption", failed, total, unknown)
+	}
+	if failed > 0 {
+		return state, fmt.Errorf("%d of %d recipients failed", failed, total)
+	}
+	return state, nil
+}
+
+// loadState 加载进度；上次中断时处于 in_flight 的收款人标记为 unknown
+func (e *Engine) loadState(ctx context.Context, plan *Plan) (*State, error) {
+	state, err := e.cfg.Store.Load(ctx, plan.ID)
+	if errors.Is(err, ErrStateNotFound) {
+		now := e.now()
+		state = &State{
+			PlanID:    plan.ID,
+			Items:     make([]ItemState, len(plan.Recipients)),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		for i := range state.Items {
+			state.Items[i].Status = ItemPending
+		}
+		return state, e.save(ctx, state)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load payout state failed: %w", err)
+	}
+	if len(state.Items) != len(plan.Recipients) {
+		return nil, fmt.Errorf("payout state does not match plan: %d items, %d recipients", len(state.Items), len(plan.Recipients))
+	}
+
+	interrupted := false
+	for i := range state.Items {
+		if state.Items[i].Status == ItemInFlight {
+			state.Items[i].Status = ItemUnknown
+			state.Items[i].Error = "interrupted before result was recorded; may be on chain"
+			interrupted = true
+		}
+	}
+	if interrupted {
+		if err := e.save(ctx, state); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// pendingChunks 按 ChunkSize 对待发放的收款人分组
+func (e *Engine) pendingChunks(state *State) [][]int {
+	var chunks [][]int
+	var current []int
+	for i, item := range state.Items {
+		if item.Status != ItemPending && !(item.Status == ItemFailed && e.cfg.RetryFailed) {
+			continue
+		}
+		current = append(current, i)
+		if len(current) == e.cfg.ChunkSize {
+			chunks = append(chunks, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// runChunk 提交一组收款人，仅在进度无法持久化时返回错误
+func (e *Engine) runChunk(ctx context.Context, plan *Plan, state *State, chunk []int) error {
+	// 1. 提交前标记 in_flight 并持久化
+	req := &token.BatchTransferRequest{
+		From:            e.cfg.From,
+		Transfers:       make([]token.TransferItem, len(chunk)),
+		MaxOutputsPerTx: e.cfg.MaxOutputsPerTx,
+		MaxInputsPerTx:  e.cfg.MaxInputsPerTx,
+	}
+	for j, idx := range chunk {
+		r := plan.Recipients[idx]
+		req.Transfers[j] = token.TransferItem{
+			To:      r.address,
+			Amount:  r.Amount,
+			TokenID: r.TokenIDBytes(),
+		}
+		state.Items[idx] = ItemState{Status: ItemInFlight}
+	}
+	if err := e.save(ctx, state); err != nil {
+		return err
+	}
+
+	// 2. 提交批量转账
+	var wallets []wallet.Wallet
+	if e.cfg.Wallet != nil {
+		wallets = append(wallets, e.cfg.Wallet)
+	}
+	result, err := e.transferer.BatchTransfer(ctx, req, wallets...)
+
+	// 3. 记录结果（部分成功时 RecipientTxHashes 中已提交的项有交易哈希）
+	//    没有交易哈希时，只有确定未广播的错误（token.ErrNotSubmitted）记为 failed，
+	//    其余（如提交请求网络错误、超时）交易可能已到达节点，记为 unknown
+	for j, idx := range chunk {
+		txHash := ""
+		if result != nil && j < len(result.RecipientTxHashes) {
+			txHash = result.RecipientTxHashes[j]
+		}
+		switch {
+		case txHash != "":
+			state.Items[idx] = ItemState{Status: ItemSubmitted, TxHash: txHash}
+		case errors.Is(err, token.ErrNotSubmitted):
+			state.Items[idx] = ItemState{Status: ItemFailed, Error: err.Error()}
+		case err != nil:
+			state.Items[idx] = ItemState{Status: ItemUnknown, Error: err.Error()}
+		default:
+			state.Items[idx] = ItemState{Status: ItemUnknown, Error: "missing transaction hash in batch result"}
+		}
+	}
+	return e.save(ctx, state)
+}
+
+// save 持久化进度
+func (e *Engine) save(ctx context.Context, state *State) error {
+	state.UpdatedAt = e.now()
+	if err := e.cfg.Store.Save(ctx, state); err != nil {
+		return fmt.Errorf("save payout state failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package payout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services/token"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+func testAddress(t *testing.T, b byte) string {
+	t.Helper()
+	addr, err := utils.AddressBytesToBase58(bytes.Repeat([]byte{b}, 20))
+	if err != nil {
+		t.Fatalf("AddressBytesToBase58: %v", err)
+	}
+	return addr
+}
+
+// fakeTransferer 记录每次 BatchTransfer 调用，failOn 指定第几次调用（从 1 开始）返回 failErr
+// （为 nil 时返回确定未广播的错误）
+type fakeTransferer struct {
+	calls   [][]token.TransferItem
+	failOn  int
+	failErr error
+}
+
+func (f *fakeTransferer) BatchTransfer(ctx context.Context, req *token.BatchTransferRequest, wallets ...wallet.Wallet) (*token.BatchTransferResult, error) {
+	f.calls = append(f.calls, req.Transfers)
+	n := len(f.calls)
+	if n == f.failOn {
+		if f.failErr != nil {
+			return nil, f.failErr
+		}
+		return nil, fmt.Errorf("%w: insufficient balance", token.ErrNotSubmitted)
+	}
+	txHash := fmt.Sprintf("%064x", n)
+	hashes := make([]string, len(req.Transfers))
+	for i := range hashes {
+		hashes[i] = txHash
+	}
+	return &token.BatchTransferResult{TxHash: txHash, TxHashes: []string{txHash}, RecipientTxHashes: hashes, Success: true}, nil
+}
+
+// failingStore 在第 failAt 次 Save 时返回错误，模拟进程崩溃
+type failingStore struct {
+	*MemoryStateStore
+	saves  int
+	failAt int
+}
+
+func (s *failingStore) Save(ctx context.Context, state *State) error {
+	s.saves++
+	if s.saves == s.failAt {
+		return errors.New("disk full")
+	}
+	return s.MemoryStateStore.Save(ctx, state)
+}
+
+func TestReadAndPrepare(t *testing.T) {
+	a, b := testAddress(t, 1), testAddress(t, 2)
+	tokenID := strings.Repeat("ab", 32)
+	csvData := "address,amount,token_id,reference\n" +
+		a + ",100,,emp-1\n" +
+		"# comment\n" +
+		b + ",50," + tokenID + ",emp-2\n" +
+		a + ",100,,emp-1-dup\n" +
+		"not-an-address,10\n" +
+		b + ",0\n"
+
+	recipients, err := ReadCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	if len(recipients) != 5 {
+		t.Fatalf("read %d recipients, want 5", len(recipients))
+	}
+
+	plan := Prepare(recipients, DedupeSkip)
+	if len(plan.Recipients) != 2 || len(plan.Duplicates) != 1 || len(plan.Invalid) != 2 {
+		t.Fatalf("recipients=%d duplicates=%d invalid=%d", len(plan.Recipients), len(plan.Duplicates), len(plan.Invalid))
+	}
+	if plan.Duplicates[0].Line != 5 || plan.Invalid[0].Line != 6 {
+		t.Fatalf("unexpected line numbers: duplicate %d, invalid %d", plan.Duplicates[0].Line, plan.Invalid[0].Line)
+	}
+
+	merged := Prepare(recipients, DedupeMerge)
+	if got := merged.Recipients[0].Amount.String(); got != "200" {
+		t.Fatalf("merged amount = %s, want 200", got)
+	}
+	if merged.ID == plan.ID {
+		t.Fatalf("plans with different amounts must have different IDs")
+	}
+
+	jsonRecipients, err := ReadJSON(strings.NewReader(`[{"address":"` + a + `","amount":"100","reference":"emp-1"},{"address":"` + b + `","amount":50,"token_id":"` + tokenID + `"}]`))
+	if err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	if Prepare(jsonRecipients, DedupeSkip).ID != plan.ID {
+		t.Fatalf("CSV and JSON inputs with the same content should produce the same plan ID")
+	}
+}
+
+func testPlan(t *testing.T, n int) *Plan {
+	t.Helper()
+	recipients := make([]Recipient, n)
+	for i := range recipients {
+		recipients[i] = Recipient{Address: testAddress(t, byte(i+1)), Amount: types.NewAmount(uint64(i + 1))}
+	}
+	return Prepare(recipients, DedupeSkip)
+}
+
+func TestRunResumesAfterFailure(t *testing.T) {
+	plan := testPlan(t, 5)
+	store := NewMemoryStateStore()
+	from := types.MustAddressFromBytes(bytes.Repeat([]byte{0xff}, 20))
+
+	// 第二组失败
+	tr := &fakeTransferer{failOn: 2}
+	var progress []utils.BatchProgress
+	engine := New(tr, Config{From: from, ChunkSize: 2, Store: store, OnProgress: func(p utils.BatchProgress) {
+		progress = append(progress, p)
+	}})
+	state, err := engine.Run(context.Background(), plan)
+	if err == nil {
+		t.Fatalf("expected error for failed chunk")
+	}
+	if state.Count(ItemSubmitted) != 3 || state.Count(ItemFailed) != 2 {
+		t.Fatalf("submitted=%d failed=%d", state.Count(ItemSubmitted), state.Count(ItemFailed))
+	}
+	if len(progress) != 3 || progress[2].Completed != 5 || progress[2].Failed != 2 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+
+	// 重新运行：默认不重试失败项
+	tr2 := &fakeTransferer{}
+	if _, err := New(tr2, Config{From: from, ChunkSize: 2, Store: store}).Run(context.Background(), plan); err == nil {
+		t.Fatalf("expected error while failed items remain")
+	}
+	if len(tr2.calls) != 0 {
+		t.Fatalf("failed items must not be retried without RetryFailed")
+	}
+
+	// RetryFailed：只重试失败的两项
+	state, err = New(tr2, Config{From: from, ChunkSize: 2, Store: store, RetryFailed: true}).Run(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("Run with RetryFailed: %v", err)
+	}
+	if len(tr2.calls) != 1 || len(tr2.calls[0]) != 2 || state.Count(ItemSubmitted) != 5 {
+		t.Fatalf("calls=%v submitted=%d", tr2.calls, state.Count(ItemSubmitted))
+	}
+}
+
+func TestRunInterruptedChunkIsNotResubmitted(t *testing.T) {
+	plan := testPlan(t, 4)
+	from := types.MustAddressFromBytes(bytes.Repeat([]byte{0xff}, 20))
+
+	// Save 次序：1 初始化，2 第一组 in_flight，3 第一组结果（失败 = 崩溃）
+	store := &failingStore{MemoryStateStore: NewMemoryStateStore(), failAt: 3}
+	tr := &fakeTransferer{}
+	if _, err := New(tr, Config{From: from, ChunkSize: 2, Store: store}).Run(context.Background(), plan); err == nil {
+		t.Fatalf("expected save error")
+	}
+	if len(tr.calls) != 1 {
+		t.Fatalf("expected 1 submission before crash, got %d", len(tr.calls))
+	}
+
+	// 重启：第一组结果未知，标记为 unknown 且不重新提交（即使 RetryFailed）；第二组继续
+	tr2 := &fakeTransferer{}
+	state, err := New(tr2, Config{From: from, ChunkSize: 2, Store: store.MemoryStateStore, RetryFailed: true}).Run(context.Background(), plan)
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Fatalf("expected error for interrupted items, got %v", err)
+	}
+	if len(tr2.calls) != 1 || !bytes.Equal(tr2.calls[0][0].To[:], plan.Recipients[2].AddressBytes()) {
+		t.Fatalf("only the second chunk should be submitted, calls=%d", len(tr2.calls))
+	}
+	if state.Items[0].Status != ItemUnknown || !strings.Contains(state.Items[0].Error, "interrupted") {
+		t.Fatalf("interrupted item state = %+v", state.Items[0])
+	}
+
+	// 对账：无交易哈希的中断项报告为 unknown，而不是 not_submitted
+	c := &receiptClient{receipts: map[string]interface{}{
+		fmt.Sprintf("%064x", 1): map[string]interface{}{"status": "0x1"},
+	}}
+	report, err := Reconcile(context.Background(), c, plan, state)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if report.Counts[ReportUnknown] != 2 || report.Counts[ReportNotSubmitted] != 0 {
+		t.Fatalf("counts = %v", report.Counts)
+	}
+
+	// 人工确认未上链后才能重试
+	if err := state.MarkNotSubmitted(2); err == nil {
+		t.Fatalf("expected error marking a submitted item")
+	}
+	if err := state.MarkNotSubmitted(0, 1); err != nil {
+		t.Fatalf("MarkNotSubmitted: %v", err)
+	}
+	if err := store.MemoryStateStore.Save(context.Background(), state); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	tr3 := &fakeTransferer{}
+	if _, err := New(tr3, Config{From: from, ChunkSize: 2, Store: store.MemoryStateStore, RetryFailed: true}).Run(context.Background(), plan); err != nil {
+		t.Fatalf("Run after MarkNotSubmitted: %v", err)
+	}
+	if len(tr3.calls) != 1 || !bytes.Equal(tr3.calls[0][0].To[:], plan.Recipients[0].AddressBytes()) {
+		t.Fatalf("only the confirmed-unsubmitted chunk should be retried, calls=%d", len(tr3.calls))
+	}
+}
+
+func TestRunSendErrorIsNotRetried(t *testing.T) {
+	plan := testPlan(t, 4)
+	from := types.MustAddressFromBytes(bytes.Repeat([]byte{0xff}, 20))
+	store := NewMemoryStateStore()
+
+	// 第二组提交请求网络错误：交易可能已到达节点，标记为 unknown
+	tr := &fakeTransferer{failOn: 2, failErr: fmt.Errorf("send raw transaction failed: %w", errors.New("i/o timeout"))}
+	state, err := New(tr, Config{From: from, ChunkSize: 2, Store: store, RetryFailed: true}).Run(context.Background(), plan)
+	if err == nil {
+		t.Fatalf("expected error for send failure")
+	}
+	if state.Count(ItemSubmitted) != 2 || state.Count(ItemUnknown) != 2 || state.Count(ItemFailed) != 0 {
+		t.Fatalf("submitted=%d unknown=%d failed=%d", state.Count(ItemSubmitted), state.Count(ItemUnknown), state.Count(ItemFailed))
+	}
+
+	// RetryFailed 不会重新提交 unknown 的收款人
+	tr2 := &fakeTransferer{}
+	if _, err := New(tr2, Config{From: from, ChunkSize: 2, Store: store, RetryFailed: true}).Run(context.Background(), plan); err == nil {
+		t.Fatalf("expected error for unknown items")
+	}
+	if len(tr2.calls) != 0 {
+		t.Fatalf("unknown items must not be resubmitted, calls=%d", len(tr2.calls))
+	}
+}
+
+// receiptClient 按交易哈希返回回执
+type receiptClient struct {
+	receipts map[string]interface{}
+}
+
+func (c *receiptClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if method != "wes_getTransactionReceipt" {
+		return nil, fmt.Errorf("unexpected method %s", method)
+	}
+	hash := strings.TrimPrefix(params.([]interface{})[0].(string), "0x")
+	return c.receipts[hash], nil
+}
+
+func (c *receiptClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (c *receiptClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (c *receiptClient) Close() error { return nil }
+
+func TestReconcile(t *testing.T) {
+	plan := testPlan(t, 5)
+	tr := &fakeTransferer{failOn: 3}
+	state, _ := New(tr, Config{From: types.MustAddressFromBytes(bytes.Repeat([]byte{0xff}, 20)), ChunkSize: 2}).Run(context.Background(), plan)
+
+	c := &receiptClient{receipts: map[string]interface{}{
+		fmt.Sprintf("%064x", 1): map[string]interface{}{"status": "0x1", "block_height": float64(10)},
+		fmt.Sprintf("%064x", 2): map[string]interface{}{"status": "0x0", "statusReason": "out of gas"},
+	}}
+	report, err := Reconcile(context.Background(), c, plan, state)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if report.Counts[ReportConfirmed] != 2 || report.Counts[ReportReverted] != 2 || report.Counts[ReportNotSubmitted] != 1 {
+		t.Fatalf("counts = %v", report.Counts)
+	}
+	if report.Complete() {
+		t.Fatalf("report should not be complete")
+	}
+	if got := report.Paid[""].String(); got != "3" {
+		t.Fatalf("paid = %s, want 3", got)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if !strings.Contains(buf.String(), "out of gas") || !strings.Contains(buf.String(), "# paid,,,3") {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,208 @@
+package payout
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// Recipient 收款记录
+type Recipient struct {
+	Address   string       `json:"address"`             // 地址（Base58，亦接受 types.ParseAddress 支持的其他格式）
+	Amount    types.Amount `json:"amount"`              // 金额（最小单位）
+	TokenID   string       `json:"token_id,omitempty"`  // 代币ID（hex，空表示原生币）
+	Reference string       `json:"reference,omitempty"` // 业务参考号（可选，如员工编号）
+	Line      int          `json:"-"`                   // 来源行号（由 ReadCSV / ReadJSON 设置）
+
+	address      types.Address
+	tokenIDBytes []byte
+}
+
+// AddressBytes 返回校验后的 20 字节地址（Prepare 之后有效）
+func (r *Recipient) AddressBytes() []byte {
+	return r.address.Bytes()
+}
+
+// TokenIDBytes 返回校验后的代币ID（原生币为 nil，Prepare 之后有效）
+func (r *Recipient) TokenIDBytes() []byte {
+	return r.tokenIDBytes
+}
+
+// RowError 无效或被跳过的收款记录
+type RowError struct {
+	Line      int       // 来源行号（未设置时为列表下标 + 1）
+	Recipient Recipient // 原始记录
+	Err       error     // 原因
+}
+
+// DedupePolicy 重复收款人（相同地址 + 代币）的处理策略
+type DedupePolicy int
+
+const (
+	// DedupeSkip 保留第一条，后续重复记录计入 Plan.Duplicates（默认，避免重复支付）
+	DedupeSkip DedupePolicy = iota
+	// DedupeMerge 合并重复记录的金额
+	DedupeMerge
+)
+
+// Plan 经过校验与去重的发放计划
+type Plan struct {
+	ID         string       // 计划ID：收款列表的 SHA256（用于断点续发）
+	Recipients []*Recipient // 有效收款人（保持输入顺序）
+	Invalid    []RowError   // 无效记录
+	Duplicates []RowError   // 被跳过的重复记录（DedupeSkip）
+}
+
+// Totals 按代币汇总发放总额（key 为代币ID hex，原生币为空字符串）
+func (p *Plan) Totals() map[string]types.Amount {
+	totals := make(map[string]types.Amount)
+	for _, r := range p.Recipients {
+		totals[r.TokenID] = totals[r.TokenID].Add(r.Amount)
+	}
+	return totals
+}
+
+// ReadCSV 读取 CSV 收款列表
+//
+// 列顺序：address, amount[, token_id[, reference]]。
+// 首行为表头时（第一列为 "address"）自动跳过；空行与 "#" 开头的行忽略。
+func ReadCSV(r io.Reader) ([]Recipient, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	var recipients []Recipient
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv failed: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("csv line %d: expected at least 2 columns (address, amount)", line)
+		}
+
+		amount, err := types.ParseAmount(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", line, err)
+		}
+		rec := Recipient{
+			Address: strings.TrimSpace(record[0]),
+			Amount:  amount,
+			Line:    line,
+		}
+		if len(record) > 2 {
+			rec.TokenID = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 {
+			rec.Reference = strings.TrimSpace(record[3])
+		}
+		recipients = append(recipients, rec)
+	}
+	return recipients, nil
+}
+
+// ReadJSON 读取 JSON 收款列表（Recipient 数组）
+func ReadJSON(r io.Reader) ([]Recipient, error) {
+	var recipients []Recipient
+	if err := json.NewDecoder(r).Decode(&recipients); err != nil {
+		return nil, fmt.Errorf("read json failed: %w", err)
+	}
+	for i := range recipients {
+		recipients[i].Line = i + 1
+	}
+	return recipients, nil
+}
+
+// Prepare 校验地址与金额、去重并生成发放计划
+//
+// 无效记录不会中断处理，统一计入 Plan.Invalid，由调用方决定是否继续发放。
+func Prepare(recipients []Recipient, policy DedupePolicy) *Plan {
+	plan := &Plan{}
+	seen := make(map[string]*Recipient)
+
+	for i := range recipients {
+		rec := recipients[i]
+		line := rec.Line
+		if line == 0 {
+			line = i + 1
+		}
+
+		addr, err := types.ParseAddress(rec.Address)
+		if err != nil {
+			plan.Invalid = append(plan.Invalid, RowError{Line: line, Recipient: rec, Err: fmt.Errorf("invalid address: %w", err)})
+			continue
+		}
+		if rec.Amount.IsZero() {
+			plan.Invalid = append(plan.Invalid, RowError{Line: line, Recipient: rec, Err: fmt.Errorf("amount must be greater than 0")})
+			continue
+		}
+		var tokenID []byte
+		if rec.TokenID != "" {
+			tokenID, err = hex.DecodeString(strings.TrimPrefix(rec.TokenID, "0x"))
+			if err != nil || len(tokenID) != 32 {
+				plan.Invalid = append(plan.Invalid, RowError{Line: line, Recipient: rec, Err: fmt.Errorf("token_id must be 32 bytes hex")})
+				continue
+			}
+		}
+
+		rec.address = addr
+		rec.tokenIDBytes = tokenID
+		rec.TokenID = hex.EncodeToString(tokenID)
+
+		key := hex.EncodeToString(addr[:]) + ":" + rec.TokenID
+		if prev, ok := seen[key]; ok {
+			if policy == DedupeMerge {
+				prev.Amount = prev.Amount.Add(rec.Amount)
+			} else {
+				plan.Duplicates = append(plan.Duplicates, RowError{Line: line, Recipient: rec, Err: fmt.Errorf("duplicate recipient")})
+			}
+			continue
+		}
+
+		r := rec
+		seen[key] = &r
+		plan.Recipients = append(plan.Recipients, &r)
+	}
+
+	plan.ID = planID(plan.Recipients)
+	return plan
+}
+
+// planID 计算计划ID：对规范化后的收款列表（地址、代币、金额）做 SHA256
+//
+// 输入顺序参与哈希：同一列表重排后分组不同，不能复用进度。
+func planID(recipients []*Recipient) string {
+	h := sha256.New()
+	for _, r := range recipients {
+		fmt.Fprintf(h, "%x,%s,%s\n", r.address[:], r.TokenID, r.Amount)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sortedTokenKeys 返回排序后的代币 key（报告输出使用）
+func sortedTokenKeys(m map[string]types.Amount) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
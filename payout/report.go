@@ -0,0 +1,154 @@
+package payout
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// ReportStatus 对账状态
+type ReportStatus string
+
+const (
+	// ReportConfirmed 交易已上链且执行成功
+	ReportConfirmed ReportStatus = "confirmed"
+	// ReportReverted 交易已上链但执行失败
+	ReportReverted ReportStatus = "reverted"
+	// ReportPending 已提交但尚无回执
+	ReportPending ReportStatus = "pending"
+	// ReportNotSubmitted 未提交或提交失败
+	ReportNotSubmitted ReportStatus = "not_submitted"
+	// ReportUnknown 回执查询失败，或无交易哈希但可能已上链（ItemUnknown）
+	ReportUnknown ReportStatus = "unknown"
+)
+
+// ReportItem 单个收款人的对账结果
+type ReportItem struct {
+	Line        int
+	Address     string
+	TokenID     string
+	Amount      types.Amount
+	Reference   string
+	TxHash      string
+	Status      ReportStatus
+	BlockHeight uint64
+	Reason      string // 失败原因（提交错误或回执中的 statusReason）
+}
+
+// Report 对账报告
+type Report struct {
+	PlanID string
+	Items  []ReportItem
+	Counts map[ReportStatus]int
+	Paid   map[string]types.Amount // 已确认的发放总额（key 为代币ID hex，原生币为空字符串）
+}
+
+// Reconcile 按交易回执核对发放结果
+//
+// 每个交易哈希只查询一次 wes_getTransactionReceipt：status "0x1" 为 confirmed，
+// 其他状态为 reverted，回执不存在为 pending。ItemUnknown 的收款人没有交易哈希，
+// 报告为 unknown 而不是 not_submitted，需人工核对后才能重试。
+func Reconcile(ctx context.Context, c client.Client, plan *Plan, state *State) (*Report, error) {
+	if plan == nil || state == nil {
+		return nil, fmt.Errorf("plan and state are required")
+	}
+	if state.PlanID != plan.ID || len(state.Items) != len(plan.Recipients) {
+		return nil, fmt.Errorf("payout state does not match plan")
+	}
+
+	type txStatus struct {
+		status ReportStatus
+		height uint64
+		reason string
+	}
+	wes := client.NewWESClientFromClient(c)
+	cache := make(map[string]txStatus)
+
+	report := &Report{
+		PlanID: plan.ID,
+		Items:  make([]ReportItem, 0, len(plan.Recipients)),
+		Counts: make(map[ReportStatus]int),
+		Paid:   make(map[string]types.Amount),
+	}
+	for i, r := range plan.Recipients {
+		item := state.Items[i]
+		ri := ReportItem{
+			Line:      r.Line,
+			Address:   r.Address,
+			TokenID:   r.TokenID,
+			Amount:    r.Amount,
+			Reference: r.Reference,
+			TxHash:    item.TxHash,
+			Status:    ReportNotSubmitted,
+			Reason:    item.Error,
+		}
+
+		if item.Status == ItemUnknown && item.TxHash == "" {
+			ri.Status = ReportUnknown
+		}
+		if item.TxHash != "" {
+			ts, ok := cache[item.TxHash]
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				receipt, err := wes.GetTransactionReceipt(ctx, item.TxHash)
+				switch {
+				case err != nil:
+					ts = txStatus{status: ReportUnknown, reason: err.Error()}
+				case receipt == nil:
+					ts = txStatus{status: ReportPending}
+				case receipt.Status == "0x1":
+					ts = txStatus{status: ReportConfirmed, height: receipt.BlockHeight}
+				default:
+					ts = txStatus{status: ReportReverted, height: receipt.BlockHeight, reason: receipt.StatusReason}
+				}
+				cache[item.TxHash] = ts
+			}
+			ri.Status, ri.BlockHeight, ri.Reason = ts.status, ts.height, ts.reason
+		}
+
+		if ri.Status == ReportConfirmed {
+			report.Paid[r.TokenID] = report.Paid[r.TokenID].Add(r.Amount)
+		}
+		report.Counts[ri.Status]++
+		report.Items = append(report.Items, ri)
+	}
+	return report, nil
+}
+
+// Complete 是否全部收款人均已确认
+func (r *Report) Complete() bool {
+	return r.Counts[ReportConfirmed] == len(r.Items)
+}
+
+// WriteCSV 输出 CSV 格式的对账明细，末尾附各代币已确认发放总额
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"line", "address", "token_id", "amount", "reference", "tx_hash", "status", "block_height", "reason"}}
+	for _, item := range r.Items {
+		rows = append(rows, []string{
+			strconv.Itoa(item.Line),
+			item.Address,
+			item.TokenID,
+			item.Amount.String(),
+			item.Reference,
+			item.TxHash,
+			string(item.Status),
+			strconv.FormatUint(item.BlockHeight, 10),
+			item.Reason,
+		})
+	}
+	for _, token := range sortedTokenKeys(r.Paid) {
+		rows = append(rows, []string{"# paid", "", token, r.Paid[token].String()})
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("write report failed: %w", err)
+	}
+	return nil
+}
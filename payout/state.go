@@ -0,0 +1,211 @@
+package payout
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ItemStatus 单个收款人的发放状态
+type ItemStatus string
+
+const (
+	// ItemPending 尚未提交
+	ItemPending ItemStatus = "pending"
+	// ItemInFlight 已开始提交但结果尚未记录（进程在此期间崩溃时保持该状态）
+	ItemInFlight ItemStatus = "in_flight"
+	// ItemSubmitted 节点已接受交易
+	ItemSubmitted ItemStatus = "submitted"
+	// ItemFailed 提交失败，Config.RetryFailed 时重试
+	ItemFailed ItemStatus = "failed"
+	// ItemUnknown 没有交易哈希且可能已上链（提交请求出错或提交中断）；不会被自动重试
+	ItemUnknown ItemStatus = "unknown"
+)
+
+// ItemState 单个收款人的发放进度
+type ItemState struct {
+	Status ItemStatus `json:"status"`
+	TxHash string     `json:"tx_hash,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// State 发放计划的持久化进度
+type State struct {
+	PlanID    string      `json:"plan_id"`
+	Items     []ItemState `json:"items"` // 与 Plan.Recipients 一一对应
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Count 统计各状态的收款人数量
+func (s *State) Count(status ItemStatus) int {
+	n := 0
+	for _, item := range s.Items {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// MarkNotSubmitted 将人工确认未上链的 ItemUnknown 收款人改为 ItemFailed，之后可通过 RetryFailed 重试
+//
+// index 为 Plan.Recipients 的下标；非 ItemUnknown 状态的收款人返回错误。
+func (s *State) MarkNotSubmitted(indices ...int) error {
+	for _, i := range indices {
+		if i < 0 || i >= len(s.Items) {
+			return fmt.Errorf("recipient index %d out of range", i)
+		}
+		if s.Items[i].Status != ItemUnknown {
+			return fmt.Errorf("recipient %d is %s, not %s", i, s.Items[i].Status, ItemUnknown)
+		}
+	}
+	for _, i := range indices {
+		s.Items[i] = ItemState{Status: ItemFailed, Error: "confirmed not submitted after interruption"}
+	}
+	return nil
+}
+
+// ErrStateNotFound 进度不存在
+var ErrStateNotFound = errors.New("payout state not found")
+
+// StateStore 进度存储接口
+//
+// Save 必须是原子的：进程崩溃时要么保留旧进度，要么写入完整的新进度。
+type StateStore interface {
+	// Load 加载进度，不存在时返回 ErrStateNotFound
+	Load(ctx context.Context, planID string) (*State, error)
+	// Save 保存进度
+	Save(ctx context.Context, state *State) error
+}
+
+// MemoryStateStore 内存进度存储（测试或无需断点续发的场景）
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+// NewMemoryStateStore 创建内存进度存储
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[string][]byte)}
+}
+
+// Load 加载进度
+func (s *MemoryStateStore) Load(ctx context.Context, planID string) (*State, error) {
+	s.mu.Lock()
+	data, ok := s.states[planID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save 保存进度（序列化后保存，避免调用方后续修改影响已保存的数据）
+func (s *MemoryStateStore) Save(ctx context.Context, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.PlanID] = data
+	return nil
+}
+
+// FileStateStore 文件进度存储：每个计划一个 JSON 文件（<planID>.json）
+//
+// 写入采用"临时文件 + fsync + rename"，保证崩溃时不会留下半写的进度。
+type FileStateStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStateStore 创建文件进度存储，目录不存在时自动创建
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create payout state directory: %w", err)
+	}
+	return &FileStateStore{dir: dir}, nil
+}
+
+// Load 加载进度
+func (s *FileStateStore) Load(ctx context.Context, planID string) (*State, error) {
+	path, err := s.path(planID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read payout state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decode payout state: %w", err)
+	}
+	return &state, nil
+}
+
+// Save 保存进度
+func (s *FileStateStore) Save(ctx context.Context, state *State) error {
+	path, err := s.path(state.PlanID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal payout state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-"+state.PlanID+"-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // rename 成功后为空操作
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("rename payout state: %w", err)
+	}
+	if d, err := os.Open(s.dir); err == nil {
+		// 部分平台（如 Windows）不支持目录 fsync，忽略该错误
+		_ = d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// path 返回进度文件路径（planID 必须是十六进制，防止路径穿越）
+func (s *FileStateStore) path(planID string) (string, error) {
+	if _, err := hex.DecodeString(planID); err != nil || planID == "" {
+		return "", fmt.Errorf("invalid plan id: %q", planID)
+	}
+	return filepath.Join(s.dir, planID+".json"), nil
+}
@@ -23,6 +23,9 @@ type Config struct {
 	// Escrow 合约 contentHash（32 字节）
 	EscrowContractHash []byte
 
+	// HTLC 参考合约地址（20 字节，用于 HTLC 输出的 ContractLock）
+	HTLCContractAddress []byte
+
 	// 治理相关配置
 	Governance GovernanceConfig
 }
@@ -18,7 +18,7 @@ import (
 func buildCallPayload(req *CallContractRequest) (string, error) {
 	payloadOptions := utils.BuildPayloadOptions{
 		IncludeFrom: true,
-		From:        req.From,
+		From:        req.From[:],
 	}
 
 	if req.Amount != nil {
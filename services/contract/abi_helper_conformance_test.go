@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
@@ -39,7 +40,7 @@ func TestBuildPayload_ReservedFields(t *testing.T) {
 				IncludeTo:      true,
 				To:             make([]byte, 20),
 				IncludeAmount:  true,
-				Amount:         1000000,
+				Amount:         types.NewAmount(1000000),
 				IncludeTokenID: true,
 				TokenID:        make([]byte, 32),
 			},
@@ -146,7 +147,7 @@ func TestBuildAndEncodePayload_Base64Encoding(t *testing.T) {
 		IncludeFrom:   true,
 		From:          make([]byte, 20),
 		IncludeAmount: true,
-		Amount:        1000000,
+		Amount:        types.NewAmount(1000000),
 	}
 
 	encoded, err := utils.BuildAndEncodePayload(options)
@@ -215,7 +216,7 @@ func TestBuildPayload_ConformanceWithSpec(t *testing.T) {
 		IncludeTo:      true,
 		To:             hexToBytes(toHex),
 		IncludeAmount:  true,
-		Amount:         1000000,
+		Amount:         types.NewAmount(1000000),
 		IncludeTokenID: true,
 		TokenID:        hexToBytes(tokenIdHex),
 	}
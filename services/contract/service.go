@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -53,8 +54,8 @@ type CallContractRequest struct {
 	ContractAddress []byte        // 合约地址（contentHash，32字节）
 	Method          string        // 方法名
 	Args            []interface{} // 方法参数
-	From            []byte        // 调用者地址（20字节）
-	Amount          *uint64       // 可选：金额（如果需要转账）
+	From            types.Address // 调用者地址
+	Amount          *types.Amount // 可选：金额（如果需要转账）
 	TokenID         []byte        // 可选：代币 ID（如果需要转账代币）
 }
 
@@ -78,8 +79,8 @@ func (s *contractService) CallContract(ctx context.Context, req *CallContractReq
 	if len(req.ContractAddress) != 32 {
 		return nil, fmt.Errorf("contract address must be 32 bytes")
 	}
-	if len(req.From) != 20 {
-		return nil, fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return nil, fmt.Errorf("from address is required")
 	}
 	if req.Method == "" {
 		return nil, fmt.Errorf("method name is required")
@@ -129,7 +130,10 @@ func (s *contractService) CallContract(ctx context.Context, req *CallContractReq
 		return nil, fmt.Errorf("decode unsigned tx failed: %w", err)
 	}
 
-	// 7. 使用 Wallet 签名交易
+	// 7. 使用 Wallet 签名交易（观察钱包返回携带未签名交易的 *wallet.UnsignedTxError）
+	if err := wallet.RequireTxSigner(w, unsignedTxBytes); err != nil {
+		return nil, err
+	}
 	signedTxBytes, err := w.SignTransaction(unsignedTxBytes)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -6,10 +6,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -41,20 +41,23 @@ func (s *governanceService) propose(ctx context.Context, req *ProposeRequest, wa
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.Proposer) {
+	if !bytes.Equal(w.Address(), req.Proposer[:]) {
 		return nil, fmt.Errorf("wallet address does not match proposer address")
 	}
 
 	// 4. 在 SDK 层构建 DraftJSON（不直接构建交易）
-	// TODO: 需要从配置或参数获取验证者地址列表
-	// 当前简化：使用提案者地址作为验证者（实际应该查询验证者列表）
-	validatorAddresses := [][]byte{req.Proposer} // 临时：使用提案者地址
-	threshold := uint32(1)                       // 临时：需要1个签名
+	// 验证者集合来自 Config.Governance；未配置时使用提案者地址作为唯一验证者
+	validatorAddresses := [][]byte{req.Proposer[:]}
+	threshold := uint32(1)
+	if len(s.governance.ValidatorAddresses) > 0 && s.governance.Threshold > 0 {
+		validatorAddresses = s.governance.ValidatorAddresses
+		threshold = s.governance.Threshold
+	}
 
 	draftJSON, inputIndex, err := buildProposeDraft(
 		ctx,
 		s.client,
-		req.Proposer,
+		req.Proposer[:],
 		req.Title,
 		req.Description,
 		req.VotingPeriod,
@@ -65,32 +68,17 @@ func (s *governanceService) propose(ctx context.Context, req *ProposeRequest, wa
 		return nil, fmt.Errorf("build propose draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -171,8 +159,8 @@ func (s *governanceService) propose(ctx context.Context, req *ProposeRequest, wa
 // validateProposeRequest 验证提案请求
 func (s *governanceService) validateProposeRequest(req *ProposeRequest) error {
 	// 1. 验证地址
-	if len(req.Proposer) != 20 {
-		return fmt.Errorf("proposer address must be 20 bytes")
+	if req.Proposer.IsZero() {
+		return fmt.Errorf("proposer address is required")
 	}
 
 	// 2. 验证标题
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -21,8 +23,9 @@ type Service interface {
 
 // governanceService Governance 服务实现
 type governanceService struct {
-	client client.Client
-	wallet wallet.Wallet // 可选：默认 Wallet
+	client     client.Client
+	wallet     wallet.Wallet             // 可选：默认 Wallet
+	governance services.GovernanceConfig // 可选：验证者集合与门限
 }
 
 // NewService 创建 Governance 服务（不带 Wallet）
@@ -40,6 +43,18 @@ func NewServiceWithWallet(client client.Client, w wallet.Wallet) Service {
 	}
 }
 
+// NewServiceWithConfig 创建带业务配置的 Governance 服务（使用 Config.Governance 的验证者集合与门限）
+func NewServiceWithConfig(client client.Client, cfg *services.Config, w wallet.Wallet) Service {
+	s := &governanceService{
+		client: client,
+		wallet: w,
+	}
+	if cfg != nil {
+		s.governance = cfg.Governance
+	}
+	return s
+}
+
 // getWallet 获取 Wallet（优先使用参数，其次使用默认 Wallet）
 func (s *governanceService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
 	if len(wallets) > 0 && wallets[0] != nil {
@@ -50,10 +65,10 @@ func (s *governanceService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
 
 // ProposeRequest 提案请求
 type ProposeRequest struct {
-	Proposer     []byte // 提案者地址（20字节）
-	Title        string // 提案标题
-	Description  string // 提案描述
-	VotingPeriod uint64 // 投票期限（区块数）
+	Proposer     types.Address // 提案者地址
+	Title        string        // 提案标题
+	Description  string        // 提案描述
+	VotingPeriod uint64        // 投票期限（区块数）
 }
 
 // ProposeResult 提案结果
@@ -65,10 +80,10 @@ type ProposeResult struct {
 
 // VoteRequest 投票请求
 type VoteRequest struct {
-	Voter      []byte // 投票者地址（20字节）
-	ProposalID []byte // 提案ID
-	Choice     int    // 投票选择（1=支持, 0=反对, -1=弃权）
-	VoteWeight uint64 // 投票权重
+	Voter      types.Address // 投票者地址
+	ProposalID []byte        // 提案ID
+	Choice     int           // 投票选择（1=支持, 0=反对, -1=弃权）
+	VoteWeight uint64        // 投票权重
 }
 
 // VoteResult 投票结果
@@ -80,9 +95,9 @@ type VoteResult struct {
 
 // UpdateParamRequest 更新参数请求
 type UpdateParamRequest struct {
-	Proposer   []byte // 提案者地址（20字节）
-	ParamKey   string // 参数键
-	ParamValue string // 参数值
+	Proposer   types.Address // 提案者地址
+	ParamKey   string        // 参数键
+	ParamValue string        // 参数值
 }
 
 // UpdateParamResult 更新参数结果
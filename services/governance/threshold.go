@@ -0,0 +1,649 @@
+package governance
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services"
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// DefaultShareTimeout 等待验证者签名分片的默认超时
+const DefaultShareTimeout = 2 * time.Minute
+
+// ThresholdSignatureScheme 协调器支持的签名方案（各验证者独立 secp256k1 签名的分片集合），
+// 被花费 UTXO 的 ThresholdLock 须声明该方案
+const ThresholdSignatureScheme = "ECDSA_SECP256K1"
+
+// defaultThresholdScheme 锁定条件未声明签名方案时节点采用的默认方案
+const defaultThresholdScheme = "BLS_THRESHOLD"
+
+var (
+	// ErrQuorumNotReached 在超时前未收集到门限数量的有效签名分片
+	ErrQuorumNotReached = errors.New("threshold quorum not reached")
+
+	// ErrUnsupportedScheme 门限锁的签名方案不是 ThresholdSignatureScheme，无法由验证者分片聚合
+	ErrUnsupportedScheme = errors.New("unsupported threshold signature scheme")
+)
+
+// Operation 治理控制的操作（消费 ThresholdLock 锁定的输出）
+type Operation struct {
+	Caller      types.Address            // 发起方地址（写入草稿 metadata.caller_address）
+	Inputs      []string                 // 门限锁定的输入 outpoint（"txHash:index"）
+	Outputs     []map[string]interface{} // 草稿输出（格式同 wes_computeSignatureHashFromDraft）
+	Description string                   // 操作说明（随签名请求发送给验证者）
+}
+
+// ShareRequest 分发给验证者的签名请求
+type ShareRequest struct {
+	SessionID   string          `json:"session_id"` // SHA-256(unsignedTx)（hex）
+	Draft       json.RawMessage `json:"draft"`
+	UnsignedTx  string          `json:"unsignedTx"`
+	Inputs      []ShareInput    `json:"inputs"`
+	Description string          `json:"description"`
+	Deadline    time.Time       `json:"deadline"`
+}
+
+// ShareInput 待签名的输入及其签名哈希
+type ShareInput struct {
+	InputIndex uint32 `json:"input_index"`
+	Sighash    string `json:"sighash"` // hex
+}
+
+// SignatureShare 验证者返回的签名分片
+type SignatureShare struct {
+	Validator  string            `json:"validator"` // 验证者地址（hex）
+	Pubkey     string            `json:"pubkey"`    // 0x 前缀压缩公钥
+	Signatures map[uint32]string `json:"signatures"`
+}
+
+// ValidatorSigner 验证者签名端（可为进程内钱包或远程服务的客户端）
+type ValidatorSigner interface {
+	// Address 验证者地址（20字节，须在 GovernanceConfig.ValidatorAddresses 中）
+	Address() []byte
+
+	// SignShare 校验请求并返回签名分片；返回错误表示拒绝签名
+	SignShare(ctx context.Context, req *ShareRequest) (*SignatureShare, error)
+}
+
+// QuorumStatus 门限签名收集状态报告
+type QuorumStatus struct {
+	Threshold uint32            // 门限值
+	Total     int               // 验证者总数
+	Signed    []string          // 已提交有效分片的验证者（hex，按验证者顺序）
+	Failed    map[string]string // 拒绝、分片无效或请求失败的验证者 → 原因
+	Pending   []string          // 超时或达到门限后未再等待的验证者
+	Reached   bool              // 是否达到门限
+	Elapsed   time.Duration     // 收集耗时
+}
+
+// String 简要状态（如 "3/5 signed, threshold 3, reached"）
+func (s *QuorumStatus) String() string {
+	state := "not reached"
+	if s.Reached {
+		state = "reached"
+	}
+	return fmt.Sprintf("%d/%d signed, threshold %d, %s", len(s.Signed), s.Total, s.Threshold, state)
+}
+
+// ThresholdCoordinatorOptions 协调器选项
+type ThresholdCoordinatorOptions struct {
+	// Timeout 等待签名分片的超时（0 表示 DefaultShareTimeout）
+	Timeout time.Duration
+
+	// WaitAll 达到门限后仍等待其余验证者（用于完整的状态报告）
+	WaitAll bool
+}
+
+// ThresholdCoordinator 门限签名协调器：构建治理操作交易、分发签名哈希、聚合门限证明
+type ThresholdCoordinator struct {
+	client     client.Client
+	validators [][]byte
+	threshold  uint32
+	signers    []ValidatorSigner
+	opts       ThresholdCoordinatorOptions
+	now        func() time.Time
+}
+
+// NewThresholdCoordinator 按治理配置创建协调器
+func NewThresholdCoordinator(c client.Client, cfg services.GovernanceConfig, signers []ValidatorSigner, opts ThresholdCoordinatorOptions) (*ThresholdCoordinator, error) {
+	if len(cfg.ValidatorAddresses) == 0 {
+		return nil, fmt.Errorf("validator addresses cannot be empty")
+	}
+	if cfg.Threshold == 0 || cfg.Threshold > uint32(len(cfg.ValidatorAddresses)) {
+		return nil, fmt.Errorf("threshold must be between 1 and %d, got %d", len(cfg.ValidatorAddresses), cfg.Threshold)
+	}
+	for _, s := range signers {
+		if validatorIndex(cfg.ValidatorAddresses, s.Address()) < 0 {
+			return nil, fmt.Errorf("signer %x is not a configured validator", s.Address())
+		}
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultShareTimeout
+	}
+	return &ThresholdCoordinator{
+		client:     c,
+		validators: cfg.ValidatorAddresses,
+		threshold:  cfg.Threshold,
+		signers:    signers,
+		opts:       opts,
+		now:        time.Now,
+	}, nil
+}
+
+// ThresholdSession 已收集的签名分片（达到门限后可 Finalize / Submit）
+type ThresholdSession struct {
+	Request *ShareRequest
+	Status  *QuorumStatus
+	shares  map[int]*SignatureShare // 验证者序号 → 分片
+	lock    *resource.ThresholdLockCondition
+}
+
+// BuildOperationDraft 构建治理操作的交易草稿，返回草稿与门限锁定输入的索引
+func BuildOperationDraft(op *Operation) ([]byte, []uint32, error) {
+	if op == nil || len(op.Inputs) == 0 {
+		return nil, nil, fmt.Errorf("operation has no inputs")
+	}
+	if op.Caller.IsZero() {
+		return nil, nil, fmt.Errorf("caller address is required")
+	}
+
+	inputs := make([]map[string]interface{}, 0, len(op.Inputs))
+	indices := make([]uint32, 0, len(op.Inputs))
+	for i, outpoint := range op.Inputs {
+		parts := strings.Split(outpoint, ":")
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid outpoint format: %s", outpoint)
+		}
+		outputIndex, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid output index: %w", err)
+		}
+		inputs = append(inputs, map[string]interface{}{
+			"tx_hash":           parts[0],
+			"output_index":      uint32(outputIndex),
+			"is_reference_only": false,
+		})
+		indices = append(indices, uint32(i))
+	}
+
+	draft := map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs":    inputs,
+		"outputs":   op.Outputs,
+		"metadata": map[string]interface{}{
+			"caller_address": hex.EncodeToString(op.Caller[:]),
+		},
+	}
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal draft failed: %w", err)
+	}
+	return draftJSON, indices, nil
+}
+
+// Collect 构建操作草稿，计算签名哈希并分发给验证者，收集签名分片直到达到门限或超时
+//
+// 未达到门限时返回 ErrQuorumNotReached，同时返回的会话中带有状态报告。
+func (c *ThresholdCoordinator) Collect(ctx context.Context, op *Operation) (*ThresholdSession, error) {
+	draftJSON, indices, err := BuildOperationDraft(op)
+	if err != nil {
+		return nil, fmt.Errorf("build operation draft failed: %w", err)
+	}
+	return c.CollectDraft(ctx, draftJSON, indices, op.Description)
+}
+
+// CollectDraft 对已有草稿收集门限签名（inputIndices 为门限锁定的输入）
+//
+// 分发前通过 wes_getUTXO 查询输入的 ThresholdLock（所有者取草稿 metadata.caller_address），
+// 签名方案不受支持时返回 ErrUnsupportedScheme。
+func (c *ThresholdCoordinator) CollectDraft(ctx context.Context, draftJSON []byte, inputIndices []uint32, description string) (*ThresholdSession, error) {
+	sighash, err := utils.ComputeDraftSighash(ctx, c.client, draftJSON, inputIndices, txcodec.SighashAll)
+	if err != nil {
+		return nil, fmt.Errorf("compute signature hash failed: %w", err)
+	}
+	lock, err := c.queryThresholdLock(ctx, draftJSON, sighash.Tx, inputIndices)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := hex.DecodeString(strings.TrimPrefix(sighash.UnsignedTx, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid unsignedTx: %w", err)
+	}
+	id := sha256.Sum256(txBytes)
+
+	start := c.now()
+	req := &ShareRequest{
+		SessionID:   hex.EncodeToString(id[:]),
+		Draft:       append(json.RawMessage(nil), draftJSON...),
+		UnsignedTx:  sighash.UnsignedTx,
+		Description: description,
+		Deadline:    start.Add(c.opts.Timeout),
+	}
+	for _, idx := range inputIndices {
+		req.Inputs = append(req.Inputs, ShareInput{InputIndex: idx, Sighash: hex.EncodeToString(sighash.Hash(idx))})
+	}
+
+	session := &ThresholdSession{
+		Request: req,
+		Status:  &QuorumStatus{Threshold: c.threshold, Total: len(c.validators), Failed: make(map[string]string)},
+		shares:  make(map[int]*SignatureShare),
+		lock:    lock,
+	}
+	c.gather(ctx, session)
+	session.Status.Elapsed = c.now().Sub(start)
+	if !session.Status.Reached {
+		return session, fmt.Errorf("%w: %s", ErrQuorumNotReached, session.Status)
+	}
+	return session, nil
+}
+
+// gather 并发请求各验证者签名并校验分片
+func (c *ThresholdCoordinator) gather(ctx context.Context, session *ThresholdSession) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	type reply struct {
+		index int
+		share *SignatureShare
+		err   error
+	}
+	replies := make(chan reply, len(c.signers))
+	var wg sync.WaitGroup
+	for _, s := range c.signers {
+		wg.Add(1)
+		go func(s ValidatorSigner) {
+			defer wg.Done()
+			share, err := s.SignShare(ctx, session.Request)
+			replies <- reply{index: validatorIndex(c.validators, s.Address()), share: share, err: err}
+		}(s)
+	}
+	go func() {
+		wg.Wait()
+		close(replies)
+	}()
+
+	status := session.Status
+	responded := make(map[int]bool)
+collect:
+	for {
+		select {
+		case r, ok := <-replies:
+			if !ok {
+				break collect
+			}
+			responded[r.index] = true
+			addr := hex.EncodeToString(c.validators[r.index])
+			if r.err == nil {
+				r.err = c.verifyShare(session, r.index, r.share)
+			}
+			if r.err != nil {
+				status.Failed[addr] = r.err.Error()
+				continue
+			}
+			session.shares[r.index] = r.share
+			if uint32(len(session.shares)) >= c.threshold && !c.opts.WaitAll {
+				break collect
+			}
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	for i, v := range c.validators {
+		addr := hex.EncodeToString(v)
+		switch {
+		case session.shares[i] != nil:
+			status.Signed = append(status.Signed, addr)
+		case !responded[i]:
+			status.Pending = append(status.Pending, addr)
+		}
+	}
+	status.Reached = uint32(len(session.shares)) >= c.threshold
+}
+
+// queryThresholdLock 查询门限锁定输入的锁定条件：各输入须为同一 ThresholdLock，
+// 签名方案须为 ThresholdSignatureScheme，门限须与治理配置一致
+func (c *ThresholdCoordinator) queryThresholdLock(ctx context.Context, draftJSON []byte, tx *txcodec.Transaction, inputIndices []uint32) (*resource.ThresholdLockCondition, error) {
+	if len(inputIndices) == 0 {
+		return nil, fmt.Errorf("no threshold inputs")
+	}
+	var draft struct {
+		Metadata struct {
+			CallerAddress string `json:"caller_address"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(draftJSON, &draft); err != nil {
+		return nil, fmt.Errorf("parse draft failed: %w", err)
+	}
+	owner, err := hex.DecodeString(strings.TrimPrefix(draft.Metadata.CallerAddress, "0x"))
+	if err != nil || len(owner) == 0 {
+		return nil, fmt.Errorf("draft metadata.caller_address is required to look up the threshold utxo")
+	}
+
+	var lock *resource.ThresholdLockCondition
+	for _, idx := range inputIndices {
+		if int(idx) >= len(tx.Inputs) {
+			return nil, fmt.Errorf("input %d out of range", idx)
+		}
+		prev := tx.Inputs[idx].PreviousOutput
+		m, err := utils.QueryUTXOLock(ctx, c.client, owner, prev.TxID, prev.OutputIndex)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", idx, err)
+		}
+		actual, err := thresholdLockFromMap(m)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", idx, err)
+		}
+		if lock == nil {
+			lock = actual
+		} else if !sameThresholdLock(lock, actual) {
+			return nil, fmt.Errorf("input %d is locked by a different threshold lock than input %d", idx, inputIndices[0])
+		}
+	}
+	if lock.SignatureScheme != ThresholdSignatureScheme {
+		return nil, fmt.Errorf("%w: %s (only %s shares can be aggregated)", ErrUnsupportedScheme, lock.SignatureScheme, ThresholdSignatureScheme)
+	}
+	if lock.Threshold != c.threshold {
+		return nil, fmt.Errorf("threshold lock requires %d shares, governance config has threshold %d", lock.Threshold, c.threshold)
+	}
+	return lock, nil
+}
+
+// thresholdLockFromMap 解析 wes_getUTXO 返回的 threshold_lock（公钥统一为压缩格式，未声明方案时取节点默认值）
+func thresholdLockFromMap(lock map[string]interface{}) (*resource.ThresholdLockCondition, error) {
+	for _, key := range []string{"time_lock", "height_lock"} {
+		if inner, ok := lock[key].(map[string]interface{}); ok {
+			base, _ := inner["base_lock"].(map[string]interface{})
+			return thresholdLockFromMap(base)
+		}
+	}
+	tl, ok := lock["threshold_lock"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("utxo is not locked by a threshold_lock")
+	}
+	scheme, _ := tl["signature_scheme"].(string)
+	if scheme == "" {
+		scheme = defaultThresholdScheme
+	}
+	result := &resource.ThresholdLockCondition{
+		Threshold:       uint32(utils.ParseUint(tl["threshold"])),
+		TotalParties:    uint32(utils.ParseUint(tl["total_parties"])),
+		SignatureScheme: scheme,
+	}
+	keys, _ := tl["party_verification_keys"].([]interface{})
+	for i, k := range keys {
+		encoded, _ := k.(string)
+		raw, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+		if err != nil {
+			// 兼容 protobuf JSON 的 Base64 编码
+			if raw, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+				return nil, fmt.Errorf("party verification key %d: %w", i, err)
+			}
+		}
+		pub, err := compressedPubkey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("party verification key %d: %w", i, err)
+		}
+		result.PartyVerificationKeys = append(result.PartyVerificationKeys, pub)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid threshold_lock: %w", err)
+	}
+	return result, nil
+}
+
+func sameThresholdLock(a, b *resource.ThresholdLockCondition) bool {
+	if a.Threshold != b.Threshold || a.SignatureScheme != b.SignatureScheme || len(a.PartyVerificationKeys) != len(b.PartyVerificationKeys) {
+		return false
+	}
+	for i := range a.PartyVerificationKeys {
+		if !bytes.Equal(a.PartyVerificationKeys[i], b.PartyVerificationKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// compressedPubkey 将 33 / 65 字节 secp256k1 公钥统一为压缩格式
+func compressedPubkey(raw []byte) ([]byte, error) {
+	var pub *ecdsa.PublicKey
+	var err error
+	if len(raw) == 33 {
+		pub, err = ethcrypto.DecompressPubkey(raw)
+	} else {
+		pub, err = ethcrypto.UnmarshalPubkey(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+	return ethcrypto.CompressPubkey(pub), nil
+}
+
+// partyIndex 返回公钥在门限锁 PartyVerificationKeys 中的序号（不存在时返回 -1）
+func partyIndex(lock *resource.ThresholdLockCondition, pubkey []byte) int {
+	compressed, err := compressedPubkey(pubkey)
+	if err != nil {
+		return -1
+	}
+	for i, k := range lock.PartyVerificationKeys {
+		if bytes.Equal(k, compressed) {
+			return i
+		}
+	}
+	return -1
+}
+
+// verifyShare 校验分片：公钥对应验证者地址且是门限锁的参与方公钥，每个输入的签名有效
+func (c *ThresholdCoordinator) verifyShare(session *ThresholdSession, index int, share *SignatureShare) error {
+	if share == nil {
+		return fmt.Errorf("empty signature share")
+	}
+	pubkey, err := hex.DecodeString(strings.TrimPrefix(share.Pubkey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+	addr, err := wallet.AddressFromPubKey(pubkey)
+	if err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+	if !bytes.Equal(addr, c.validators[index]) {
+		return fmt.Errorf("pubkey does not belong to validator")
+	}
+	if partyIndex(session.lock, pubkey) < 0 {
+		return fmt.Errorf("pubkey is not a party verification key of the threshold lock")
+	}
+	for _, in := range session.Request.Inputs {
+		sigHex, ok := share.Signatures[in.InputIndex]
+		if !ok {
+			return fmt.Errorf("missing signature for input %d", in.InputIndex)
+		}
+		sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid signature for input %d: %w", in.InputIndex, err)
+		}
+		hash, _ := hex.DecodeString(in.Sighash)
+		if !wallet.VerifySignature(pubkey, hash, sig) {
+			return fmt.Errorf("signature for input %d does not verify", in.InputIndex)
+		}
+	}
+	return nil
+}
+
+// ThresholdProofs 按参与方顺序取前 Threshold 个分片，组装 wes_finalizeTransactionFromDraft 的门限证明
+//
+// 格式：[{input_index, sighash_type, threshold_proof: {shares: [{party_id, signature_share, verification_key}],
+// signature_scheme}}]，party_id 为公钥在 UTXO 门限锁 PartyVerificationKeys 中的序号，signature_scheme 取自门限锁。
+func (s *ThresholdSession) ThresholdProofs() ([]map[string]interface{}, error) {
+	if !s.Status.Reached {
+		return nil, fmt.Errorf("%w: %s", ErrQuorumNotReached, s.Status)
+	}
+	type partyShare struct {
+		party int
+		share *SignatureShare
+	}
+	parties := make([]partyShare, 0, len(s.shares))
+	for _, share := range s.shares {
+		pubkey, _ := hex.DecodeString(strings.TrimPrefix(share.Pubkey, "0x"))
+		parties = append(parties, partyShare{party: partyIndex(s.lock, pubkey), share: share})
+	}
+	sort.Slice(parties, func(i, j int) bool { return parties[i].party < parties[j].party })
+	parties = parties[:s.lock.Threshold]
+
+	proofs := make([]map[string]interface{}, 0, len(s.Request.Inputs))
+	for _, in := range s.Request.Inputs {
+		shares := make([]map[string]interface{}, 0, len(parties))
+		for _, p := range parties {
+			shares = append(shares, map[string]interface{}{
+				"party_id":         uint32(p.party),
+				"signature_share":  p.share.Signatures[in.InputIndex],
+				"verification_key": p.share.Pubkey,
+			})
+		}
+		proofs = append(proofs, map[string]interface{}{
+			"input_index":  in.InputIndex,
+			"sighash_type": "SIGHASH_ALL",
+			"threshold_proof": map[string]interface{}{
+				"shares":           shares,
+				"signature_scheme": s.lock.SignatureScheme,
+			},
+		})
+	}
+	return proofs, nil
+}
+
+// Submit 聚合门限证明、finalize 并提交交易，返回交易哈希
+func (s *ThresholdSession) Submit(ctx context.Context, c client.Client) (string, error) {
+	proofs, err := s.ThresholdProofs()
+	if err != nil {
+		return "", err
+	}
+	finalizeParams := map[string]interface{}{
+		"draft":            s.Request.Draft,
+		"unsignedTx":       s.Request.UnsignedTx,
+		"threshold_proofs": proofs,
+	}
+	finalResult, err := c.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+
+	sendResult, err := c.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	return sendResult.TxHash, nil
+}
+
+// WalletValidator 进程内验证者签名端：本地重算签名哈希后使用钱包签名
+type WalletValidator struct {
+	wallet  wallet.Wallet
+	approve func(ctx context.Context, req *ShareRequest) error
+}
+
+// NewWalletValidator 创建进程内验证者（approve 为可选的审批回调，返回错误表示拒绝签名）
+func NewWalletValidator(w wallet.Wallet, approve func(ctx context.Context, req *ShareRequest) error) *WalletValidator {
+	return &WalletValidator{wallet: w, approve: approve}
+}
+
+// Address 实现 ValidatorSigner
+func (v *WalletValidator) Address() []byte {
+	return v.wallet.Address()
+}
+
+// SignShare 实现 ValidatorSigner
+func (v *WalletValidator) SignShare(ctx context.Context, req *ShareRequest) (*SignatureShare, error) {
+	if err := VerifyShareRequest(req); err != nil {
+		return nil, err
+	}
+	if v.approve != nil {
+		if err := v.approve(ctx, req); err != nil {
+			return nil, fmt.Errorf("share request rejected: %w", err)
+		}
+	}
+	priv := v.wallet.PrivateKey()
+	if priv == nil {
+		return nil, fmt.Errorf("wallet private key is nil")
+	}
+
+	share := &SignatureShare{
+		Validator:  hex.EncodeToString(v.wallet.Address()),
+		Pubkey:     "0x" + hex.EncodeToString(ethcrypto.CompressPubkey(&priv.PublicKey)),
+		Signatures: make(map[uint32]string, len(req.Inputs)),
+	}
+	for _, in := range req.Inputs {
+		hash, _ := hex.DecodeString(in.Sighash)
+		sig, err := v.wallet.SignHash(hash)
+		if err != nil {
+			return nil, fmt.Errorf("sign input %d failed: %w", in.InputIndex, err)
+		}
+		share.Signatures[in.InputIndex] = "0x" + hex.EncodeToString(sig)
+	}
+	return share, nil
+}
+
+// VerifyShareRequest 验证者端校验：未签名交易与草稿一致，且签名哈希与本地计算一致
+func VerifyShareRequest(req *ShareRequest) error {
+	if req == nil {
+		return fmt.Errorf("share request is nil")
+	}
+	if !req.Deadline.IsZero() && time.Now().After(req.Deadline) {
+		return fmt.Errorf("share request expired at %s", req.Deadline.Format(time.RFC3339))
+	}
+	tx, err := txcodec.DecodeHex(req.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("decode unsignedTx failed: %w", err)
+	}
+	if err := utils.VerifyTxMatchesDraft(tx, req.Draft); err != nil {
+		return err
+	}
+	for _, in := range req.Inputs {
+		local, err := txcodec.ComputeSighash(tx, in.InputIndex, txcodec.SighashAll)
+		if err != nil {
+			return fmt.Errorf("compute signature hash for input %d failed: %w", in.InputIndex, err)
+		}
+		if hex.EncodeToString(local) != strings.ToLower(in.Sighash) {
+			return &utils.SighashMismatchError{InputIndex: in.InputIndex, LocalHash: local, Reason: "requested sighash does not match unsignedTx"}
+		}
+	}
+	return nil
+}
+
+func validatorIndex(validators [][]byte, addr []byte) int {
+	for i, v := range validators {
+		if bytes.Equal(v, addr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,210 @@
+package governance
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/services"
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// thresholdMockClient 模拟节点：按草稿计算签名哈希、返回门限锁定的 UTXO 并记录 finalize 参数
+type thresholdMockClient struct {
+	lock      map[string]interface{} // 草稿输入对应 UTXO 的锁定条件
+	finalized []map[string]interface{}
+}
+
+// newThresholdMockClient 以 parties（按参与方顺序）与签名方案构造门限锁
+func newThresholdMockClient(t *testing.T, threshold uint32, scheme string, parties ...wallet.Wallet) *thresholdMockClient {
+	t.Helper()
+	lock := &resource.ThresholdLockCondition{Threshold: threshold, TotalParties: uint32(len(parties)), SignatureScheme: scheme}
+	for _, p := range parties {
+		lock.PartyVerificationKeys = append(lock.PartyVerificationKeys, ethcrypto.FromECDSAPub(&p.PrivateKey().PublicKey))
+	}
+	proto, err := lock.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto: %v", err)
+	}
+	// 模拟 JSON 往返后的类型
+	data, _ := json.Marshal(proto)
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	return &thresholdMockClient{lock: decoded}
+}
+
+func (m *thresholdMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		return map[string]interface{}{"utxos": []interface{}{
+			map[string]interface{}{"outpoint": strings.Repeat("aa", 32) + ":0", "amount": "500", "locking_condition": m.lock},
+			map[string]interface{}{"outpoint": strings.Repeat("bb", 32) + ":2", "amount": "500", "locking_condition": m.lock},
+		}}, nil
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_finalizeTransactionFromDraft":
+		m.finalized = append(m.finalized, params.(map[string]interface{}))
+		return map[string]interface{}{"tx": params.(map[string]interface{})["unsignedTx"]}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *thresholdMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return &client.SendTxResult{TxHash: strings.Repeat("ee", 32), Accepted: true}, nil
+}
+
+func (m *thresholdMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *thresholdMockClient) Close() error { return nil }
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+// blockingValidator 一直等待直到请求超时
+type blockingValidator struct{ address []byte }
+
+func (v *blockingValidator) Address() []byte { return v.address }
+
+func (v *blockingValidator) SignShare(ctx context.Context, req *ShareRequest) (*SignatureShare, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// impostorValidator 冒用验证者地址，使用其他私钥签名
+type impostorValidator struct {
+	address []byte
+	*WalletValidator
+}
+
+func (v *impostorValidator) Address() []byte { return v.address }
+
+func testOperation(to []byte) *Operation {
+	return &Operation{
+		Caller:      types.MustAddressFromBytes(to),
+		Inputs:      []string{strings.Repeat("aa", 32) + ":0", strings.Repeat("bb", 32) + ":2"},
+		Outputs:     []map[string]interface{}{{"type": "asset", "owner": hex.EncodeToString(to), "amount": "1000"}},
+		Description: "treasury payout",
+	}
+}
+
+func TestThresholdCollectAndSubmit(t *testing.T) {
+	a, b, c := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	cfg := services.GovernanceConfig{ValidatorAddresses: [][]byte{a.Address(), b.Address(), c.Address()}, Threshold: 2}
+	reject := func(ctx context.Context, req *ShareRequest) error { return fmt.Errorf("not approved") }
+	// 参与方顺序与验证者顺序不同：party_id 以门限锁为准
+	mc := newThresholdMockClient(t, 2, ThresholdSignatureScheme, c, b, a)
+
+	coord, err := NewThresholdCoordinator(mc, cfg, []ValidatorSigner{
+		NewWalletValidator(a, nil),
+		NewWalletValidator(b, reject),
+		NewWalletValidator(c, nil),
+	}, ThresholdCoordinatorOptions{WaitAll: true})
+	if err != nil {
+		t.Fatalf("NewThresholdCoordinator: %v", err)
+	}
+	session, err := coord.Collect(context.Background(), testOperation(a.Address()))
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	status := session.Status
+	if !status.Reached || len(status.Signed) != 2 || len(status.Pending) != 0 ||
+		!strings.Contains(status.Failed[hex.EncodeToString(b.Address())], "not approved") {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	if _, err := session.Submit(context.Background(), mc); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	proofs := mc.finalized[0]["threshold_proofs"].([]map[string]interface{})
+	if len(proofs) != 2 {
+		t.Fatalf("expected one proof per input, got %d", len(proofs))
+	}
+	shares := proofs[1]["threshold_proof"].(map[string]interface{})["shares"].([]map[string]interface{})
+	cPub := "0x" + hex.EncodeToString(ethcrypto.CompressPubkey(&c.PrivateKey().PublicKey))
+	if len(shares) != 2 || shares[0]["party_id"] != uint32(0) || shares[0]["verification_key"] != cPub || shares[1]["party_id"] != uint32(2) {
+		t.Fatalf("unexpected shares %v", shares)
+	}
+	if scheme := proofs[0]["threshold_proof"].(map[string]interface{})["signature_scheme"]; scheme != ThresholdSignatureScheme {
+		t.Fatalf("unexpected signature scheme %v", scheme)
+	}
+}
+
+func TestThresholdLockMismatch(t *testing.T) {
+	a, b, c := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	cfg := services.GovernanceConfig{ValidatorAddresses: [][]byte{a.Address(), b.Address(), c.Address()}, Threshold: 2}
+	signers := []ValidatorSigner{NewWalletValidator(a, nil), NewWalletValidator(b, nil), NewWalletValidator(c, nil)}
+	collect := func(mc *thresholdMockClient) (*ThresholdSession, error) {
+		coord, err := NewThresholdCoordinator(mc, cfg, signers, ThresholdCoordinatorOptions{WaitAll: true})
+		if err != nil {
+			t.Fatalf("NewThresholdCoordinator: %v", err)
+		}
+		return coord.Collect(context.Background(), testOperation(a.Address()))
+	}
+
+	// 未声明方案的门限锁默认为 BLS_THRESHOLD，无法由 secp256k1 分片聚合
+	if _, err := collect(newThresholdMockClient(t, 2, "", a, b, c)); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Fatalf("expected ErrUnsupportedScheme, got %v", err)
+	}
+	if _, err := collect(newThresholdMockClient(t, 3, ThresholdSignatureScheme, a, b, c)); err == nil || !strings.Contains(err.Error(), "governance config") {
+		t.Fatalf("expected threshold mismatch error, got %v", err)
+	}
+
+	// 不在门限锁参与方中的验证者分片被拒绝
+	session, err := collect(newThresholdMockClient(t, 2, ThresholdSignatureScheme, a, c, testWallet(t, 4)))
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(session.Status.Signed) != 2 || !strings.Contains(session.Status.Failed[hex.EncodeToString(b.Address())], "party verification key") {
+		t.Fatalf("unexpected status %+v", session.Status)
+	}
+}
+
+func TestThresholdTimeoutAndInvalidShare(t *testing.T) {
+	a, b, c, d := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3), testWallet(t, 4)
+	cfg := services.GovernanceConfig{ValidatorAddresses: [][]byte{a.Address(), b.Address(), c.Address()}, Threshold: 2}
+	mc := newThresholdMockClient(t, 2, ThresholdSignatureScheme, a, b, c)
+
+	coord, err := NewThresholdCoordinator(mc, cfg, []ValidatorSigner{
+		NewWalletValidator(a, nil),
+		&blockingValidator{address: b.Address()},
+		&impostorValidator{address: c.Address(), WalletValidator: NewWalletValidator(d, nil)},
+	}, ThresholdCoordinatorOptions{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewThresholdCoordinator: %v", err)
+	}
+	session, err := coord.Collect(context.Background(), testOperation(a.Address()))
+	if !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected ErrQuorumNotReached, got %v", err)
+	}
+	status := session.Status
+	if status.Reached || len(status.Signed) != 1 ||
+		!strings.Contains(status.Failed[hex.EncodeToString(c.Address())], "does not belong") {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if _, err := session.Submit(context.Background(), mc); !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected Submit to fail without quorum, got %v", err)
+	}
+
+	if _, err := NewThresholdCoordinator(mc, cfg, []ValidatorSigner{NewWalletValidator(d, nil)}, ThresholdCoordinatorOptions{}); err == nil {
+		t.Fatalf("expected error for signer outside the validator set")
+	}
+}
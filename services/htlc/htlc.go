@@ -0,0 +1,442 @@
+// Package htlc 提供哈希时间锁合约（HTLC），用于跨方 / 跨链原子交换
+//
+// **锁定模型**：
+// WES 原生锁定条件中没有哈希锁，HTLC 输出使用 ContractLock 指向 HTLC 参考合约，
+// 交换条款（哈希锁、双方地址、超时）以 JSON 写入 ContractLock 的 parameter_schema。
+// 参考合约允许两条花费路径：
+//   - 领取：接收方签名，且交易携带 state_id = 哈希锁、execution_result_hash = 原像 的 StateOutput
+//   - 取回：达到超时高度 / 时间戳后，发送方签名
+//
+// 原像以 StateOutput 形式公开在领取交易中，另一方可通过 ExtractPreimage
+// （基于 utils.FetchAndParseTx）取得原像，在对端链上完成领取。
+//
+// **原子交换流程**：
+//
+//	preimage, hashLock, _ := htlc.NewPreimage()
+//	// Alice 在 WES 上锁定（较长超时），Bob 在对端链上用同一 hashLock 锁定（较短超时）
+//	res, _ := htlcService.Lock(ctx, &htlc.LockRequest{From: alice, Recipient: bob, Amount: amt,
+//		HashLock: hashLock, TimeoutHeight: tip + 1000}, aliceWallet)
+//	// Alice 在对端链领取时公开原像；Bob 提取原像后在 WES 上领取
+//	preimage, _ = htlcService.ExtractPreimage(ctx, claimTxHash, hashLock)
+//	_, _ = htlcService.Claim(ctx, &htlc.ClaimRequest{HTLC: res.HTLC, Preimage: preimage}, bobWallet)
+package htlc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/services/token"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+const (
+	// ContractMethod HTLC 参考合约的解锁方法（ContractLock.RequiredMethod）
+	ContractMethod = "htlc_unlock"
+	// HashAlgorithm 哈希锁算法
+	HashAlgorithm = "sha256"
+	// PreimageSize 原像长度（字节）
+	PreimageSize = 32
+)
+
+var (
+	// ErrNotExpired HTLC 尚未超时，发送方不能取回
+	ErrNotExpired = errors.New("htlc has not expired")
+	// ErrPreimageMismatch 原像与哈希锁不匹配
+	ErrPreimageMismatch = errors.New("preimage does not match hash lock")
+	// ErrPreimageNotFound 交易中没有与哈希锁匹配的原像
+	ErrPreimageNotFound = errors.New("preimage not found in transaction")
+)
+
+// HTLC 哈希时间锁输出
+type HTLC struct {
+	Outpoint         string        // "txHash:outputIndex"
+	Sender           types.Address // 发送方地址
+	Recipient        types.Address // 接收方地址
+	HashLock         []byte        // SHA-256(原像)，32字节
+	TimeoutHeight    uint64        // 超时高度（与 TimeoutTimestamp 二选一）
+	TimeoutTimestamp uint64        // 超时时间戳（秒）
+	Amount           types.Amount  // 锁定金额
+	TokenID          []byte        // 代币ID（nil 表示原生币）
+	ContractAddress  []byte        // HTLC 参考合约地址（20字节）
+}
+
+// Expired 在指定高度与时间下是否已超时（发送方可取回）
+func (h *HTLC) Expired(height uint64, now time.Time) bool {
+	if h.TimeoutHeight > 0 {
+		return height >= h.TimeoutHeight
+	}
+	return now.Unix() >= int64(h.TimeoutTimestamp)
+}
+
+// NewPreimage 生成随机原像及其哈希锁
+func NewPreimage() (preimage, hashLock []byte, err error) {
+	preimage = make([]byte, PreimageSize)
+	if _, err := rand.Read(preimage); err != nil {
+		return nil, nil, fmt.Errorf("generate preimage failed: %w", err)
+	}
+	return preimage, HashPreimage(preimage), nil
+}
+
+// HashPreimage 计算原像的哈希锁
+func HashPreimage(preimage []byte) []byte {
+	sum := sha256.Sum256(preimage)
+	return sum[:]
+}
+
+// terms 写入 ContractLock parameter_schema 的交换条款
+type terms struct {
+	HashAlgorithm    string `json:"hash_algorithm"`
+	HashLock         string `json:"hash_lock"`
+	Sender           string `json:"sender"`
+	Recipient        string `json:"recipient"`
+	TimeoutHeight    uint64 `json:"timeout_height,omitempty"`
+	TimeoutTimestamp uint64 `json:"timeout_timestamp,omitempty"`
+}
+
+// lockingCondition 构造 HTLC 输出的 ContractLock
+func (h *HTLC) lockingCondition() (resource.LockingCondition, error) {
+	schema, err := json.Marshal(terms{
+		HashAlgorithm:    HashAlgorithm,
+		HashLock:         hex.EncodeToString(h.HashLock),
+		Sender:           hex.EncodeToString(h.Sender[:]),
+		Recipient:        hex.EncodeToString(h.Recipient[:]),
+		TimeoutHeight:    h.TimeoutHeight,
+		TimeoutTimestamp: h.TimeoutTimestamp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal htlc terms failed: %w", err)
+	}
+	return &resource.ContractLockCondition{
+		ContractAddress: h.ContractAddress,
+		RequiredMethod:  ContractMethod,
+		ParameterSchema: string(schema),
+	}, nil
+}
+
+// parseHTLC 从 wes_getUTXO 条目解析 HTLC（非 HTLC 输出返回 nil）
+func parseHTLC(m map[string]interface{}) (*HTLC, error) {
+	lock := utils.LockingConditionOf(m)
+	contractLock, ok := lock["contract_lock"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	if method, _ := contractLock["required_method"].(string); method != ContractMethod {
+		return nil, nil
+	}
+	schema, _ := contractLock["parameter_schema"].(string)
+	var t terms
+	if err := json.Unmarshal([]byte(schema), &t); err != nil || t.HashAlgorithm != HashAlgorithm {
+		return nil, nil
+	}
+
+	outpoint, _ := m["outpoint"].(string)
+	h := &HTLC{
+		Outpoint:         outpoint,
+		TimeoutHeight:    t.TimeoutHeight,
+		TimeoutTimestamp: t.TimeoutTimestamp,
+	}
+	var err error
+	if h.HashLock, err = decodeHex(t.HashLock, 32); err != nil {
+		return nil, fmt.Errorf("htlc %s: hash lock: %w", outpoint, err)
+	}
+	if h.Sender, err = types.ParseAddress(t.Sender); err != nil {
+		return nil, fmt.Errorf("htlc %s: sender: %w", outpoint, err)
+	}
+	if h.Recipient, err = types.ParseAddress(t.Recipient); err != nil {
+		return nil, fmt.Errorf("htlc %s: recipient: %w", outpoint, err)
+	}
+	if addr, _ := contractLock["contract_address"].(string); addr != "" {
+		if h.ContractAddress, err = decodeHex(addr, 20); err != nil {
+			return nil, fmt.Errorf("htlc %s: contract address: %w", outpoint, err)
+		}
+	}
+	amountStr, _ := m["amount"].(string)
+	if h.Amount, err = types.ParseAmount(amountStr); err != nil {
+		return nil, fmt.Errorf("htlc %s: %w", outpoint, err)
+	}
+	if tokenIDHex, _ := m["tokenID"].(string); tokenIDHex != "" {
+		if h.TokenID, err = decodeHex(tokenIDHex, 32); err != nil {
+			return nil, fmt.Errorf("htlc %s: token ID: %w", outpoint, err)
+		}
+	}
+	return h, nil
+}
+
+// decodeHex 解码定长 hex（兼容 0x 前缀）
+func decodeHex(s string, size int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
+
+// lock 创建 HTLC 实现
+//
+// **流程**：
+// 1. 以 ContractLock（指向 HTLC 参考合约、携带交换条款）作为接收方输出的锁定条件
+// 2. 复用 token.Service.Transfer 完成选币、签名与提交
+// 3. 锁定输出为交易的第一个输出（txHash:0）
+func (s *htlcService) lock(ctx context.Context, req *LockRequest, wallets ...wallet.Wallet) (*LockResult, error) {
+	// 1. 参数验证
+	if err := validateLockRequest(req); err != nil {
+		return nil, err
+	}
+	contractAddress := req.ContractAddress
+	if len(contractAddress) == 0 {
+		contractAddress = s.contractAddress
+	}
+	if len(contractAddress) != 20 {
+		return nil, fmt.Errorf("htlc contract address is required (20 bytes)")
+	}
+
+	// 2. 获取 Wallet
+	w := s.getWallet(wallets...)
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	// 3. 构造锁定条件
+	h := &HTLC{
+		Sender:           req.From,
+		Recipient:        req.Recipient,
+		HashLock:         req.HashLock,
+		TimeoutHeight:    req.TimeoutHeight,
+		TimeoutTimestamp: req.TimeoutTimestamp,
+		Amount:           req.Amount,
+		TokenID:          req.TokenID,
+		ContractAddress:  contractAddress,
+	}
+	lock, err := h.lockingCondition()
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 转账到接收方（带 HTLC 锁定条件）
+	result, err := s.token.Transfer(ctx, &token.TransferRequest{
+		From:             req.From,
+		To:               req.Recipient,
+		Amount:           req.Amount,
+		TokenID:          req.TokenID,
+		LockingCondition: lock,
+	}, w)
+	if err != nil {
+		return nil, fmt.Errorf("lock htlc failed: %w", err)
+	}
+
+	h.Outpoint = utils.GetOutpoint(strings.TrimPrefix(result.TxHash, "0x"), 0)
+	return &LockResult{
+		TxHash:  result.TxHash,
+		HTLC:    h,
+		Success: true,
+	}, nil
+}
+
+// validateLockRequest 验证创建 HTLC 请求
+func validateLockRequest(req *LockRequest) error {
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
+	}
+	if req.Recipient.IsZero() {
+		return fmt.Errorf("recipient address is required")
+	}
+	if req.From == req.Recipient {
+		return fmt.Errorf("recipient must differ from sender")
+	}
+	if req.Amount.IsZero() {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if req.TokenID != nil && len(req.TokenID) != 32 {
+		return fmt.Errorf("tokenID must be 32 bytes if provided")
+	}
+	if len(req.HashLock) != 32 {
+		return fmt.Errorf("hash lock must be 32 bytes")
+	}
+	if (req.TimeoutHeight == 0) == (req.TimeoutTimestamp == 0) {
+		return fmt.Errorf("exactly one of timeout height or timeout timestamp is required")
+	}
+	return nil
+}
+
+// claim 领取 HTLC 实现
+//
+// 领取交易消费 HTLC 输出，将资产转给接收方，并附带公开原像的 StateOutput。
+func (s *htlcService) claim(ctx context.Context, req *ClaimRequest, wallets ...wallet.Wallet) (*ClaimResult, error) {
+	// 1. 参数验证
+	if err := validateHTLC(req.HTLC); err != nil {
+		return nil, err
+	}
+	if len(req.Preimage) != PreimageSize {
+		return nil, fmt.Errorf("preimage must be %d bytes", PreimageSize)
+	}
+	if !bytes.Equal(HashPreimage(req.Preimage), req.HTLC.HashLock) {
+		return nil, ErrPreimageMismatch
+	}
+
+	// 2. 获取 Wallet 并验证为接收方
+	w := s.getWallet(wallets...)
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	if !bytes.Equal(w.Address(), req.HTLC.Recipient[:]) {
+		return nil, fmt.Errorf("wallet address does not match htlc recipient")
+	}
+
+	// 3. 构建、签名并提交
+	draftJSON, err := buildClaimDraft(req.HTLC, req.Preimage)
+	if err != nil {
+		return nil, fmt.Errorf("build claim draft failed: %w", err)
+	}
+	txHash, err := signAndSend(ctx, s.client, w, draftJSON, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &ClaimResult{TxHash: txHash, Success: true}, nil
+}
+
+// refund 取回 HTLC 实现
+//
+// 仅在 HTLC 超时后允许：高度超时以节点当前高度判断，时间戳超时以本地时间判断。
+func (s *htlcService) refund(ctx context.Context, req *RefundRequest, wallets ...wallet.Wallet) (*RefundResult, error) {
+	// 1. 参数验证
+	if err := validateHTLC(req.HTLC); err != nil {
+		return nil, err
+	}
+
+	// 2. 获取 Wallet 并验证为发送方
+	w := s.getWallet(wallets...)
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	if !bytes.Equal(w.Address(), req.HTLC.Sender[:]) {
+		return nil, fmt.Errorf("wallet address does not match htlc sender")
+	}
+
+	// 3. 检查超时
+	height, err := currentHeight(ctx, s.client)
+	if err != nil {
+		return nil, err
+	}
+	if !req.HTLC.Expired(height, time.Now()) {
+		return nil, ErrNotExpired
+	}
+
+	// 4. 构建、签名并提交
+	draftJSON, err := buildRefundDraft(req.HTLC)
+	if err != nil {
+		return nil, fmt.Errorf("build refund draft failed: %w", err)
+	}
+	txHash, err := signAndSend(ctx, s.client, w, draftJSON, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &RefundResult{TxHash: txHash, Success: true}, nil
+}
+
+// validateHTLC 验证 HTLC 描述是否完整
+func validateHTLC(h *HTLC) error {
+	if h == nil {
+		return fmt.Errorf("htlc is required")
+	}
+	if !strings.Contains(h.Outpoint, ":") {
+		return fmt.Errorf("invalid htlc outpoint: %q", h.Outpoint)
+	}
+	if h.Sender.IsZero() || h.Recipient.IsZero() {
+		return fmt.Errorf("htlc sender and recipient are required")
+	}
+	if len(h.HashLock) != 32 {
+		return fmt.Errorf("htlc hash lock must be 32 bytes")
+	}
+	if h.Amount.IsZero() {
+		return fmt.Errorf("htlc amount must be greater than 0")
+	}
+	return nil
+}
+
+// findHTLCs 查询接收方地址下的 HTLC 输出
+//
+// 服务配置了参考合约地址时，只返回指向该合约的 HTLC。
+func (s *htlcService) findHTLCs(ctx context.Context, recipient []byte) ([]*HTLC, error) {
+	if len(recipient) != 20 {
+		return nil, fmt.Errorf("recipient address must be 20 bytes")
+	}
+	addressBase58, err := utils.AddressBytesToBase58(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("convert address to Base58 failed: %w", err)
+	}
+	result, err := s.client.Call(ctx, "wes_getUTXO", []interface{}{addressBase58})
+	if err != nil {
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
+	}
+	utxoMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid UTXO response format")
+	}
+	utxos, _ := utxoMap["utxos"].([]interface{})
+
+	var htlcs []*HTLC
+	for _, item := range utxos {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		h, err := parseHTLC(m)
+		if err != nil {
+			return nil, err
+		}
+		if h == nil || !bytes.Equal(h.Recipient[:], recipient) {
+			continue
+		}
+		if len(s.contractAddress) > 0 && !bytes.Equal(h.ContractAddress, s.contractAddress) {
+			continue
+		}
+		htlcs = append(htlcs, h)
+	}
+	return htlcs, nil
+}
+
+// extractPreimage 从领取交易中提取原像
+//
+// 查找 state_id 等于哈希锁的 StateOutput，并校验 SHA-256(原像) == 哈希锁。
+func extractPreimage(ctx context.Context, c client.Client, claimTxHash string, hashLock []byte) ([]byte, error) {
+	if len(hashLock) != 32 {
+		return nil, fmt.Errorf("hash lock must be 32 bytes")
+	}
+	parsedTx, err := utils.FetchAndParseTx(ctx, c, strings.TrimPrefix(claimTxHash, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("fetch claim transaction failed: %w", err)
+	}
+	for _, output := range utils.FindStateOutputs(parsedTx.Outputs) {
+		if !bytes.Equal(output.StateID, hashLock) {
+			continue
+		}
+		if bytes.Equal(HashPreimage(output.StateData), hashLock) {
+			return output.StateData, nil
+		}
+	}
+	return nil, ErrPreimageNotFound
+}
+
+// currentHeight 查询节点当前区块高度
+func currentHeight(ctx context.Context, c client.Client) (uint64, error) {
+	result, err := c.Call(ctx, "wes_blockNumber", []interface{}{})
+	if err != nil {
+		return 0, fmt.Errorf("query block number failed: %w", err)
+	}
+	return utils.ParseUint(result), nil
+}
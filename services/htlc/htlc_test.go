@@ -0,0 +1,279 @@
+package htlc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/services"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// htlcMockClient 模拟节点：按地址返回 UTXO，按草稿计算签名哈希并记录提交的草稿
+type htlcMockClient struct {
+	utxos  map[string][]interface{} // Base58 地址 → UTXO 列表
+	height uint64
+	drafts []map[string]interface{}
+	txs    map[string]interface{} // 交易哈希 → wes_getTransactionByHash 结果
+	sent   int
+}
+
+func (m *htlcMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		address := params.([]interface{})[0].(string)
+		return map[string]interface{}{"utxos": m.utxos[address]}, nil
+	case "wes_blockNumber":
+		return fmt.Sprintf("0x%x", m.height), nil
+	case "wes_getTransactionByHash":
+		tx, ok := m.txs[params.([]interface{})[0].(string)]
+		if !ok {
+			return nil, fmt.Errorf("transaction not found")
+		}
+		return tx, nil
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_finalizeTransactionFromDraft":
+		p := params.(map[string]interface{})
+		var draft map[string]interface{}
+		if err := json.Unmarshal(p["draft"].(json.RawMessage), &draft); err != nil {
+			return nil, err
+		}
+		m.drafts = append(m.drafts, draft)
+		return map[string]interface{}{"tx": p["unsignedTx"]}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *htlcMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent++
+	return &client.SendTxResult{TxHash: fmt.Sprintf("%064x", m.sent), Accepted: true}, nil
+}
+
+func (m *htlcMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *htlcMockClient) Close() error { return nil }
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+func base58(t *testing.T, address []byte) string {
+	t.Helper()
+	s, err := utils.AddressBytesToBase58(address)
+	if err != nil {
+		t.Fatalf("AddressBytesToBase58: %v", err)
+	}
+	return s
+}
+
+var testContract = bytes.Repeat([]byte{0xcc}, 20)
+
+// htlcUTXO 将锁定交易草稿的第一个输出转换为 wes_getUTXO 条目
+func htlcUTXO(outpoint string, draft map[string]interface{}) map[string]interface{} {
+	out := draft["outputs"].([]interface{})[0].(map[string]interface{})
+	return map[string]interface{}{
+		"outpoint":          outpoint,
+		"amount":            out["amount"],
+		"height":            float64(10),
+		"locking_condition": out["locking_condition"],
+	}
+}
+
+func TestLockClaimAndExtractPreimage(t *testing.T) {
+	alice, bob := testWallet(t, 1), testWallet(t, 2)
+	mc := &htlcMockClient{height: 10, utxos: map[string][]interface{}{
+		base58(t, alice.Address()): {map[string]interface{}{"outpoint": strings.Repeat("aa", 32) + ":0", "amount": "1000"}},
+	}}
+	svc := NewServiceWithConfig(mc, &services.Config{HTLCContractAddress: testContract}, nil)
+	ctx := context.Background()
+
+	preimage, hashLock, err := NewPreimage()
+	if err != nil {
+		t.Fatalf("NewPreimage: %v", err)
+	}
+	res, err := svc.Lock(ctx, &LockRequest{
+		From:          types.MustAddressFromBytes(alice.Address()),
+		Recipient:     types.MustAddressFromBytes(bob.Address()),
+		Amount:        types.NewAmount(300),
+		HashLock:      hashLock,
+		TimeoutHeight: 100,
+	}, alice)
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if res.HTLC.Outpoint != fmt.Sprintf("%064x:0", 1) {
+		t.Fatalf("outpoint = %s", res.HTLC.Outpoint)
+	}
+
+	// 接收方从链上发现 HTLC
+	mc.utxos[base58(t, bob.Address())] = []interface{}{
+		htlcUTXO(res.HTLC.Outpoint, mc.drafts[0]),
+		map[string]interface{}{"outpoint": strings.Repeat("bb", 32) + ":0", "amount": "5"},
+	}
+	found, err := svc.FindHTLCs(ctx, bob.Address())
+	if err != nil || len(found) != 1 {
+		t.Fatalf("FindHTLCs = %v, %v", found, err)
+	}
+	h := found[0]
+	if !bytes.Equal(h.HashLock, hashLock) || !bytes.Equal(h.Sender[:], alice.Address()) ||
+		h.TimeoutHeight != 100 || h.Amount.String() != "300" || !bytes.Equal(h.ContractAddress, testContract) {
+		t.Fatalf("unexpected htlc %+v", h)
+	}
+
+	if _, err := svc.Claim(ctx, &ClaimRequest{HTLC: h, Preimage: make([]byte, 32)}, bob); !errors.Is(err, ErrPreimageMismatch) {
+		t.Fatalf("expected ErrPreimageMismatch, got %v", err)
+	}
+	if _, err := svc.Claim(ctx, &ClaimRequest{HTLC: h, Preimage: preimage}, alice); err == nil {
+		t.Fatalf("only the recipient may claim")
+	}
+	claim, err := svc.Claim(ctx, &ClaimRequest{HTLC: h, Preimage: preimage}, bob)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	outputs := mc.drafts[1]["outputs"].([]interface{})
+	state := outputs[1].(map[string]interface{})
+	if state["state_id"] != hex.EncodeToString(hashLock) || state["execution_result_hash"] != hex.EncodeToString(preimage) {
+		t.Fatalf("claim draft should reveal the preimage, got %v", state)
+	}
+
+	// 对方从领取交易中提取原像
+	mc.txs = map[string]interface{}{claim.TxHash: map[string]interface{}{
+		"hash": claim.TxHash,
+		"outputs": []interface{}{
+			map[string]interface{}{"asset": map[string]interface{}{"native_coin": map[string]interface{}{"amount": "300"}}},
+			map[string]interface{}{"state": map[string]interface{}{
+				"state_id":              hex.EncodeToString(hashLock),
+				"execution_result_hash": hex.EncodeToString(preimage),
+			}},
+		},
+	}}
+	got, err := svc.ExtractPreimage(ctx, claim.TxHash, hashLock)
+	if err != nil || !bytes.Equal(got, preimage) {
+		t.Fatalf("ExtractPreimage = %x, %v", got, err)
+	}
+	if _, err := svc.ExtractPreimage(ctx, claim.TxHash, make([]byte, 32)); !errors.Is(err, ErrPreimageNotFound) {
+		t.Fatalf("expected ErrPreimageNotFound, got %v", err)
+	}
+}
+
+func TestRefundAndWatcher(t *testing.T) {
+	alice, bob := testWallet(t, 1), testWallet(t, 2)
+	_, hashLock, _ := NewPreimage()
+	h := &HTLC{
+		Outpoint:        strings.Repeat("ab", 32) + ":0",
+		Sender:          types.MustAddressFromBytes(alice.Address()),
+		Recipient:       types.MustAddressFromBytes(bob.Address()),
+		HashLock:        hashLock,
+		TimeoutHeight:   100,
+		Amount:          types.NewAmount(300),
+		ContractAddress: testContract,
+	}
+	mc := &htlcMockClient{height: 99, utxos: map[string][]interface{}{
+		base58(t, bob.Address()): {map[string]interface{}{"outpoint": h.Outpoint, "amount": "300"}},
+	}}
+	svc := NewServiceWithWallet(mc, alice)
+	ctx := context.Background()
+
+	watcher := NewWatcher(mc, 0)
+	watcher.Add(h)
+	if events, err := watcher.Check(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("events before expiry = %v, %v", events, err)
+	}
+	if _, err := svc.Refund(ctx, &RefundRequest{HTLC: h}); !errors.Is(err, ErrNotExpired) {
+		t.Fatalf("expected ErrNotExpired, got %v", err)
+	}
+
+	mc.height = 100
+	events, err := watcher.Check(ctx)
+	if err != nil || len(events) != 1 || events[0].Type != EventExpired {
+		t.Fatalf("expected expired event, got %v, %v", events, err)
+	}
+	if events, _ := watcher.Check(ctx); len(events) != 0 {
+		t.Fatalf("expired event should fire once, got %v", events)
+	}
+
+	if _, err := svc.Refund(ctx, &RefundRequest{HTLC: h}); err != nil {
+		t.Fatalf("Refund: %v", err)
+	}
+	out := mc.drafts[0]["outputs"].([]interface{})[0].(map[string]interface{})
+	if out["owner"] != hex.EncodeToString(alice.Address()) || out["amount"] != "300" {
+		t.Fatalf("refund should return funds to the sender, got %v", out)
+	}
+
+	mc.utxos[base58(t, bob.Address())] = nil
+	events, err = watcher.Check(ctx)
+	if err != nil || len(events) != 1 || events[0].Type != EventSpent || watcher.Len() != 0 {
+		t.Fatalf("expected spent event, got %v, %v", events, err)
+	}
+}
+
+func TestRefundWithWatchOnlyWallet(t *testing.T) {
+	alice, bob := testWallet(t, 1), testWallet(t, 2)
+	_, hashLock, _ := NewPreimage()
+	h := &HTLC{
+		Outpoint:        strings.Repeat("ab", 32) + ":0",
+		Sender:          types.MustAddressFromBytes(alice.Address()),
+		Recipient:       types.MustAddressFromBytes(bob.Address()),
+		HashLock:        hashLock,
+		TimeoutHeight:   100,
+		Amount:          types.NewAmount(300),
+		ContractAddress: testContract,
+	}
+	mc := &htlcMockClient{height: 100}
+	watch, err := wallet.NewWatchOnlyWallet(alice.Address())
+	if err != nil {
+		t.Fatalf("NewWatchOnlyWallet: %v", err)
+	}
+	svc := NewServiceWithWallet(mc, watch)
+	ctx := context.Background()
+
+	// 签名步骤返回可导出的未签名草稿
+	_, err = svc.Refund(ctx, &RefundRequest{HTLC: h})
+	if !errors.Is(err, wallet.ErrWatchOnly) {
+		t.Fatalf("expected ErrWatchOnly, got %v", err)
+	}
+	unsigned, ok := wallet.AsUnsignedDraft(err)
+	if !ok || len(unsigned.Inputs) != 1 || unsigned.Signer != hex.EncodeToString(alice.Address()) || mc.sent != 0 {
+		t.Fatalf("unexpected unsigned draft %+v", unsigned)
+	}
+
+	// 外部签名方对导出的签名哈希签名后提交
+	hash, _ := hex.DecodeString(unsigned.Inputs[0].Sighash)
+	sig, err := alice.SignHash(hash)
+	if err != nil {
+		t.Fatalf("SignHash: %v", err)
+	}
+	pub := ethcrypto.CompressPubkey(&alice.PrivateKey().PublicKey)
+	if _, err := unsigned.Submit(ctx, mc, nil); err == nil {
+		t.Fatalf("expected error for missing signatures")
+	}
+	txHash, err := unsigned.Submit(ctx, mc, []wallet.DraftSignature{{
+		InputIndex:  unsigned.Inputs[0].InputIndex,
+		SighashType: unsigned.Inputs[0].SighashType,
+		Pubkey:      "0x" + hex.EncodeToString(pub),
+		Signature:   "0x" + hex.EncodeToString(sig),
+	}})
+	if err != nil || txHash == "" || len(mc.drafts) != 1 {
+		t.Fatalf("Submit = %q, %v", txHash, err)
+	}
+}
@@ -0,0 +1,146 @@
+package htlc
+
+import (
+	"context"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services"
+	"github.com/weisyn/client-sdk-go/services/token"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// Service HTLC 业务服务接口
+type Service interface {
+	// Lock 创建 HTLC 输出（发送方锁定资产）
+	Lock(ctx context.Context, req *LockRequest, wallet ...wallet.Wallet) (*LockResult, error)
+
+	// Claim 接收方使用原像领取 HTLC 输出
+	Claim(ctx context.Context, req *ClaimRequest, wallet ...wallet.Wallet) (*ClaimResult, error)
+
+	// Refund 超时后发送方取回 HTLC 输出
+	Refund(ctx context.Context, req *RefundRequest, wallet ...wallet.Wallet) (*RefundResult, error)
+
+	// FindHTLCs 查询接收方地址下未花费的 HTLC 输出
+	FindHTLCs(ctx context.Context, recipient []byte) ([]*HTLC, error)
+
+	// ExtractPreimage 从领取交易中提取原像（用于跨链交换的另一方领取）
+	ExtractPreimage(ctx context.Context, claimTxHash string, hashLock []byte) ([]byte, error)
+}
+
+// htlcService HTLC 服务实现
+type htlcService struct {
+	client          client.Client
+	wallet          wallet.Wallet // 可选：默认 Wallet
+	contractAddress []byte        // 可选：默认 HTLC 参考合约地址
+	token           token.Service
+}
+
+// NewService 创建 HTLC 服务（不带 Wallet）
+func NewService(client client.Client) Service {
+	return &htlcService{
+		client: client,
+		token:  token.NewService(client),
+	}
+}
+
+// NewServiceWithWallet 创建带默认 Wallet 的 HTLC 服务
+func NewServiceWithWallet(client client.Client, w wallet.Wallet) Service {
+	return &htlcService{
+		client: client,
+		wallet: w,
+		token:  token.NewService(client),
+	}
+}
+
+// NewServiceWithConfig 创建带业务配置的 HTLC 服务（使用 Config.HTLCContractAddress 作为默认参考合约）
+func NewServiceWithConfig(client client.Client, cfg *services.Config, w wallet.Wallet) Service {
+	s := &htlcService{
+		client: client,
+		wallet: w,
+		token:  token.NewService(client),
+	}
+	if cfg != nil {
+		s.contractAddress = cfg.HTLCContractAddress
+	}
+	return s
+}
+
+// getWallet 获取 Wallet（优先使用参数，其次使用默认 Wallet）
+func (s *htlcService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
+	if len(wallets) > 0 && wallets[0] != nil {
+		return wallets[0]
+	}
+	return s.wallet
+}
+
+// LockRequest 创建 HTLC 请求
+type LockRequest struct {
+	From      types.Address // 发送方地址（超时后可取回）
+	Recipient types.Address // 接收方地址（凭原像领取）
+	Amount    types.Amount  // 锁定金额
+	TokenID   []byte        // 代币ID（32字节，nil 表示原生币）
+	HashLock  []byte        // SHA-256(原像)，32字节
+
+	// 超时条件（二选一）：达到该高度 / 时间戳后发送方可取回
+	TimeoutHeight    uint64
+	TimeoutTimestamp uint64
+
+	// ContractAddress HTLC 参考合约地址（20字节，为空时使用服务配置）
+	ContractAddress []byte
+}
+
+// LockResult 创建 HTLC 结果
+type LockResult struct {
+	TxHash  string
+	HTLC    *HTLC // 新建的 HTLC（Outpoint 为锁定交易的第一个输出）
+	Success bool
+}
+
+// ClaimRequest 领取 HTLC 请求（钱包须为接收方）
+type ClaimRequest struct {
+	HTLC     *HTLC  // 待领取的 HTLC（可由 LockResult 或 FindHTLCs 获得）
+	Preimage []byte // 原像（32字节）
+}
+
+// ClaimResult 领取 HTLC 结果
+type ClaimResult struct {
+	TxHash  string
+	Success bool
+}
+
+// RefundRequest 取回 HTLC 请求（钱包须为发送方）
+type RefundRequest struct {
+	HTLC *HTLC // 待取回的 HTLC
+}
+
+// RefundResult 取回 HTLC 结果
+type RefundResult struct {
+	TxHash  string
+	Success bool
+}
+
+// Lock 创建 HTLC（实现在htlc.go）
+func (s *htlcService) Lock(ctx context.Context, req *LockRequest, wallets ...wallet.Wallet) (*LockResult, error) {
+	return s.lock(ctx, req, wallets...)
+}
+
+// Claim 领取 HTLC（实现在htlc.go）
+func (s *htlcService) Claim(ctx context.Context, req *ClaimRequest, wallets ...wallet.Wallet) (*ClaimResult, error) {
+	return s.claim(ctx, req, wallets...)
+}
+
+// Refund 取回 HTLC（实现在htlc.go）
+func (s *htlcService) Refund(ctx context.Context, req *RefundRequest, wallets ...wallet.Wallet) (*RefundResult, error) {
+	return s.refund(ctx, req, wallets...)
+}
+
+// FindHTLCs 查询接收方地址下的 HTLC（实现在htlc.go）
+func (s *htlcService) FindHTLCs(ctx context.Context, recipient []byte) ([]*HTLC, error) {
+	return s.findHTLCs(ctx, recipient)
+}
+
+// ExtractPreimage 从领取交易中提取原像（实现在htlc.go）
+func (s *htlcService) ExtractPreimage(ctx context.Context, claimTxHash string, hashLock []byte) ([]byte, error) {
+	return extractPreimage(ctx, s.client, claimTxHash, hashLock)
+}
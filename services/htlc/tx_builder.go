@@ -0,0 +1,153 @@
+package htlc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// buildClaimDraft 构建领取 HTLC 的交易草稿
+//
+// **输出**：
+// 0. 资产输出：HTLC 金额转给接收方
+// 1. StateOutput：state_id = 哈希锁，execution_result_hash = 原像（供参考合约校验与对方提取）
+//
+// 注意：手续费从接收者扣除，领取金额 = HTLC 金额。
+func buildClaimDraft(h *HTLC, preimage []byte) ([]byte, error) {
+	draft, err := newSpendDraft(h, h.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	outputs := draft["outputs"].([]map[string]interface{})
+	draft["outputs"] = append(outputs, map[string]interface{}{
+		"type":                  "state",
+		"owner":                 hex.EncodeToString(h.Recipient[:]),
+		"state_id":              hex.EncodeToString(h.HashLock),
+		"execution_result_hash": hex.EncodeToString(preimage),
+	})
+
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return nil, fmt.Errorf("marshal draft failed: %w", err)
+	}
+	return draftJSON, nil
+}
+
+// buildRefundDraft 构建取回 HTLC 的交易草稿（HTLC 金额返还发送方）
+func buildRefundDraft(h *HTLC) ([]byte, error) {
+	draft, err := newSpendDraft(h, h.Sender)
+	if err != nil {
+		return nil, err
+	}
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return nil, fmt.Errorf("marshal draft failed: %w", err)
+	}
+	return draftJSON, nil
+}
+
+// newSpendDraft 构建消费 HTLC 输出、将全部金额转给 to 的草稿
+func newSpendDraft(h *HTLC, to types.Address) (map[string]interface{}, error) {
+	outpointParts := strings.Split(h.Outpoint, ":")
+	if len(outpointParts) != 2 {
+		return nil, fmt.Errorf("invalid outpoint format: %s", h.Outpoint)
+	}
+	outputIndex, err := strconv.ParseUint(outpointParts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output index: %w", err)
+	}
+
+	output := map[string]interface{}{
+		"type":   "asset",
+		"owner":  hex.EncodeToString(to[:]),
+		"amount": h.Amount.String(),
+	}
+	if len(h.TokenID) > 0 {
+		output["token_id"] = hex.EncodeToString(h.TokenID)
+	}
+
+	return map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs": []map[string]interface{}{
+			{
+				"tx_hash":           outpointParts[0],
+				"output_index":      uint32(outputIndex),
+				"is_reference_only": false,
+			},
+		},
+		"outputs": []map[string]interface{}{output},
+		"metadata": map[string]interface{}{
+			"caller_address": hex.EncodeToString(to[:]),
+		},
+	}, nil
+}
+
+// signAndSend 对草稿指定输入签名、finalize 并提交，返回交易哈希
+func signAndSend(ctx context.Context, c client.Client, w wallet.Wallet, draftJSON []byte, inputIndex uint32) (string, error) {
+	// 1. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, c, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
+	if err != nil {
+		return "", fmt.Errorf("compute signature hash failed: %w", err)
+	}
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return "", err
+	}
+
+	// 2. 使用 Wallet 签名
+	sigBytes, err := w.SignHash(sighash.Hash(inputIndex))
+	if err != nil {
+		return "", fmt.Errorf("sign hash failed: %w", err)
+	}
+	priv := w.PrivateKey()
+	if priv == nil {
+		return "", fmt.Errorf("wallet private key is nil")
+	}
+	pubCompressed := ethcrypto.CompressPubkey(&priv.PublicKey)
+
+	// 3. 调用 wes_finalizeTransactionFromDraft
+	finalizeParams := map[string]interface{}{
+		"draft":      json.RawMessage(draftJSON),
+		"unsignedTx": sighash.UnsignedTx,
+		"signatures": []map[string]interface{}{
+			{
+				"input_index":  inputIndex,
+				"sighash_type": "SIGHASH_ALL",
+				"pubkey":       "0x" + hex.EncodeToString(pubCompressed),
+				"signature":    "0x" + hex.EncodeToString(sigBytes),
+			},
+		},
+	}
+	finalResult, err := c.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+
+	// 4. 提交交易
+	sendResult, err := c.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	return sendResult.TxHash, nil
+}
@@ -0,0 +1,183 @@
+package htlc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// DefaultWatchInterval 默认轮询间隔
+const DefaultWatchInterval = 15 * time.Second
+
+// EventType 监视事件类型
+type EventType string
+
+const (
+	// EventExpired HTLC 已超时且仍未花费（发送方可取回）
+	EventExpired EventType = "expired"
+	// EventSpent HTLC 已被花费（领取或取回），随后不再监视
+	EventSpent EventType = "spent"
+)
+
+// Event 监视事件
+type Event struct {
+	Type   EventType
+	HTLC   *HTLC
+	Height uint64    // 检测时的区块高度
+	Time   time.Time // 检测时间
+}
+
+// Watcher 轮询监视一组 HTLC 的超时与花费状态
+//
+// 每个 HTLC 的 EventExpired 只触发一次；EventSpent 触发后自动移除。
+// 典型用法：发送方收到 EventExpired 后调用 Refund；接收方在自己的超时前完成 Claim。
+type Watcher struct {
+	client   client.Client
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	htlcs   map[string]*HTLC // outpoint → HTLC
+	expired map[string]bool  // 已报告超时的 outpoint
+}
+
+// NewWatcher 创建监视器（interval <= 0 时使用 DefaultWatchInterval）
+func NewWatcher(c client.Client, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	return &Watcher{
+		client:   c,
+		interval: interval,
+		now:      time.Now,
+		htlcs:    make(map[string]*HTLC),
+		expired:  make(map[string]bool),
+	}
+}
+
+// Add 添加监视的 HTLC
+func (w *Watcher) Add(h *HTLC) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.htlcs[h.Outpoint] = h
+}
+
+// Remove 移除监视的 HTLC
+func (w *Watcher) Remove(outpoint string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.htlcs, outpoint)
+	delete(w.expired, outpoint)
+}
+
+// Len 当前监视的 HTLC 数量
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.htlcs)
+}
+
+// Check 执行一次检查并返回新产生的事件（按 outpoint 排序）
+//
+// 花费状态通过查询接收方地址的 UTXO 判断：HTLC 输出不再出现即视为已花费。
+func (w *Watcher) Check(ctx context.Context) ([]*Event, error) {
+	w.mu.Lock()
+	byRecipient := make(map[types.Address][]*HTLC)
+	for _, h := range w.htlcs {
+		byRecipient[h.Recipient] = append(byRecipient[h.Recipient], h)
+	}
+	w.mu.Unlock()
+	if len(byRecipient) == 0 {
+		return nil, nil
+	}
+
+	height, err := currentHeight(ctx, w.client)
+	if err != nil {
+		return nil, err
+	}
+	now := w.now()
+
+	var events []*Event
+	for _, htlcs := range byRecipient {
+		unspent, err := queryOutpoints(ctx, w.client, htlcs[0].Recipient)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range htlcs {
+			switch {
+			case !unspent[h.Outpoint]:
+				events = append(events, &Event{Type: EventSpent, HTLC: h, Height: height, Time: now})
+			case h.Expired(height, now) && !w.markExpired(h.Outpoint):
+				events = append(events, &Event{Type: EventExpired, HTLC: h, Height: height, Time: now})
+			}
+		}
+	}
+
+	for _, ev := range events {
+		if ev.Type == EventSpent {
+			w.Remove(ev.HTLC.Outpoint)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].HTLC.Outpoint < events[j].HTLC.Outpoint })
+	return events, nil
+}
+
+// markExpired 标记已报告超时，返回此前是否已标记
+func (w *Watcher) markExpired(outpoint string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	reported := w.expired[outpoint]
+	w.expired[outpoint] = true
+	return reported
+}
+
+// Run 按间隔轮询，将事件写入 events，直到 ctx 取消
+//
+// 单次检查失败（如节点暂时不可用）不会中断监视，等待下一轮重试。
+func (w *Watcher) Run(ctx context.Context, events chan<- *Event) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if evs, err := w.Check(ctx); err == nil {
+			for _, ev := range evs {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// queryOutpoints 查询地址当前未花费的 outpoint 集合
+func queryOutpoints(ctx context.Context, c client.Client, address types.Address) (map[string]bool, error) {
+	result, err := c.Call(ctx, "wes_getUTXO", []interface{}{address.String()})
+	if err != nil {
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
+	}
+	utxoMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid UTXO response format")
+	}
+	utxos, _ := utxoMap["utxos"].([]interface{})
+	outpoints := make(map[string]bool, len(utxos))
+	for _, item := range utxos {
+		if m, ok := item.(map[string]interface{}); ok {
+			if outpoint, _ := m["outpoint"].(string); outpoint != "" {
+				outpoints[outpoint] = true
+			}
+		}
+	}
+	return outpoints, nil
+}
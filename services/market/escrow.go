@@ -6,10 +6,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -41,7 +41,7 @@ func (s *marketService) createEscrow(ctx context.Context, req *CreateEscrowReque
 	}
 
 	// 3. 验证地址匹配（买方创建托管）
-	if !bytes.Equal(w.Address(), req.Buyer) {
+	if !bytes.Equal(w.Address(), req.Buyer[:]) {
 		return nil, fmt.Errorf("wallet address does not match buyer address")
 	}
 
@@ -51,8 +51,8 @@ func (s *marketService) createEscrow(ctx context.Context, req *CreateEscrowReque
 	draftJSON, inputIndex, err := buildEscrowDraft(
 		ctx,
 		s.client,
-		req.Buyer,
-		req.Seller,
+		req.Buyer[:],
+		req.Seller[:],
 		req.Amount,
 		req.TokenID,
 		req.Expiry,
@@ -62,32 +62,17 @@ func (s *marketService) createEscrow(ctx context.Context, req *CreateEscrowReque
 		return nil, fmt.Errorf("build escrow draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -146,7 +131,7 @@ func (s *marketService) createEscrow(ctx context.Context, req *CreateEscrowReque
 		for _, output := range parsedTx.Outputs {
 			if output.Type == "asset" {
 				// 托管输出可能是买方或卖方地址（取决于具体实现）
-				if bytes.Equal(output.Owner, req.Buyer) || bytes.Equal(output.Owner, req.Seller) {
+				if bytes.Equal(output.Owner, req.Buyer[:]) || bytes.Equal(output.Owner, req.Seller[:]) {
 					escrowID = []byte(output.Outpoint)
 					break
 				}
@@ -164,15 +149,15 @@ func (s *marketService) createEscrow(ctx context.Context, req *CreateEscrowReque
 // validateCreateEscrowRequest 验证创建托管请求
 func (s *marketService) validateCreateEscrowRequest(req *CreateEscrowRequest) error {
 	// 1. 验证地址
-	if len(req.Buyer) != 20 {
-		return fmt.Errorf("buyer address must be 20 bytes")
+	if req.Buyer.IsZero() {
+		return fmt.Errorf("buyer address is required")
 	}
-	if len(req.Seller) != 20 {
-		return fmt.Errorf("seller address must be 20 bytes")
+	if req.Seller.IsZero() {
+		return fmt.Errorf("seller address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -212,7 +197,7 @@ func (s *marketService) releaseEscrow(ctx context.Context, req *ReleaseEscrowReq
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -220,40 +205,25 @@ func (s *marketService) releaseEscrow(ctx context.Context, req *ReleaseEscrowReq
 	draftJSON, inputIndex, err := buildReleaseEscrowDraft(
 		ctx,
 		s.client,
-		req.From,
-		req.SellerAddress,
+		req.From[:],
+		req.SellerAddress[:],
 		req.EscrowID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("build release escrow draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -315,11 +285,11 @@ func (s *marketService) releaseEscrow(ctx context.Context, req *ReleaseEscrowReq
 // validateReleaseEscrowRequest 验证释放托管请求
 func (s *marketService) validateReleaseEscrowRequest(req *ReleaseEscrowRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.SellerAddress) != 20 {
-		return fmt.Errorf("seller address must be 20 bytes")
+	if req.SellerAddress.IsZero() {
+		return fmt.Errorf("seller address is required")
 	}
 
 	// 2. 验证托管ID
@@ -358,7 +328,7 @@ func (s *marketService) refundEscrow(ctx context.Context, req *RefundEscrowReque
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -366,40 +336,25 @@ func (s *marketService) refundEscrow(ctx context.Context, req *RefundEscrowReque
 	draftJSON, inputIndex, err := buildRefundEscrowDraft(
 		ctx,
 		s.client,
-		req.From,
-		req.BuyerAddress,
+		req.From[:],
+		req.BuyerAddress[:],
 		req.EscrowID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("build refund escrow draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -459,11 +414,11 @@ func (s *marketService) refundEscrow(ctx context.Context, req *RefundEscrowReque
 // validateRefundEscrowRequest 验证退款托管请求
 func (s *marketService) validateRefundEscrowRequest(req *RefundEscrowRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.BuyerAddress) != 20 {
-		return fmt.Errorf("buyer address must be 20 bytes")
+	if req.BuyerAddress.IsZero() {
+		return fmt.Errorf("buyer address is required")
 	}
 
 	// 2. 验证托管ID
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -39,7 +40,7 @@ func (s *marketService) addLiquidity(ctx context.Context, req *AddLiquidityReque
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -53,7 +54,7 @@ func (s *marketService) addLiquidity(ctx context.Context, req *AddLiquidityReque
 	// 规范来源：weisyn.git/docs/components/core/ispc/abi-and-payload.md
 	payloadOptions := utils.BuildPayloadOptions{
 		IncludeFrom: true,
-		From:        req.From,
+		From:        req.From[:],
 		MethodParams: map[string]interface{}{
 			"tokenA":  hex.EncodeToString(req.TokenA),
 			"tokenB":  hex.EncodeToString(req.TokenB),
@@ -98,7 +99,10 @@ func (s *marketService) addLiquidity(ctx context.Context, req *AddLiquidityReque
 		return nil, fmt.Errorf("decode unsignedTx failed: %w", err)
 	}
 
-	// 9. 使用 Wallet 签名交易
+	// 9. 使用 Wallet 签名交易（观察钱包返回携带未签名交易的 *wallet.UnsignedTxError）
+	if err := wallet.RequireTxSigner(w, unsignedTxBytes); err != nil {
+		return nil, err
+	}
 	signedTxBytes, err := w.SignTransaction(unsignedTxBytes)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -122,7 +126,7 @@ func (s *marketService) addLiquidity(ctx context.Context, req *AddLiquidityReque
 		// 查找流动性输出（通常是第一个资产输出，且 owner 是流动性提供者地址）
 		// 流动性输出可能带有特殊的锁定条件或 metadata
 		for _, output := range parsedTx.Outputs {
-			if output.Type == "asset" && bytes.Equal(output.Owner, req.From) {
+			if output.Type == "asset" && bytes.Equal(output.Owner, req.From[:]) {
 				liquidityID = []byte(output.Outpoint)
 				break
 			}
@@ -139,8 +143,8 @@ func (s *marketService) addLiquidity(ctx context.Context, req *AddLiquidityReque
 // validateAddLiquidityRequest 验证添加流动性请求
 func (s *marketService) validateAddLiquidityRequest(req *AddLiquidityRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证 AMM 合约地址（contentHash，32字节）
@@ -149,7 +153,7 @@ func (s *marketService) validateAddLiquidityRequest(req *AddLiquidityRequest) er
 	}
 
 	// 3. 验证金额
-	if req.AmountA == 0 || req.AmountB == 0 {
+	if req.AmountA.IsZero() || req.AmountB.IsZero() {
 		return fmt.Errorf("both amounts must be greater than 0")
 	}
 
@@ -184,7 +188,7 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -198,7 +202,7 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 	// 规范来源：weisyn.git/docs/components/core/ispc/abi-and-payload.md
 	payloadOptions := utils.BuildPayloadOptions{
 		IncludeFrom: true,
-		From:        req.From,
+		From:        req.From[:],
 		MethodParams: map[string]interface{}{
 			"liquidityID": hex.EncodeToString(req.LiquidityID),
 			"amount":      req.Amount,
@@ -241,7 +245,10 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 		return nil, fmt.Errorf("decode unsignedTx failed: %w", err)
 	}
 
-	// 9. 使用 Wallet 签名交易
+	// 9. 使用 Wallet 签名交易（观察钱包返回携带未签名交易的 *wallet.UnsignedTxError）
+	if err := wallet.RequireTxSigner(w, unsignedTxBytes); err != nil {
+		return nil, err
+	}
 	signedTxBytes, err := w.SignTransaction(unsignedTxBytes)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -259,13 +266,12 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 	}
 
 	// 11. 解析交易结果，提取实际获得的代币金额
-	amountA := uint64(0)
-	amountB := uint64(0)
+	var amountA, amountB types.Amount
 
 	parsedTx, err := utils.FetchAndParseTx(ctx, s.client, sendResult.TxHash)
 	if err == nil && parsedTx != nil {
 		// 查找返回给用户的输出（owner 是流动性提供者地址）
-		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From)
+		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From[:])
 
 		// 分别汇总 TokenA 和 TokenB 的金额
 		// 注意：需要从请求中获取 TokenA 和 TokenB，但当前 RemoveLiquidityRequest 没有这些字段
@@ -274,17 +280,17 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 			if output.Amount != nil {
 				if output.TokenID == nil {
 					// 原生币，可能是 TokenA 或 TokenB（简化处理）
-					if amountA == 0 {
-						amountA = output.Amount.Uint64()
+					if amountA.IsZero() {
+						amountA, _ = types.NewAmountFromBigInt(output.Amount)
 					} else {
-						amountB = output.Amount.Uint64()
+						amountB, _ = types.NewAmountFromBigInt(output.Amount)
 					}
 				} else {
 					// 代币，根据 TokenID 判断
-					if amountA == 0 {
-						amountA = output.Amount.Uint64()
+					if amountA.IsZero() {
+						amountA, _ = types.NewAmountFromBigInt(output.Amount)
 					} else {
-						amountB = output.Amount.Uint64()
+						amountB, _ = types.NewAmountFromBigInt(output.Amount)
 					}
 				}
 			}
@@ -302,8 +308,8 @@ func (s *marketService) removeLiquidity(ctx context.Context, req *RemoveLiquidit
 // validateRemoveLiquidityRequest 验证移除流动性请求
 func (s *marketService) validateRemoveLiquidityRequest(req *RemoveLiquidityRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证 AMM 合约地址（contentHash，32字节）
@@ -317,7 +323,7 @@ func (s *marketService) validateRemoveLiquidityRequest(req *RemoveLiquidityReque
 	}
 
 	// 4. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -65,29 +66,29 @@ func (s *marketService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
 
 // SwapRequest AMM交换请求
 type SwapRequest struct {
-	From            []byte // 交换者地址（20字节）
-	AMMContractAddr []byte // AMM 合约地址（contentHash，32字节）
-	TokenIn         []byte // 输入代币ID（nil表示原生币）
-	TokenOut        []byte // 输出代币ID（nil表示原生币）
-	AmountIn        uint64 // 输入金额
-	AmountOutMin    uint64 // 最小输出金额（滑点保护）
+	From            types.Address // 交换者地址
+	AMMContractAddr []byte        // AMM 合约地址（contentHash，32字节）
+	TokenIn         []byte        // 输入代币ID（nil表示原生币）
+	TokenOut        []byte        // 输出代币ID（nil表示原生币）
+	AmountIn        types.Amount  // 输入金额
+	AmountOutMin    types.Amount  // 最小输出金额（滑点保护）
 }
 
 // SwapResult AMM交换结果
 type SwapResult struct {
-	TxHash    string // 交易哈希
-	AmountOut uint64 // 实际输出金额
-	Success   bool   // 是否成功
+	TxHash    string       // 交易哈希
+	AmountOut types.Amount // 实际输出金额
+	Success   bool         // 是否成功
 }
 
 // AddLiquidityRequest 添加流动性请求
 type AddLiquidityRequest struct {
-	From            []byte // 流动性提供者地址（20字节）
-	AMMContractAddr []byte // AMM 合约地址（contentHash，32字节）
-	TokenA          []byte // 代币A ID
-	TokenB          []byte // 代币B ID
-	AmountA         uint64 // 代币A金额
-	AmountB         uint64 // 代币B金额
+	From            types.Address // 流动性提供者地址
+	AMMContractAddr []byte        // AMM 合约地址（contentHash，32字节）
+	TokenA          []byte        // 代币A ID
+	TokenB          []byte        // 代币B ID
+	AmountA         types.Amount  // 代币A金额
+	AmountB         types.Amount  // 代币B金额
 }
 
 // AddLiquidityResult 添加流动性结果
@@ -99,28 +100,28 @@ type AddLiquidityResult struct {
 
 // RemoveLiquidityRequest 移除流动性请求
 type RemoveLiquidityRequest struct {
-	From            []byte // 流动性提供者地址（20字节）
-	AMMContractAddr []byte // AMM 合约地址（contentHash，32字节）
-	LiquidityID     []byte // 流动性ID
-	Amount          uint64 // 移除金额
+	From            types.Address // 流动性提供者地址
+	AMMContractAddr []byte        // AMM 合约地址（contentHash，32字节）
+	LiquidityID     []byte        // 流动性ID
+	Amount          types.Amount  // 移除金额
 }
 
 // RemoveLiquidityResult 移除流动性结果
 type RemoveLiquidityResult struct {
-	TxHash  string // 交易哈希
-	AmountA uint64 // 获得的代币A金额
-	AmountB uint64 // 获得的代币B金额
-	Success bool   // 是否成功
+	TxHash  string       // 交易哈希
+	AmountA types.Amount // 获得的代币A金额
+	AmountB types.Amount // 获得的代币B金额
+	Success bool         // 是否成功
 }
 
 // CreateVestingRequest 创建归属计划请求
 type CreateVestingRequest struct {
-	From      []byte // 创建者地址（20字节）
-	To        []byte // 受益人地址（20字节）
-	TokenID   []byte // 代币ID
-	Amount    uint64 // 总金额
-	StartTime uint64 // 开始时间（Unix时间戳）
-	Duration  uint64 // 持续时间（秒）
+	From      types.Address // 创建者地址
+	To        types.Address // 受益人地址
+	TokenID   []byte        // 代币ID
+	Amount    types.Amount  // 总金额
+	StartTime uint64        // 开始时间（Unix时间戳）
+	Duration  uint64        // 持续时间（秒）
 }
 
 // CreateVestingResult 创建归属计划结果
@@ -132,24 +133,24 @@ type CreateVestingResult struct {
 
 // ClaimVestingRequest 领取归属代币请求
 type ClaimVestingRequest struct {
-	From      []byte // 领取者地址（20字节）
-	VestingID []byte // 归属计划ID
+	From      types.Address // 领取者地址
+	VestingID []byte        // 归属计划ID
 }
 
 // ClaimVestingResult 领取归属代币结果
 type ClaimVestingResult struct {
-	TxHash      string // 交易哈希
-	ClaimAmount uint64 // 领取金额
-	Success     bool   // 是否成功
+	TxHash      string       // 交易哈希
+	ClaimAmount types.Amount // 领取金额
+	Success     bool         // 是否成功
 }
 
 // CreateEscrowRequest 创建托管请求
 type CreateEscrowRequest struct {
-	Buyer   []byte // 买方地址（20字节）
-	Seller  []byte // 卖方地址（20字节）
-	TokenID []byte // 代币ID
-	Amount  uint64 // 托管金额
-	Expiry  uint64 // 过期时间（Unix时间戳）
+	Buyer   types.Address // 买方地址
+	Seller  types.Address // 卖方地址
+	TokenID []byte        // 代币ID
+	Amount  types.Amount  // 托管金额
+	Expiry  uint64        // 过期时间（Unix时间戳）
 }
 
 // CreateEscrowResult 创建托管结果
@@ -161,9 +162,9 @@ type CreateEscrowResult struct {
 
 // ReleaseEscrowRequest 释放托管请求
 type ReleaseEscrowRequest struct {
-	From          []byte // 释放者地址（通常是买方，20字节）
-	SellerAddress []byte // 卖方地址（20字节）
-	EscrowID      []byte // 托管ID
+	From          types.Address // 释放者地址（通常是买方）
+	SellerAddress types.Address // 卖方地址
+	EscrowID      []byte        // 托管ID
 }
 
 // ReleaseEscrowResult 释放托管结果
@@ -174,9 +175,9 @@ type ReleaseEscrowResult struct {
 
 // RefundEscrowRequest 退款托管请求
 type RefundEscrowRequest struct {
-	From         []byte // 退款者地址（通常是买方或卖方，20字节）
-	BuyerAddress []byte // 买方地址（20字节）
-	EscrowID     []byte // 托管ID
+	From         types.Address // 退款者地址（通常是买方或卖方）
+	BuyerAddress types.Address // 买方地址
+	EscrowID     []byte        // 托管ID
 }
 
 // RefundEscrowResult 退款托管结果
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -39,7 +40,7 @@ func (s *marketService) swapAMM(ctx context.Context, req *SwapRequest, wallets .
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -53,7 +54,7 @@ func (s *marketService) swapAMM(ctx context.Context, req *SwapRequest, wallets .
 	// 规范来源：weisyn.git/docs/components/core/ispc/abi-and-payload.md
 	payloadOptions := utils.BuildPayloadOptions{
 		IncludeFrom: true,
-		From:        req.From,
+		From:        req.From[:],
 		MethodParams: map[string]interface{}{
 			"tokenIn":      hex.EncodeToString(req.TokenIn),
 			"tokenOut":     hex.EncodeToString(req.TokenOut),
@@ -98,7 +99,10 @@ func (s *marketService) swapAMM(ctx context.Context, req *SwapRequest, wallets .
 		return nil, fmt.Errorf("decode unsignedTx failed: %w", err)
 	}
 
-	// 9. 使用 Wallet 签名交易
+	// 9. 使用 Wallet 签名交易（观察钱包返回携带未签名交易的 *wallet.UnsignedTxError）
+	if err := wallet.RequireTxSigner(w, unsignedTxBytes); err != nil {
+		return nil, err
+	}
 	signedTxBytes, err := w.SignTransaction(unsignedTxBytes)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -121,19 +125,19 @@ func (s *marketService) swapAMM(ctx context.Context, req *SwapRequest, wallets .
 	parsedTx, err := utils.FetchAndParseTx(ctx, s.client, sendResult.TxHash)
 	if err == nil && parsedTx != nil {
 		// 查找返回给用户的输出（owner 是交换者地址）
-		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From)
+		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From[:])
 
 		// 汇总 tokenOut 金额
 		if len(req.TokenOut) > 0 {
 			totalAmount := utils.SumAmountsByToken(userOutputs, req.TokenOut)
-			if totalAmount != nil {
-				amountOut = totalAmount.Uint64()
+			if amount, err := types.NewAmountFromBigInt(totalAmount); err == nil && totalAmount != nil {
+				amountOut = amount
 			}
 		} else {
 			// 原生币
 			totalAmount := utils.SumAmountsByToken(userOutputs, nil)
-			if totalAmount != nil {
-				amountOut = totalAmount.Uint64()
+			if amount, err := types.NewAmountFromBigInt(totalAmount); err == nil && totalAmount != nil {
+				amountOut = amount
 			}
 		}
 	}
@@ -148,8 +152,8 @@ func (s *marketService) swapAMM(ctx context.Context, req *SwapRequest, wallets .
 // validateSwapRequest 验证交换请求
 func (s *marketService) validateSwapRequest(req *SwapRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证 AMM 合约地址（contentHash，32字节）
@@ -158,10 +162,10 @@ func (s *marketService) validateSwapRequest(req *SwapRequest) error {
 	}
 
 	// 3. 验证金额
-	if req.AmountIn == 0 {
+	if req.AmountIn.IsZero() {
 		return fmt.Errorf("amount in must be greater than 0")
 	}
-	if req.AmountOutMin == 0 {
+	if req.AmountOutMin.IsZero() {
 		return fmt.Errorf("minimum amount out must be greater than 0")
 	}
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
@@ -38,7 +39,7 @@ func buildVestingDraft(
 	client client.Client,
 	fromAddress []byte,
 	toAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 	startTime uint64, // 开始时间（Unix时间戳）
 	duration uint64, // 持续时间（秒）
@@ -51,7 +52,7 @@ func buildVestingDraft(
 	if len(toAddress) == 0 {
 		return nil, 0, fmt.Errorf("toAddress cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if duration == 0 {
@@ -117,7 +118,7 @@ func buildVestingDraft(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -206,7 +207,7 @@ func buildVestingDraft(
 	vestingOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(toAddress),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	if len(tokenID) > 0 {
@@ -256,7 +257,7 @@ func buildVestingTransaction(
 	client client.Client,
 	fromAddress []byte,
 	toAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 	startTime uint64, // 开始时间（Unix时间戳）
 	duration uint64, // 持续时间（秒）
@@ -316,7 +317,7 @@ func buildVestingTransaction(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -403,7 +404,7 @@ func buildVestingTransaction(
 	vestingOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(toAddress),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	if len(tokenID) > 0 {
@@ -478,7 +479,7 @@ func buildEscrowDraft(
 	client client.Client,
 	buyerAddress []byte,
 	sellerAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 	expiryTime uint64, // 过期时间（Unix时间戳）
 	escrowContractAddr []byte, // Escrow 合约地址（可选）
@@ -490,7 +491,7 @@ func buildEscrowDraft(
 	if len(sellerAddress) == 0 {
 		return nil, 0, fmt.Errorf("sellerAddress cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if expiryTime == 0 {
@@ -556,7 +557,7 @@ func buildEscrowDraft(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -641,7 +642,7 @@ func buildEscrowDraft(
 	escrowOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(buyerAddress), // 托管给买方（但需要双方签名才能解锁）
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	if len(tokenID) > 0 {
@@ -691,7 +692,7 @@ func buildEscrowTransaction(
 	client client.Client,
 	buyerAddress []byte,
 	sellerAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 	expiryTime uint64, // 过期时间（Unix时间戳）
 	escrowContractAddr []byte, // Escrow 合约地址（可选）
@@ -750,7 +751,7 @@ func buildEscrowTransaction(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -833,7 +834,7 @@ func buildEscrowTransaction(
 	escrowOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(buyerAddress), // 托管给买方（但需要双方签名才能解锁）
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	if len(tokenID) > 0 {
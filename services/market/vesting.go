@@ -6,10 +6,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -41,7 +42,7 @@ func (s *marketService) createVesting(ctx context.Context, req *CreateVestingReq
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -51,8 +52,8 @@ func (s *marketService) createVesting(ctx context.Context, req *CreateVestingReq
 	draftJSON, inputIndex, err := buildVestingDraft(
 		ctx,
 		s.client,
-		req.From,
-		req.To,
+		req.From[:],
+		req.To[:],
 		req.Amount,
 		req.TokenID,
 		req.StartTime,
@@ -63,32 +64,17 @@ func (s *marketService) createVesting(ctx context.Context, req *CreateVestingReq
 		return nil, fmt.Errorf("build vesting draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -145,7 +131,7 @@ func (s *marketService) createVesting(ctx context.Context, req *CreateVestingReq
 		// 查找归属输出（通常是第一个资产输出，且 owner 是受益人地址）
 		// 归属输出通常带有 TimeLock
 		for _, output := range parsedTx.Outputs {
-			if output.Type == "asset" && bytes.Equal(output.Owner, req.To) {
+			if output.Type == "asset" && bytes.Equal(output.Owner, req.To[:]) {
 				vestingID = []byte(output.Outpoint)
 				break
 			}
@@ -162,15 +148,15 @@ func (s *marketService) createVesting(ctx context.Context, req *CreateVestingReq
 // validateCreateVestingRequest 验证创建归属计划请求
 func (s *marketService) validateCreateVestingRequest(req *CreateVestingRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.To) != 20 {
-		return fmt.Errorf("to address must be 20 bytes")
+	if req.To.IsZero() {
+		return fmt.Errorf("to address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -211,7 +197,7 @@ func (s *marketService) claimVesting(ctx context.Context, req *ClaimVestingReque
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -219,39 +205,24 @@ func (s *marketService) claimVesting(ctx context.Context, req *ClaimVestingReque
 	draftJSON, inputIndex, err := buildClaimVestingDraft(
 		ctx,
 		s.client,
-		req.From,
+		req.From[:],
 		req.VestingID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("build claim vesting draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -302,17 +273,17 @@ func (s *marketService) claimVesting(ctx context.Context, req *ClaimVestingReque
 	}
 
 	// 7. 解析交易结果，提取实际领取金额
-	claimAmount := uint64(0)
+	var claimAmount types.Amount
 
 	parsedTx, err := utils.FetchAndParseTx(ctx, s.client, sendResult.TxHash)
 	if err == nil && parsedTx != nil {
 		// 查找返回给用户的输出（owner 是领取者地址）
-		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From)
+		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From[:])
 
 		// 汇总金额（归属代币可能是原生币或特定代币）
 		totalAmount := utils.SumAmountsByToken(userOutputs, nil)
-		if totalAmount != nil {
-			claimAmount = totalAmount.Uint64()
+		if amount, err := types.NewAmountFromBigInt(totalAmount); err == nil {
+			claimAmount = amount
 		}
 	}
 
@@ -326,8 +297,8 @@ func (s *marketService) claimVesting(ctx context.Context, req *ClaimVestingReque
 // validateClaimVestingRequest 验证领取归属代币请求
 func (s *marketService) validateClaimVestingRequest(req *ClaimVestingRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证归属计划ID
@@ -5,11 +5,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -24,8 +25,14 @@ type Service interface {
 	// GrantDelegation 授予委托授权
 	GrantDelegation(ctx context.Context, intent GrantDelegationIntent, wallets ...wallet.Wallet) (*TransactionResult, error)
 
+	// RevokeDelegation 撤销委托授权
+	RevokeDelegation(ctx context.Context, intent RevokeDelegationIntent, wallets ...wallet.Wallet) (*TransactionResult, error)
+
 	// SetTimeOrHeightLock 设置时间/高度锁
 	SetTimeOrHeightLock(ctx context.Context, intent SetTimeOrHeightLockIntent, wallets ...wallet.Wallet) (*TransactionResult, error)
+
+	// CreateSessionKey 生成临时会话密钥并授予其对指定资源的委托权限（实现在 session_key.go）
+	CreateSessionKey(ctx context.Context, req *SessionKeyRequest, wallets ...wallet.Wallet) (*SessionKey, error)
 }
 
 // TransactionResult 交易结果
@@ -75,31 +82,17 @@ func (s *permissionService) signAndSubmitTransaction(
 		return nil, fmt.Errorf("marshal draft failed: %w", err)
 	}
 
-	// 2. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  unsignedTx.InputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 2. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{unsignedTx.InputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(unsignedTx.InputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{unsignedTx.InputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 3. 使用 Wallet 对哈希进行签名
@@ -202,6 +195,21 @@ func (s *permissionService) GrantDelegation(ctx context.Context, intent GrantDel
 	return s.signAndSubmitTransaction(ctx, unsignedTx, w)
 }
 
+// RevokeDelegation 撤销委托授权
+func (s *permissionService) RevokeDelegation(ctx context.Context, intent RevokeDelegationIntent, wallets ...wallet.Wallet) (*TransactionResult, error) {
+	w := s.getWallet(wallets...)
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+
+	unsignedTx, err := BuildRevokeDelegationTx(ctx, s.client, intent)
+	if err != nil {
+		return nil, fmt.Errorf("build revoke delegation tx failed: %w", err)
+	}
+
+	return s.signAndSubmitTransaction(ctx, unsignedTx, w)
+}
+
 // SetTimeOrHeightLock 设置时间/高度锁
 func (s *permissionService) SetTimeOrHeightLock(ctx context.Context, intent SetTimeOrHeightLockIntent, wallets ...wallet.Wallet) (*TransactionResult, error) {
 	w := s.getWallet(wallets...)
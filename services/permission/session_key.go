@@ -0,0 +1,343 @@
+package permission
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// ErrNotDelegated 会话密钥对该输入或操作没有委托权限
+var ErrNotDelegated = errors.New("not delegated to session key")
+
+// SessionKeyRequest 会话密钥请求
+type SessionKeyRequest struct {
+	ResourceIDs          []string        // 授权的资源（txId:outputIndex）
+	Operations           []string        // 授权操作类型（同 GrantDelegationIntent.Operations）
+	ExpiryBlocks         uint64          // 链上过期区块数（必填，保证会话密钥权限有界）
+	MaxValuePerOperation *types.Amount   // 单次操作最大价值（可选）
+	TTL                  time.Duration   // 本地会话时长，到期后私钥清零并由所有者钱包撤销剩余委托（0 表示仅受 ExpiryBlocks 约束）
+	OnExpire             func(err error) // TTL 到期自动撤销完成后回调（可选），err 为撤销失败的汇总
+}
+
+// SessionDelegation 会话密钥持有的单个委托
+type SessionDelegation struct {
+	ResourceID   string // 授权前的资源 ID
+	DelegationID string // 携带 DelegationLock 的资源输出（grantTxHash:0），委托花费与撤销均以其为输入
+	GrantTxHash  string // 授权交易哈希
+}
+
+// DelegatedInput 需以委托证明解锁的草稿输入
+type DelegatedInput struct {
+	InputIndex uint32       // 草稿输入下标
+	Operation  string       // 操作类型（须在授权范围内）
+	Value      types.Amount // 本次操作价值（受 MaxValuePerOperation 限制）
+}
+
+// SessionKey 会话密钥
+//
+// 持有内存中的临时私钥，TTL 到期或调用 Close 后私钥清零，签名方法返回 wallet.ErrWalletLocked。
+//
+// **注意**：
+//   - 花费委托资源只能使用 Submit，它以委托证明（delegation_proofs）解锁输入
+//   - SessionKey 同时实现 wallet.Wallet，但交给其他服务（如 TokenService）时只会产生会话地址的
+//     单签证明，无法解锁所有者的 DelegationLock；这种用法仅适用于锁定到会话地址本身的 UTXO
+//   - TTL 到期时使用创建时的所有者钱包撤销尚未花费的委托（见 SessionKeyRequest.OnExpire），
+//     所有者钱包届时已锁定等原因导致撤销失败的委托仍保留在 Delegations 中，链上在 ExpiryBlocks 后过期
+type SessionKey struct {
+	*wallet.UnlockedWallet
+
+	service    *permissionService
+	address    types.Address
+	operations []string
+	maxValue   *types.Amount
+
+	mu          sync.Mutex
+	delegations []SessionDelegation
+
+	closeMu sync.Mutex  // 串行化 Close，避免到期撤销与手动 Close 重复撤销同一委托
+	expiry  *time.Timer // TTL 到期撤销定时器
+}
+
+// CreateSessionKey 生成临时会话密钥并授予其对指定资源的委托权限
+//
+// 逐个资源调用 GrantDelegation（由所有者钱包签名）；任一授权失败时撤销已授予的委托并锁定密钥。
+func (s *permissionService) CreateSessionKey(ctx context.Context, req *SessionKeyRequest, wallets ...wallet.Wallet) (*SessionKey, error) {
+	owner := s.getWallet(wallets...)
+	if owner == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	if req == nil || len(req.ResourceIDs) == 0 {
+		return nil, fmt.Errorf("at least one resource is required")
+	}
+	if len(req.Operations) == 0 {
+		return nil, fmt.Errorf("at least one operation is required")
+	}
+	if req.ExpiryBlocks == 0 {
+		return nil, fmt.Errorf("expiry blocks is required for session keys")
+	}
+
+	key, err := wallet.NewEphemeralWallet(req.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("generate session key: %w", err)
+	}
+	sk := &SessionKey{
+		UnlockedWallet: key,
+		service:        s,
+		address:        types.MustAddressFromBytes(key.Address()),
+		operations:     append([]string(nil), req.Operations...),
+		maxValue:       req.MaxValuePerOperation,
+	}
+
+	for _, resourceID := range req.ResourceIDs {
+		result, err := s.GrantDelegation(ctx, GrantDelegationIntent{
+			ResourceID:           resourceID,
+			DelegateAddress:      sk.address,
+			Operations:           sk.operations,
+			ExpiryBlocks:         req.ExpiryBlocks,
+			MaxValuePerOperation: req.MaxValuePerOperation,
+		}, owner)
+		if err != nil {
+			if closeErr := sk.Close(ctx, owner); closeErr != nil {
+				err = fmt.Errorf("%w (rollback: %v)", err, closeErr)
+			}
+			return nil, fmt.Errorf("grant delegation for %s: %w", resourceID, err)
+		}
+		sk.delegations = append(sk.delegations, SessionDelegation{
+			ResourceID:   resourceID,
+			DelegationID: result.TxHash + ":0",
+			GrantTxHash:  result.TxHash,
+		})
+	}
+
+	if req.TTL > 0 {
+		// 到期撤销在调用返回后执行，保留 ctx 中的值但不继承其取消
+		expireCtx := context.WithoutCancel(ctx)
+		sk.closeMu.Lock()
+		sk.expiry = time.AfterFunc(req.TTL, func() {
+			err := sk.Close(expireCtx, owner)
+			if req.OnExpire != nil {
+				req.OnExpire(err)
+			}
+		})
+		sk.closeMu.Unlock()
+	}
+	return sk, nil
+}
+
+// DelegateAddress 被委托者地址（会话密钥地址）
+func (k *SessionKey) DelegateAddress() types.Address {
+	return k.address
+}
+
+// Operations 授权操作类型
+func (k *SessionKey) Operations() []string {
+	return append([]string(nil), k.operations...)
+}
+
+// Delegations 尚未花费或撤销的委托
+func (k *SessionKey) Delegations() []SessionDelegation {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return append([]SessionDelegation(nil), k.delegations...)
+}
+
+// Submit 以委托证明签名草稿中的委托资源输入，finalize 并提交，返回交易哈希
+//
+// 每个输入必须花费本会话的某个委托（DelegationID），操作类型在授权范围内且价值不超过上限。
+// 提交成功后被花费的委托从 Delegations 中移除。
+func (k *SessionKey) Submit(ctx context.Context, draftJSON []byte, inputs []DelegatedInput) (string, error) {
+	if k.IsLocked() {
+		return "", wallet.ErrWalletLocked
+	}
+	if len(inputs) == 0 {
+		return "", fmt.Errorf("no delegated inputs")
+	}
+	outpoints, err := draftInputOutpoints(draftJSON)
+	if err != nil {
+		return "", err
+	}
+
+	used := make([]SessionDelegation, len(inputs))
+	indices := make([]uint32, len(inputs))
+	for i, in := range inputs {
+		if int(in.InputIndex) >= len(outpoints) {
+			return "", fmt.Errorf("input index %d out of range (draft has %d inputs)", in.InputIndex, len(outpoints))
+		}
+		d, ok := k.delegation(outpoints[in.InputIndex])
+		if !ok {
+			return "", fmt.Errorf("%w: input %d spends %s", ErrNotDelegated, in.InputIndex, outpoints[in.InputIndex])
+		}
+		if !k.allows(in.Operation) {
+			return "", fmt.Errorf("%w: operation %q", ErrNotDelegated, in.Operation)
+		}
+		if k.maxValue != nil && in.Value.Cmp(*k.maxValue) > 0 {
+			return "", fmt.Errorf("%w: value %s exceeds max per operation %s", ErrNotDelegated, in.Value, k.maxValue)
+		}
+		used[i] = d
+		indices[i] = in.InputIndex
+	}
+
+	sighash, err := utils.ComputeDraftSighash(ctx, k.service.client, draftJSON, indices, txcodec.SighashAll)
+	if err != nil {
+		return "", fmt.Errorf("compute signature hash failed: %w", err)
+	}
+
+	priv := k.PrivateKey()
+	if priv == nil {
+		return "", wallet.ErrWalletLocked
+	}
+	pubCompressed := ethcrypto.CompressPubkey(&priv.PublicKey)
+
+	proofs := make([]map[string]interface{}, 0, len(inputs))
+	for i, in := range inputs {
+		sig, err := k.SignHash(sighash.Hash(in.InputIndex))
+		if err != nil {
+			return "", fmt.Errorf("sign input %d: %w", in.InputIndex, err)
+		}
+		value, ok := in.Value.Uint64()
+		if !ok {
+			return "", fmt.Errorf("value %s of input %d exceeds uint64", in.Value, in.InputIndex)
+		}
+		txID, indexStr, _ := strings.Cut(used[i].DelegationID, ":")
+		outputIndex, err := strconv.ParseUint(indexStr, 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid delegation id %s: %w", used[i].DelegationID, err)
+		}
+		proofs = append(proofs, map[string]interface{}{
+			"input_index":  in.InputIndex,
+			"sighash_type": "SIGHASH_ALL",
+			"pubkey":       "0x" + hex.EncodeToString(pubCompressed),
+			"delegation_proof": map[string]interface{}{
+				"delegation_tx_id":        strings.TrimPrefix(txID, "0x"),
+				"delegation_output_index": uint32(outputIndex),
+				"delegate_signature":      "0x" + hex.EncodeToString(sig),
+				"operation_type":          in.Operation,
+				"value_amount":            value,
+				"delegate_address":        strings.TrimPrefix(k.address.Hex(), "0x"),
+			},
+		})
+	}
+
+	finalizeParams := map[string]interface{}{
+		"draft":             json.RawMessage(draftJSON),
+		"unsignedTx":        sighash.UnsignedTx,
+		"delegation_proofs": proofs,
+	}
+	finalResult, err := k.service.client.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+
+	sendResult, err := k.service.client.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	for _, d := range used {
+		k.remove(d.DelegationID)
+	}
+	return sendResult.TxHash, nil
+}
+
+// Close 结束会话：立即清零私钥；owner 不为 nil 时逐个撤销尚未花费的委托
+//
+// 同时取消 TTL 到期撤销。owner 为 nil 时仅本地锁定，链上委托在 ExpiryBlocks 后自然过期。
+// 撤销失败的委托保留在 Delegations 中，可再次调用 Close 重试。
+func (k *SessionKey) Close(ctx context.Context, owner wallet.Wallet) error {
+	k.closeMu.Lock()
+	defer k.closeMu.Unlock()
+
+	if k.expiry != nil {
+		k.expiry.Stop()
+	}
+	k.Lock()
+	if owner == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, d := range k.Delegations() {
+		_, err := k.service.RevokeDelegation(ctx, RevokeDelegationIntent{
+			ResourceID:      d.DelegationID,
+			DelegateAddress: k.address,
+		}, owner)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("revoke %s: %w", d.DelegationID, err))
+			continue
+		}
+		k.remove(d.DelegationID)
+	}
+	return errors.Join(errs...)
+}
+
+func (k *SessionKey) delegation(outpoint string) (SessionDelegation, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, d := range k.delegations {
+		if strings.EqualFold(strings.TrimPrefix(d.DelegationID, "0x"), outpoint) {
+			return d, true
+		}
+	}
+	return SessionDelegation{}, false
+}
+
+func (k *SessionKey) remove(delegationID string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i, d := range k.delegations {
+		if d.DelegationID == delegationID {
+			k.delegations = append(k.delegations[:i], k.delegations[i+1:]...)
+			return
+		}
+	}
+}
+
+func (k *SessionKey) allows(operation string) bool {
+	for _, op := range k.operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// draftInputOutpoints 按顺序返回草稿输入的 "txHash:outputIndex"（txHash 不带 0x）
+func draftInputOutpoints(draftJSON []byte) ([]string, error) {
+	var draft struct {
+		Inputs []struct {
+			TxHash      string `json:"tx_hash"`
+			OutputIndex uint32 `json:"output_index"`
+		} `json:"inputs"`
+	}
+	if err := json.Unmarshal(draftJSON, &draft); err != nil {
+		return nil, fmt.Errorf("parse draft: %w", err)
+	}
+	outpoints := make([]string, len(draft.Inputs))
+	for i, in := range draft.Inputs {
+		outpoints[i] = fmt.Sprintf("%s:%d", strings.ToLower(strings.TrimPrefix(in.TxHash, "0x")), in.OutputIndex)
+	}
+	return outpoints, nil
+}
@@ -0,0 +1,203 @@
+package permission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// sessionMockClient 模拟节点：按 outpoint 保存资源输出的锁定条件，提交后以新交易哈希记录草稿输出
+type sessionMockClient struct {
+	utxos     map[string][]interface{}
+	finalized []map[string]interface{}
+	pending   []interface{}
+	sent      int
+}
+
+func newSessionMockClient(owner []byte, resourceIDs ...string) *sessionMockClient {
+	m := &sessionMockClient{utxos: map[string][]interface{}{}}
+	for _, id := range resourceIDs {
+		m.utxos[id] = []interface{}{
+			map[string]interface{}{"single_key_lock": map[string]interface{}{"required_address_hash": fmt.Sprintf("%x", owner)}},
+		}
+	}
+	return m
+}
+
+func (m *sessionMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		p := params.([]interface{})[0].(map[string]interface{})
+		id := fmt.Sprintf("%s:%d", p["txId"], p["outputIndex"])
+		locks, ok := m.utxos[id]
+		if !ok {
+			return nil, fmt.Errorf("utxo %s not found", id)
+		}
+		return map[string]interface{}{"output": map[string]interface{}{
+			"owner":              "aa",
+			"resource_output":    map[string]interface{}{"resource": map[string]interface{}{"content_hash": "cc"}},
+			"locking_conditions": locks,
+		}}, nil
+	case "wes_computeSignatureHashFromDraft":
+		return map[string]interface{}{"hash": strings.Repeat("11", 32)}, nil
+	case "wes_finalizeTransactionFromDraft":
+		p := params.(map[string]interface{})
+		m.finalized = append(m.finalized, p)
+		var draft struct {
+			Outputs []struct {
+				LockingConditions []interface{} `json:"locking_conditions"`
+			} `json:"outputs"`
+		}
+		if err := json.Unmarshal(p["draft"].(json.RawMessage), &draft); err != nil {
+			return nil, err
+		}
+		m.pending = nil
+		if len(draft.Outputs) > 0 {
+			m.pending = draft.Outputs[0].LockingConditions
+		}
+		return map[string]interface{}{"tx": "00"}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *sessionMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent++
+	txHash := fmt.Sprintf("%064x", m.sent)
+	if m.pending != nil {
+		m.utxos[txHash+":0"] = m.pending
+	}
+	return &client.SendTxResult{TxHash: txHash, Accepted: true}, nil
+}
+
+func (m *sessionMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *sessionMockClient) Close() error { return nil }
+
+func TestSessionKeyLifecycle(t *testing.T) {
+	ctx := utils.WithSighashVerifyMode(context.Background(), utils.SighashVerifyNodeOnly)
+	owner, _ := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + "01")
+	resA, resB := strings.Repeat("a", 64)+":0", strings.Repeat("b", 64)+":1"
+	mc := newSessionMockClient(owner.Address(), resA, resB)
+	svc := NewServiceWithWallet(mc, owner)
+
+	if _, err := svc.CreateSessionKey(ctx, &SessionKeyRequest{ResourceIDs: []string{resA}, Operations: []string{"transfer"}}); err == nil {
+		t.Fatalf("expected error without ExpiryBlocks")
+	}
+
+	maxValue := types.NewAmount(10)
+	sk, err := svc.CreateSessionKey(ctx, &SessionKeyRequest{
+		ResourceIDs:          []string{resA, resB},
+		Operations:           []string{"transfer", "execute"},
+		ExpiryBlocks:         100,
+		MaxValuePerOperation: &maxValue,
+	})
+	if err != nil {
+		t.Fatalf("CreateSessionKey: %v", err)
+	}
+	var _ wallet.Wallet = sk
+	delegations := sk.Delegations()
+	if len(delegations) != 2 || delegations[1].ResourceID != resB {
+		t.Fatalf("unexpected delegations %+v", delegations)
+	}
+	grant := mc.utxos[delegations[0].DelegationID]
+	if len(grant) != 2 || !strings.Contains(fmt.Sprint(grant[1]), strings.TrimPrefix(sk.DelegateAddress().Hex(), "0x")) {
+		t.Fatalf("delegation lock not added: %v", grant)
+	}
+
+	// 以委托证明花费第一个委托
+	draft := func(id string) []byte {
+		txHash, index, _ := strings.Cut(id, ":")
+		return []byte(fmt.Sprintf(`{"inputs":[{"tx_hash":"%s","output_index":%s}],"outputs":[]}`, txHash, index))
+	}
+	if _, err := sk.Submit(ctx, draft(delegations[0].DelegationID), []DelegatedInput{{Operation: "vote"}}); !errors.Is(err, ErrNotDelegated) {
+		t.Fatalf("expected ErrNotDelegated for operation, got %v", err)
+	}
+	if _, err := sk.Submit(ctx, draft(delegations[0].DelegationID), []DelegatedInput{{Operation: "transfer", Value: types.NewAmount(11)}}); !errors.Is(err, ErrNotDelegated) {
+		t.Fatalf("expected ErrNotDelegated for value, got %v", err)
+	}
+	if _, err := sk.Submit(ctx, draft(resA), []DelegatedInput{{Operation: "transfer"}}); !errors.Is(err, ErrNotDelegated) {
+		t.Fatalf("expected ErrNotDelegated for foreign input, got %v", err)
+	}
+	if _, err := sk.Submit(ctx, draft(delegations[0].DelegationID), []DelegatedInput{{Operation: "transfer", Value: types.NewAmount(5)}}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	proofs := mc.finalized[len(mc.finalized)-1]["delegation_proofs"].([]map[string]interface{})
+	proof := proofs[0]["delegation_proof"].(map[string]interface{})
+	if proof["operation_type"] != "transfer" || proof["value_amount"] != uint64(5) ||
+		proof["delegate_address"] != strings.TrimPrefix(sk.DelegateAddress().Hex(), "0x") {
+		t.Fatalf("unexpected delegation proof %v", proof)
+	}
+	if remaining := sk.Delegations(); len(remaining) != 1 || remaining[0].ResourceID != resB {
+		t.Fatalf("spent delegation should be removed, got %+v", remaining)
+	}
+
+	// 结束会话：撤销剩余委托并清零私钥
+	if err := sk.Close(ctx, owner); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	last := mc.finalized[len(mc.finalized)-1]
+	if !strings.Contains(string(last["draft"].(json.RawMessage)), "revoke_delegation") {
+		t.Fatalf("expected revoke transaction, got %s", last["draft"])
+	}
+	revoked := mc.utxos[fmt.Sprintf("%064x", mc.sent)+":0"]
+	if len(revoked) != 1 || strings.Contains(fmt.Sprint(revoked), "delegation_lock") {
+		t.Fatalf("delegation lock should be removed: %v", revoked)
+	}
+	if len(sk.Delegations()) != 0 {
+		t.Fatalf("revoked delegations should be removed")
+	}
+	if _, err := sk.SignHash(make([]byte, 32)); !errors.Is(err, wallet.ErrWalletLocked) {
+		t.Fatalf("expected ErrWalletLocked after Close, got %v", err)
+	}
+}
+
+func TestSessionKeyExpiryRevokesDelegations(t *testing.T) {
+	ctx := utils.WithSighashVerifyMode(context.Background(), utils.SighashVerifyNodeOnly)
+	owner, _ := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + "01")
+	res := strings.Repeat("a", 64) + ":0"
+	mc := newSessionMockClient(owner.Address(), res)
+	svc := NewServiceWithWallet(mc, owner)
+
+	expired := make(chan error, 1)
+	sk, err := svc.CreateSessionKey(ctx, &SessionKeyRequest{
+		ResourceIDs:  []string{res},
+		Operations:   []string{"transfer"},
+		ExpiryBlocks: 100,
+		TTL:          20 * time.Millisecond,
+		OnExpire:     func(err error) { expired <- err },
+	})
+	if err != nil {
+		t.Fatalf("CreateSessionKey: %v", err)
+	}
+
+	select {
+	case err := <-expired:
+		if err != nil {
+			t.Fatalf("revoke on expiry: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("OnExpire was not called")
+	}
+
+	last := mc.finalized[len(mc.finalized)-1]
+	if !strings.Contains(string(last["draft"].(json.RawMessage)), "revoke_delegation") {
+		t.Fatalf("expected revoke transaction on expiry, got %s", last["draft"])
+	}
+	if len(sk.Delegations()) != 0 {
+		t.Fatalf("revoked delegations should be removed, got %+v", sk.Delegations())
+	}
+	if !sk.IsLocked() {
+		t.Fatalf("session key should be locked after expiry")
+	}
+}
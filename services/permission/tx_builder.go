@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
@@ -91,17 +92,10 @@ func BuildTransferOwnershipTx(
 	}
 
 	// 3. 转换新所有者地址为 hex
-	newOwnerAddressHex, err := utils.AddressBase58ToHex(intent.NewOwnerAddress)
-	if err != nil {
-		// 尝试 hex 格式
-		if strings.HasPrefix(intent.NewOwnerAddress, "0x") {
-			newOwnerAddressHex = intent.NewOwnerAddress
-		} else if len(intent.NewOwnerAddress) == 40 {
-			newOwnerAddressHex = "0x" + intent.NewOwnerAddress
-		} else {
-			return nil, fmt.Errorf("invalid address format: %s: %w", intent.NewOwnerAddress, err)
-		}
+	if intent.NewOwnerAddress.IsZero() {
+		return nil, fmt.Errorf("new owner address is required")
 	}
+	newOwnerAddressHex := intent.NewOwnerAddress.Hex()
 
 	// 4. 构建新的锁定条件（SingleKeyLock）
 	newLockingConditions := []map[string]interface{}{
@@ -479,16 +473,10 @@ func BuildGrantDelegationTx(
 	}
 
 	// 4. 转换被委托者地址
-	delegateAddressHex, err := utils.AddressBase58ToHex(intent.DelegateAddress)
-	if err != nil {
-		if strings.HasPrefix(intent.DelegateAddress, "0x") {
-			delegateAddressHex = intent.DelegateAddress
-		} else if len(intent.DelegateAddress) == 40 {
-			delegateAddressHex = "0x" + intent.DelegateAddress
-		} else {
-			return nil, fmt.Errorf("invalid delegate address format: %s: %w", intent.DelegateAddress, err)
-		}
+	if intent.DelegateAddress.IsZero() {
+		return nil, fmt.Errorf("delegate address is required")
 	}
+	delegateAddressHex := intent.DelegateAddress.Hex()
 
 	// 5. 验证授权操作类型
 	validOperations := map[string]bool{
@@ -519,7 +507,7 @@ func BuildGrantDelegationTx(
 		delegationLock["delegation_lock"].(map[string]interface{})["expiry_duration_blocks"] = intent.ExpiryBlocks
 	}
 	if intent.MaxValuePerOperation != nil {
-		delegationLock["delegation_lock"].(map[string]interface{})["max_value_per_operation"] = fmt.Sprintf("%d", *intent.MaxValuePerOperation)
+		delegationLock["delegation_lock"].(map[string]interface{})["max_value_per_operation"] = intent.MaxValuePerOperation.String()
 	}
 
 	// 7. 合并原有锁定条件和新的 DelegationLock
@@ -762,3 +750,143 @@ func BuildSetLockTx(
 		InputIndex: 0,
 	}, nil
 }
+
+// BuildRevokeDelegationTx 构建撤销委托授权交易
+//
+// 重新创建资源输出，移除 allowed_delegates 包含被委托者地址的 DelegationLock，其余锁定条件保持不变。
+func BuildRevokeDelegationTx(
+	ctx context.Context,
+	client client.Client,
+	intent RevokeDelegationIntent,
+) (*UnsignedTransaction, error) {
+	// 1. 解析资源 ID
+	parts := strings.Split(intent.ResourceID, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid resourceId format: %s. Expected format: txId:outputIndex", intent.ResourceID)
+	}
+	txId := parts[0]
+	outputIndex, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outputIndex: %w", err)
+	}
+	if intent.DelegateAddress.IsZero() {
+		return nil, fmt.Errorf("delegate address is required")
+	}
+
+	// 2. 查询当前资源 UTXO
+	utxoParams := map[string]interface{}{
+		"txId":        txId,
+		"outputIndex": outputIndex,
+	}
+	utxoResult, err := client.Call(ctx, "wes_getUTXO", []interface{}{utxoParams})
+	if err != nil {
+		errorMsg := err.Error()
+		if strings.Contains(errorMsg, "not found") || strings.Contains(errorMsg, "NOT_FOUND") {
+			return nil, fmt.Errorf("resource UTXO not found or already spent: %s. The resource may have been transferred or consumed", intent.ResourceID)
+		}
+		return nil, fmt.Errorf("failed to query UTXO: %w", err)
+	}
+
+	utxoMap, ok := utxoResult.(map[string]interface{})
+	if !ok {
+		if utxoArray, ok := utxoResult.([]interface{}); ok && len(utxoArray) > 0 {
+			if utxoMap, ok = utxoArray[0].(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("invalid UTXO response format")
+			}
+		} else {
+			return nil, fmt.Errorf("invalid UTXO response format")
+		}
+	}
+
+	var utxo map[string]interface{}
+	if utxos, ok := utxoMap["utxos"].([]interface{}); ok && len(utxos) > 0 {
+		utxo, ok = utxos[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid UTXO format")
+		}
+	} else {
+		utxo = utxoMap
+	}
+
+	output, ok := utxo["output"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("resource UTXO not found: %s. The UTXO may have been spent or the resource ID is incorrect", intent.ResourceID)
+	}
+
+	resourceOutput, ok := output["resource_output"].(map[string]interface{})
+	if !ok {
+		if resourceOutput, ok = output["resource"].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("resource output not found in UTXO: %s", intent.ResourceID)
+		}
+	}
+
+	// 3. 过滤掉授权给该地址的 DelegationLock
+	currentLockingConditions, _ := output["locking_conditions"].([]interface{})
+	newLockingConditions := make([]interface{}, 0, len(currentLockingConditions))
+	removed := 0
+	for _, condRaw := range currentLockingConditions {
+		if condition, ok := condRaw.(map[string]interface{}); ok {
+			if delegation, ok := condition["delegation_lock"].(map[string]interface{}); ok && delegatesInclude(delegation, intent.DelegateAddress) {
+				removed++
+				continue
+			}
+		}
+		newLockingConditions = append(newLockingConditions, condRaw)
+	}
+	if removed == 0 {
+		return nil, fmt.Errorf("no delegation to %s found on resource %s", intent.DelegateAddress, intent.ResourceID)
+	}
+	if len(newLockingConditions) == 0 {
+		return nil, fmt.Errorf("resource %s has no locking conditions besides the delegation", intent.ResourceID)
+	}
+
+	// 4. 构建交易草稿
+	owner, _ := output["owner"].(string)
+	draft := map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs": []map[string]interface{}{
+			{
+				"tx_hash":           txId,
+				"output_index":      outputIndex,
+				"is_reference_only": false,
+			},
+		},
+		"outputs": []map[string]interface{}{
+			{
+				"owner":       strings.TrimPrefix(owner, "0x"),
+				"output_type": "resource",
+				"resource_output": map[string]interface{}{
+					"resource":           resourceOutput["resource"],
+					"creation_timestamp": resourceOutput["creation_timestamp"],
+					"storage_strategy":   resourceOutput["storage_strategy"],
+					"is_immutable":       resourceOutput["is_immutable"],
+				},
+				"locking_conditions": newLockingConditions,
+			},
+		},
+		"metadata": map[string]interface{}{
+			"operation":        "revoke_delegation",
+			"delegate_address": intent.DelegateAddress.Hex(),
+		},
+	}
+
+	return &UnsignedTransaction{
+		Draft:      draft,
+		InputIndex: 0,
+	}, nil
+}
+
+// delegatesInclude DelegationLock 的 allowed_delegates 是否包含指定地址（hex / Base64 / Base58 均可）
+func delegatesInclude(delegation map[string]interface{}, delegate types.Address) bool {
+	delegates, _ := delegation["allowed_delegates"].([]interface{})
+	for _, d := range delegates {
+		s, ok := d.(string)
+		if !ok {
+			continue
+		}
+		if addr, err := types.ParseAddress(s); err == nil && addr == delegate {
+			return true
+		}
+	}
+	return false
+}
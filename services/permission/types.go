@@ -1,10 +1,12 @@
 package permission
 
+import "github.com/weisyn/client-sdk-go/types"
+
 // TransferOwnershipIntent 所有权转移意图
 type TransferOwnershipIntent struct {
-	ResourceID      string // txId:outputIndex
-	NewOwnerAddress string // Base58 地址或 hex 地址
-	Memo            string // 可选备注
+	ResourceID      string        // txId:outputIndex
+	NewOwnerAddress types.Address // 新所有者地址（可用 types.ParseAddress 从 Base58/hex 解析）
+	Memo            string        // 可选备注
 }
 
 // UpdateCollaboratorsIntent 协作者/白名单管理意图
@@ -16,11 +18,17 @@ type UpdateCollaboratorsIntent struct {
 
 // GrantDelegationIntent 临时授权意图
 type GrantDelegationIntent struct {
-	ResourceID           string   // txId:outputIndex
-	DelegateAddress      string   // 被委托者地址
-	Operations           []string // 授权操作类型: "reference", "execute", "query", "consume", "transfer", "stake", "vote"
-	ExpiryBlocks         uint64   // 过期区块数（0 = 永不过期）
-	MaxValuePerOperation *uint64  // 单次操作最大价值（可选）
+	ResourceID           string        // txId:outputIndex
+	DelegateAddress      types.Address // 被委托者地址
+	Operations           []string      // 授权操作类型: "reference", "execute", "query", "consume", "transfer", "stake", "vote"
+	ExpiryBlocks         uint64        // 过期区块数（0 = 永不过期）
+	MaxValuePerOperation *types.Amount // 单次操作最大价值（可选）
+}
+
+// RevokeDelegationIntent 撤销委托授权意图
+type RevokeDelegationIntent struct {
+	ResourceID      string        // 携带 DelegationLock 的资源输出（txId:outputIndex）
+	DelegateAddress types.Address // 被撤销的被委托者地址
 }
 
 // SetTimeOrHeightLockIntent 时间/高度锁意图
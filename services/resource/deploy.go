@@ -44,7 +44,7 @@ func (s *resourceService) deployStaticResource(ctx context.Context, req *DeployS
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -57,20 +57,11 @@ func (s *resourceService) deployStaticResource(ctx context.Context, req *DeployS
 	// 5. Base64 编码文件内容
 	fileContentBase64 := base64.StdEncoding.EncodeToString(fileBytes)
 
-	// 6. 获取私钥（用于 API 调用）
-	privateKey := w.PrivateKey()
-	if privateKey == nil {
-		return nil, fmt.Errorf("wallet private key not available")
-	}
-	// 从 ECDSA 私钥中提取 D 值（32字节）
-	privateKeyBytes := privateKey.D.Bytes()
-	// 确保是32字节（可能需要填充）
-	if len(privateKeyBytes) < 32 {
-		padded := make([]byte, 32)
-		copy(padded[32-len(privateKeyBytes):], privateKeyBytes)
-		privateKeyBytes = padded
+	// 6. 获取私钥（部署 API 由节点签名，需要明文私钥）
+	privateKeyHex, err := deployPrivateKeyHex(w)
+	if err != nil {
+		return nil, err
 	}
-	privateKeyHex := hex.EncodeToString(privateKeyBytes)
 
 	// 7. 调用 `wes_deployContract` API（静态资源可以作为特殊类型的合约）
 	// 注意：当前实现使用 wes_deployContract，如果未来有专门的 wes_deployStaticResource API，可以切换
@@ -119,8 +110,8 @@ func (s *resourceService) deployStaticResource(ctx context.Context, req *DeployS
 // validateDeployStaticResourceRequest 验证部署静态资源请求
 func (s *resourceService) validateDeployStaticResourceRequest(req *DeployStaticResourceRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证文件路径
@@ -181,7 +172,7 @@ func (s *resourceService) deployContract(ctx context.Context, req *DeployContrac
 	}
 
 	// 4. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -194,20 +185,11 @@ func (s *resourceService) deployContract(ctx context.Context, req *DeployContrac
 	// 6. Base64 编码 WASM 内容
 	wasmContentBase64 := base64.StdEncoding.EncodeToString(wasmBytes)
 
-	// 7. 获取私钥（用于 API 调用）
-	privateKey := w.PrivateKey()
-	if privateKey == nil {
-		return nil, fmt.Errorf("wallet private key not available")
-	}
-	// 从 ECDSA 私钥中提取 D 值（32字节）
-	privateKeyBytes := privateKey.D.Bytes()
-	// 确保是32字节（可能需要填充）
-	if len(privateKeyBytes) < 32 {
-		padded := make([]byte, 32)
-		copy(padded[32-len(privateKeyBytes):], privateKeyBytes)
-		privateKeyBytes = padded
+	// 7. 获取私钥（部署 API 由节点签名，需要明文私钥）
+	privateKeyHex, err := deployPrivateKeyHex(w)
+	if err != nil {
+		return nil, err
 	}
-	privateKeyHex := hex.EncodeToString(privateKeyBytes)
 
 	// 8. ✅ 构造锁定条件（转换为 proto 格式）
 	var lockingConditionsProto []interface{}
@@ -278,8 +260,8 @@ func (s *resourceService) deployContract(ctx context.Context, req *DeployContrac
 // validateDeployContractRequest 验证部署合约请求
 func (s *resourceService) validateDeployContractRequest(req *DeployContractRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证WASM路径
@@ -327,7 +309,7 @@ func (s *resourceService) deployAIModel(ctx context.Context, req *DeployAIModelR
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -340,20 +322,11 @@ func (s *resourceService) deployAIModel(ctx context.Context, req *DeployAIModelR
 	// 5. Base64 编码 ONNX 内容
 	onnxContentBase64 := base64.StdEncoding.EncodeToString(onnxBytes)
 
-	// 6. 获取私钥（用于 API 调用）
-	privateKey := w.PrivateKey()
-	if privateKey == nil {
-		return nil, fmt.Errorf("wallet private key not available")
-	}
-	// 从 ECDSA 私钥中提取 D 值（32字节）
-	privateKeyBytes := privateKey.D.Bytes()
-	// 确保是32字节（可能需要填充）
-	if len(privateKeyBytes) < 32 {
-		padded := make([]byte, 32)
-		copy(padded[32-len(privateKeyBytes):], privateKeyBytes)
-		privateKeyBytes = padded
+	// 6. 获取私钥（部署 API 由节点签名，需要明文私钥）
+	privateKeyHex, err := deployPrivateKeyHex(w)
+	if err != nil {
+		return nil, err
 	}
-	privateKeyHex := hex.EncodeToString(privateKeyBytes)
 
 	// 7. 调用 `wes_deployAIModel` API
 	deployParams := map[string]interface{}{
@@ -400,8 +373,8 @@ func (s *resourceService) deployAIModel(ctx context.Context, req *DeployAIModelR
 // validateDeployAIModelRequest 验证部署AI模型请求
 func (s *resourceService) validateDeployAIModelRequest(req *DeployAIModelRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证模型路径
@@ -416,3 +389,15 @@ func (s *resourceService) validateDeployAIModelRequest(req *DeployAIModelRequest
 
 	return nil
 }
+
+// deployPrivateKeyHex 取出部署 API 所需的私钥（hex）
+//
+// wes_deployContract / wes_deployAIModel 由节点代为构建并签名交易，只能传入明文私钥；
+// 观察钱包以及只暴露公钥的策略 / 远程钱包返回包装 wallet.ErrWatchOnly 的错误。
+func deployPrivateKeyHex(w wallet.Wallet) (string, error) {
+	privateKey := w.PrivateKey()
+	if wallet.IsWatchOnly(w) || privateKey == nil || privateKey.D == nil || privateKey.D.Sign() == 0 {
+		return "", fmt.Errorf("deployment is signed by the node and needs the private key: %w", wallet.ErrWatchOnly)
+	}
+	return hex.EncodeToString(privateKey.D.FillBytes(make([]byte, 32))), nil
+}
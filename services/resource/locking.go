@@ -3,6 +3,8 @@ package resource
 import (
 	"encoding/hex"
 	"fmt"
+
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // LockingConditionType 锁定条件类型
@@ -172,7 +174,7 @@ type DelegationLockCondition struct {
 	AllowedDelegates     [][]byte
 	AuthorizedOperations []string
 	ExpiryDurationBlocks uint64
-	MaxValuePerOperation uint64
+	MaxValuePerOperation types.Amount
 }
 
 func (l *DelegationLockCondition) Type() LockingConditionType {
@@ -190,7 +192,7 @@ func (l *DelegationLockCondition) ToProto() (map[string]interface{}, error) {
 			"allowed_delegates":       delegates,
 			"authorized_operations":   l.AuthorizedOperations,
 			"expiry_duration_blocks":  l.ExpiryDurationBlocks,
-			"max_value_per_operation": l.MaxValuePerOperation,
+			"max_value_per_operation": l.MaxValuePerOperation.String(),
 		},
 	}, nil
 }
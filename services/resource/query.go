@@ -92,9 +92,9 @@ func (s *resourceService) getResources(ctx context.Context, filters *ResourceFil
 		if filters.ResourceType != "" {
 			filterMap["resourceType"] = filters.ResourceType
 		}
-		if len(filters.Owner) > 0 {
+		if !filters.Owner.IsZero() {
 			// owner 需要转换为 hex 字符串（带 0x 前缀）
-			filterMap["owner"] = "0x" + hex.EncodeToString(filters.Owner)
+			filterMap["owner"] = "0x" + hex.EncodeToString(filters.Owner[:])
 		}
 		if filters.Limit > 0 {
 			filterMap["limit"] = filters.Limit
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -65,9 +66,9 @@ func (s *resourceService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
 
 // DeployStaticResourceRequest 部署静态资源请求
 type DeployStaticResourceRequest struct {
-	From     []byte // 部署者地址（20字节）
-	FilePath string // 文件路径
-	MimeType string // MIME类型
+	From     types.Address // 部署者地址
+	FilePath string        // 文件路径
+	MimeType string        // MIME类型
 }
 
 // DeployStaticResourceResult 部署静态资源结果
@@ -79,10 +80,10 @@ type DeployStaticResourceResult struct {
 
 // DeployContractRequest 部署合约请求
 type DeployContractRequest struct {
-	From         []byte // 部署者地址（20字节）
-	WasmPath     string // WASM文件路径
-	ContractName string // 合约名称
-	InitArgs     []byte // 初始化参数
+	From         types.Address // 部署者地址
+	WasmPath     string        // WASM文件路径
+	ContractName string        // 合约名称
+	InitArgs     []byte        // 初始化参数
 
 	// ✅ 新增：锁定条件列表
 	LockingConditions []LockingCondition
@@ -102,9 +103,9 @@ type DeployContractResult struct {
 
 // DeployAIModelRequest 部署AI模型请求
 type DeployAIModelRequest struct {
-	From      []byte // 部署者地址（20字节）
-	ModelPath string // 模型文件路径
-	ModelName string // 模型名称
+	From      types.Address // 部署者地址
+	ModelPath string        // 模型文件路径
+	ModelName string        // 模型名称
 }
 
 // DeployAIModelResult 部署AI模型结果
@@ -116,10 +117,10 @@ type DeployAIModelResult struct {
 
 // ResourceFilters 资源查询过滤器
 type ResourceFilters struct {
-	ResourceType string // 资源类型过滤："contract" | "model" | "static"
-	Owner        []byte // 创建者地址过滤（hex 字节）
-	Limit        int    // 返回数量限制（默认50，最大200）
-	Offset       int    // 偏移量（默认0）
+	ResourceType string        // 资源类型过滤："contract" | "model" | "static"
+	Owner        types.Address // 创建者地址过滤（零值表示不过滤）
+	Limit        int           // 返回数量限制（默认50，最大200）
+	Offset       int           // 偏移量（默认0）
 }
 
 // ResourceInfo 资源信息
@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // ListResources 列出资源列表（新版本，使用 ResourceView）
@@ -25,8 +27,8 @@ func (s *resourceService) ListResources(ctx context.Context, filters *ResourceFi
 		if filters.ResourceType != "" {
 			filterMap["resourceType"] = filters.ResourceType
 		}
-		if len(filters.Owner) > 0 {
-			filterMap["owner"] = "0x" + hex.EncodeToString(filters.Owner)
+		if !filters.Owner.IsZero() {
+			filterMap["owner"] = "0x" + hex.EncodeToString(filters.Owner[:])
 		}
 		if filters.Limit > 0 {
 			filterMap["limit"] = filters.Limit
@@ -404,14 +406,20 @@ func (s *resourceService) parseSingleLockingCondition(lcMap map[string]interface
 		}
 
 		expiryDuration, _ := dlMap["expiry_duration_blocks"].(float64)
-		maxValue, _ := dlMap["max_value_per_operation"].(float64)
+		var maxValue types.Amount
+		switch v := dlMap["max_value_per_operation"].(type) {
+		case string:
+			maxValue, _ = types.ParseAmount(v)
+		case float64:
+			maxValue = types.NewAmount(uint64(v))
+		}
 
 		return &DelegationLockCondition{
 			OriginalOwner:        originalOwner,
 			AllowedDelegates:     delegates,
 			AuthorizedOperations: ops,
 			ExpiryDurationBlocks: uint64(expiryDuration),
-			MaxValuePerOperation: uint64(maxValue),
+			MaxValuePerOperation: maxValue,
 		}
 	}
 
@@ -6,10 +6,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -41,7 +42,7 @@ func (s *stakingService) delegate(ctx context.Context, req *DelegateRequest, wal
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -50,8 +51,8 @@ func (s *stakingService) delegate(ctx context.Context, req *DelegateRequest, wal
 	draftJSON, inputIndex, err := buildDelegateDraft(
 		ctx,
 		s.client,
-		req.From,
-		req.ValidatorAddr,
+		req.From[:],
+		req.ValidatorAddr[:],
 		req.Amount,
 		0,          // expiryDurationBlocks: 0 = 永不过期
 		req.Amount, // maxValuePerOperation: 等于委托金额
@@ -60,32 +61,17 @@ func (s *stakingService) delegate(ctx context.Context, req *DelegateRequest, wal
 		return nil, fmt.Errorf("build delegate draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -142,7 +128,7 @@ func (s *stakingService) delegate(ctx context.Context, req *DelegateRequest, wal
 		// 查找委托输出（通常是第一个资产输出，且 owner 是委托者地址）
 		// 委托输出通常带有 DelegationLock
 		for _, output := range parsedTx.Outputs {
-			if output.Type == "asset" && bytes.Equal(output.Owner, req.From) {
+			if output.Type == "asset" && bytes.Equal(output.Owner, req.From[:]) {
 				delegateID = output.Outpoint
 				break
 			}
@@ -159,15 +145,15 @@ func (s *stakingService) delegate(ctx context.Context, req *DelegateRequest, wal
 // validateDelegateRequest 验证委托请求
 func (s *stakingService) validateDelegateRequest(req *DelegateRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.ValidatorAddr) != 20 {
-		return fmt.Errorf("validator address must be 20 bytes")
+	if req.ValidatorAddr.IsZero() {
+		return fmt.Errorf("validator address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -201,7 +187,7 @@ func (s *stakingService) undelegate(ctx context.Context, req *UndelegateRequest,
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -209,7 +195,7 @@ func (s *stakingService) undelegate(ctx context.Context, req *UndelegateRequest,
 	draftJSON, inputIndex, err := buildUndelegateDraft(
 		ctx,
 		s.client,
-		req.From,
+		req.From[:],
 		req.DelegateID,
 		req.Amount,
 	)
@@ -217,32 +203,17 @@ func (s *stakingService) undelegate(ctx context.Context, req *UndelegateRequest,
 		return nil, fmt.Errorf("build undelegate draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -302,8 +273,8 @@ func (s *stakingService) undelegate(ctx context.Context, req *UndelegateRequest,
 // validateUndelegateRequest 验证取消委托请求
 func (s *stakingService) validateUndelegateRequest(req *UndelegateRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证委托ID
@@ -342,7 +313,7 @@ func (s *stakingService) claimReward(ctx context.Context, req *ClaimRewardReques
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -350,7 +321,7 @@ func (s *stakingService) claimReward(ctx context.Context, req *ClaimRewardReques
 	draftJSON, inputIndex, err := buildClaimRewardDraft(
 		ctx,
 		s.client,
-		req.From,
+		req.From[:],
 		req.StakeID,
 		req.DelegateID,
 	)
@@ -358,32 +329,17 @@ func (s *stakingService) claimReward(ctx context.Context, req *ClaimRewardReques
 		return nil, fmt.Errorf("build claim reward draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -434,17 +390,17 @@ func (s *stakingService) claimReward(ctx context.Context, req *ClaimRewardReques
 	}
 
 	// 10. 解析交易结果，提取奖励金额
-	rewardAmount := uint64(0)
+	var rewardAmount types.Amount
 
 	parsedTx, err := utils.FetchAndParseTx(ctx, s.client, sendResult.TxHash)
 	if err == nil && parsedTx != nil {
 		// 查找返回给用户的输出（owner 是领取者地址）
-		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From)
+		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From[:])
 
 		// 汇总原生币金额（奖励通常是原生币）
 		totalAmount := utils.SumAmountsByToken(userOutputs, nil)
-		if totalAmount != nil {
-			rewardAmount = totalAmount.Uint64()
+		if amount, err := types.NewAmountFromBigInt(totalAmount); err == nil {
+			rewardAmount = amount
 		}
 	}
 
@@ -458,8 +414,8 @@ func (s *stakingService) claimReward(ctx context.Context, req *ClaimRewardReques
 // validateClaimRewardRequest 验证领取奖励请求
 func (s *stakingService) validateClaimRewardRequest(req *ClaimRewardRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证至少提供一个ID
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -89,10 +90,10 @@ func (s *stakingService) Slash(ctx context.Context, req *SlashRequest, wallets .
 
 // StakeRequest 质押请求
 type StakeRequest struct {
-	From          []byte // 质押者地址（20字节）
-	ValidatorAddr []byte // 验证者地址（20字节）
-	Amount        uint64 // 质押金额
-	LockBlocks    uint64 // 锁定期（区块数）
+	From          types.Address // 质押者地址
+	ValidatorAddr types.Address // 验证者地址
+	Amount        types.Amount  // 质押金额
+	LockBlocks    uint64        // 锁定期（区块数）
 }
 
 // StakeResult 质押结果
@@ -104,24 +105,24 @@ type StakeResult struct {
 
 // UnstakeRequest 解除质押请求
 type UnstakeRequest struct {
-	From    []byte // 质押者地址（20字节）
-	StakeID []byte // 质押ID
-	Amount  uint64 // 解除质押金额（0表示全部）
+	From    types.Address // 质押者地址
+	StakeID []byte        // 质押ID
+	Amount  types.Amount  // 解除质押金额（0表示全部）
 }
 
 // UnstakeResult 解除质押结果
 type UnstakeResult struct {
-	TxHash        string // 交易哈希
-	UnstakeAmount uint64 // 解除质押金额
-	RewardAmount  uint64 // 奖励金额
-	Success       bool   // 是否成功
+	TxHash        string       // 交易哈希
+	UnstakeAmount types.Amount // 解除质押金额
+	RewardAmount  types.Amount // 奖励金额
+	Success       bool         // 是否成功
 }
 
 // DelegateRequest 委托请求
 type DelegateRequest struct {
-	From          []byte // 委托者地址（20字节）
-	ValidatorAddr []byte // 验证者地址（20字节）
-	Amount        uint64 // 委托金额
+	From          types.Address // 委托者地址
+	ValidatorAddr types.Address // 验证者地址
+	Amount        types.Amount  // 委托金额
 }
 
 // DelegateResult 委托结果
@@ -133,9 +134,9 @@ type DelegateResult struct {
 
 // UndelegateRequest 取消委托请求
 type UndelegateRequest struct {
-	From       []byte // 委托者地址（20字节）
-	DelegateID []byte // 委托ID
-	Amount     uint64 // 取消委托金额（0表示全部）
+	From       types.Address // 委托者地址
+	DelegateID []byte        // 委托ID
+	Amount     types.Amount  // 取消委托金额（0表示全部）
 }
 
 // UndelegateResult 取消委托结果
@@ -146,23 +147,23 @@ type UndelegateResult struct {
 
 // ClaimRewardRequest 领取奖励请求
 type ClaimRewardRequest struct {
-	From       []byte // 领取者地址（20字节）
-	StakeID    []byte // 质押ID（可选）
-	DelegateID []byte // 委托ID（可选）
+	From       types.Address // 领取者地址
+	StakeID    []byte        // 质押ID（可选）
+	DelegateID []byte        // 委托ID（可选）
 }
 
 // ClaimRewardResult 领取奖励结果
 type ClaimRewardResult struct {
-	TxHash       string // 交易哈希
-	RewardAmount uint64 // 奖励金额
-	Success      bool   // 是否成功
+	TxHash       string       // 交易哈希
+	RewardAmount types.Amount // 奖励金额
+	Success      bool         // 是否成功
 }
 
 // SlashRequest 罚没请求
 type SlashRequest struct {
-	ValidatorAddr []byte // 被罚没的验证者地址
-	Amount        uint64 // 罚没金额
-	Reason        string // 罚没原因
+	ValidatorAddr types.Address // 被罚没的验证者地址
+	Amount        types.Amount  // 罚没金额
+	Reason        string        // 罚没原因
 }
 
 // SlashResult 罚没结果
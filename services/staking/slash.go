@@ -49,12 +49,12 @@ func (s *stakingService) slash(ctx context.Context, req *SlashRequest, wallets .
 // validateSlashRequest 验证罚没请求
 func (s *stakingService) validateSlashRequest(req *SlashRequest) error {
 	// 1. 验证验证者地址
-	if len(req.ValidatorAddr) != 20 {
-		return fmt.Errorf("validator address must be 20 bytes")
+	if req.ValidatorAddr.IsZero() {
+		return fmt.Errorf("validator address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
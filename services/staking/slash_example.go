@@ -37,7 +37,7 @@ func slashViaContract(
 	// 规范来源：weisyn.git/docs/components/core/ispc/abi-and-payload.md
 	payloadOptions := utils.BuildPayloadOptions{
 		MethodParams: map[string]interface{}{
-			"validator_addr": hex.EncodeToString(request.ValidatorAddr),
+			"validator_addr": hex.EncodeToString(request.ValidatorAddr[:]),
 			"amount":         request.Amount,
 			"reason":         request.Reason,
 		},
@@ -78,6 +78,9 @@ func slashViaContract(
 		return nil, fmt.Errorf("decode unsigned tx failed: %w", err)
 	}
 
+	if err := wallet.RequireTxSigner(w, unsignedTx); err != nil {
+		return nil, err
+	}
 	signature, err := w.SignTransaction(unsignedTx)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -146,10 +149,10 @@ func slashViaGovernance(
 	votingPeriod uint64,
 ) (*SlashResult, error) {
 	// 1. 构建提案内容
-	proposalTitle := fmt.Sprintf("Slash Validator: %s", hex.EncodeToString(request.ValidatorAddr))
+	proposalTitle := fmt.Sprintf("Slash Validator: %s", hex.EncodeToString(request.ValidatorAddr[:]))
 	proposalDescription := fmt.Sprintf(
-		"Slash Request:\n- Validator: %s\n- Amount: %d\n- Reason: %s",
-		hex.EncodeToString(request.ValidatorAddr),
+		"Slash Request:\n- Validator: %s\n- Amount: %s\n- Reason: %s",
+		hex.EncodeToString(request.ValidatorAddr[:]),
 		request.Amount,
 		request.Reason,
 	)
@@ -193,12 +196,12 @@ func slashViaGovernance(
 // validateSlashRequest 验证罚没请求（辅助函数）
 func validateSlashRequest(req *SlashRequest) error {
 	// 1. 验证验证者地址
-	if len(req.ValidatorAddr) != 20 {
-		return fmt.Errorf("validator address must be 20 bytes")
+	if req.ValidatorAddr.IsZero() {
+		return fmt.Errorf("validator address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
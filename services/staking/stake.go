@@ -6,10 +6,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -43,7 +44,7 @@ func (s *stakingService) stake(ctx context.Context, req *StakeRequest, wallets .
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -53,8 +54,8 @@ func (s *stakingService) stake(ctx context.Context, req *StakeRequest, wallets .
 	draftJSON, inputIndex, err := buildStakeDraft(
 		ctx,
 		s.client,
-		req.From,
-		req.ValidatorAddr,
+		req.From[:],
+		req.ValidatorAddr[:],
 		req.Amount,
 		req.LockBlocks,
 		stakingContractAddr,
@@ -63,32 +64,17 @@ func (s *stakingService) stake(ctx context.Context, req *StakeRequest, wallets .
 		return nil, fmt.Errorf("build stake draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -145,7 +131,7 @@ func (s *stakingService) stake(ctx context.Context, req *StakeRequest, wallets .
 		// 查找质押输出（通常是第一个资产输出，且 owner 是质押者地址）
 		// 质押输出通常带有 HeightLock 或 ContractLock
 		for _, output := range parsedTx.Outputs {
-			if output.Type == "asset" && bytes.Equal(output.Owner, req.From) {
+			if output.Type == "asset" && bytes.Equal(output.Owner, req.From[:]) {
 				stakeID = output.Outpoint
 				break
 			}
@@ -162,15 +148,15 @@ func (s *stakingService) stake(ctx context.Context, req *StakeRequest, wallets .
 // validateStakeRequest 验证质押请求
 func (s *stakingService) validateStakeRequest(req *StakeRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.ValidatorAddr) != 20 {
-		return fmt.Errorf("validator address must be 20 bytes")
+	if req.ValidatorAddr.IsZero() {
+		return fmt.Errorf("validator address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -209,7 +195,7 @@ func (s *stakingService) unstake(ctx context.Context, req *UnstakeRequest, walle
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
@@ -217,7 +203,7 @@ func (s *stakingService) unstake(ctx context.Context, req *UnstakeRequest, walle
 	draftJSON, inputIndex, err := buildUnstakeDraft(
 		ctx,
 		s.client,
-		req.From,
+		req.From[:],
 		req.StakeID,
 		req.Amount,
 	)
@@ -225,32 +211,17 @@ func (s *stakingService) unstake(ctx context.Context, req *UnstakeRequest, walle
 		return nil, fmt.Errorf("build unstake draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -302,20 +273,20 @@ func (s *stakingService) unstake(ctx context.Context, req *UnstakeRequest, walle
 
 	// 10. 解析交易结果，提取解质押金额和奖励金额
 	unstakeAmount := req.Amount
-	rewardAmount := uint64(0)
+	var rewardAmount types.Amount
 
 	parsedTx, err := utils.FetchAndParseTx(ctx, s.client, sendResult.TxHash)
 	if err == nil && parsedTx != nil {
 		// 查找返回给用户的输出（owner 是解质押者地址）
-		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From)
+		userOutputs := utils.FindOutputsByOwner(parsedTx.Outputs, req.From[:])
 
 		// 汇总原生币金额（解质押金额 + 奖励）
 		totalAmount := utils.SumAmountsByToken(userOutputs, nil)
-		if totalAmount != nil {
-			unstakeAmount = totalAmount.Uint64()
+		if amount, err := types.NewAmountFromBigInt(totalAmount); err == nil && totalAmount != nil {
+			unstakeAmount = amount
 			// 奖励金额 = 总金额 - 请求的解质押金额（简化处理）
-			if unstakeAmount > req.Amount {
-				rewardAmount = unstakeAmount - req.Amount
+			if unstakeAmount.Cmp(req.Amount) > 0 {
+				rewardAmount, _ = unstakeAmount.Sub(req.Amount)
 			}
 		}
 	}
@@ -331,8 +302,8 @@ func (s *stakingService) unstake(ctx context.Context, req *UnstakeRequest, walle
 // validateUnstakeRequest 验证解除质押请求
 func (s *stakingService) validateUnstakeRequest(req *UnstakeRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证质押ID
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
@@ -38,7 +39,7 @@ func buildStakeDraft(
 	client client.Client,
 	fromAddress []byte,
 	validatorAddr []byte,
-	amount uint64,
+	amount types.Amount,
 	lockBlocks uint64,
 	stakingContractAddr []byte, // Staking 合约地址（可选，如果为空则只使用 HeightLock）
 ) ([]byte, uint32, error) {
@@ -49,7 +50,7 @@ func buildStakeDraft(
 	if len(validatorAddr) == 0 {
 		return nil, 0, fmt.Errorf("validatorAddr cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if lockBlocks == 0 {
@@ -106,7 +107,7 @@ func buildStakeDraft(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -125,7 +126,7 @@ func buildStakeDraft(
 	}
 
 	if selectedUTXO.Outpoint == "" {
-		return nil, 0, fmt.Errorf("insufficient balance: required %d, but no UTXO found with sufficient amount", amount)
+		return nil, 0, fmt.Errorf("insufficient balance: required %s, but no UTXO found with sufficient amount", amount)
 	}
 
 	// 6. 解析 outpoint
@@ -221,7 +222,7 @@ func buildStakeDraft(
 	stakeOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(validatorAddr),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	draft["outputs"] = append(outputs, stakeOutput)
@@ -267,7 +268,7 @@ func buildStakeTransaction(
 	client client.Client,
 	fromAddress []byte,
 	validatorAddr []byte,
-	amount uint64,
+	amount types.Amount,
 	lockBlocks uint64,
 	stakingContractAddr []byte, // Staking 合约地址（可选，如果为空则只使用 HeightLock）
 ) ([]byte, error) {
@@ -318,7 +319,7 @@ func buildStakeTransaction(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -431,7 +432,7 @@ func buildStakeTransaction(
 	stakeOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(validatorAddr),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": lockingCondition,
 	}
 	draft["outputs"] = append(outputs, stakeOutput)
@@ -501,7 +502,7 @@ func buildUnstakeDraft(
 	client client.Client,
 	fromAddress []byte,
 	stakeID []byte, // StakeID（outpoint 格式：txHash:index）
-	amount uint64, // 解质押金额（0表示全部）
+	amount types.Amount, // 解质押金额（0表示全部）
 ) ([]byte, uint32, error) {
 	// 0. 参数验证
 	if len(fromAddress) == 0 {
@@ -585,8 +586,8 @@ func buildUnstakeDraft(
 	}
 
 	// 5. 计算解质押金额
-	unstakeAmount := big.NewInt(int64(amount))
-	if amount == 0 || unstakeAmount.Cmp(stakeAmount) > 0 {
+	unstakeAmount := amount.BigInt()
+	if amount.IsZero() || unstakeAmount.Cmp(stakeAmount) > 0 {
 		unstakeAmount = stakeAmount // 全部解质押
 	}
 
@@ -658,7 +659,7 @@ func buildUnstakeTransaction(
 	client client.Client,
 	fromAddress []byte,
 	stakeID []byte, // StakeID（可能是 outpoint 字符串或交易哈希）
-	amount uint64, // 解质押金额（0表示全部）
+	amount types.Amount, // 解质押金额（0表示全部）
 ) ([]byte, error) {
 	// 1. 解析 StakeID（假设是 outpoint 格式：txHash:index）
 	stakeIDStr := string(stakeID)
@@ -729,8 +730,8 @@ func buildUnstakeTransaction(
 	}
 
 	// 5. 计算解质押金额
-	unstakeAmount := big.NewInt(int64(amount))
-	if amount == 0 || unstakeAmount.Cmp(stakeAmount) > 0 {
+	unstakeAmount := amount.BigInt()
+	if amount.IsZero() || unstakeAmount.Cmp(stakeAmount) > 0 {
 		unstakeAmount = stakeAmount // 全部解质押
 	}
 
@@ -828,9 +829,9 @@ func buildDelegateDraft(
 	client client.Client,
 	fromAddress []byte,
 	validatorAddr []byte,
-	amount uint64,
+	amount types.Amount,
 	expiryDurationBlocks uint64, // 委托有效期（区块数，0=永不过期）
-	maxValuePerOperation uint64, // 单次操作最大价值
+	maxValuePerOperation types.Amount, // 单次操作最大价值
 ) ([]byte, uint32, error) {
 	// 0. 参数验证
 	if len(fromAddress) == 0 {
@@ -839,7 +840,7 @@ func buildDelegateDraft(
 	if len(validatorAddr) == 0 {
 		return nil, 0, fmt.Errorf("validatorAddr cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if client == nil {
@@ -893,7 +894,7 @@ func buildDelegateDraft(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -912,7 +913,7 @@ func buildDelegateDraft(
 	}
 
 	if selectedUTXO.Outpoint == "" {
-		return nil, 0, fmt.Errorf("insufficient balance: required %d, but no UTXO found with sufficient amount", amount)
+		return nil, 0, fmt.Errorf("insufficient balance: required %s, but no UTXO found with sufficient amount", amount)
 	}
 
 	// 6. 解析 outpoint
@@ -938,7 +939,7 @@ func buildDelegateDraft(
 		"original_owner":          hex.EncodeToString(fromAddress),
 		"allowed_delegates":       []string{hex.EncodeToString(validatorAddr)},
 		"authorized_operations":   []string{"stake", "consume"},
-		"max_value_per_operation": fmt.Sprintf("%d", maxValuePerOperation),
+		"max_value_per_operation": maxValuePerOperation.String(),
 	}
 	if expiryDurationBlocks > 0 {
 		delegationLock["expiry_duration_blocks"] = fmt.Sprintf("%d", expiryDurationBlocks)
@@ -965,7 +966,7 @@ func buildDelegateDraft(
 	delegateOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(validatorAddr),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": delegationLock,
 	}
 	draft["outputs"] = append(outputs, delegateOutput)
@@ -1009,9 +1010,9 @@ func buildDelegateTransaction(
 	client client.Client,
 	fromAddress []byte,
 	validatorAddr []byte,
-	amount uint64,
+	amount types.Amount,
 	expiryDurationBlocks uint64, // 委托有效期（区块数，0=永不过期）
-	maxValuePerOperation uint64, // 单次操作最大价值
+	maxValuePerOperation types.Amount, // 单次操作最大价值
 ) ([]byte, error) {
 	// 1. 将地址转换为 Base58 格式
 	fromAddressBase58, err := utils.AddressBytesToBase58(fromAddress)
@@ -1060,7 +1061,7 @@ func buildDelegateTransaction(
 	}
 
 	// 5. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range utxos {
@@ -1103,7 +1104,7 @@ func buildDelegateTransaction(
 		"original_owner":          hex.EncodeToString(fromAddress),
 		"allowed_delegates":       []string{hex.EncodeToString(validatorAddr)},
 		"authorized_operations":   []string{"stake", "consume"},
-		"max_value_per_operation": fmt.Sprintf("%d", maxValuePerOperation),
+		"max_value_per_operation": maxValuePerOperation.String(),
 	}
 	if expiryDurationBlocks > 0 {
 		delegationLock["expiry_duration_blocks"] = fmt.Sprintf("%d", expiryDurationBlocks)
@@ -1130,7 +1131,7 @@ func buildDelegateTransaction(
 	delegateOutput := map[string]interface{}{
 		"type":              "asset",
 		"owner":             hex.EncodeToString(validatorAddr),
-		"amount":            fmt.Sprintf("%d", amount),
+		"amount":            amount.String(),
 		"locking_condition": delegationLock,
 	}
 	draft["outputs"] = append(outputs, delegateOutput)
@@ -1200,7 +1201,7 @@ func buildUndelegateDraft(
 	client client.Client,
 	fromAddress []byte,
 	delegateID []byte, // DelegateID（outpoint 格式：txHash:index）
-	amount uint64, // 取消委托金额（0表示全部）
+	amount types.Amount, // 取消委托金额（0表示全部）
 ) ([]byte, uint32, error) {
 	// 0. 参数验证
 	if len(fromAddress) == 0 {
@@ -1279,8 +1280,8 @@ func buildUndelegateDraft(
 	}
 
 	// 5. 计算取消委托金额
-	undelegateAmount := big.NewInt(int64(amount))
-	if amount == 0 || undelegateAmount.Cmp(delegateAmount) > 0 {
+	undelegateAmount := amount.BigInt()
+	if amount.IsZero() || undelegateAmount.Cmp(delegateAmount) > 0 {
 		undelegateAmount = delegateAmount // 全部取消委托
 	}
 
@@ -1346,7 +1347,7 @@ func buildUndelegateTransaction(
 	client client.Client,
 	fromAddress []byte,
 	delegateID []byte, // DelegateID（outpoint 格式：txHash:index）
-	amount uint64, // 取消委托金额（0表示全部）
+	amount types.Amount, // 取消委托金额（0表示全部）
 ) ([]byte, error) {
 	// 1. 解析 DelegateID（outpoint 格式：txHash:index）
 	delegateIDStr := string(delegateID)
@@ -1412,8 +1413,8 @@ func buildUndelegateTransaction(
 	}
 
 	// 5. 计算取消委托金额
-	undelegateAmount := big.NewInt(int64(amount))
-	if amount == 0 || undelegateAmount.Cmp(delegateAmount) > 0 {
+	undelegateAmount := amount.BigInt()
+	if amount.IsZero() || undelegateAmount.Cmp(delegateAmount) > 0 {
 		undelegateAmount = delegateAmount // 全部取消委托
 	}
 
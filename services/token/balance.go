@@ -1,58 +1,400 @@
 package token
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
-// getBalance 查询余额实现
-func (s *tokenService) getBalance(ctx context.Context, address []byte, tokenID []byte) (uint64, error) {
-	// 1. 验证地址
-	if len(address) != 20 {
-		return 0, fmt.Errorf("address must be 20 bytes")
+// BalanceSource 余额数据来源
+type BalanceSource string
+
+const (
+	BalanceSourceNative        BalanceSource = "wes_getBalance"              // 原生币余额接口
+	BalanceSourceContractToken BalanceSource = "wes_getContractTokenBalance" // 合约代币余额接口
+	BalanceSourceUTXO          BalanceSource = "wes_getUTXO"                 // UTXO 汇总
+)
+
+// BalanceRequest 余额查询请求
+type BalanceRequest struct {
+	Address      types.Address // 查询地址
+	TokenID      []byte        // 代币ID（nil 表示原生币）
+	ContractHash []byte        // 合约 contentHash（32字节，可选：提供时使用 wes_getContractTokenBalance）
+	Height       *uint64       // 历史区块高度（可选，nil 表示最新；需节点支持）
+}
+
+// BalanceResult 余额查询结果
+type BalanceResult struct {
+	Address   types.Address // 查询地址
+	TokenID   []byte        // 代币ID（nil 表示原生币）
+	Balance   types.Amount  // 余额
+	UTXOCount int           // UTXO 数量（节点或汇总结果提供时有效）
+	Height    uint64        // 查询时的区块高度（节点提供时有效）
+	Source    BalanceSource // 数据来源
+}
+
+// AssetBalance 单个资产的余额明细
+type AssetBalance struct {
+	TokenID      []byte       // 代币ID（nil 表示原生币）
+	Total        types.Amount // 总额 = 以下各项之和
+	Spendable    types.Amount // 可花费
+	TimeLocked   types.Amount // 时间锁未到期
+	HeightLocked types.Amount // 高度锁未到期
+	Delegated    types.Amount // 委托锁定
+	Locked       types.Amount // 其他锁定（多签、合约锁、门限锁等，需额外条件才能花费）
+	UTXOCount    int          // UTXO 数量
+}
+
+// IsNative 是否为原生币
+func (a *AssetBalance) IsNative() bool {
+	return len(a.TokenID) == 0
+}
+
+// Portfolio 地址的多资产余额
+type Portfolio struct {
+	Address types.Address   // 查询地址
+	Height  uint64          // 查询时的区块高度（无法获取时为 0）
+	Assets  []*AssetBalance // 原生币在前，其余按代币ID排序
+}
+
+// Asset 按代币ID查找资产（nil 表示原生币），不存在时返回 nil
+func (p *Portfolio) Asset(tokenID []byte) *AssetBalance {
+	for _, asset := range p.Assets {
+		if bytes.Equal(asset.TokenID, tokenID) {
+			return asset
+		}
 	}
+	return nil
+}
 
-	// 2. 将地址转换为 Base58 格式
-	addressBase58, err := utils.AddressBytesToBase58(address)
+// getBalance 查询余额实现
+//
+// tokenID 为 nil 时查询原生币余额（wes_getBalance），否则按代币ID汇总 wes_getUTXO。
+func (s *tokenService) getBalance(ctx context.Context, address []byte, tokenID []byte) (types.Amount, error) {
+	addr, err := types.NewAddressFromBytes(address)
+	if err != nil {
+		return types.Amount{}, err
+	}
+	result, err := s.queryBalance(ctx, &BalanceRequest{Address: addr, TokenID: tokenID})
 	if err != nil {
-		return 0, fmt.Errorf("address conversion failed: %w", err)
+		return types.Amount{}, err
+	}
+	return result.Balance, nil
+}
+
+// queryBalance 按请求查询余额
+func (s *tokenService) queryBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error) {
+	// 1. 验证参数
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if req.Address.IsZero() {
+		return nil, fmt.Errorf("address is required")
+	}
+	if len(req.ContractHash) > 0 && len(req.ContractHash) != 32 {
+		return nil, fmt.Errorf("contract hash must be 32 bytes")
+	}
+
+	// 2. 按数据来源分发
+	switch {
+	case len(req.ContractHash) > 0:
+		return s.queryContractTokenBalance(ctx, req)
+	case len(req.TokenID) == 0:
+		return s.queryNativeBalance(ctx, req)
+	default:
+		return s.queryUTXOBalance(ctx, req)
 	}
+}
 
-	// 3. 构建查询参数
+// queryNativeBalance 通过 wes_getBalance 查询原生币余额
+func (s *tokenService) queryNativeBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error) {
+	// 1. 构建查询参数（Base58 地址）
 	params := []interface{}{
-		addressBase58,
-		"latest", // blockParameter: "latest" | "pending" | blockNumber
+		req.Address.String(),
+		blockParameter(req.Height), // blockParameter: "latest" | "pending" | blockNumber
 	}
 
-	// 4. 调用JSON-RPC方法
+	// 3. 调用JSON-RPC方法
 	result, err := s.client.Call(ctx, "wes_getBalance", params)
 	if err != nil {
-		return 0, fmt.Errorf("call wes_getBalance failed: %w", err)
+		return nil, fmt.Errorf("call wes_getBalance failed: %w", err)
 	}
 
-	// 5. 解析结果 - wes_getBalance 返回包含 balance 字段的对象
+	// 4. 解析结果 - wes_getBalance 返回包含 balance 字段的对象
 	resultMap, ok := result.(map[string]interface{})
 	if !ok {
-		return 0, fmt.Errorf("invalid response format: expected map, got %T", result)
+		return nil, fmt.Errorf("invalid response format: expected map, got %T", result)
 	}
 
 	balanceStr, ok := resultMap["balance"].(string)
 	if !ok {
-		return 0, fmt.Errorf("invalid response format: balance field not found or not a string")
+		return nil, fmt.Errorf("invalid response format: balance field not found or not a string")
+	}
+
+	// 5. 解析任意精度金额（balance 是十六进制字符串，如 "0x4a817c800"，前缀可能缺省）
+	if !strings.HasPrefix(balanceStr, "0x") {
+		balanceStr = "0x" + balanceStr
+	}
+	balance, err := types.ParseAmount(balanceStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse balance failed: %w", err)
+	}
+
+	res := &BalanceResult{
+		Address: req.Address,
+		Balance: balance,
+		Source:  BalanceSourceNative,
+	}
+	if req.Height != nil {
+		res.Height = *req.Height
+	} else {
+		res.Height = utils.ParseUint(resultMap["height"])
+	}
+	return res, nil
+}
+
+// queryContractTokenBalance 通过 wes_getContractTokenBalance 查询合约代币余额
+func (s *tokenService) queryContractTokenBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error) {
+	tokenIDHex := ""
+	if len(req.TokenID) > 0 {
+		tokenIDHex = hex.EncodeToString(req.TokenID)
+	}
+
+	var (
+		tb  *client.TokenBalance
+		err error
+	)
+	if req.Height == nil {
+		tb, err = client.NewWESClientFromClient(s.client).GetContractTokenBalance(ctx, req.Address.Bytes(), req.ContractHash, tokenIDHex)
+	} else {
+		tb, err = s.callContractTokenBalanceAt(ctx, req, tokenIDHex)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query contract token balance failed: %w", err)
+	}
+
+	balance, err := types.ParseAmount(tb.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("parse balance failed: %w", err)
+	}
+	return &BalanceResult{
+		Address:   req.Address,
+		TokenID:   req.TokenID,
+		Balance:   balance,
+		UTXOCount: tb.UTXOCount,
+		Height:    tb.Height,
+		Source:    BalanceSourceContractToken,
+	}, nil
+}
+
+// callContractTokenBalanceAt 在指定历史高度查询合约代币余额
+// WESClient.GetContractTokenBalance 不支持高度参数，这里直接构造请求
+func (s *tokenService) callContractTokenBalanceAt(ctx context.Context, req *BalanceRequest, tokenIDHex string) (*client.TokenBalance, error) {
+	params := map[string]interface{}{
+		"address":      req.Address.String(),
+		"content_hash": hex.EncodeToString(req.ContractHash),
+		"height":       *req.Height,
+	}
+	if tokenIDHex != "" {
+		params["token_id"] = tokenIDHex
+	}
+
+	raw, err := s.client.Call(ctx, "wes_getContractTokenBalance", []interface{}{params})
+	if err != nil {
+		return nil, err
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid token balance format")
+	}
+	tb := &client.TokenBalance{Height: *req.Height}
+	if bal, ok := m["balance"].(string); ok {
+		tb.Balance = bal
+	}
+	if count, ok := m["utxo_count"].(float64); ok {
+		tb.UTXOCount = int(count)
+	}
+	return tb, nil
+}
+
+// queryUTXOBalance 按代币ID汇总 UTXO 余额（包含锁定部分）
+func (s *tokenService) queryUTXOBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error) {
+	entries, err := queryBalanceUTXOs(ctx, s.client, req.Address, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	total := types.Amount{}
+	count := 0
+	for _, e := range entries {
+		if bytes.Equal(e.tokenID, req.TokenID) {
+			total = total.Add(e.amount)
+			count++
+		}
+	}
+
+	res := &BalanceResult{
+		Address:   req.Address,
+		TokenID:   req.TokenID,
+		Balance:   total,
+		UTXOCount: count,
+		Source:    BalanceSourceUTXO,
+	}
+	if req.Height != nil {
+		res.Height = *req.Height
+	}
+	return res, nil
+}
+
+// getPortfolio 查询地址下全部资产余额
+//
+// **锁定分类**（按 UTXO 最外层锁定条件）：
+// - time_lock：unlock_timestamp 晚于当前时间 → TimeLocked
+// - height_lock：unlock_height 高于查询高度 → HeightLocked
+// - delegation_lock → Delegated
+// - multi_key / contract / threshold 等 → Locked
+// - single_key 或无锁定信息 → Spendable
+//
+// 历史高度查询时，时间锁仍按当前时间判断。
+func (s *tokenService) getPortfolio(ctx context.Context, address []byte, height *uint64) (*Portfolio, error) {
+	addr, err := types.NewAddressFromBytes(address)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := queryBalanceUTXOs(ctx, s.client, addr, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// 确定用于判断高度锁的参考高度
+	refHeight := uint64(0)
+	if height != nil {
+		refHeight = *height
+	} else {
+		refHeight = queryCurrentHeight(ctx, s.client)
+	}
+	now := time.Now().Unix()
+
+	byToken := make(map[string]*AssetBalance)
+	for _, e := range entries {
+		key := hex.EncodeToString(e.tokenID)
+		asset, ok := byToken[key]
+		if !ok {
+			asset = &AssetBalance{TokenID: e.tokenID}
+			byToken[key] = asset
+		}
+
+		asset.Total = asset.Total.Add(e.amount)
+		asset.UTXOCount++
+
+		switch utils.ClassifyLock(e.lock, refHeight, now) {
+		case utils.LockTime:
+			asset.TimeLocked = asset.TimeLocked.Add(e.amount)
+		case utils.LockHeight:
+			asset.HeightLocked = asset.HeightLocked.Add(e.amount)
+		case utils.LockDelegated:
+			asset.Delegated = asset.Delegated.Add(e.amount)
+		case utils.LockOther:
+			asset.Locked = asset.Locked.Add(e.amount)
+		default:
+			asset.Spendable = asset.Spendable.Add(e.amount)
+		}
+	}
+
+	keys := make([]string, 0, len(byToken))
+	for k := range byToken {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // 原生币 key 为空字符串，排在最前
+
+	portfolio := &Portfolio{Address: addr, Height: refHeight}
+	for _, k := range keys {
+		portfolio.Assets = append(portfolio.Assets, byToken[k])
+	}
+	return portfolio, nil
+}
+
+// balanceUTXO 余额汇总用的 UTXO 条目
+type balanceUTXO struct {
+	outpoint string
+	amount   types.Amount
+	tokenID  []byte
+	lock     map[string]interface{}
+}
+
+// queryBalanceUTXOs 查询地址的 UTXO 并解析金额、代币ID与锁定条件
+func queryBalanceUTXOs(ctx context.Context, c client.Client, address types.Address, height *uint64) ([]balanceUTXO, error) {
+	params := []interface{}{address.String()}
+	if height != nil {
+		params = append(params, blockParameter(height))
+	}
+	result, err := c.Call(ctx, "wes_getUTXO", params)
+	if err != nil {
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
+	}
+
+	utxoMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid UTXO response format")
 	}
+	utxosArray, _ := utxoMap["utxos"].([]interface{})
 
-	// 6. 转换为uint64（balance 是十六进制字符串，如 "0x4a817c800"）
-	// 移除 0x 前缀
-	if len(balanceStr) > 2 && balanceStr[:2] == "0x" {
-		balanceStr = balanceStr[2:]
+	entries := make([]balanceUTXO, 0, len(utxosArray))
+	for _, item := range utxosArray {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		amountStr := getString(m, "amount")
+		if amountStr == "" {
+			continue
+		}
+		amount, err := types.ParseAmount(amountStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UTXO amount %q: %w", amountStr, err)
+		}
+
+		var tokenID []byte
+		if tokenIDHex := getString(m, "tokenID"); tokenIDHex != "" {
+			tokenID, err = hex.DecodeString(strings.TrimPrefix(tokenIDHex, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid UTXO tokenID %q: %w", tokenIDHex, err)
+			}
+		}
+
+		entries = append(entries, balanceUTXO{
+			outpoint: getString(m, "outpoint"),
+			amount:   amount,
+			tokenID:  tokenID,
+			lock:     utils.LockingConditionOf(m),
+		})
 	}
-	balance, err := strconv.ParseUint(balanceStr, 16, 64)
+	return entries, nil
+}
+
+// queryCurrentHeight 查询当前区块高度，失败时返回 0
+func queryCurrentHeight(ctx context.Context, c client.Client) uint64 {
+	result, err := c.Call(ctx, "wes_blockNumber", []interface{}{})
 	if err != nil {
-		return 0, fmt.Errorf("parse balance failed: %w", err)
+		return 0
 	}
+	return utils.ParseUint(result)
+}
 
-	return balance, nil
+// blockParameter 构造区块参数："latest" 或十六进制高度
+func blockParameter(height *uint64) string {
+	if height == nil {
+		return "latest"
+	}
+	return "0x" + strconv.FormatUint(*height, 16)
 }
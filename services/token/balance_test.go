@@ -0,0 +1,185 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// balanceMockClient 按方法名返回固定响应的 mock 客户端
+type balanceMockClient struct {
+	responses map[string]interface{}
+	calls     map[string][]interface{}
+}
+
+func newBalanceMockClient(responses map[string]interface{}) *balanceMockClient {
+	return &balanceMockClient{responses: responses, calls: make(map[string][]interface{})}
+}
+
+func (m *balanceMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	m.calls[method] = append(m.calls[method], params)
+	resp, ok := m.responses[method]
+	if !ok {
+		return nil, fmt.Errorf("unexpected method %s", method)
+	}
+	return resp, nil
+}
+
+func (m *balanceMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *balanceMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *balanceMockClient) Close() error { return nil }
+
+var (
+	testAddress = types.MustAddressFromBytes(bytes.Repeat([]byte{0x01}, 20))
+	testTokenID = []byte{0xaa, 0xbb}
+)
+
+func portfolioUTXOs() map[string]interface{} {
+	return map[string]interface{}{
+		"utxos": []interface{}{
+			map[string]interface{}{"outpoint": "01:0", "amount": "100"},
+			map[string]interface{}{"outpoint": "02:0", "amount": "40", "locking_condition": map[string]interface{}{
+				"height_lock": map[string]interface{}{"unlock_height": float64(200)},
+			}},
+			map[string]interface{}{"outpoint": "03:0", "amount": "7", "locking_condition": map[string]interface{}{
+				"height_lock": map[string]interface{}{"unlock_height": float64(50)},
+			}},
+			map[string]interface{}{"outpoint": "04:0", "amount": "5", "locking_condition": map[string]interface{}{
+				"time_lock": map[string]interface{}{"unlock_timestamp": "99999999999"},
+			}},
+			map[string]interface{}{"outpoint": "05:0", "amount": "3", "locking_condition": map[string]interface{}{
+				"delegation_lock": map[string]interface{}{},
+			}},
+			map[string]interface{}{"outpoint": "06:0", "amount": "18446744073709551616", "tokenID": "aabb"},
+			map[string]interface{}{"outpoint": "07:0", "amount": "2", "tokenID": "aabb", "locking_condition": map[string]interface{}{
+				"multi_key_lock": map[string]interface{}{},
+			}},
+		},
+	}
+}
+
+func TestGetBalanceNative(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{
+		"wes_getBalance": map[string]interface{}{"balance": "0x10"},
+	})
+	bal, err := NewService(mc).GetBalance(context.Background(), testAddress[:], nil)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if bal.String() != "16" {
+		t.Fatalf("balance = %s, want 16", bal)
+	}
+}
+
+func TestGetBalanceToken(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{"wes_getUTXO": portfolioUTXOs()})
+	bal, err := NewService(mc).GetBalance(context.Background(), testAddress[:], testTokenID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if bal.String() != "18446744073709551618" {
+		t.Fatalf("balance = %s", bal)
+	}
+	if len(mc.calls["wes_getBalance"]) != 0 {
+		t.Fatalf("token balance must not use wes_getBalance")
+	}
+}
+
+func TestQueryBalanceAtHeight(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{
+		"wes_getBalance": map[string]interface{}{"balance": "ff"},
+	})
+	height := uint64(256)
+	res, err := NewService(mc).QueryBalance(context.Background(), &BalanceRequest{Address: testAddress, Height: &height})
+	if err != nil {
+		t.Fatalf("QueryBalance: %v", err)
+	}
+	if res.Balance.String() != "255" || res.Height != 256 || res.Source != BalanceSourceNative {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	params := mc.calls["wes_getBalance"][0].([]interface{})
+	if params[1] != "0x100" {
+		t.Fatalf("block parameter = %v, want 0x100", params[1])
+	}
+}
+
+func TestQueryBalanceContractToken(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{
+		"wes_getContractTokenBalance": map[string]interface{}{"balance": "500", "utxo_count": float64(2), "height": float64(9)},
+	})
+	res, err := NewService(mc).QueryBalance(context.Background(), &BalanceRequest{
+		Address:      testAddress,
+		TokenID:      testTokenID,
+		ContractHash: make([]byte, 32),
+	})
+	if err != nil {
+		t.Fatalf("QueryBalance: %v", err)
+	}
+	if res.Balance.Cmp(types.NewAmount(500)) != 0 || res.UTXOCount != 2 || res.Source != BalanceSourceContractToken {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestGetPortfolio(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{
+		"wes_getUTXO":     portfolioUTXOs(),
+		"wes_blockNumber": "0x64", // 100
+	})
+	p, err := NewService(mc).GetPortfolio(context.Background(), testAddress[:])
+	if err != nil {
+		t.Fatalf("GetPortfolio: %v", err)
+	}
+	if p.Height != 100 || len(p.Assets) != 2 {
+		t.Fatalf("unexpected portfolio height=%d assets=%d", p.Height, len(p.Assets))
+	}
+
+	native := p.Assets[0]
+	if !native.IsNative() || native.UTXOCount != 5 {
+		t.Fatalf("first asset should be native with 5 UTXOs, got %+v", native)
+	}
+	checks := map[string]types.Amount{
+		"total":        native.Total,
+		"spendable":    native.Spendable,
+		"heightLocked": native.HeightLocked,
+		"timeLocked":   native.TimeLocked,
+		"delegated":    native.Delegated,
+	}
+	want := map[string]uint64{"total": 155, "spendable": 107, "heightLocked": 40, "timeLocked": 5, "delegated": 3}
+	for k, v := range want {
+		if checks[k].Cmp(types.NewAmount(v)) != 0 {
+			t.Errorf("%s = %s, want %d", k, checks[k], v)
+		}
+	}
+
+	token := p.Asset(testTokenID)
+	if token == nil || token.Locked.String() != "2" || token.Spendable.String() != "18446744073709551616" {
+		t.Fatalf("unexpected token asset %+v", token)
+	}
+}
+
+func TestGetPortfolioAtHeight(t *testing.T) {
+	mc := newBalanceMockClient(map[string]interface{}{"wes_getUTXO": portfolioUTXOs()})
+	p, err := NewService(mc).GetPortfolioAt(context.Background(), testAddress[:], 300)
+	if err != nil {
+		t.Fatalf("GetPortfolioAt: %v", err)
+	}
+	if len(mc.calls["wes_blockNumber"]) != 0 {
+		t.Fatalf("historic query must not fetch current height")
+	}
+	if params := mc.calls["wes_getUTXO"][0].([]interface{}); len(params) != 2 || params[1] != "0x12c" {
+		t.Fatalf("unexpected wes_getUTXO params %v", params)
+	}
+	if got := p.Asset(nil).HeightLocked; !got.IsZero() {
+		t.Fatalf("height lock at 200 should be unlocked at 300, got %s", got)
+	}
+}
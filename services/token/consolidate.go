@@ -0,0 +1,373 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// DefaultMaxInputsPerTx 归集 / 清扫 / 批量转账交易默认的最大输入数
+const DefaultMaxInputsPerTx = 50
+
+// DustPolicy 粉尘策略：价值不足以覆盖花费成本的 UTXO 不参与归集与清扫
+type DustPolicy struct {
+	FeePerInput types.Amount // 花费单个输入的预估手续费，金额 ≤ 该值的 UTXO 视为粉尘
+	MinValue    types.Amount // 额外的最小价值门槛（金额 < 该值视为粉尘，可选）
+}
+
+// IsDust 判断金额是否为粉尘
+func (p *DustPolicy) IsDust(amount types.Amount) bool {
+	if p == nil {
+		return false
+	}
+	if !p.FeePerInput.IsZero() && amount.Cmp(p.FeePerInput) <= 0 {
+		return true
+	}
+	return amount.Cmp(p.MinValue) < 0
+}
+
+// ConsolidateOptions UTXO 归集选项
+type ConsolidateOptions struct {
+	Threshold      types.Amount // 仅归集金额低于该值的 UTXO（零值表示全部可花费 UTXO）
+	MaxInputsPerTx int          // 单笔交易最大输入数（0 表示 DefaultMaxInputsPerTx）
+	MinInputs      int          // 少于该数量的批次不提交（0 表示 2，单个 UTXO 无需归集）
+	Dust           *DustPolicy  // 粉尘策略（可选）
+}
+
+// ConsolidateResult UTXO 归集结果
+type ConsolidateResult struct {
+	TxHashes    []string     // 每批一笔交易
+	Merged      int          // 被归集的 UTXO 数量
+	Amount      types.Amount // 归集总额
+	SkippedDust int          // 因粉尘策略跳过的 UTXO 数量
+}
+
+// SweepRequest 清扫请求：将一个地址的全部可花费资产转移到另一个地址（如密钥轮换）
+type SweepRequest struct {
+	From           types.Address // 源地址
+	To             types.Address // 目标地址
+	TokenIDs       [][]byte      // 仅清扫指定资产（nil 表示全部资产；原生币用 nil 元素表示）
+	MaxInputsPerTx int           // 单笔交易最大输入数（0 表示 DefaultMaxInputsPerTx）
+	Dust           *DustPolicy   // 粉尘策略（可选）
+}
+
+// SweptAsset 单个资产的清扫结果
+type SweptAsset struct {
+	TokenID   []byte       // 代币ID（nil 表示原生币）
+	Amount    types.Amount // 转移总额
+	UTXOCount int          // 转移的 UTXO 数量
+}
+
+// SweepResult 清扫结果
+type SweepResult struct {
+	TxHashes    []string
+	Assets      []SweptAsset // 原生币在前，其余按代币ID排序
+	SkippedDust int          // 因粉尘策略跳过的 UTXO 数量
+	Skipped     int          // 锁定中（时间锁、高度锁、委托、多签等）未清扫的 UTXO 数量
+}
+
+// consolidate UTXO 归集实现
+//
+// **流程**：
+// 1. 查询地址的 UTXO，筛选指定资产中可花费（无未到期锁定）且低于阈值的 UTXO
+// 2. 按粉尘策略剔除不值得花费的 UTXO
+// 3. 按金额从小到大分批（每批不超过 MaxInputsPerTx 个输入），每批合并为一个输出返回给自己
+// 4. 每批签名并提交
+//
+// 中途失败时返回已提交部分的结果与错误。
+func (s *tokenService) consolidate(ctx context.Context, address []byte, tokenID []byte, opts *ConsolidateOptions, wallets ...wallet.Wallet) (*ConsolidateResult, error) {
+	// 1. 参数验证
+	addr, err := types.NewAddressFromBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ConsolidateOptions{}
+	}
+	maxInputs := opts.MaxInputsPerTx
+	if maxInputs <= 0 {
+		maxInputs = DefaultMaxInputsPerTx
+	}
+	minInputs := opts.MinInputs
+	if minInputs <= 0 {
+		minInputs = 2
+	}
+	if minInputs > maxInputs {
+		return nil, fmt.Errorf("min inputs (%d) exceeds max inputs per transaction (%d)", minInputs, maxInputs)
+	}
+
+	// 2. 获取 Wallet 并验证地址
+	w, err := s.requireWallet(addr, wallets...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 筛选候选 UTXO
+	spendable, _, err := s.spendableUTXOs(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	result := &ConsolidateResult{}
+	var candidates []balanceUTXO
+	for _, u := range spendable {
+		if !bytes.Equal(u.tokenID, tokenID) {
+			continue
+		}
+		if !opts.Threshold.IsZero() && u.amount.Cmp(opts.Threshold) >= 0 {
+			continue
+		}
+		if opts.Dust.IsDust(u.amount) {
+			result.SkippedDust++
+			continue
+		}
+		candidates = append(candidates, u)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].amount.Cmp(candidates[j].amount) < 0
+	})
+
+	// 4. 分批归集
+	for start := 0; start < len(candidates); start += maxInputs {
+		end := start + maxInputs
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		batch := candidates[start:end]
+		if len(batch) < minInputs {
+			break
+		}
+
+		txHash, err := s.sendSweepBatch(ctx, w, addr, addr, batch)
+		if err != nil {
+			if len(result.TxHashes) == 0 {
+				return nil, err
+			}
+			return result, fmt.Errorf("consolidate batch %d failed: %w", len(result.TxHashes)+1, err)
+		}
+		result.TxHashes = append(result.TxHashes, txHash)
+		for _, u := range batch {
+			result.Merged++
+			result.Amount = result.Amount.Add(u.amount)
+		}
+	}
+	return result, nil
+}
+
+// sweepAll 清扫实现：将源地址全部可花费资产转移到目标地址
+//
+// 每批交易可包含多种资产，每种资产合并为一个输出；锁定中的 UTXO 不会被转移（计入 Skipped）。
+func (s *tokenService) sweepAll(ctx context.Context, req *SweepRequest, wallets ...wallet.Wallet) (*SweepResult, error) {
+	// 1. 参数验证
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if req.From.IsZero() {
+		return nil, fmt.Errorf("from address is required")
+	}
+	if req.To.IsZero() {
+		return nil, fmt.Errorf("to address is required")
+	}
+	if req.From == req.To {
+		return nil, fmt.Errorf("from and to addresses must differ (use Consolidate to merge UTXOs)")
+	}
+	maxInputs := req.MaxInputsPerTx
+	if maxInputs <= 0 {
+		maxInputs = DefaultMaxInputsPerTx
+	}
+
+	// 2. 获取 Wallet 并验证地址
+	w, err := s.requireWallet(req.From, wallets...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 筛选可花费 UTXO
+	spendable, locked, err := s.spendableUTXOs(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	result := &SweepResult{Skipped: locked}
+	var candidates []balanceUTXO
+	for _, u := range spendable {
+		if req.TokenIDs != nil && !containsTokenID(req.TokenIDs, u.tokenID) {
+			continue
+		}
+		if req.Dust.IsDust(u.amount) {
+			result.SkippedDust++
+			continue
+		}
+		candidates = append(candidates, u)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no spendable UTXOs to sweep")
+	}
+
+	// 4. 分批提交
+	swept := make(map[string]*SweptAsset)
+	for start := 0; start < len(candidates); start += maxInputs {
+		end := start + maxInputs
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		batch := candidates[start:end]
+
+		txHash, err := s.sendSweepBatch(ctx, w, req.From, req.To, batch)
+		if err != nil {
+			if len(result.TxHashes) == 0 {
+				return nil, err
+			}
+			result.Assets = sortedSweptAssets(swept)
+			return result, fmt.Errorf("sweep batch %d failed: %w", len(result.TxHashes)+1, err)
+		}
+		result.TxHashes = append(result.TxHashes, txHash)
+		for _, u := range batch {
+			key := hex.EncodeToString(u.tokenID)
+			asset, ok := swept[key]
+			if !ok {
+				asset = &SweptAsset{TokenID: u.tokenID}
+				swept[key] = asset
+			}
+			asset.Amount = asset.Amount.Add(u.amount)
+			asset.UTXOCount++
+		}
+	}
+
+	result.Assets = sortedSweptAssets(swept)
+	return result, nil
+}
+
+// requireWallet 获取 Wallet 并验证其地址
+func (s *tokenService) requireWallet(address types.Address, wallets ...wallet.Wallet) (wallet.Wallet, error) {
+	w := s.getWallet(wallets...)
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	if !bytes.Equal(w.Address(), address[:]) {
+		return nil, fmt.Errorf("wallet address does not match from address")
+	}
+	return w, nil
+}
+
+// spendableUTXOs 查询当前可花费的 UTXO，同时返回锁定中的 UTXO 数量
+func (s *tokenService) spendableUTXOs(ctx context.Context, address types.Address) ([]balanceUTXO, int, error) {
+	entries, err := queryBalanceUTXOs(ctx, s.client, address, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	height := queryCurrentHeight(ctx, s.client)
+	now := time.Now().Unix()
+
+	var spendable []balanceUTXO
+	locked := 0
+	for _, e := range entries {
+		if e.outpoint == "" {
+			continue
+		}
+		if utils.ClassifyLock(e.lock, height, now) != utils.LockSpendable {
+			locked++
+			continue
+		}
+		spendable = append(spendable, e)
+	}
+	return spendable, locked, nil
+}
+
+// sendSweepBatch 构建、签名并提交一批 UTXO 的合并交易（每种资产一个输出）
+func (s *tokenService) sendSweepBatch(ctx context.Context, w wallet.Wallet, from, to types.Address, batch []balanceUTXO) (string, error) {
+	draftJSON, inputIndices, err := buildSweepDraft(from, to, batch)
+	if err != nil {
+		return "", fmt.Errorf("build sweep draft failed: %w", err)
+	}
+	return s.signAndSendBatchDraft(ctx, w, draftJSON, inputIndices)
+}
+
+// buildSweepDraft 构建合并交易草稿：消费全部给定 UTXO，每种资产输出一笔给目标地址
+// 注意：手续费从接收者扣除，因此输出金额等于输入总额
+func buildSweepDraft(from, to types.Address, utxos []balanceUTXO) ([]byte, []uint32, error) {
+	draft := map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs":    []map[string]interface{}{},
+		"outputs":   []map[string]interface{}{},
+		"metadata": map[string]interface{}{
+			"caller_address": hex.EncodeToString(from[:]),
+		},
+	}
+
+	var inputIndices []uint32
+	var tokenOrder []string
+	totals := make(map[string]types.Amount)
+	for _, u := range utxos {
+		outpointParts := strings.Split(u.outpoint, ":")
+		if len(outpointParts) != 2 {
+			return nil, nil, fmt.Errorf("invalid outpoint format: %s", u.outpoint)
+		}
+		var outputIndex uint32
+		if _, err := fmt.Sscanf(outpointParts[1], "%d", &outputIndex); err != nil {
+			return nil, nil, fmt.Errorf("invalid output index: %w", err)
+		}
+
+		inputs := draft["inputs"].([]map[string]interface{})
+		inputIndices = append(inputIndices, uint32(len(inputs)))
+		draft["inputs"] = append(inputs, map[string]interface{}{
+			"tx_hash":           outpointParts[0],
+			"output_index":      outputIndex,
+			"is_reference_only": false,
+		})
+
+		key := hex.EncodeToString(u.tokenID)
+		if _, ok := totals[key]; !ok {
+			tokenOrder = append(tokenOrder, key)
+		}
+		totals[key] = totals[key].Add(u.amount)
+	}
+
+	for _, key := range tokenOrder {
+		outputs := draft["outputs"].([]map[string]interface{})
+		output := map[string]interface{}{
+			"type":   "asset",
+			"owner":  hex.EncodeToString(to[:]),
+			"amount": totals[key].String(),
+		}
+		if key != "" {
+			output["token_id"] = key
+		}
+		draft["outputs"] = append(outputs, output)
+	}
+
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal draft failed: %w", err)
+	}
+	return draftJSON, inputIndices, nil
+}
+
+func containsTokenID(list [][]byte, tokenID []byte) bool {
+	for _, id := range list {
+		if bytes.Equal(id, tokenID) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSweptAssets(m map[string]*SweptAsset) []SweptAsset {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	assets := make([]SweptAsset, 0, len(keys))
+	for _, k := range keys {
+		assets = append(assets, *m[k])
+	}
+	return assets
+}
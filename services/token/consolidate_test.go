@@ -0,0 +1,179 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// nodeMockClient 模拟节点：按草稿构造未签名交易、计算签名哈希并记录提交的草稿
+type nodeMockClient struct {
+	utxos   []interface{}
+	height  uint64
+	drafts  []map[string]interface{} // 每笔已提交交易对应的草稿
+	sent    int
+	sendErr error // SendRawTransaction 返回的错误（模拟网络错误）
+}
+
+func (m *nodeMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		return map[string]interface{}{"utxos": m.utxos}, nil
+	case "wes_blockNumber":
+		return fmt.Sprintf("0x%x", m.height), nil
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_finalizeTransactionFromDraft":
+		p := params.(map[string]interface{})
+		var draft map[string]interface{}
+		if err := json.Unmarshal(p["draft"].(json.RawMessage), &draft); err != nil {
+			return nil, err
+		}
+		m.drafts = append(m.drafts, draft)
+		return map[string]interface{}{"tx": p["unsignedTx"]}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *nodeMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	if m.sendErr != nil {
+		return nil, m.sendErr
+	}
+	m.sent++
+	return &client.SendTxResult{TxHash: fmt.Sprintf("%064x", m.sent), Accepted: true}, nil
+}
+
+func (m *nodeMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *nodeMockClient) Close() error { return nil }
+
+func mockUTXO(i int, amount string, tokenID string, lock map[string]interface{}) map[string]interface{} {
+	u := map[string]interface{}{
+		"outpoint": fmt.Sprintf("%064x:%d", i, 0),
+		"amount":   amount,
+	}
+	if tokenID != "" {
+		u["tokenID"] = tokenID
+	}
+	if lock != nil {
+		u["locking_condition"] = lock
+	}
+	return u
+}
+
+func testWallet(t *testing.T) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 63) + "1")
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+func draftOutputs(draft map[string]interface{}) []string {
+	var outs []string
+	for _, o := range draft["outputs"].([]interface{}) {
+		m := o.(map[string]interface{})
+		token, _ := m["token_id"].(string)
+		outs = append(outs, fmt.Sprintf("%s:%s:%s", m["owner"], m["amount"], token))
+	}
+	return outs
+}
+
+func TestConsolidate(t *testing.T) {
+	w := testWallet(t)
+	owner := hex.EncodeToString(w.Address())
+	tokenHex := strings.Repeat("cd", 32)
+
+	mc := &nodeMockClient{height: 100, utxos: []interface{}{
+		mockUTXO(1, "5", "", nil), // 粉尘
+		mockUTXO(2, "30", "", nil),
+		mockUTXO(3, "20", "", nil),
+		mockUTXO(4, "40", "", nil),
+		mockUTXO(5, "10", "", map[string]interface{}{"height_lock": map[string]interface{}{"unlock_height": float64(500)}}),
+		mockUTXO(6, "1000", "", nil), // 高于阈值
+		mockUTXO(7, "25", "", nil),
+		mockUTXO(8, "15", tokenHex, nil),
+	}}
+	svc := NewServiceWithWallet(mc, w)
+
+	result, err := svc.Consolidate(context.Background(), w.Address(), nil, &ConsolidateOptions{
+		Threshold:      types.NewAmount(100),
+		MaxInputsPerTx: 3,
+		Dust:           &DustPolicy{FeePerInput: types.NewAmount(5)},
+	})
+	if err != nil {
+		t.Fatalf("Consolidate: %v", err)
+	}
+
+	// 候选（升序）：20, 25, 30, 40 → 第一批 3 个，剩余 1 个不足 MinInputs 不提交
+	if len(result.TxHashes) != 1 || result.Merged != 3 || result.SkippedDust != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.Amount.String() != "75" {
+		t.Fatalf("amount = %s, want 75", result.Amount)
+	}
+	if got := draftOutputs(mc.drafts[0]); len(got) != 1 || got[0] != owner+":75:" {
+		t.Fatalf("outputs = %v", got)
+	}
+}
+
+func TestSweepAll(t *testing.T) {
+	w := testWallet(t)
+	from := types.MustAddressFromBytes(w.Address())
+	to := types.MustAddressFromBytes(bytes.Repeat([]byte{0x09}, 20))
+	toHex := hex.EncodeToString(to[:])
+	tokenHex := strings.Repeat("cd", 32)
+
+	mc := &nodeMockClient{height: 100, utxos: []interface{}{
+		mockUTXO(1, "100", "", nil),
+		mockUTXO(2, "7", tokenHex, nil),
+		mockUTXO(3, "50", "", map[string]interface{}{"delegation_lock": map[string]interface{}{}}),
+		mockUTXO(4, "200", "", nil),
+		mockUTXO(5, "3", tokenHex, nil),
+		mockUTXO(6, "1", "", nil), // 粉尘
+	}}
+	svc := NewServiceWithWallet(mc, w)
+
+	result, err := svc.SweepAll(context.Background(), &SweepRequest{
+		From:           from,
+		To:             to,
+		MaxInputsPerTx: 3,
+		Dust:           &DustPolicy{MinValue: types.NewAmount(2)},
+	})
+	if err != nil {
+		t.Fatalf("SweepAll: %v", err)
+	}
+	if len(result.TxHashes) != 2 || result.Skipped != 1 || result.SkippedDust != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(result.Assets) != 2 || result.Assets[0].Amount.String() != "300" || result.Assets[1].Amount.String() != "10" {
+		t.Fatalf("unexpected assets %+v", result.Assets)
+	}
+
+	// 第一批：100, 7(token), 200 → 每种资产一个输出；第二批：3(token)
+	want := [][]string{
+		{toHex + ":300:", toHex + ":7:" + tokenHex},
+		{toHex + ":3:" + tokenHex},
+	}
+	for i, d := range mc.drafts {
+		if got := draftOutputs(d); fmt.Sprint(got) != fmt.Sprint(want[i]) {
+			t.Errorf("tx %d outputs = %v, want %v", i, got, want[i])
+		}
+	}
+
+	if _, err := svc.SweepAll(context.Background(), &SweepRequest{From: from, To: from}); err == nil {
+		t.Fatalf("sweeping to the same address should fail")
+	}
+}
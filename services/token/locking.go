@@ -0,0 +1,60 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/weisyn/client-sdk-go/services/resource"
+)
+
+// transferLockingCondition 将接收方输出的锁定条件转换为草稿输出的 locking_condition 字段
+//
+// **规则**：
+// - lock 为 nil 时返回 nil（节点默认使用接收方单签锁）
+// - 时间锁 / 高度锁未指定 BaseLock 时，以接收方地址的单签锁作为基础锁
+// - 转换前调用 Validate 校验参数
+//
+// **示例**：
+//
+//	// 一小时后才能花费的付款
+//	&resource.TimeLockCondition{UnlockTimestamp: uint64(time.Now().Add(time.Hour).Unix())}
+//	// 付款到 2-of-3 多签
+//	&resource.MultiKeyLockCondition{RequiredSignatures: 2, AuthorizedKeys: pubKeys}
+//	// 区块高度 10000 后释放
+//	&resource.HeightLockCondition{UnlockHeight: 10000}
+func transferLockingCondition(to []byte, lock resource.LockingCondition) (map[string]interface{}, error) {
+	if lock == nil {
+		return nil, nil
+	}
+	lock = withDefaultBaseLock(to, lock)
+	if err := lock.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid locking condition: %w", err)
+	}
+	proto, err := lock.ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("convert locking condition failed: %w", err)
+	}
+	return proto, nil
+}
+
+// withDefaultBaseLock 为未指定 BaseLock 的时间锁 / 高度锁补充接收方单签锁（不修改入参）
+func withDefaultBaseLock(to []byte, lock resource.LockingCondition) resource.LockingCondition {
+	switch l := lock.(type) {
+	case *resource.TimeLockCondition:
+		c := *l
+		c.BaseLock = defaultBaseLock(to, l.BaseLock)
+		return &c
+	case *resource.HeightLockCondition:
+		c := *l
+		c.BaseLock = defaultBaseLock(to, l.BaseLock)
+		return &c
+	}
+	return lock
+}
+
+// defaultBaseLock 返回基础锁（递归补全嵌套的时间锁 / 高度锁），为 nil 时使用接收方单签锁
+func defaultBaseLock(to []byte, base resource.LockingCondition) resource.LockingCondition {
+	if base == nil {
+		return &resource.SingleKeyLockCondition{RequiredAddressHash: to}
+	}
+	return withDefaultBaseLock(to, base)
+}
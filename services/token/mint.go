@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/weisyn/client-sdk-go/txcodec"
 	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
@@ -52,7 +53,7 @@ func (s *tokenService) mint(ctx context.Context, req *MintRequest, wallets ...wa
 		IncludeFrom:   true,
 		From:          w.Address(),
 		IncludeTo:     true,
-		To:            req.To,
+		To:            req.To[:],
 		IncludeAmount: true,
 		Amount:        req.Amount,
 	}
@@ -98,7 +99,10 @@ func (s *tokenService) mint(ctx context.Context, req *MintRequest, wallets ...wa
 		return nil, fmt.Errorf("decode unsignedTx failed: %w", err)
 	}
 
-	// 8. 使用 Wallet 签名交易
+	// 8. 使用 Wallet 签名交易（观察钱包返回携带未签名交易的 *wallet.UnsignedTxError）
+	if err := wallet.RequireTxSigner(w, unsignedTxBytes); err != nil {
+		return nil, err
+	}
 	signedTxBytes, err := w.SignTransaction(unsignedTxBytes)
 	if err != nil {
 		return nil, fmt.Errorf("sign transaction failed: %w", err)
@@ -125,12 +129,12 @@ func (s *tokenService) mint(ctx context.Context, req *MintRequest, wallets ...wa
 // validateMintRequest 验证铸造请求
 func (s *tokenService) validateMintRequest(req *MintRequest) error {
 	// 1. 验证接收地址
-	if len(req.To) != 20 {
-		return fmt.Errorf("to address must be 20 bytes")
+	if req.To.IsZero() {
+		return fmt.Errorf("to address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -168,42 +172,27 @@ func (s *tokenService) burn(ctx context.Context, req *BurnRequest, wallets ...wa
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
 	// 4. 构建 DraftJSON
-	draftJSON, inputIndex, err := buildBurnDraft(ctx, s.client, req.From, req.Amount, req.TokenID)
+	draftJSON, inputIndex, err := buildBurnDraft(ctx, s.client, req.From[:], req.Amount, req.TokenID)
 	if err != nil {
 		return nil, fmt.Errorf("build burn draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -263,12 +252,12 @@ func (s *tokenService) burn(ctx context.Context, req *BurnRequest, wallets ...wa
 // validateBurnRequest 验证销毁请求
 func (s *tokenService) validateBurnRequest(req *BurnRequest) error {
 	// 1. 验证发送地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
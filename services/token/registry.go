@@ -0,0 +1,426 @@
+package token
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/weisyn/client-sdk-go/services/contract"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// 原生币默认元数据（可通过 Registry.Register 覆盖）
+const (
+	NativeSymbol   = "WES"
+	NativeName     = "WES"
+	NativeDecimals = 8
+)
+
+// TokenMetadata 代币元数据
+type TokenMetadata struct {
+	TokenID      []byte // 代币ID（nil 表示原生币）
+	ContractHash []byte // 发行合约 contentHash（32字节，可选）
+	Symbol       string // 代币符号，如 "WES"
+	Name         string // 代币名称
+	Decimals     uint8  // 小数位数
+}
+
+// IsNative 是否为原生币
+func (m *TokenMetadata) IsNative() bool {
+	return len(m.TokenID) == 0 && len(m.ContractHash) == 0
+}
+
+// tokenMetadataJSON 元数据 JSON 格式（ID 与合约哈希使用十六进制字符串）
+type tokenMetadataJSON struct {
+	TokenID      string `json:"token_id,omitempty"`
+	ContractHash string `json:"contract_hash,omitempty"`
+	Symbol       string `json:"symbol"`
+	Name         string `json:"name,omitempty"`
+	Decimals     uint8  `json:"decimals"`
+}
+
+// MarshalJSON 序列化为 JSON
+func (m TokenMetadata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tokenMetadataJSON{
+		TokenID:      hex.EncodeToString(m.TokenID),
+		ContractHash: hex.EncodeToString(m.ContractHash),
+		Symbol:       m.Symbol,
+		Name:         m.Name,
+		Decimals:     m.Decimals,
+	})
+}
+
+// UnmarshalJSON 从 JSON 反序列化
+func (m *TokenMetadata) UnmarshalJSON(data []byte) error {
+	var raw tokenMetadataJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	tokenID, err := decodeHexField(raw.TokenID)
+	if err != nil {
+		return fmt.Errorf("invalid token_id: %w", err)
+	}
+	contractHash, err := decodeHexField(raw.ContractHash)
+	if err != nil {
+		return fmt.Errorf("invalid contract_hash: %w", err)
+	}
+	*m = TokenMetadata{
+		TokenID:      tokenID,
+		ContractHash: contractHash,
+		Symbol:       raw.Symbol,
+		Name:         raw.Name,
+		Decimals:     raw.Decimals,
+	}
+	return nil
+}
+
+// ContractQuerier 合约只读查询接口（contract.Service 满足该接口）
+type ContractQuerier interface {
+	QueryContract(ctx context.Context, req *contract.QueryContractRequest) (interface{}, error)
+}
+
+// Registry 代币元数据注册表
+//
+// **用途**：
+// - 将 TokenID + 合约 contentHash 解析为符号、名称与小数位数
+// - 在 "1.25 WES" 这类显示值与最小单位金额之间转换（全程整数运算）
+//
+// 元数据可从本地 JSON 文件（LoadFile）或链上合约查询（LoadFromContract）加载。
+// Registry 并发安全。
+type Registry struct {
+	mu     sync.RWMutex
+	tokens map[string]*TokenMetadata
+}
+
+// NewRegistry 创建注册表（预置原生币元数据）
+func NewRegistry() *Registry {
+	r := &Registry{tokens: make(map[string]*TokenMetadata)}
+	r.tokens[registryKey(nil, nil)] = &TokenMetadata{
+		Symbol:   NativeSymbol,
+		Name:     NativeName,
+		Decimals: NativeDecimals,
+	}
+	return r
+}
+
+// Register 注册或覆盖代币元数据
+func (r *Registry) Register(meta *TokenMetadata) error {
+	if meta == nil {
+		return fmt.Errorf("metadata is nil")
+	}
+	symbol := strings.TrimSpace(meta.Symbol)
+	if symbol == "" || strings.ContainsAny(symbol, " \t\n") {
+		return fmt.Errorf("invalid token symbol: %q", meta.Symbol)
+	}
+	if len(meta.ContractHash) > 0 && len(meta.ContractHash) != 32 {
+		return fmt.Errorf("contract hash must be 32 bytes")
+	}
+
+	m := *meta
+	m.Symbol = symbol
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[registryKey(m.TokenID, m.ContractHash)] = &m
+	return nil
+}
+
+// Lookup 按 TokenID 与合约 contentHash 查找元数据
+//
+// contractHash 为 nil 时，若只有一个合约注册了该 TokenID 也能匹配。
+func (r *Registry) Lookup(tokenID, contractHash []byte) (*TokenMetadata, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if m, ok := r.tokens[registryKey(tokenID, contractHash)]; ok {
+		return copyMetadata(m), true
+	}
+	if len(contractHash) > 0 || len(tokenID) == 0 {
+		return nil, false
+	}
+
+	var found *TokenMetadata
+	for _, m := range r.tokens {
+		if hex.EncodeToString(m.TokenID) != hex.EncodeToString(tokenID) {
+			continue
+		}
+		if found != nil {
+			return nil, false // 多个合约使用相同 TokenID，无法确定
+		}
+		found = m
+	}
+	if found == nil {
+		return nil, false
+	}
+	return copyMetadata(found), true
+}
+
+// LookupSymbol 按符号查找元数据（不区分大小写），符号冲突时返回错误
+func (r *Registry) LookupSymbol(symbol string) (*TokenMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *TokenMetadata
+	for _, m := range r.tokens {
+		if !strings.EqualFold(m.Symbol, symbol) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("token symbol %q is ambiguous", symbol)
+		}
+		found = m
+	}
+	if found == nil {
+		return nil, fmt.Errorf("unknown token symbol: %q", symbol)
+	}
+	return copyMetadata(found), nil
+}
+
+// Tokens 返回全部已注册的元数据
+func (r *Registry) Tokens() []*TokenMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*TokenMetadata, 0, len(r.tokens))
+	for _, m := range r.tokens {
+		list = append(list, copyMetadata(m))
+	}
+	return list
+}
+
+// LoadJSON 从 JSON 加载元数据
+//
+// 支持数组格式 `[{...}, ...]` 或对象格式 `{"tokens": [{...}, ...]}`。
+func (r *Registry) LoadJSON(reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("read token registry failed: %w", err)
+	}
+
+	var list []*TokenMetadata
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var wrapper struct {
+			Tokens []*TokenMetadata `json:"tokens"`
+		}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return fmt.Errorf("parse token registry failed: %w", err)
+		}
+		list = wrapper.Tokens
+	} else if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("parse token registry failed: %w", err)
+	}
+
+	for i, m := range list {
+		if err := r.Register(m); err != nil {
+			return fmt.Errorf("token %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// LoadFile 从本地 JSON 文件加载元数据
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open token registry failed: %w", err)
+	}
+	defer f.Close()
+	return r.LoadJSON(f)
+}
+
+// LoadFromContract 通过合约只读查询加载元数据并注册
+//
+// **查询顺序**：
+// 1. 调用合约 "metadata" 方法，期望返回包含 symbol / name / decimals 的对象（或其 JSON 字符串）
+// 2. 失败时分别调用 "symbol"、"name"、"decimals" 方法
+func (r *Registry) LoadFromContract(ctx context.Context, q ContractQuerier, contractHash, tokenID []byte) (*TokenMetadata, error) {
+	if len(contractHash) != 32 {
+		return nil, fmt.Errorf("contract hash must be 32 bytes")
+	}
+
+	var args []interface{}
+	if len(tokenID) > 0 {
+		args = []interface{}{hex.EncodeToString(tokenID)}
+	}
+	query := func(method string) (interface{}, error) {
+		return q.QueryContract(ctx, &contract.QueryContractRequest{
+			ContractAddress: contractHash,
+			Method:          method,
+			Args:            args,
+		})
+	}
+
+	meta := &TokenMetadata{TokenID: tokenID, ContractHash: contractHash}
+	if result, err := query("metadata"); err == nil && parseMetadataResult(result, meta) == nil {
+		if err := r.Register(meta); err != nil {
+			return nil, err
+		}
+		return copyMetadata(meta), nil
+	}
+
+	symbol, err := query("symbol")
+	if err != nil {
+		return nil, fmt.Errorf("query token symbol failed: %w", err)
+	}
+	decimals, err := query("decimals")
+	if err != nil {
+		return nil, fmt.Errorf("query token decimals failed: %w", err)
+	}
+	name, _ := query("name") // name 可选
+
+	meta.Symbol = toString(symbol)
+	meta.Name = toString(name)
+	if meta.Decimals, err = toDecimals(decimals); err != nil {
+		return nil, err
+	}
+	if err := r.Register(meta); err != nil {
+		return nil, err
+	}
+	return copyMetadata(meta), nil
+}
+
+// FormatAmount 将最小单位金额格式化为显示值，如 125000000 → "1.25 WES"
+func (r *Registry) FormatAmount(amount types.Amount, tokenID, contractHash []byte) (string, error) {
+	meta, ok := r.Lookup(tokenID, contractHash)
+	if !ok {
+		return "", fmt.Errorf("unknown token: %s", hex.EncodeToString(tokenID))
+	}
+	return amount.FormatUnits(meta.Decimals) + " " + meta.Symbol, nil
+}
+
+// ParseAmount 解析带符号的显示值（如 "1.25 WES"）为最小单位金额，并返回对应代币元数据
+func (r *Registry) ParseAmount(s string) (types.Amount, *TokenMetadata, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return types.Amount{}, nil, fmt.Errorf("invalid amount %q: expected \"<value> <symbol>\"", s)
+	}
+	meta, err := r.LookupSymbol(fields[1])
+	if err != nil {
+		return types.Amount{}, nil, err
+	}
+	amount, err := types.ParseUnits(fields[0], meta.Decimals)
+	if err != nil {
+		return types.Amount{}, nil, err
+	}
+	return amount, meta, nil
+}
+
+// NewTransferRequest 使用显示值构建转账请求，如 NewTransferRequest(from, to, "1.25 WES")
+func (r *Registry) NewTransferRequest(from, to types.Address, amount string) (*TransferRequest, error) {
+	value, meta, err := r.ParseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferRequest{
+		From:    from,
+		To:      to,
+		Amount:  value,
+		TokenID: meta.TokenID,
+	}, nil
+}
+
+// NewTransferItem 使用显示值构建批量转账项
+func (r *Registry) NewTransferItem(to types.Address, amount string) (TransferItem, error) {
+	value, meta, err := r.ParseAmount(amount)
+	if err != nil {
+		return TransferItem{}, err
+	}
+	return TransferItem{
+		To:      to,
+		Amount:  value,
+		TokenID: meta.TokenID,
+	}, nil
+}
+
+// registryKey 注册表键：contentHash:tokenID（十六进制）
+func registryKey(tokenID, contractHash []byte) string {
+	return hex.EncodeToString(contractHash) + ":" + hex.EncodeToString(tokenID)
+}
+
+func copyMetadata(m *TokenMetadata) *TokenMetadata {
+	c := *m
+	c.TokenID = append([]byte(nil), m.TokenID...)
+	c.ContractHash = append([]byte(nil), m.ContractHash...)
+	if len(c.TokenID) == 0 {
+		c.TokenID = nil
+	}
+	if len(c.ContractHash) == 0 {
+		c.ContractHash = nil
+	}
+	return &c
+}
+
+// decodeHexField 解析可选的十六进制字段（允许 "0x" 前缀）
+func decodeHexField(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "0x")
+	if s == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(s)
+}
+
+// parseMetadataResult 解析合约 metadata 方法返回值
+func parseMetadataResult(result interface{}, meta *TokenMetadata) error {
+	if str, ok := result.(string); ok {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(str), &m); err != nil {
+			return fmt.Errorf("invalid metadata result: %w", err)
+		}
+		result = m
+	}
+	m, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid metadata result type: %T", result)
+	}
+
+	meta.Symbol = toString(m["symbol"])
+	meta.Name = toString(m["name"])
+	if meta.Symbol == "" {
+		return fmt.Errorf("metadata missing symbol")
+	}
+	decimals, err := toDecimals(m["decimals"])
+	if err != nil {
+		return err
+	}
+	meta.Decimals = decimals
+	return nil
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(s)
+	}
+}
+
+// toDecimals 解析小数位数（JSON 数字或字符串）
+func toDecimals(v interface{}) (uint8, error) {
+	var n uint64
+	var err error
+	switch d := v.(type) {
+	case float64:
+		if d < 0 || d != float64(uint64(d)) {
+			return 0, fmt.Errorf("invalid decimals: %v", d)
+		}
+		n = uint64(d)
+	case json.Number:
+		n, err = strconv.ParseUint(d.String(), 10, 8)
+	case string:
+		n, err = strconv.ParseUint(strings.TrimSpace(d), 10, 8)
+	default:
+		return 0, fmt.Errorf("invalid decimals type: %T", v)
+	}
+	if err != nil || n > 255 {
+		return 0, fmt.Errorf("invalid decimals: %v", v)
+	}
+	return uint8(n), nil
+}
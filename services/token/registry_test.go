@@ -0,0 +1,102 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/services/contract"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// registryMockQuerier 按方法名返回合约查询结果
+type registryMockQuerier struct {
+	results map[string]interface{}
+}
+
+func (q *registryMockQuerier) QueryContract(ctx context.Context, req *contract.QueryContractRequest) (interface{}, error) {
+	if r, ok := q.results[req.Method]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("method %s not found", req.Method)
+}
+
+func TestRegistryFormatAndParse(t *testing.T) {
+	r := NewRegistry()
+
+	s, err := r.FormatAmount(types.NewAmount(125000000), nil, nil)
+	if err != nil || s != "1.25 WES" {
+		t.Fatalf("FormatAmount = %q, %v", s, err)
+	}
+
+	amount, meta, err := r.ParseAmount("1.25 wes")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	if amount.String() != "125000000" || !meta.IsNative() {
+		t.Fatalf("ParseAmount = %s, %+v", amount, meta)
+	}
+
+	for _, bad := range []string{"1.25", "1.25 XYZ", "1.123456789 WES", "abc WES"} {
+		if _, _, err := r.ParseAmount(bad); err == nil {
+			t.Errorf("ParseAmount(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRegistryLoadJSON(t *testing.T) {
+	r := NewRegistry()
+	hash := strings.Repeat("11", 32)
+	data := `{"tokens": [{"token_id": "aabb", "contract_hash": "0x` + hash + `", "symbol": "USDX", "name": "USD X", "decimals": 18}]}`
+	if err := r.LoadJSON(strings.NewReader(data)); err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+
+	// 未指定合约哈希时，TokenID 唯一即可匹配
+	meta, ok := r.Lookup([]byte{0xaa, 0xbb}, nil)
+	if !ok || meta.Symbol != "USDX" || meta.Decimals != 18 || len(meta.ContractHash) != 32 {
+		t.Fatalf("Lookup = %+v, %v", meta, ok)
+	}
+
+	req, err := r.NewTransferRequest(types.Address{}, types.Address{}, "1000.000000000000000001 USDX")
+	if err != nil {
+		t.Fatalf("NewTransferRequest: %v", err)
+	}
+	if req.Amount.String() != "1000000000000000000001" || !bytes.Equal(req.TokenID, []byte{0xaa, 0xbb}) {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	if err := r.LoadJSON(strings.NewReader(`[{"symbol": "", "decimals": 2}]`)); err == nil {
+		t.Fatalf("empty symbol should be rejected")
+	}
+}
+
+func TestRegistryLoadFromContract(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x22}, 32)
+
+	// metadata 方法返回 JSON 字符串
+	r := NewRegistry()
+	q := &registryMockQuerier{results: map[string]interface{}{
+		"metadata": `{"symbol":"GOLD","name":"Gold","decimals":6}`,
+	}}
+	meta, err := r.LoadFromContract(context.Background(), q, hash, []byte{0x01})
+	if err != nil || meta.Symbol != "GOLD" || meta.Decimals != 6 {
+		t.Fatalf("LoadFromContract(metadata) = %+v, %v", meta, err)
+	}
+	if s, _ := r.FormatAmount(types.NewAmount(1500000), []byte{0x01}, hash); s != "1.5 GOLD" {
+		t.Fatalf("FormatAmount = %q", s)
+	}
+
+	// 回退到 symbol / decimals 方法
+	r = NewRegistry()
+	q = &registryMockQuerier{results: map[string]interface{}{
+		"symbol":   "SLV",
+		"decimals": float64(2),
+	}}
+	meta, err = r.LoadFromContract(context.Background(), q, hash, nil)
+	if err != nil || meta.Symbol != "SLV" || meta.Decimals != 2 {
+		t.Fatalf("LoadFromContract(fallback) = %+v, %v", meta, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -23,7 +25,23 @@ type Service interface {
 	Burn(ctx context.Context, req *BurnRequest, wallet ...wallet.Wallet) (*BurnResult, error)
 
 	// GetBalance 查询余额（不需要 Wallet）
-	GetBalance(ctx context.Context, address []byte, tokenID []byte) (uint64, error)
+	// tokenID 为 nil 时查询原生币，否则按代币ID汇总 UTXO
+	GetBalance(ctx context.Context, address []byte, tokenID []byte) (types.Amount, error)
+
+	// QueryBalance 按请求查询余额（支持合约代币与历史高度）
+	QueryBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error)
+
+	// GetPortfolio 查询地址下全部资产（含可花费/锁定/委托明细）
+	GetPortfolio(ctx context.Context, address []byte) (*Portfolio, error)
+
+	// GetPortfolioAt 查询地址在指定历史高度的全部资产（需节点支持）
+	GetPortfolioAt(ctx context.Context, address []byte, height uint64) (*Portfolio, error)
+
+	// Consolidate 将地址下的小额 UTXO 分批合并为较少的输出
+	Consolidate(ctx context.Context, address []byte, tokenID []byte, opts *ConsolidateOptions, wallet ...wallet.Wallet) (*ConsolidateResult, error)
+
+	// SweepAll 将地址下全部可花费资产转移到另一个地址（如密钥轮换）
+	SweepAll(ctx context.Context, req *SweepRequest, wallet ...wallet.Wallet) (*SweepResult, error)
 }
 
 // tokenService Token 服务实现
@@ -57,10 +75,15 @@ func (s *tokenService) getWallet(wallets ...wallet.Wallet) wallet.Wallet {
 
 // TransferRequest 转账请求
 type TransferRequest struct {
-	From    []byte // 发送方地址（20字节）
-	To      []byte // 接收方地址（20字节）
-	Amount  uint64 // 转账金额
-	TokenID []byte // 代币ID（32字节，nil 表示原生币）
+	From    types.Address // 发送方地址
+	To      types.Address // 接收方地址
+	Amount  types.Amount  // 转账金额
+	TokenID []byte        // 代币ID（32字节，nil 表示原生币）
+
+	// LockingCondition 接收方输出的锁定条件（可选，nil 表示接收方单签锁）
+	// 例如 TimeLockCondition（定时付款）、HeightLockCondition（按高度释放）、
+	// MultiKeyLockCondition（付款到 M-of-N 多签）；时间锁 / 高度锁未指定 BaseLock 时默认为接收方单签锁
+	LockingCondition resource.LockingCondition
 }
 
 // TransferResult 转账结果
@@ -77,23 +100,39 @@ func (s *tokenService) Transfer(ctx context.Context, req *TransferRequest, walle
 // transfer 单笔转账实现（在transfer.go中）
 // 实际实现在transfer.go文件中
 
+// DefaultMaxBatchOutputs 单笔批量转账交易默认的最大输出数（含找零输出）
+const DefaultMaxBatchOutputs = 128
+
 // BatchTransferRequest 批量转账请求
 type BatchTransferRequest struct {
-	Transfers []TransferItem // 转账列表
-	From      []byte         // 发送方地址（20字节，所有转账的发送方）
+	Transfers []TransferItem // 转账列表（可混合原生币与多种代币）
+	From      types.Address  // 发送方地址（所有转账的发送方）
+
+	// MaxOutputsPerTx 单笔交易最大输出数（含每种资产的找零输出）
+	// 超出时自动拆分为多笔交易；0 表示使用 DefaultMaxBatchOutputs
+	MaxOutputsPerTx int
+
+	// MaxInputsPerTx 单笔交易最大输入数（所有资产合计）
+	// 选币后超出时将该组继续拆分；0 表示使用 DefaultMaxInputsPerTx
+	MaxInputsPerTx int
 }
 
 // TransferItem 转账项
 type TransferItem struct {
-	To      []byte // 接收方地址（20字节）
-	Amount  uint64 // 转账金额
-	TokenID []byte // 代币ID（32字节，可选，nil表示原生币）
+	To      types.Address // 接收方地址
+	Amount  types.Amount  // 转账金额
+	TokenID []byte        // 代币ID（32字节，可选，nil表示原生币）
+
+	// LockingCondition 该输出的锁定条件（可选，nil 表示接收方单签锁），规则同 TransferRequest.LockingCondition
+	LockingCondition resource.LockingCondition
 }
 
 // BatchTransferResult 批量转账结果
 type BatchTransferResult struct {
-	TxHash  string
-	Success bool
+	TxHash            string   // 第一笔交易哈希（未拆分时即唯一的交易）
+	TxHashes          []string // 全部交易哈希（按提交顺序）
+	RecipientTxHashes []string // 与 Transfers 一一对应的交易哈希（未提交的项为空字符串）
+	Success           bool     // 全部交易均已被节点接受
 }
 
 // BatchTransfer 批量转账（实现在transfer.go）
@@ -106,10 +145,10 @@ func (s *tokenService) BatchTransfer(ctx context.Context, req *BatchTransferRequ
 
 // MintRequest 铸造请求
 type MintRequest struct {
-	To                  []byte // 接收者地址（20字节）
-	Amount              uint64 // 铸造数量
-	TokenID             []byte // 代币ID（业务标识，可选）
-	ContractContentHash []byte // 合约 contentHash（32字节，必需）
+	To                  types.Address // 接收者地址
+	Amount              types.Amount  // 铸造数量
+	TokenID             []byte        // 代币ID（业务标识，可选）
+	ContractContentHash []byte        // 合约 contentHash（32字节，必需）
 }
 
 // MintResult 铸造结果
@@ -128,10 +167,10 @@ func (s *tokenService) Mint(ctx context.Context, req *MintRequest, wallets ...wa
 
 // BurnRequest 销毁请求
 type BurnRequest struct {
-	From      []byte // 销毁者地址（20字节）
-	Amount    uint64 // 销毁数量
-	TokenID   []byte // 代币ID（32字节，必需）
-	BurnProof []byte // 销毁证明（可选）
+	From      types.Address // 销毁者地址
+	Amount    types.Amount  // 销毁数量
+	TokenID   []byte        // 代币ID（32字节，必需）
+	BurnProof []byte        // 销毁证明（可选）
 }
 
 // BurnResult 销毁结果
@@ -149,9 +188,34 @@ func (s *tokenService) Burn(ctx context.Context, req *BurnRequest, wallets ...wa
 // 实际实现在mint.go文件中
 
 // GetBalance 查询余额（实现在balance.go）
-func (s *tokenService) GetBalance(ctx context.Context, address []byte, tokenID []byte) (uint64, error) {
+func (s *tokenService) GetBalance(ctx context.Context, address []byte, tokenID []byte) (types.Amount, error) {
 	return s.getBalance(ctx, address, tokenID)
 }
 
 // getBalance 查询余额实现（在balance.go中）
 // 实际实现在balance.go文件中
+
+// QueryBalance 按请求查询余额（实现在balance.go）
+func (s *tokenService) QueryBalance(ctx context.Context, req *BalanceRequest) (*BalanceResult, error) {
+	return s.queryBalance(ctx, req)
+}
+
+// GetPortfolio 查询地址下全部资产（实现在balance.go）
+func (s *tokenService) GetPortfolio(ctx context.Context, address []byte) (*Portfolio, error) {
+	return s.getPortfolio(ctx, address, nil)
+}
+
+// GetPortfolioAt 查询地址在指定历史高度的全部资产（实现在balance.go）
+func (s *tokenService) GetPortfolioAt(ctx context.Context, address []byte, height uint64) (*Portfolio, error) {
+	return s.getPortfolio(ctx, address, &height)
+}
+
+// Consolidate UTXO 归集（实现在consolidate.go）
+func (s *tokenService) Consolidate(ctx context.Context, address []byte, tokenID []byte, opts *ConsolidateOptions, wallets ...wallet.Wallet) (*ConsolidateResult, error) {
+	return s.consolidate(ctx, address, tokenID, opts, wallets...)
+}
+
+// SweepAll 清扫全部可花费资产（实现在consolidate.go）
+func (s *tokenService) SweepAll(ctx context.Context, req *SweepRequest, wallets ...wallet.Wallet) (*SweepResult, error) {
+	return s.sweepAll(ctx, req, wallets...)
+}
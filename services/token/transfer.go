@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/utils"
 	"github.com/weisyn/client-sdk-go/wallet"
 )
 
@@ -39,42 +41,31 @@ func (s *tokenService) transfer(ctx context.Context, req *TransferRequest, walle
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
+	if !bytes.Equal(w.Address(), req.From[:]) {
 		return nil, fmt.Errorf("wallet address does not match from address")
 	}
 
 	// 4. 在 SDK 层构建 DraftJSON（不直接构建交易）
-	draftJSON, inputIndex, err := buildTransferDraft(ctx, s.client, req.From, req.To, req.Amount, req.TokenID)
+	lockingCondition, err := transferLockingCondition(req.To[:], req.LockingCondition)
+	if err != nil {
+		return nil, err
+	}
+	draftJSON, inputIndex, err := buildTransferDraft(ctx, s.client, req.From[:], req.To[:], req.Amount, req.TokenID, lockingCondition)
 	if err != nil {
 		return nil, fmt.Errorf("build transfer draft failed: %w", err)
 	}
 
-	// 5. 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-	hashParams := map[string]interface{}{
-		"draft":        json.RawMessage(draftJSON),
-		"input_index":  inputIndex,
-		"sighash_type": "SIGHASH_ALL",
-	}
-	hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	// 5. 计算签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, []uint32{inputIndex}, txcodec.SighashAll)
 	if err != nil {
 		return nil, fmt.Errorf("compute signature hash failed: %w", err)
 	}
 
-	hashMap, ok := hashResult.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft")
-	}
-	hashHex, ok := hashMap["hash"].(string)
-	if !ok || hashHex == "" {
-		return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response")
-	}
-
-	// 同时获取对应的 unsignedTx，确保后续 finalize 使用同一份交易
-	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
-
-	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("decode signature hash failed: %w", err)
+	// 使用同一份 unsignedTx 进行 finalize
+	unsignedTxHex := sighash.UnsignedTx
+	hashBytes := sighash.Hash(inputIndex)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{inputIndex}); err != nil {
+		return nil, err
 	}
 
 	// 6. 使用 Wallet 对哈希进行签名
@@ -135,15 +126,15 @@ func (s *tokenService) transfer(ctx context.Context, req *TransferRequest, walle
 // validateTransferRequest 验证转账请求
 func (s *tokenService) validateTransferRequest(req *TransferRequest) error {
 	// 1. 验证地址
-	if len(req.From) != 20 {
-		return fmt.Errorf("from address must be 20 bytes")
+	if req.From.IsZero() {
+		return fmt.Errorf("from address is required")
 	}
-	if len(req.To) != 20 {
-		return fmt.Errorf("to address must be 20 bytes")
+	if req.To.IsZero() {
+		return fmt.Errorf("to address is required")
 	}
 
 	// 2. 验证金额
-	if req.Amount == 0 {
+	if req.Amount.IsZero() {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
@@ -156,118 +147,209 @@ func (s *tokenService) validateTransferRequest(req *TransferRequest) error {
 // BatchTransfer 业务语义在 SDK 层，通过查询 UTXO、选择 UTXO、构建交易实现。
 //
 // **流程**：
-// 1. 调用 `buildBatchTransferDraft` 构建 DraftJSON
-// 2. 为每个输入调用 `wes_computeSignatureHashFromDraft` 获取签名哈希
-// 3. 使用 Wallet 签名每个哈希
+// 1. 按 MaxOutputsPerTx 将转账项拆分为一组或多组（每组一笔交易）；选币后输入数超过 MaxInputsPerTx 的组继续对半拆分
+// 2. 为每组调用 `buildBatchTransferDraftFromUTXOs` 构建 DraftJSON（按资产分别选币与找零）
+// 3. 计算每个输入的签名哈希并使用 Wallet 签名
 // 4. 调用 `wes_finalizeTransactionFromDraft` 使用多输入签名模式生成带 SingleKeyProof 的交易
 // 5. 调用 `wes_sendRawTransaction` 提交已签名交易
 //
 // **注意**：
-// - SDK 层使用 `wes_getUTXO` 查询 UTXO
-// - 批量转账需要按 tokenID 分组 UTXO，为每个转账选择足够的 UTXO
-// - 每个输入都需要单独签名
+//   - SDK 层使用 `wes_getUTXO` 查询 UTXO（只查询一次，后续分组不会重复花费已选中的 UTXO）
+//   - 拆分后的交易彼此独立，前一笔的找零不会用于后一笔
+//   - 某一组失败时返回已提交部分的结果与错误，RecipientTxHashes 中未提交的项为空字符串
+//   - 确定未广播的失败（构建、签名失败或被节点拒绝）满足 errors.Is(err, ErrNotSubmitted)；
+//     提交请求本身出错（网络错误、超时）时交易可能已到达节点，不满足该条件
 func (s *tokenService) batchTransfer(ctx context.Context, req *BatchTransferRequest, wallets ...wallet.Wallet) (*BatchTransferResult, error) {
 	// 1. 参数验证
 	if err := s.validateBatchTransferRequest(req); err != nil {
-		return nil, err
+		return nil, notSubmitted(err)
 	}
 
 	// 2. 获取 Wallet
 	w := s.getWallet(wallets...)
 	if w == nil {
-		return nil, fmt.Errorf("wallet is required")
+		return nil, notSubmitted(fmt.Errorf("wallet is required"))
 	}
 
 	// 3. 验证地址匹配
-	if !bytes.Equal(w.Address(), req.From) {
-		return nil, fmt.Errorf("wallet address does not match from address")
+	if !bytes.Equal(w.Address(), req.From[:]) {
+		return nil, notSubmitted(fmt.Errorf("wallet address does not match from address"))
 	}
 
-	// 4. 构建 DraftJSON
-	draftJSON, inputIndices, err := buildBatchTransferDraft(ctx, s.client, req.From, req.Transfers)
+	// 4. 拆分转账项
+	maxOutputs := req.MaxOutputsPerTx
+	if maxOutputs <= 0 {
+		maxOutputs = DefaultMaxBatchOutputs
+	}
+	chunks, err := splitBatchTransfers(req.Transfers, maxOutputs)
 	if err != nil {
-		return nil, fmt.Errorf("build batch transfer draft failed: %w", err)
+		return nil, notSubmitted(err)
 	}
 
-	if len(inputIndices) == 0 {
-		return nil, fmt.Errorf("no inputs to sign")
+	// 5. 查询 UTXO（所有分组共用）
+	utxos, err := queryUTXOs(ctx, s.client, req.From[:])
+	if err != nil {
+		return nil, notSubmitted(fmt.Errorf("query utxos failed: %w", err))
 	}
-
-	// 5. 获取压缩公钥（所有输入使用同一个公钥）
-	priv := w.PrivateKey()
-	if priv == nil {
-		return nil, fmt.Errorf("wallet private key is nil")
+	maxInputs := req.MaxInputsPerTx
+	if maxInputs <= 0 {
+		maxInputs = DefaultMaxInputsPerTx
 	}
-	pubCompressed := ethcrypto.CompressPubkey(&priv.PublicKey)
-	pubKeyHex := "0x" + hex.EncodeToString(pubCompressed)
 
-	// 6. 为每个输入计算签名哈希并签名
-	type signatureItem struct {
-		InputIndex uint32
-		SigHex     string
+	// 6. 逐组构建、签名并提交
+	result := &BatchTransferResult{
+		RecipientTxHashes: make([]string, len(req.Transfers)),
 	}
-	var signatures []signatureItem
-	var unsignedTxHex string
-
-	for i, inputIndex := range inputIndices {
-		// 调用 wes_computeSignatureHashFromDraft 获取签名哈希
-		hashParams := map[string]interface{}{
-			"draft":        json.RawMessage(draftJSON),
-			"input_index":  inputIndex,
-			"sighash_type": "SIGHASH_ALL",
+	spent := make(map[string]bool)
+	for i := 0; i < len(chunks); i++ {
+		chunk := chunks[i]
+		items := make([]TransferItem, len(chunk))
+		for j, idx := range chunk {
+			items[j] = req.Transfers[idx]
 		}
-		hashResult, err := s.client.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+
+		draft, err := buildBatchTransferDraftFromUTXOs(req.From[:], items, utxos, spent)
 		if err != nil {
-			return nil, fmt.Errorf("compute signature hash for input %d failed: %w", inputIndex, err)
+			return partialBatchResult(result, len(chunks), i, notSubmitted(fmt.Errorf("build batch transfer draft failed: %w", err)))
 		}
 
-		hashMap, ok := hashResult.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft for input %d", inputIndex)
-		}
-		hashHex, ok := hashMap["hash"].(string)
-		if !ok || hashHex == "" {
-			return nil, fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response for input %d", inputIndex)
+		// 输入数超限：将该组对半拆分后重新构建（单个转账项仍超限时需先归集 UTXO）
+		if len(draft.InputIndices) > maxInputs {
+			if len(chunk) == 1 {
+				return partialBatchResult(result, len(chunks), i, notSubmitted(fmt.Errorf("transfer[%d] needs %d inputs, exceeding max %d per transaction; consolidate utxos first",
+					chunk[0], len(draft.InputIndices), maxInputs)))
+			}
+			half := len(chunk) / 2
+			chunks = append(chunks[:i], append([][]int{chunk[:half], chunk[half:]}, chunks[i+1:]...)...)
+			i--
+			continue
 		}
 
-		// 第一次调用时获取 unsignedTx，后续调用应该返回相同的 unsignedTx
-		if i == 0 {
-			unsignedTxHex, _ = hashMap["unsignedTx"].(string)
+		txHash, err := s.signAndSendBatchDraft(ctx, w, draft.DraftJSON, draft.InputIndices)
+		if err != nil {
+			return partialBatchResult(result, len(chunks), i, err)
 		}
 
-		hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
-		if err != nil {
-			return nil, fmt.Errorf("decode signature hash for input %d failed: %w", inputIndex, err)
+		for _, outpoint := range draft.Spent {
+			spent[outpoint] = true
+		}
+		if result.TxHash == "" {
+			result.TxHash = txHash
 		}
+		result.TxHashes = append(result.TxHashes, txHash)
+		for _, idx := range chunk {
+			result.RecipientTxHashes[idx] = txHash
+		}
+	}
 
-		// 使用 Wallet 对哈希进行签名
-		sigBytes, err := w.SignHash(hashBytes)
-		if err != nil {
-			return nil, fmt.Errorf("sign hash for input %d failed: %w", inputIndex, err)
+	// 7. 返回结果
+	result.Success = true
+	return result, nil
+}
+
+// ErrNotSubmitted 交易确定未广播（构建、签名失败或被节点明确拒绝），可安全重试
+var ErrNotSubmitted = errors.New("transaction not submitted")
+
+// notSubmittedError 标记广播前的失败，错误信息与原错误相同
+type notSubmittedError struct{ err error }
+
+func (e *notSubmittedError) Error() string        { return e.err.Error() }
+func (e *notSubmittedError) Unwrap() error        { return e.err }
+func (e *notSubmittedError) Is(target error) bool { return target == ErrNotSubmitted }
+
+// notSubmitted 将错误标记为 ErrNotSubmitted
+func notSubmitted(err error) error {
+	return &notSubmittedError{err: err}
+}
+
+// partialBatchResult 拆分提交中途失败时的返回值
+// 未提交任何交易时只返回错误；否则同时返回已提交部分的结果
+func partialBatchResult(result *BatchTransferResult, total, failed int, err error) (*BatchTransferResult, error) {
+	if total > 1 {
+		err = fmt.Errorf("batch %d/%d: %w", failed+1, total, err)
+	}
+	if len(result.TxHashes) == 0 {
+		return nil, err
+	}
+	return result, err
+}
+
+// splitBatchTransfers 按最大输出数拆分转账项，返回每组的转账项下标
+//
+// 每组输出数 = 转账项数 + 该组涉及的资产种类数（找零输出）。
+// 输入数取决于选币结果，由 batchTransfer 构建草稿后按 MaxInputsPerTx 继续拆分。
+func splitBatchTransfers(transfers []TransferItem, maxOutputs int) ([][]int, error) {
+	if maxOutputs < 2 {
+		return nil, fmt.Errorf("max outputs per transaction must be at least 2")
+	}
+
+	var chunks [][]int
+	var current []int
+	assets := make(map[string]bool)
+	for i, transfer := range transfers {
+		key := hex.EncodeToString(transfer.TokenID)
+		outputs := len(current) + 1 + len(assets)
+		if !assets[key] {
+			outputs++
 		}
+		if outputs > maxOutputs && len(current) > 0 {
+			chunks = append(chunks, current)
+			current = nil
+			assets = make(map[string]bool)
+		}
+		current = append(current, i)
+		assets[key] = true
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
 
-		signatures = append(signatures, signatureItem{
-			InputIndex: inputIndex,
-			SigHex:     "0x" + hex.EncodeToString(sigBytes),
-		})
+// signAndSendBatchDraft 对批量转账草稿的全部输入签名并提交，返回交易哈希
+// 除 SendRawTransaction 本身出错外，其余错误均标记为 ErrNotSubmitted
+func (s *tokenService) signAndSendBatchDraft(ctx context.Context, w wallet.Wallet, draftJSON []byte, inputIndices []uint32) (string, error) {
+	if len(inputIndices) == 0 {
+		return "", notSubmitted(fmt.Errorf("no inputs to sign"))
 	}
 
+	// 1. 计算所有输入的签名哈希（本地解码 unsignedTx 并与节点结果交叉校验）
+	sighash, err := utils.ComputeDraftSighash(ctx, s.client, draftJSON, inputIndices, txcodec.SighashAll)
+	if err != nil {
+		return "", notSubmitted(fmt.Errorf("compute signature hash failed: %w", err))
+	}
+	unsignedTxHex := sighash.UnsignedTx
 	if unsignedTxHex == "" {
-		return nil, fmt.Errorf("missing unsignedTx from wes_computeSignatureHashFromDraft")
+		return "", notSubmitted(fmt.Errorf("missing unsignedTx from wes_computeSignatureHashFromDraft"))
+	}
+	if err := wallet.RequireSigner(w, draftJSON, sighash, inputIndices); err != nil {
+		return "", notSubmitted(err)
 	}
 
-	// 7. 构建多输入签名数组
-	signatureArray := make([]map[string]interface{}, len(signatures))
-	for i, sig := range signatures {
-		signatureArray[i] = map[string]interface{}{
-			"input_index":  sig.InputIndex,
+	// 2. 获取压缩公钥（所有输入使用同一个公钥）
+	priv := w.PrivateKey()
+	if priv == nil {
+		return "", notSubmitted(fmt.Errorf("wallet private key is nil"))
+	}
+	pubCompressed := ethcrypto.CompressPubkey(&priv.PublicKey)
+	pubKeyHex := "0x" + hex.EncodeToString(pubCompressed)
+
+	// 3. 使用 Wallet 逐个签名，构建多输入签名数组
+	signatureArray := make([]map[string]interface{}, 0, len(inputIndices))
+	for _, inputIndex := range inputIndices {
+		sigBytes, err := w.SignHash(sighash.Hash(inputIndex))
+		if err != nil {
+			return "", notSubmitted(fmt.Errorf("sign hash for input %d failed: %w", inputIndex, err))
+		}
+		signatureArray = append(signatureArray, map[string]interface{}{
+			"input_index":  inputIndex,
 			"sighash_type": "SIGHASH_ALL",
 			"pubkey":       pubKeyHex,
-			"signature":    sig.SigHex,
-		}
+			"signature":    "0x" + hex.EncodeToString(sigBytes),
+		})
 	}
 
-	// 8. 调用 wes_finalizeTransactionFromDraft 使用多输入签名模式
+	// 4. 调用 wes_finalizeTransactionFromDraft 使用多输入签名模式
 	finalizeParams := map[string]interface{}{
 		"draft":      json.RawMessage(draftJSON),
 		"unsignedTx": unsignedTxHex,
@@ -275,34 +357,29 @@ func (s *tokenService) batchTransfer(ctx context.Context, req *BatchTransferRequ
 	}
 	finalResult, err := s.client.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
 	if err != nil {
-		return nil, fmt.Errorf("finalize transaction from draft failed: %w", err)
+		return "", notSubmitted(fmt.Errorf("finalize transaction from draft failed: %w", err))
 	}
 
 	finalMap, ok := finalResult.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+		return "", notSubmitted(fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft"))
 	}
 
 	txHex, ok := finalMap["tx"].(string)
 	if !ok || txHex == "" {
-		return nil, fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+		return "", notSubmitted(fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response"))
 	}
 
-	// 9. 提交交易
+	// 5. 提交交易
 	sendResult, err := s.client.SendRawTransaction(ctx, txHex)
 	if err != nil {
-		return nil, fmt.Errorf("send raw transaction failed: %w", err)
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
 	}
 
 	if !sendResult.Accepted {
-		return nil, fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+		return "", notSubmitted(fmt.Errorf("transaction rejected: %s", sendResult.Reason))
 	}
-
-	// 10. 返回结果
-	return &BatchTransferResult{
-		TxHash:  sendResult.TxHash,
-		Success: true,
-	}, nil
+	return sendResult.TxHash, nil
 }
 
 // validateBatchTransferRequest 验证批量转账请求
@@ -314,16 +391,20 @@ func (s *tokenService) validateBatchTransferRequest(req *BatchTransferRequest) e
 
 	// 2. 验证每个转账项
 	for i, transfer := range req.Transfers {
-		if len(transfer.To) != 20 {
-			return fmt.Errorf("transfer %d: to address must be 20 bytes", i)
+		if transfer.To.IsZero() {
+			return fmt.Errorf("transfer %d: to address is required", i)
 		}
-		if transfer.Amount == 0 {
+		if transfer.Amount.IsZero() {
 			return fmt.Errorf("transfer %d: amount must be greater than 0", i)
 		}
 		// TokenID可选，但如果提供必须是32字节
 		if transfer.TokenID != nil && len(transfer.TokenID) != 32 {
 			return fmt.Errorf("transfer %d: tokenID must be 32 bytes if provided", i)
 		}
+		// 锁定条件在提交任何一笔拆分交易前校验
+		if _, err := transferLockingCondition(transfer.To[:], transfer.LockingCondition); err != nil {
+			return fmt.Errorf("transfer %d: %w", i, err)
+		}
 	}
 
 	return nil
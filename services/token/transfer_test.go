@@ -0,0 +1,212 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+func TestSplitBatchTransfers(t *testing.T) {
+	tokenA := bytes.Repeat([]byte{0xa1}, 32)
+	tokenB := bytes.Repeat([]byte{0xb2}, 32)
+	var to types.Address
+	transfers := []TransferItem{
+		{To: to, Amount: types.NewAmount(1)},
+		{To: to, Amount: types.NewAmount(1), TokenID: tokenA},
+		{To: to, Amount: types.NewAmount(1)},
+		{To: to, Amount: types.NewAmount(1), TokenID: tokenB},
+		{To: to, Amount: types.NewAmount(1), TokenID: tokenA},
+	}
+
+	// 单组：5 个转账 + 3 个找零 = 8 个输出
+	chunks, err := splitBatchTransfers(transfers, 8)
+	if err != nil {
+		t.Fatalf("splitBatchTransfers: %v", err)
+	}
+	if want := [][]int{{0, 1, 2, 3, 4}}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("chunks = %v, want %v", chunks, want)
+	}
+
+	// 限制为 4 个输出：[0,1]+2 找零，[2,3]+2 找零，[4]+1 找零
+	chunks, err = splitBatchTransfers(transfers, 4)
+	if err != nil {
+		t.Fatalf("splitBatchTransfers: %v", err)
+	}
+	if want := [][]int{{0, 1}, {2, 3}, {4}}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("chunks = %v, want %v", chunks, want)
+	}
+
+	if _, err := splitBatchTransfers(transfers, 1); err == nil {
+		t.Fatalf("max outputs 1 should be rejected")
+	}
+}
+
+func TestBuildBatchTransferDraftMixedAssets(t *testing.T) {
+	from := bytes.Repeat([]byte{0x01}, 20)
+	to1 := types.MustAddressFromBytes(bytes.Repeat([]byte{0x02}, 20))
+	to2 := types.MustAddressFromBytes(bytes.Repeat([]byte{0x03}, 20))
+	tokenA := bytes.Repeat([]byte{0xa1}, 32)
+	tokenAHex := "a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"
+
+	utxos := []UTXO{
+		{Outpoint: "aa:0", Amount: "30"},
+		{Outpoint: "aa:1", Amount: "50"},
+		{Outpoint: "bb:0", Amount: "1000", TokenID: tokenAHex},
+		{Outpoint: "cc:0", Amount: "10"},
+	}
+	transfers := []TransferItem{
+		{To: to1, Amount: types.NewAmount(60)},
+		{To: to2, Amount: types.NewAmount(400), TokenID: tokenA},
+	}
+
+	draft, err := buildBatchTransferDraftFromUTXOs(from, transfers, utxos, map[string]bool{"aa:0": true})
+	if err != nil {
+		t.Fatalf("build draft: %v", err)
+	}
+	if want := []string{"aa:1", "cc:0", "bb:0"}; !reflect.DeepEqual(draft.Spent, want) {
+		t.Fatalf("spent = %v, want %v", draft.Spent, want)
+	}
+	if want := []uint32{0, 1, 2}; !reflect.DeepEqual(draft.InputIndices, want) {
+		t.Fatalf("input indices = %v, want %v", draft.InputIndices, want)
+	}
+
+	var decoded struct {
+		Outputs []map[string]interface{} `json:"outputs"`
+	}
+	if err := json.Unmarshal(draft.DraftJSON, &decoded); err != nil {
+		t.Fatalf("unmarshal draft: %v", err)
+	}
+	type out struct{ amount, token string }
+	var got []out
+	for _, o := range decoded.Outputs {
+		token, _ := o["token_id"].(string)
+		got = append(got, out{o["amount"].(string), token})
+	}
+	// 原生币 50+10-60=0 无找零，代币找零 600
+	want := []out{{"60", ""}, {"400", tokenAHex}, {"600", tokenAHex}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("outputs = %v, want %v", got, want)
+	}
+
+	// 排除已花费 UTXO 后余额不足
+	_, err = buildBatchTransferDraftFromUTXOs(from, transfers, utxos, map[string]bool{"aa:1": true})
+	if err == nil {
+		t.Fatalf("expected insufficient balance error")
+	}
+}
+
+func TestBuildBatchTransferDraftLockingConditions(t *testing.T) {
+	from := bytes.Repeat([]byte{0x01}, 20)
+	to := types.MustAddressFromBytes(bytes.Repeat([]byte{0x02}, 20))
+	utxos := []UTXO{{Outpoint: "aa:0", Amount: "100"}}
+	transfers := []TransferItem{
+		{To: to, Amount: types.NewAmount(10), LockingCondition: &resource.TimeLockCondition{UnlockTimestamp: 1900000000}},
+		{To: to, Amount: types.NewAmount(20), LockingCondition: &resource.MultiKeyLockCondition{
+			RequiredSignatures: 2,
+			AuthorizedKeys:     [][]byte{{0x02, 0x01}, {0x02, 0x02}, {0x02, 0x03}},
+		}},
+		{To: to, Amount: types.NewAmount(30)},
+	}
+
+	draft, err := buildBatchTransferDraftFromUTXOs(from, transfers, utxos, nil)
+	if err != nil {
+		t.Fatalf("buildBatchTransferDraftFromUTXOs: %v", err)
+	}
+	var parsed struct {
+		Outputs []map[string]interface{} `json:"outputs"`
+	}
+	if err := json.Unmarshal(draft.DraftJSON, &parsed); err != nil {
+		t.Fatalf("unmarshal draft: %v", err)
+	}
+
+	timeLock := parsed.Outputs[0]["locking_condition"].(map[string]interface{})["time_lock"].(map[string]interface{})
+	if timeLock["unlock_timestamp"].(float64) != 1900000000 {
+		t.Fatalf("unexpected time lock %v", timeLock)
+	}
+	base := timeLock["base_lock"].(map[string]interface{})["single_key_lock"].(map[string]interface{})
+	if base["required_address_hash"] != "0202020202020202020202020202020202020202" {
+		t.Fatalf("time lock should default to the recipient's single-key lock, got %v", base)
+	}
+	if _, ok := parsed.Outputs[1]["locking_condition"].(map[string]interface{})["multi_key_lock"]; !ok {
+		t.Fatalf("expected multi_key_lock output, got %v", parsed.Outputs[1])
+	}
+	for _, i := range []int{2, 3} {
+		if _, ok := parsed.Outputs[i]["locking_condition"]; ok {
+			t.Fatalf("output %d should use the default lock, got %v", i, parsed.Outputs[i])
+		}
+	}
+
+	// 非法锁定条件在构建草稿前报错
+	transfers[1].LockingCondition = &resource.MultiKeyLockCondition{RequiredSignatures: 4, AuthorizedKeys: [][]byte{{0x02}}}
+	if _, err := buildBatchTransferDraftFromUTXOs(from, transfers, utxos, nil); err == nil {
+		t.Fatalf("invalid multi-key lock should be rejected")
+	}
+}
+
+func TestBatchTransferSplitsByInputCount(t *testing.T) {
+	w := testWallet(t)
+	from := types.MustAddressFromBytes(w.Address())
+	mc := &nodeMockClient{height: 100}
+	for i := 1; i <= 6; i++ {
+		mc.utxos = append(mc.utxos, mockUTXO(i, "10", "", nil))
+	}
+	svc := NewServiceWithWallet(mc, w)
+
+	var to types.Address
+	to[0] = 0x01
+	req := &BatchTransferRequest{
+		From:           from,
+		MaxInputsPerTx: 2,
+		Transfers: []TransferItem{
+			{To: to, Amount: types.NewAmount(15)},
+			{To: to, Amount: types.NewAmount(15)},
+			{To: to, Amount: types.NewAmount(15)},
+		},
+	}
+	// 一组需要 5 个输入 → 拆为 [0] 与 [1,2]；[1,2] 需要 3 个输入 → 再拆为 [1] 与 [2]
+	result, err := svc.BatchTransfer(context.Background(), req)
+	if err != nil {
+		t.Fatalf("BatchTransfer: %v", err)
+	}
+	if len(result.TxHashes) != 3 || len(mc.drafts) != 3 {
+		t.Fatalf("expected 3 transactions, got %+v", result)
+	}
+	for i, draft := range mc.drafts {
+		if n := len(draft["inputs"].([]interface{})); n != 2 {
+			t.Fatalf("draft %d has %d inputs, want 2", i, n)
+		}
+	}
+
+	// 单个转账项即超出输入上限
+	mc = &nodeMockClient{height: 100, utxos: mc.utxos}
+	req.Transfers = []TransferItem{{To: to, Amount: types.NewAmount(25)}}
+	if _, err := NewServiceWithWallet(mc, w).BatchTransfer(context.Background(), req); err == nil || !strings.Contains(err.Error(), "consolidate") {
+		t.Fatalf("expected input limit error, got %v", err)
+	} else if !errors.Is(err, ErrNotSubmitted) {
+		t.Fatalf("input limit error should be ErrNotSubmitted, got %v", err)
+	}
+}
+
+func TestBatchTransferSendErrorIsNotMarkedNotSubmitted(t *testing.T) {
+	w := testWallet(t)
+	from := types.MustAddressFromBytes(w.Address())
+	mc := &nodeMockClient{height: 100, sendErr: errors.New("connection reset by peer")}
+	mc.utxos = append(mc.utxos, mockUTXO(1, "100", "", nil))
+
+	var to types.Address
+	to[0] = 0x01
+	req := &BatchTransferRequest{From: from, Transfers: []TransferItem{{To: to, Amount: types.NewAmount(10)}}}
+
+	// 提交请求出错时交易可能已到达节点，不能标记为未广播
+	_, err := NewServiceWithWallet(mc, w).BatchTransfer(context.Background(), req)
+	if err == nil || errors.Is(err, ErrNotSubmitted) {
+		t.Fatalf("send error must not be ErrNotSubmitted, got %v", err)
+	}
+}
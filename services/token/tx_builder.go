@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 	"github.com/weisyn/client-sdk-go/utils"
 )
 
@@ -86,7 +87,7 @@ func buildTransferTransaction(
 	client client.Client,
 	fromAddress []byte,
 	toAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 ) ([]byte, error) {
 	// 1. 将地址转换为 Base58 格式
@@ -165,7 +166,7 @@ func buildTransferTransaction(
 	}
 
 	// 6. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range matchingUTXOs {
@@ -184,7 +185,7 @@ func buildTransferTransaction(
 	}
 
 	if selectedUTXO.Outpoint == "" {
-		return nil, fmt.Errorf("insufficient balance: required %d, but no UTXO found with sufficient amount", amount)
+		return nil, fmt.Errorf("insufficient balance: required %s, but no UTXO found with sufficient amount", amount)
 	}
 
 	// 7. 解析 outpoint
@@ -223,7 +224,7 @@ func buildTransferTransaction(
 	transferOutput := map[string]interface{}{
 		"type":   "asset",
 		"owner":  hex.EncodeToString(toAddress),
-		"amount": fmt.Sprintf("%d", amount),
+		"amount": amount.String(),
 	}
 	if len(tokenID) > 0 {
 		transferOutput["token_id"] = hex.EncodeToString(tokenID)
@@ -317,14 +318,14 @@ func buildBurnDraft(
 	ctx context.Context,
 	client client.Client,
 	fromAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 ) ([]byte, uint32, error) {
 	// 0. 参数验证
 	if len(fromAddress) == 0 {
 		return nil, 0, fmt.Errorf("fromAddress cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if client == nil {
@@ -406,7 +407,7 @@ func buildBurnDraft(
 	}
 
 	// 6. 选择足够的 UTXO
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range matchingUTXOs {
@@ -425,7 +426,7 @@ func buildBurnDraft(
 	}
 
 	if selectedUTXO.Outpoint == "" {
-		return nil, 0, fmt.Errorf("insufficient balance: required %d, but no UTXO found with sufficient amount", amount)
+		return nil, 0, fmt.Errorf("insufficient balance: required %s, but no UTXO found with sufficient amount", amount)
 	}
 
 	// 7. 解析 outpoint
@@ -500,7 +501,7 @@ func buildBurnTransaction(
 	ctx context.Context,
 	client client.Client,
 	fromAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
 ) ([]byte, error) {
 	// 调用新的 buildBurnDraft 函数
@@ -657,7 +658,7 @@ func buildBatchTransferTransaction(
 
 	for _, transfer := range transfers {
 		// 选择足够的 UTXO
-		requiredAmount := big.NewInt(int64(transfer.Amount))
+		requiredAmount := transfer.Amount.BigInt()
 		var selectedUTXO UTXO
 		var selectedAmount *big.Int
 		for _, utxo := range matchingUTXOs {
@@ -682,7 +683,7 @@ func buildBatchTransferTransaction(
 		}
 
 		if selectedUTXO.Outpoint == "" {
-			return nil, fmt.Errorf("insufficient balance for transfer to %s, amount %d", hex.EncodeToString(transfer.To), transfer.Amount)
+			return nil, fmt.Errorf("insufficient balance for transfer to %s, amount %s", hex.EncodeToString(transfer.To[:]), transfer.Amount)
 		}
 
 		// 解析 outpoint
@@ -708,8 +709,8 @@ func buildBatchTransferTransaction(
 		outputs := draft["outputs"].([]map[string]interface{})
 		transferOutput := map[string]interface{}{
 			"type":   "asset",
-			"owner":  hex.EncodeToString(transfer.To),
-			"amount": fmt.Sprintf("%d", transfer.Amount),
+			"owner":  hex.EncodeToString(transfer.To[:]),
+			"amount": transfer.Amount.String(),
 		}
 		if len(commonTokenID) > 0 {
 			transferOutput["token_id"] = hex.EncodeToString(commonTokenID)
@@ -780,12 +781,13 @@ func buildBatchTransferTransaction(
 //
 // **功能**：
 // 构建批量转账的交易草稿，返回 DraftJSON 字节数组。
+// 支持混合资产：同一笔交易中可同时包含原生币与多种合约代币的转账。
 //
 // **流程**：
 // 1. 查询发送方的 UTXO（通过 `wes_getUTXO` API）
 // 2. 按 tokenID 分组 UTXO
-// 3. 为每个转账选择足够的 UTXO
-// 4. 计算手续费和找零
+// 3. 为每种资产分别选择足够的 UTXO
+// 4. 为每种资产分别计算找零
 // 5. 构建交易草稿（JSON 格式）
 //
 // **返回**：
@@ -797,102 +799,121 @@ func buildBatchTransferDraft(
 	fromAddress []byte,
 	transfers []TransferItem,
 ) ([]byte, []uint32, error) {
-	// 0. 参数验证
-	if len(fromAddress) == 0 {
-		return nil, nil, fmt.Errorf("fromAddress cannot be empty")
-	}
-	if len(transfers) == 0 {
-		return nil, nil, fmt.Errorf("transfers list cannot be empty")
-	}
 	if client == nil {
 		return nil, nil, fmt.Errorf("client cannot be nil")
 	}
-	// 验证每个转账项
-	for i, transfer := range transfers {
-		if len(transfer.To) == 0 {
-			return nil, nil, fmt.Errorf("transfer[%d]: toAddress cannot be empty", i)
-		}
-		if transfer.Amount == 0 {
-			return nil, nil, fmt.Errorf("transfer[%d]: amount must be greater than 0", i)
-		}
+
+	utxos, err := queryUTXOs(ctx, client, fromAddress)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// 1. 验证所有转账使用同一个 tokenID
-	var commonTokenID []byte
-	var commonTokenIDHex string
-	for i, transfer := range transfers {
-		if i == 0 {
-			commonTokenID = transfer.TokenID
-			if len(transfer.TokenID) > 0 {
-				commonTokenIDHex = hex.EncodeToString(transfer.TokenID)
-			} else {
-				commonTokenIDHex = "native"
-			}
-		} else {
-			currentTokenIDHex := "native"
-			if len(transfer.TokenID) > 0 {
-				currentTokenIDHex = hex.EncodeToString(transfer.TokenID)
-			}
-			if currentTokenIDHex != commonTokenIDHex {
-				return nil, nil, fmt.Errorf("all transfers must use the same tokenID, found %s and %s", commonTokenIDHex, currentTokenIDHex)
-			}
-		}
+	draft, err := buildBatchTransferDraftFromUTXOs(fromAddress, transfers, utxos, nil)
+	if err != nil {
+		return nil, nil, err
 	}
+	return draft.DraftJSON, draft.InputIndices, nil
+}
 
-	// 2. 将地址转换为 Base58 格式
-	fromAddressBase58, err := utils.AddressBytesToBase58(fromAddress)
+// batchDraft 批量转账草稿构建结果
+type batchDraft struct {
+	DraftJSON    []byte
+	InputIndices []uint32
+	Spent        []string // 本草稿消费的 outpoint 列表
+}
+
+// queryUTXOs 查询地址的全部 UTXO
+func queryUTXOs(ctx context.Context, client client.Client, address []byte) ([]UTXO, error) {
+	// 1. 将地址转换为 Base58 格式
+	addressBase58, err := utils.AddressBytesToBase58(address)
 	if err != nil {
-		return nil, nil, fmt.Errorf("convert address to Base58 failed: %w", err)
+		return nil, fmt.Errorf("convert address to Base58 failed: %w", err)
 	}
 
-	// 3. 查询 UTXO
-	utxoParams := []interface{}{fromAddressBase58}
-	utxoResult, err := client.Call(ctx, "wes_getUTXO", utxoParams)
+	// 2. 查询 UTXO
+	utxoResult, err := client.Call(ctx, "wes_getUTXO", []interface{}{addressBase58})
 	if err != nil {
-		return nil, nil, fmt.Errorf("query UTXO failed: %w", err)
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
 	}
 
-	// 4. 解析 UTXO 列表
+	// 3. 解析 UTXO 列表
 	utxoMap, ok := utxoResult.(map[string]interface{})
 	if !ok {
-		return nil, nil, fmt.Errorf("invalid UTXO response format")
+		return nil, fmt.Errorf("invalid UTXO response format")
 	}
 
 	utxosArray, ok := utxoMap["utxos"].([]interface{})
 	if !ok {
-		return nil, nil, fmt.Errorf("invalid UTXOs format")
+		return nil, fmt.Errorf("invalid UTXOs format")
 	}
 
-	// 5. 转换为 UTXO 结构并过滤匹配的 tokenID
-	var matchingUTXOs []UTXO
+	// 4. 转换为 UTXO 结构
+	utxos := make([]UTXO, 0, len(utxosArray))
 	for _, item := range utxosArray {
-		utxoMap, ok := item.(map[string]interface{})
+		m, ok := item.(map[string]interface{})
 		if !ok {
 			continue
 		}
-		utxo := UTXO{
-			Outpoint: getString(utxoMap, "outpoint"),
-			Height:   getString(utxoMap, "height"),
-			Amount:   getString(utxoMap, "amount"),
+		utxos = append(utxos, UTXO{
+			Outpoint: getString(m, "outpoint"),
+			Height:   getString(m, "height"),
+			Amount:   getString(m, "amount"),
+			TokenID:  getString(m, "tokenID"),
+		})
+	}
+	return utxos, nil
+}
+
+// buildBatchTransferDraftFromUTXOs 基于给定 UTXO 集合构建混合资产批量转账草稿
+//
+// spent 中的 outpoint 不会被选中（用于自动拆分时避免多笔交易重复花费同一 UTXO）。
+// 输出顺序：先按 transfers 顺序排列转账输出，再按资产首次出现顺序排列找零输出。
+func buildBatchTransferDraftFromUTXOs(
+	fromAddress []byte,
+	transfers []TransferItem,
+	utxos []UTXO,
+	spent map[string]bool,
+) (*batchDraft, error) {
+	// 0. 参数验证
+	if len(fromAddress) == 0 {
+		return nil, fmt.Errorf("fromAddress cannot be empty")
+	}
+	if len(transfers) == 0 {
+		return nil, fmt.Errorf("transfers list cannot be empty")
+	}
+	for i, transfer := range transfers {
+		if len(transfer.To) == 0 {
+			return nil, fmt.Errorf("transfer[%d]: toAddress cannot be empty", i)
 		}
-		if tokenIDStr := getString(utxoMap, "tokenID"); tokenIDStr != "" {
-			utxo.TokenID = tokenIDStr
+		if transfer.Amount.IsZero() {
+			return nil, fmt.Errorf("transfer[%d]: amount must be greater than 0", i)
 		}
+	}
 
-		utxoTokenIDHex := "native"
-		if utxo.TokenID != "" {
-			utxoTokenIDHex = utxo.TokenID
-		}
-		if utxoTokenIDHex == commonTokenIDHex {
-			matchingUTXOs = append(matchingUTXOs, utxo)
+	// 1. 按 tokenID 汇总每种资产的需求（保持首次出现顺序）
+	var tokenOrder []string
+	required := make(map[string]*big.Int)
+	for _, transfer := range transfers {
+		key := hex.EncodeToString(transfer.TokenID) // 原生币为空字符串
+		if _, ok := required[key]; !ok {
+			tokenOrder = append(tokenOrder, key)
+			required[key] = big.NewInt(0)
 		}
+		required[key].Add(required[key], transfer.Amount.BigInt())
 	}
 
-	if len(matchingUTXOs) == 0 {
-		return nil, nil, fmt.Errorf("no matching UTXOs for tokenID: %s", commonTokenIDHex)
+	// 2. 按 tokenID 分组可用 UTXO
+	available := make(map[string][]UTXO)
+	for _, utxo := range utxos {
+		if utxo.Outpoint == "" || spent[utxo.Outpoint] {
+			continue
+		}
+		key := strings.TrimPrefix(utxo.TokenID, "0x")
+		if _, ok := required[key]; ok {
+			available[key] = append(available[key], utxo)
+		}
 	}
 
-	// 6. 构建交易草稿
 	draft := map[string]interface{}{
 		"sign_mode": "defer_sign",
 		"inputs":    []map[string]interface{}{},
@@ -902,102 +923,103 @@ func buildBatchTransferDraft(
 		},
 	}
 
-	// 7. 计算所有转账的总需求
-	totalOutputAmount := big.NewInt(0)
-	for _, transfer := range transfers {
-		totalOutputAmount.Add(totalOutputAmount, big.NewInt(int64(transfer.Amount)))
-	}
-
-	// 8. 选择足够的UTXO来满足所有转账需求
-	var selectedUTXOList []UTXO
-	totalInputAmount := big.NewInt(0)
-	for _, utxo := range matchingUTXOs {
-		if utxo.Amount == "" {
-			continue
-		}
-		utxoAmount, ok := new(big.Int).SetString(utxo.Amount, 10)
-		if !ok {
-			continue
+	// 3. 为每种资产分别选择 UTXO 并添加输入
+	// 注意：手续费从接收者扣除，发送者只需要满足总输出金额即可
+	result := &batchDraft{}
+	change := make(map[string]*big.Int)
+	for _, key := range tokenOrder {
+		need := required[key]
+		total := big.NewInt(0)
+		var selected []UTXO
+		for _, utxo := range available[key] {
+			utxoAmount, ok := new(big.Int).SetString(utxo.Amount, 10)
+			if !ok {
+				continue
+			}
+			selected = append(selected, utxo)
+			total.Add(total, utxoAmount)
+			if total.Cmp(need) >= 0 {
+				break
+			}
 		}
-		selectedUTXOList = append(selectedUTXOList, utxo)
-		totalInputAmount.Add(totalInputAmount, utxoAmount)
-		// 如果累计金额已经足够，就停止选择
-		// 注意：手续费从接收者扣除，发送者不需要支付手续费，只需要满足总输出金额即可
-		if totalInputAmount.Cmp(totalOutputAmount) >= 0 {
-			break
+
+		if total.Cmp(need) < 0 {
+			asset := key
+			if asset == "" {
+				asset = "native"
+			}
+			return nil, fmt.Errorf("insufficient balance for %s: total required %s, available %s",
+				asset, need.String(), total.String())
 		}
-	}
 
-	// 验证是否有足够的UTXO
-	// 注意：手续费从接收者扣除，发送者只需要满足总输出金额即可
-	if totalInputAmount.Cmp(totalOutputAmount) < 0 {
-		return nil, nil, fmt.Errorf("insufficient balance: total required %s, available %s",
-			totalOutputAmount.String(), totalInputAmount.String())
-	}
+		for _, utxo := range selected {
+			outpointParts := strings.Split(utxo.Outpoint, ":")
+			if len(outpointParts) != 2 {
+				return nil, fmt.Errorf("invalid outpoint format: %s", utxo.Outpoint)
+			}
+			var outputIndex uint32
+			if _, err := fmt.Sscanf(outpointParts[1], "%d", &outputIndex); err != nil {
+				return nil, fmt.Errorf("invalid output index: %w", err)
+			}
 
-	// 9. 为所有选中的UTXO添加输入
-	var inputIndices []uint32
-	for _, selectedUTXO := range selectedUTXOList {
-		outpointParts := strings.Split(selectedUTXO.Outpoint, ":")
-		if len(outpointParts) != 2 {
-			return nil, nil, fmt.Errorf("invalid outpoint format: %s", selectedUTXO.Outpoint)
-		}
-		txHash := outpointParts[0]
-		var outputIndex uint32
-		if _, err := fmt.Sscanf(outpointParts[1], "%d", &outputIndex); err != nil {
-			return nil, nil, fmt.Errorf("invalid output index: %w", err)
+			inputs := draft["inputs"].([]map[string]interface{})
+			result.InputIndices = append(result.InputIndices, uint32(len(inputs)))
+			draft["inputs"] = append(inputs, map[string]interface{}{
+				"tx_hash":           outpointParts[0],
+				"output_index":      outputIndex,
+				"is_reference_only": false,
+			})
+			result.Spent = append(result.Spent, utxo.Outpoint)
 		}
 
-		// 添加输入
-		inputs := draft["inputs"].([]map[string]interface{})
-		inputIndex := uint32(len(inputs))
-		draft["inputs"] = append(inputs, map[string]interface{}{
-			"tx_hash":           txHash,
-			"output_index":      outputIndex,
-			"is_reference_only": false,
-		})
-		inputIndices = append(inputIndices, inputIndex)
+		change[key] = total.Sub(total, need)
 	}
 
-	// 10. 为每个转账添加输出
-	for _, transfer := range transfers {
+	// 4. 为每个转账添加输出
+	for i, transfer := range transfers {
 		outputs := draft["outputs"].([]map[string]interface{})
 		transferOutput := map[string]interface{}{
 			"type":   "asset",
-			"owner":  hex.EncodeToString(transfer.To),
-			"amount": fmt.Sprintf("%d", transfer.Amount),
+			"owner":  hex.EncodeToString(transfer.To[:]),
+			"amount": transfer.Amount.String(),
 		}
-		if len(commonTokenID) > 0 {
-			transferOutput["token_id"] = hex.EncodeToString(commonTokenID)
+		if len(transfer.TokenID) > 0 {
+			transferOutput["token_id"] = hex.EncodeToString(transfer.TokenID)
+		}
+		lockingCondition, err := transferLockingCondition(transfer.To[:], transfer.LockingCondition)
+		if err != nil {
+			return nil, fmt.Errorf("transfer[%d]: %w", i, err)
+		}
+		if lockingCondition != nil {
+			transferOutput["locking_condition"] = lockingCondition
 		}
 		draft["outputs"] = append(outputs, transferOutput)
 	}
 
-	// 11. 计算找零
-	// 注意：手续费从接收者扣除，发送者不需要支付手续费，找零 = totalInputAmount - totalOutputAmount
-	changeBig := new(big.Int).Sub(totalInputAmount, totalOutputAmount)
-
-	// 12. 添加找零输出
-	if changeBig.Sign() > 0 {
+	// 5. 为每种资产添加找零输出
+	for _, key := range tokenOrder {
+		if change[key].Sign() <= 0 {
+			continue
+		}
 		outputs := draft["outputs"].([]map[string]interface{})
 		changeOutput := map[string]interface{}{
 			"type":   "asset",
 			"owner":  hex.EncodeToString(fromAddress),
-			"amount": changeBig.String(),
+			"amount": change[key].String(),
 		}
-		if len(commonTokenID) > 0 {
-			changeOutput["token_id"] = hex.EncodeToString(commonTokenID)
+		if key != "" {
+			changeOutput["token_id"] = key
 		}
 		draft["outputs"] = append(outputs, changeOutput)
 	}
 
-	// 13. 序列化交易草稿为 JSON
+	// 6. 序列化交易草稿为 JSON
 	draftJSON, err := json.Marshal(draft)
 	if err != nil {
-		return nil, nil, fmt.Errorf("marshal draft failed: %w", err)
+		return nil, fmt.Errorf("marshal draft failed: %w", err)
 	}
-
-	return draftJSON, inputIndices, nil
+	result.DraftJSON = draftJSON
+	return result, nil
 }
 
 // getString 从 map 中获取字符串值
@@ -1021,8 +1043,9 @@ func buildTransferDraft(
 	client client.Client,
 	fromAddress []byte,
 	toAddress []byte,
-	amount uint64,
+	amount types.Amount,
 	tokenID []byte,
+	lockingCondition map[string]interface{},
 ) ([]byte, uint32, error) {
 	// 0. 参数验证
 	if len(fromAddress) == 0 {
@@ -1031,7 +1054,7 @@ func buildTransferDraft(
 	if len(toAddress) == 0 {
 		return nil, 0, fmt.Errorf("toAddress cannot be empty")
 	}
-	if amount == 0 {
+	if amount.IsZero() {
 		return nil, 0, fmt.Errorf("amount must be greater than 0")
 	}
 	if client == nil {
@@ -1114,7 +1137,7 @@ func buildTransferDraft(
 	}
 
 	// 6. 选择足够的 UTXO（当前实现只选择一个即可满足需求）
-	requiredAmount := big.NewInt(int64(amount))
+	requiredAmount := amount.BigInt()
 	var selectedUTXO UTXO
 	var selectedAmount *big.Int
 	for _, utxo := range matchingUTXOs {
@@ -1133,7 +1156,7 @@ func buildTransferDraft(
 	}
 
 	if selectedUTXO.Outpoint == "" {
-		return nil, 0, fmt.Errorf("insufficient balance: required %d, but no UTXO found with sufficient amount", amount)
+		return nil, 0, fmt.Errorf("insufficient balance: required %s, but no UTXO found with sufficient amount", amount)
 	}
 
 	// 7. 解析 outpoint
@@ -1172,11 +1195,14 @@ func buildTransferDraft(
 	transferOutput := map[string]interface{}{
 		"type":   "asset",
 		"owner":  hex.EncodeToString(toAddress),
-		"amount": fmt.Sprintf("%d", amount),
+		"amount": amount.String(),
 	}
 	if len(tokenID) > 0 {
 		transferOutput["token_id"] = hex.EncodeToString(tokenID)
 	}
+	if lockingCondition != nil {
+		transferOutput["locking_condition"] = lockingCondition
+	}
 	draft["outputs"] = append(outputs, transferOutput)
 
 	// 12. 添加找零输出（如果有剩余）
@@ -0,0 +1,209 @@
+package siwx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+// Version 消息格式版本
+const Version = "1"
+
+const headerSuffix = " wants you to sign in with your WES account:"
+
+// Message 登录挑战消息
+//
+// 文本格式（String）参照 EIP-4361，钱包签名的是该文本：
+//
+//	example.com wants you to sign in with your WES account:
+//	CGTta3M4t3yXu8uRgkKvaWd2d8DR32W9vM
+//
+//	Sign in to Example
+//
+//	URI: https://example.com/login
+//	Version: 1
+//	Chain ID: 0x1
+//	Nonce: 5f0c2a...
+//	Issued At: 2026-01-01T12:00:00Z
+//	Expiration Time: 2026-01-01T12:05:00Z
+type Message struct {
+	Domain         string        // 请求登录的站点（host[:port]）
+	Address        types.Address // 登录地址
+	Statement      string        // 可选：展示给用户的说明（单行）
+	URI            string        // 登录资源 URI
+	Version        string        // 固定为 Version
+	ChainID        string        // 节点 wes_chainId 返回的链 ID
+	Nonce          string        // 服务端签发的随机数（防重放）
+	IssuedAt       time.Time     // 签发时间
+	ExpirationTime time.Time     // 可选：过期时间
+	NotBefore      time.Time     // 可选：生效时间
+	Resources      []string      // 可选：附带的资源 URI
+}
+
+// String 返回待签名的消息文本
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.String() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+	fmt.Fprintf(&b, "URI: %s\n", m.URI)
+	fmt.Fprintf(&b, "Version: %s\n", m.Version)
+	fmt.Fprintf(&b, "Chain ID: %s\n", m.ChainID)
+	fmt.Fprintf(&b, "Nonce: %s\n", m.Nonce)
+	fmt.Fprintf(&b, "Issued At: %s", formatTime(m.IssuedAt))
+	if !m.ExpirationTime.IsZero() {
+		fmt.Fprintf(&b, "\nExpiration Time: %s", formatTime(m.ExpirationTime))
+	}
+	if !m.NotBefore.IsZero() {
+		fmt.Fprintf(&b, "\nNot Before: %s", formatTime(m.NotBefore))
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, r := range m.Resources {
+			b.WriteString("\n- " + r)
+		}
+	}
+	return b.String()
+}
+
+// validate 检查字段能否无歧义地编码为消息文本
+func (m *Message) validate() error {
+	switch {
+	case m.Domain == "" || strings.ContainsAny(m.Domain, " \n"):
+		return fmt.Errorf("%w: invalid domain %q", ErrInvalidMessage, m.Domain)
+	case m.Address.IsZero():
+		return fmt.Errorf("%w: address is required", ErrInvalidMessage)
+	case strings.Contains(m.Statement, "\n"):
+		return fmt.Errorf("%w: statement must be a single line", ErrInvalidMessage)
+	case m.URI == "" || strings.ContainsAny(m.URI, " \n"):
+		return fmt.Errorf("%w: invalid uri %q", ErrInvalidMessage, m.URI)
+	case m.Version != Version:
+		return fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, m.Version)
+	case m.ChainID == "" || strings.ContainsAny(m.ChainID, " \n"):
+		return fmt.Errorf("%w: invalid chain id %q", ErrInvalidMessage, m.ChainID)
+	case len(m.Nonce) < 8 || !isAlphanumeric(m.Nonce):
+		return fmt.Errorf("%w: nonce must be at least 8 alphanumeric characters", ErrInvalidMessage)
+	case m.IssuedAt.IsZero():
+		return fmt.Errorf("%w: issued at is required", ErrInvalidMessage)
+	}
+	for _, r := range m.Resources {
+		if r == "" || strings.ContainsAny(r, " \n") {
+			return fmt.Errorf("%w: invalid resource %q", ErrInvalidMessage, r)
+		}
+	}
+	return nil
+}
+
+// ParseMessage 解析消息文本
+//
+// 只接受规范格式：解析结果重新编码后必须与输入逐字节一致。
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	invalid := func(format string, args ...interface{}) (*Message, error) {
+		return nil, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidMessage}, args...)...)
+	}
+	if len(lines) < 3 || lines[2] != "" {
+		return invalid("missing header")
+	}
+	domain, ok := strings.CutSuffix(lines[0], headerSuffix)
+	if !ok {
+		return invalid("missing header")
+	}
+	address, err := types.ParseAddress(lines[1])
+	if err != nil {
+		return invalid("%v", err)
+	}
+	m := &Message{Domain: domain, Address: address}
+
+	rest := lines[3:]
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "URI: ") {
+		if len(rest) < 2 || rest[1] != "" {
+			return invalid("statement must be followed by an empty line")
+		}
+		m.Statement, rest = rest[0], rest[2:]
+	}
+
+	field := func(name string, required bool) (string, error) {
+		if len(rest) > 0 && strings.HasPrefix(rest[0], name+": ") {
+			v := strings.TrimPrefix(rest[0], name+": ")
+			rest = rest[1:]
+			return v, nil
+		}
+		if required {
+			return "", fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+		}
+		return "", nil
+	}
+	timeField := func(name string, required bool) (time.Time, error) {
+		v, err := field(name, required)
+		if err != nil || v == "" {
+			return time.Time{}, err
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: invalid %s: %v", ErrInvalidMessage, name, err)
+		}
+		return t, nil
+	}
+
+	if m.URI, err = field("URI", true); err != nil {
+		return nil, err
+	}
+	if m.Version, err = field("Version", true); err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = field("Chain ID", true); err != nil {
+		return nil, err
+	}
+	if m.Nonce, err = field("Nonce", true); err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = timeField("Issued At", true); err != nil {
+		return nil, err
+	}
+	if m.ExpirationTime, err = timeField("Expiration Time", false); err != nil {
+		return nil, err
+	}
+	if m.NotBefore, err = timeField("Not Before", false); err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 && rest[0] == "Resources:" {
+		for _, line := range rest[1:] {
+			r, ok := strings.CutPrefix(line, "- ")
+			if !ok {
+				return invalid("invalid resource line %q", line)
+			}
+			m.Resources = append(m.Resources, r)
+		}
+		rest = nil
+	}
+	if len(rest) > 0 {
+		return invalid("unexpected line %q", rest[0])
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if m.String() != text {
+		return invalid("message is not in canonical form")
+	}
+	return m, nil
+}
+
+// formatTime 以秒精度的 UTC RFC 3339 格式输出时间
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package siwx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NonceStore 挑战随机数存储（可替换为 Redis / 数据库实现，供多实例共享）
+//
+// nonce 下保存签发的完整消息文本，校验时要求提交的消息与之逐字节一致，
+// 防止客户端改写地址、有效期或资源列表后复用合法 nonce。
+type NonceStore interface {
+	// Issue 记录新签发的 nonce 及其消息文本，expiresAt 之后不再接受
+	Issue(ctx context.Context, nonce, message string, expiresAt time.Time) error
+	// Consume 原子地核对并消费 nonce：
+	// 未签发、已消费或已过期时返回 ErrInvalidNonce；消息与签发时不一致时返回 ErrInvalidMessage 且不消费
+	Consume(ctx context.Context, nonce, message string, now time.Time) error
+}
+
+// NewNonce 生成 128 位随机 nonce（32 个十六进制字符）
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// MaxMemoryNonces MemoryNonceStore 最多保存的未过期 nonce 数量
+const MaxMemoryNonces = 100000
+
+// MemoryNonceStore 内存 nonce 存储（单实例部署使用，进程重启后未消费的挑战失效）
+//
+// Issue 与 Consume 都会清理已过期的 nonce；未过期的 nonce 达到 MaxMemoryNonces 时 Issue 返回错误，
+// 防止大量只领取挑战不登录的请求耗尽内存。
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]issuedNonce
+	now    func() time.Time // Issue 时用于清理的时钟
+	max    int
+}
+
+// issuedNonce 已签发的挑战
+type issuedNonce struct {
+	message   string
+	expiresAt time.Time
+}
+
+// NewMemoryNonceStore 创建内存 nonce 存储
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return newMemoryNonceStore(time.Now)
+}
+
+func newMemoryNonceStore(now func() time.Time) *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]issuedNonce), now: now, max: MaxMemoryNonces}
+}
+
+// Issue 实现 NonceStore
+func (s *MemoryNonceStore) Issue(ctx context.Context, nonce, message string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(s.now())
+	if _, ok := s.nonces[nonce]; ok {
+		return fmt.Errorf("nonce %s already issued", nonce)
+	}
+	if len(s.nonces) >= s.max {
+		return fmt.Errorf("too many outstanding nonces (%d)", len(s.nonces))
+	}
+	s.nonces[nonce] = issuedNonce{message: message, expiresAt: expiresAt}
+	return nil
+}
+
+// Consume 实现 NonceStore（顺带清理已过期的 nonce）
+func (s *MemoryNonceStore) Consume(ctx context.Context, nonce, message string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(now)
+	issued, ok := s.nonces[nonce]
+	if !ok {
+		return ErrInvalidNonce
+	}
+	if issued.message != message {
+		return fmt.Errorf("%w: message does not match the issued challenge", ErrInvalidMessage)
+	}
+	delete(s.nonces, nonce)
+	return nil
+}
+
+// prune 删除 now 时已过期的 nonce（调用方持有锁）
+func (s *MemoryNonceStore) prune(now time.Time) {
+	for n, issued := range s.nonces {
+		if !now.Before(issued.expiresAt) {
+			delete(s.nonces, n)
+		}
+	}
+}
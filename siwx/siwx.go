@@ -0,0 +1,212 @@
+// Package siwx 提供 Sign-In-With-WES 登录协议辅助工具
+//
+// 用户以 WES 私钥签名服务端签发的结构化挑战完成登录：
+//  1. 服务端 Issuer.Challenge 生成挑战（站点、地址、nonce、链 ID、签发 / 过期时间），nonce 写入 NonceStore
+//  2. 客户端 Sign 通过 wallet.Wallet 签名挑战文本（wallet.SignMessageForChain，绑定链 ID）
+//  3. 服务端 Issuer.Verify 解析消息、核对站点 / 链 ID / 有效期，从签名恢复地址，最后核对签发的消息并消费 nonce 防重放
+//
+// **使用示例**：
+//
+//	issuer, _ := siwx.NewIssuer(cli, siwx.Config{Domain: "example.com", URI: "https://example.com/login"})
+//	msg, _ := issuer.Challenge(ctx, address)       // 把 msg.String() 发给前端
+//	sig, _ := siwx.Sign(w, msg)                     // 前端 / 客户端签名
+//	verified, err := issuer.Verify(ctx, msg.String(), sig)
+package siwx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// DefaultTTL 挑战默认有效期
+const DefaultTTL = 5 * time.Minute
+
+var (
+	// ErrInvalidMessage 消息格式错误，站点 / URI / 链 ID 与服务端不符，或与签发的挑战不一致
+	ErrInvalidMessage = errors.New("siwx: invalid message")
+
+	// ErrExpired 消息已过期或尚未生效
+	ErrExpired = errors.New("siwx: message expired or not yet valid")
+
+	// ErrInvalidSignature 签名无效或签名者与消息地址不符
+	ErrInvalidSignature = errors.New("siwx: invalid signature")
+
+	// ErrInvalidNonce nonce 未签发、已使用或已过期（重放）
+	ErrInvalidNonce = errors.New("siwx: invalid or reused nonce")
+)
+
+// Config 签发方配置
+type Config struct {
+	Domain    string           // 站点（必填）
+	URI       string           // 登录资源 URI（必填）
+	Statement string           // 可选：展示给用户的说明
+	Resources []string         // 可选：附带的资源 URI
+	ChainID   string           // 可选：链 ID（为空时首次签发挑战时调用 wes_chainId 获取）
+	TTL       time.Duration    // 挑战有效期（0 表示 DefaultTTL）
+	Nonces    NonceStore       // nonce 存储（nil 时使用 MemoryNonceStore）
+	Now       func() time.Time // 时钟（测试用，nil 时使用 time.Now）
+}
+
+// Issuer 登录挑战签发与校验方（服务端）
+type Issuer struct {
+	client client.Client
+	cfg    Config
+
+	mu      sync.Mutex
+	chainID string
+}
+
+// NewIssuer 创建签发方（cfg.ChainID 为空时 c 不能为 nil）
+func NewIssuer(c client.Client, cfg Config) (*Issuer, error) {
+	if cfg.Domain == "" || cfg.URI == "" {
+		return nil, fmt.Errorf("domain and uri are required")
+	}
+	if cfg.ChainID == "" && c == nil {
+		return nil, fmt.Errorf("client is required to query chain id")
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Nonces == nil {
+		cfg.Nonces = newMemoryNonceStore(cfg.Now)
+	}
+	return &Issuer{client: c, cfg: cfg, chainID: cfg.ChainID}, nil
+}
+
+// ChainID 返回链 ID（首次调用 wes_chainId 并缓存）
+func (i *Issuer) ChainID(ctx context.Context) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.chainID != "" {
+		return i.chainID, nil
+	}
+	result, err := i.client.Call(ctx, "wes_chainId", nil)
+	if err != nil {
+		return "", fmt.Errorf("call wes_chainId failed: %w", err)
+	}
+	var chainID string
+	switch v := result.(type) {
+	case string:
+		chainID = v
+	case float64:
+		chainID = fmt.Sprintf("0x%x", uint64(v))
+	}
+	if chainID == "" {
+		return "", fmt.Errorf("invalid response format from wes_chainId")
+	}
+	i.chainID = chainID
+	return chainID, nil
+}
+
+// Challenge 为指定地址签发登录挑战
+func (i *Issuer) Challenge(ctx context.Context, address types.Address) (*Message, error) {
+	chainID, err := i.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := NewNonce()
+	if err != nil {
+		return nil, err
+	}
+	now := i.cfg.Now().UTC().Truncate(time.Second)
+	m := &Message{
+		Domain:         i.cfg.Domain,
+		Address:        address,
+		Statement:      i.cfg.Statement,
+		URI:            i.cfg.URI,
+		Version:        Version,
+		ChainID:        chainID,
+		Nonce:          nonce,
+		IssuedAt:       now,
+		ExpirationTime: now.Add(i.cfg.TTL),
+		Resources:      append([]string(nil), i.cfg.Resources...),
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if err := i.cfg.Nonces.Issue(ctx, nonce, m.String(), m.ExpirationTime); err != nil {
+		return nil, fmt.Errorf("store nonce: %w", err)
+	}
+	return m, nil
+}
+
+// Verify 校验签名后的登录消息，成功时返回解析出的消息（Address 即登录地址）
+//
+// 依次检查：消息格式 → 站点 / URI / 链 ID → 有效期 → 签名恢复地址 → 核对签发的消息并消费 nonce。
+// 消息须与 Challenge 签发的文本完全一致（地址、有效期、资源等均不可改写）；
+// nonce 在签名与消息核对通过后才消费，无效请求不会使合法挑战失效。
+func (i *Issuer) Verify(ctx context.Context, text string, signature []byte) (*Message, error) {
+	m, err := ParseMessage(text)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := i.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case m.Domain != i.cfg.Domain:
+		return nil, fmt.Errorf("%w: domain %q does not match %q", ErrInvalidMessage, m.Domain, i.cfg.Domain)
+	case m.URI != i.cfg.URI:
+		return nil, fmt.Errorf("%w: uri %q does not match %q", ErrInvalidMessage, m.URI, i.cfg.URI)
+	case !strings.EqualFold(m.ChainID, chainID):
+		return nil, fmt.Errorf("%w: chain id %s does not match %s", ErrInvalidMessage, m.ChainID, chainID)
+	}
+
+	now := i.cfg.Now()
+	switch {
+	case m.ExpirationTime.IsZero():
+		return nil, fmt.Errorf("%w: expiration time is required", ErrInvalidMessage)
+	case !now.Before(m.ExpirationTime):
+		return nil, fmt.Errorf("%w: expired at %s", ErrExpired, formatTime(m.ExpirationTime))
+	case !m.NotBefore.IsZero() && now.Before(m.NotBefore):
+		return nil, fmt.Errorf("%w: not valid before %s", ErrExpired, formatTime(m.NotBefore))
+	case m.IssuedAt.After(now):
+		return nil, fmt.Errorf("%w: issued in the future", ErrExpired)
+	}
+
+	signer, err := wallet.RecoverMessageSigner(m.ChainID, []byte(text), signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if !bytes.Equal(signer, m.Address[:]) {
+		return nil, fmt.Errorf("%w: signed by %x, message is for %s", ErrInvalidSignature, signer, m.Address)
+	}
+
+	if err := i.cfg.Nonces.Consume(ctx, m.Nonce, text, now); err != nil {
+		if errors.Is(err, ErrInvalidNonce) || errors.Is(err, ErrInvalidMessage) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("consume nonce: %w", err)
+	}
+	return m, nil
+}
+
+// Sign 使用钱包签名登录消息，返回 65 字节可恢复签名
+func Sign(w wallet.Wallet, m *Message) ([]byte, error) {
+	if w == nil {
+		return nil, fmt.Errorf("wallet is nil")
+	}
+	if m == nil {
+		return nil, fmt.Errorf("message is nil")
+	}
+	if !bytes.Equal(w.Address(), m.Address[:]) {
+		return nil, fmt.Errorf("wallet address %x does not match message address %s", w.Address(), m.Address)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return wallet.SignMessageForChain(w, m.ChainID, []byte(m.String()))
+}
@@ -0,0 +1,233 @@
+package siwx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+	"github.com/weisyn/client-sdk-go/wallet/policy"
+)
+
+// chainIDMockClient 仅实现 wes_chainId
+type chainIDMockClient struct{ calls int }
+
+func (m *chainIDMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if method != "wes_chainId" {
+		return nil, fmt.Errorf("unexpected method %s", method)
+	}
+	m.calls++
+	return "0x2a", nil
+}
+
+func (m *chainIDMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *chainIDMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *chainIDMockClient) Close() error { return nil }
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+func TestSignInFlow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 500, time.UTC)
+	mc := &chainIDMockClient{}
+	issuer, err := NewIssuer(mc, Config{
+		Domain:    "example.com",
+		URI:       "https://example.com/login",
+		Statement: "Sign in to Example",
+		Now:       func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewIssuer: %v", err)
+	}
+
+	w := testWallet(t, 1)
+	address := types.MustAddressFromBytes(w.Address())
+	msg, err := issuer.Challenge(ctx, address)
+	if err != nil {
+		t.Fatalf("Challenge: %v", err)
+	}
+	if msg.ChainID != "0x2a" || msg.ExpirationTime.Sub(msg.IssuedAt) != DefaultTTL || len(msg.Nonce) != 32 {
+		t.Fatalf("unexpected challenge %+v", msg)
+	}
+	text := msg.String()
+	if !strings.HasPrefix(text, "example.com wants you to sign in with your WES account:\n"+address.String()+"\n\nSign in to Example\n\n") {
+		t.Fatalf("unexpected message text:\n%s", text)
+	}
+
+	sig, err := Sign(w, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	verified, err := issuer.Verify(ctx, text, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if verified.Address != address {
+		t.Fatalf("verified address %s, want %s", verified.Address, address)
+	}
+	// 重放
+	if _, err := issuer.Verify(ctx, text, sig); !errors.Is(err, ErrInvalidNonce) {
+		t.Fatalf("expected ErrInvalidNonce on replay, got %v", err)
+	}
+	if mc.calls != 1 {
+		t.Fatalf("chain id should be cached, got %d calls", mc.calls)
+	}
+
+	// 他人签名：失败且不消费 nonce
+	msg, _ = issuer.Challenge(ctx, address)
+	other := *msg
+	other.Address = types.MustAddressFromBytes(testWallet(t, 2).Address())
+	forged, _ := Sign(testWallet(t, 2), &other)
+	if _, err := issuer.Verify(ctx, msg.String(), forged); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+	if _, err := Sign(testWallet(t, 2), msg); err == nil {
+		t.Fatalf("expected error signing another address's message")
+	}
+	sig, _ = Sign(w, msg)
+	if _, err := issuer.Verify(ctx, msg.String(), sig); err != nil {
+		t.Fatalf("nonce should survive a failed verification: %v", err)
+	}
+
+	// 以自己的地址 / 更长有效期复用他人挑战的 nonce：与签发消息不符，不消费 nonce
+	msg, _ = issuer.Challenge(ctx, address)
+	attacker := testWallet(t, 2)
+	rewritten := *msg
+	rewritten.Address = types.MustAddressFromBytes(attacker.Address())
+	rewritten.ExpirationTime = msg.ExpirationTime.Add(time.Hour)
+	forged, _ = Sign(attacker, &rewritten)
+	if _, err := issuer.Verify(ctx, rewritten.String(), forged); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("expected ErrInvalidMessage for rewritten challenge, got %v", err)
+	}
+	sig, _ = Sign(w, msg)
+	if _, err := issuer.Verify(ctx, msg.String(), sig); err != nil {
+		t.Fatalf("nonce should survive a rewritten challenge: %v", err)
+	}
+
+	// 篡改消息文本
+	msg, _ = issuer.Challenge(ctx, address)
+	sig, _ = Sign(w, msg)
+	tampered := strings.Replace(msg.String(), "Sign in to Example", "Sign in to Evil", 1)
+	if _, err := issuer.Verify(ctx, tampered, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for tampered message, got %v", err)
+	}
+
+	// 其他站点 / 过期
+	evil, _ := NewIssuer(nil, Config{Domain: "evil.com", URI: "https://example.com/login", ChainID: "0x2a", Now: func() time.Time { return now }})
+	if _, err := evil.Verify(ctx, msg.String(), sig); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("expected ErrInvalidMessage for domain mismatch, got %v", err)
+	}
+	now = now.Add(DefaultTTL)
+	if _, err := issuer.Verify(ctx, msg.String(), sig); !errors.Is(err, ErrExpired) {
+		t.Fatalf("expected ErrExpired, got %v", err)
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	issuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Message{
+		Domain:         "localhost:8080",
+		Address:        types.MustAddressFromBytes(testWallet(t, 1).Address()),
+		URI:            "http://localhost:8080",
+		Version:        Version,
+		ChainID:        "0x1",
+		Nonce:          "abcdef0123456789",
+		IssuedAt:       issuedAt,
+		ExpirationTime: issuedAt.Add(time.Hour),
+		NotBefore:      issuedAt,
+		Resources:      []string{"https://example.com/a", "ipfs://b"},
+	}
+	parsed, err := ParseMessage(m.String())
+	if err != nil {
+		t.Fatalf("ParseMessage: %v", err)
+	}
+	if parsed.String() != m.String() || parsed.Statement != "" || len(parsed.Resources) != 2 || !parsed.NotBefore.Equal(issuedAt) {
+		t.Fatalf("round trip mismatch:\n%s\n---\n%s", parsed, m)
+	}
+
+	bad := []string{
+		"",
+		strings.Replace(m.String(), "Version: 1", "Version: 2", 1),
+		strings.Replace(m.String(), "Nonce: abcdef0123456789", "Nonce: abc", 1),
+		strings.Replace(m.String(), m.Address.String(), m.Address.Hex(), 1),
+		strings.Replace(m.String(), "Z\nExpiration", ".5Z\nExpiration", 1),
+		m.String() + "\nExtra: field",
+	}
+	for _, text := range bad {
+		if _, err := ParseMessage(text); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("expected ErrInvalidMessage for:\n%s\ngot %v", text, err)
+		}
+	}
+}
+
+func TestSignWithPolicyWallet(t *testing.T) {
+	ctx := context.Background()
+	issuer, _ := NewIssuer(nil, Config{Domain: "example.com", URI: "https://example.com", ChainID: "0x1"})
+	w := testWallet(t, 1)
+	msg, _ := issuer.Challenge(ctx, types.MustAddressFromBytes(w.Address()))
+
+	denied, _ := policy.NewWallet(w, nil, policy.Options{})
+	if _, err := Sign(denied, msg); !errors.Is(err, policy.ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation, got %v", err)
+	}
+	allowed, _ := policy.NewWallet(w, &policy.Policy{AllowMessages: true}, policy.Options{})
+	sig, err := Sign(allowed, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, err := issuer.Verify(ctx, msg.String(), sig); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestMemoryNonceStorePrunesOnIssue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newMemoryNonceStore(func() time.Time { return now })
+	store.max = 2
+
+	// 只签发不消费：过期的 nonce 在下次签发时被清理
+	for i := 0; i < 10; i++ {
+		if err := store.Issue(ctx, fmt.Sprintf("n%d", i), "msg", now.Add(time.Minute)); err != nil {
+			t.Fatalf("Issue(%d): %v", i, err)
+		}
+		now = now.Add(time.Minute)
+	}
+	if len(store.nonces) != 1 {
+		t.Fatalf("expired nonces should be pruned on Issue, %d left", len(store.nonces))
+	}
+
+	// 未过期的 nonce 达到上限时拒绝签发
+	for _, n := range []string{"a", "b"} {
+		if err := store.Issue(ctx, n, "msg", now.Add(time.Hour)); err != nil {
+			t.Fatalf("Issue(%s): %v", n, err)
+		}
+	}
+	if err := store.Issue(ctx, "c", "msg", now.Add(time.Hour)); err == nil {
+		t.Fatalf("expected error when the store is full")
+	}
+	if err := store.Consume(ctx, "a", "msg", now); err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if err := store.Issue(ctx, "c", "msg", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Issue after Consume: %v", err)
+	}
+}
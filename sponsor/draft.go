@@ -0,0 +1,182 @@
+package sponsor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// draft 交易草稿（输出保留为 map，以免丢失锁定条件等字段）
+type draft struct {
+	SignMode string                   `json:"sign_mode"`
+	Inputs   []draftInput             `json:"inputs"`
+	Outputs  []map[string]interface{} `json:"outputs"`
+	Metadata map[string]interface{}   `json:"metadata"`
+}
+
+// draftInput 草稿输入
+type draftInput struct {
+	TxHash          string `json:"tx_hash"`
+	OutputIndex     uint32 `json:"output_index"`
+	IsReferenceOnly bool   `json:"is_reference_only"`
+}
+
+func (in draftInput) outpoint() string {
+	return strings.TrimPrefix(in.TxHash, "0x") + ":" + strconv.FormatUint(uint64(in.OutputIndex), 10)
+}
+
+func parseDraft(raw []byte) (*draft, error) {
+	var d draft
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, fmt.Errorf("parse draft failed: %w", err)
+	}
+	if d.Metadata == nil {
+		d.Metadata = make(map[string]interface{})
+	}
+	return &d, nil
+}
+
+func (d *draft) marshal() ([]byte, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("marshal draft failed: %w", err)
+	}
+	return raw, nil
+}
+
+// toMap 转换为通用 map（供 wes_estimateFee 等接受草稿对象的接口使用）
+func (d *draft) toMap() map[string]interface{} {
+	raw, _ := json.Marshal(d)
+	var m map[string]interface{}
+	_ = json.Unmarshal(raw, &m)
+	return m
+}
+
+// outputOwner 输出 owner（小写 hex，无 0x 前缀）
+func outputOwner(out map[string]interface{}) string {
+	owner, _ := out["owner"].(string)
+	return strings.ToLower(strings.TrimPrefix(owner, "0x"))
+}
+
+// isNativeOutput 是否为原生币资产输出
+func isNativeOutput(out map[string]interface{}) bool {
+	if t, _ := out["type"].(string); t != "asset" {
+		return false
+	}
+	tokenID, _ := out["token_id"].(string)
+	return tokenID == ""
+}
+
+func normalizeHex(s string) string {
+	return strings.ToLower(strings.TrimPrefix(s, "0x"))
+}
+
+// spendableUTXO 可花费的 UTXO
+type spendableUTXO struct {
+	input   draftInput
+	amount  types.Amount
+	tokenID []byte
+}
+
+// querySpendable 查询地址当前可花费的 UTXO（跳过时间锁 / 高度锁未到期的输出）
+func querySpendable(ctx context.Context, c client.Client, address []byte) ([]spendableUTXO, error) {
+	addressBase58, err := utils.AddressBytesToBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("address conversion failed: %w", err)
+	}
+	result, err := c.Call(ctx, "wes_getUTXO", []interface{}{addressBase58})
+	if err != nil {
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
+	}
+	utxoMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid UTXO response format")
+	}
+	utxosArray, _ := utxoMap["utxos"].([]interface{})
+
+	var height uint64
+	if h, err := c.Call(ctx, "wes_blockNumber", []interface{}{}); err == nil {
+		height = utils.ParseUint(h)
+	}
+	now := time.Now().Unix()
+
+	utxos := make([]spendableUTXO, 0, len(utxosArray))
+	for _, item := range utxosArray {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if utils.ClassifyLock(utils.LockingConditionOf(m), height, now) != utils.LockSpendable {
+			continue
+		}
+		outpoint, _ := m["outpoint"].(string)
+		parts := strings.Split(outpoint, ":")
+		if len(parts) != 2 {
+			continue
+		}
+		outputIndex, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output index in outpoint %q: %w", outpoint, err)
+		}
+		amountStr, _ := m["amount"].(string)
+		amount, err := types.ParseAmount(amountStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UTXO amount %q: %w", amountStr, err)
+		}
+		var tokenID []byte
+		if tokenIDHex, _ := m["tokenID"].(string); tokenIDHex != "" {
+			if tokenID, err = hex.DecodeString(strings.TrimPrefix(tokenIDHex, "0x")); err != nil {
+				return nil, fmt.Errorf("invalid UTXO tokenID %q: %w", tokenIDHex, err)
+			}
+		}
+		utxos = append(utxos, spendableUTXO{
+			input:   draftInput{TxHash: parts[0], OutputIndex: uint32(outputIndex)},
+			amount:  amount,
+			tokenID: tokenID,
+		})
+	}
+	return utxos, nil
+}
+
+// signInputs 计算草稿签名哈希并对指定输入签名
+func signInputs(ctx context.Context, c client.Client, w wallet.Wallet, draftJSON []byte, indices []uint32) (*utils.DraftSighash, []Signature, error) {
+	sighash, err := utils.ComputeDraftSighash(ctx, c, draftJSON, indices, txcodec.SighashAll)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compute signature hash failed: %w", err)
+	}
+	if err := wallet.RequireSigner(w, draftJSON, sighash, indices); err != nil {
+		return nil, nil, err
+	}
+	priv := w.PrivateKey()
+	if priv == nil {
+		return nil, nil, fmt.Errorf("wallet private key is nil")
+	}
+	pubkey := "0x" + hex.EncodeToString(ethcrypto.CompressPubkey(&priv.PublicKey))
+
+	sigs := make([]Signature, 0, len(indices))
+	for _, idx := range indices {
+		sigBytes, err := w.SignHash(sighash.Hash(idx))
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign input %d failed: %w", idx, err)
+		}
+		sigs = append(sigs, Signature{
+			InputIndex:  idx,
+			SighashType: "SIGHASH_ALL",
+			Pubkey:      pubkey,
+			Signature:   "0x" + hex.EncodeToString(sigBytes),
+		})
+	}
+	return sighash, sigs, nil
+}
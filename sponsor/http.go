@@ -0,0 +1,114 @@
+package sponsor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxRequestBody 请求体大小上限
+const maxRequestBody = 1 << 20
+
+// errorBody HTTP 错误响应体
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// Handler 返回赞助方 HTTP 处理器
+//
+// 接受 POST JSON Request，成功返回 200 + Response；付款方签名无效返回 401，策略拒绝返回 403，
+// 请求格式错误返回 400，其他错误返回 500。错误响应体为 {"error": "..."}。
+func Handler(s *Sponsor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, errorBody{Error: "method not allowed"})
+			return
+		}
+		var req Request
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBody)).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody{Error: fmt.Sprintf("invalid request: %v", err)})
+			return
+		}
+		resp, err := s.Cosign(r.Context(), &req)
+		switch {
+		case errors.Is(err, ErrUnauthorizedPayer):
+			writeJSON(w, http.StatusUnauthorized, errorBody{Error: err.Error()})
+		case errors.Is(err, ErrPolicyRejected):
+			writeJSON(w, http.StatusForbidden, errorBody{Error: err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, errorBody{Error: err.Error()})
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// HTTPClient 通过 HTTP 请求远程赞助方联签（实现 Cosigner）
+type HTTPClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPClient 创建赞助方 HTTP 客户端（httpClient 为 nil 时使用 30 秒超时的默认客户端）
+func NewHTTPClient(endpoint string, httpClient *http.Client) *HTTPClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &HTTPClient{endpoint: endpoint, client: httpClient}
+}
+
+// Cosign 实现 Cosigner（403 响应返回的错误满足 errors.Is(err, ErrPolicyRejected)）
+func (c *HTTPClient) Cosign(ctx context.Context, req *Request) (*Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request failed: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("sponsor request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, maxRequestBody))
+	if err != nil {
+		return nil, fmt.Errorf("read sponsor response failed: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var e errorBody
+		_ = json.Unmarshal(respBody, &e)
+		if e.Error == "" {
+			e.Error = http.StatusText(httpResp.StatusCode)
+		}
+		switch httpResp.StatusCode {
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s", ErrPolicyRejected, strings.TrimPrefix(e.Error, ErrPolicyRejected.Error()+": "))
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("%w: %s", ErrUnauthorizedPayer, strings.TrimPrefix(e.Error, ErrUnauthorizedPayer.Error()+": "))
+		}
+		return nil, fmt.Errorf("sponsor returned HTTP %d: %s", httpResp.StatusCode, e.Error)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("decode sponsor response failed: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,246 @@
+package sponsor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// Payer 付款方：构建草稿、请求赞助方联签并提交交易
+type Payer struct {
+	client   client.Client
+	cosigner Cosigner
+	wallet   wallet.Wallet
+}
+
+// NewPayer 创建付款方
+func NewPayer(c client.Client, cosigner Cosigner, w wallet.Wallet) *Payer {
+	return &Payer{client: c, cosigner: cosigner, wallet: w}
+}
+
+// TransferRequest 代付转账请求（付款方为 Payer 的 Wallet）
+type TransferRequest struct {
+	To      types.Address // 接收方地址
+	Amount  types.Amount  // 转账金额
+	TokenID []byte        // 代币ID（必填，代付模式不支持原生币转账）
+}
+
+// TransferResult 代付转账结果
+type TransferResult struct {
+	TxHash  string
+	Fee     types.Amount // 赞助方承担的手续费
+	Sponsor []byte       // 赞助方地址
+	Success bool
+}
+
+// Transfer 构建代币转账草稿，请求赞助方联签后签名并提交
+func (p *Payer) Transfer(ctx context.Context, req *TransferRequest) (*TransferResult, error) {
+	draftJSON, inputIndices, err := BuildTransferDraft(ctx, p.client, p.wallet.Address(), req)
+	if err != nil {
+		return nil, err
+	}
+	sponsorReq, err := SignRequest(p.wallet, draftJSON)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.cosigner.Cosign(ctx, sponsorReq)
+	if err != nil {
+		return nil, fmt.Errorf("request sponsorship failed: %w", err)
+	}
+	txHash, err := Complete(ctx, p.client, p.wallet, draftJSON, inputIndices, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, _ := types.ParseAmount(resp.Fee)
+	sponsorAddr, _ := hex.DecodeString(normalizeHex(resp.Sponsor))
+	return &TransferResult{TxHash: txHash, Fee: fee, Sponsor: sponsorAddr, Success: true}, nil
+}
+
+// BuildTransferDraft 构建仅包含付款方代币输入与输出的转账草稿，返回草稿与付款方输入索引
+//
+// **输出**：
+// 0. 转账金额给接收方
+// 1. 代币找零给付款方（如有）
+func BuildTransferDraft(ctx context.Context, c client.Client, from []byte, req *TransferRequest) ([]byte, []uint32, error) {
+	if req.To.IsZero() {
+		return nil, nil, fmt.Errorf("to address is required")
+	}
+	if len(req.TokenID) == 0 {
+		return nil, nil, fmt.Errorf("token ID is required for sponsored transfers")
+	}
+	if req.Amount.IsZero() {
+		return nil, nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	utxos, err := querySpendable(ctx, c, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	fromHex := hex.EncodeToString(from)
+	d := &draft{
+		SignMode: "defer_sign",
+		Metadata: map[string]interface{}{"caller_address": fromHex},
+	}
+	var total types.Amount
+	var inputIndices []uint32
+	for _, u := range utxos {
+		if !bytes.Equal(u.tokenID, req.TokenID) {
+			continue
+		}
+		inputIndices = append(inputIndices, uint32(len(d.Inputs)))
+		d.Inputs = append(d.Inputs, u.input)
+		total = total.Add(u.amount)
+		if total.Cmp(req.Amount) >= 0 {
+			break
+		}
+	}
+	if total.Cmp(req.Amount) < 0 {
+		return nil, nil, fmt.Errorf("insufficient token balance: have %s, need %s", total, req.Amount)
+	}
+
+	tokenIDHex := hex.EncodeToString(req.TokenID)
+	d.Outputs = append(d.Outputs, map[string]interface{}{
+		"type":     "asset",
+		"owner":    hex.EncodeToString(req.To[:]),
+		"amount":   req.Amount.String(),
+		"token_id": tokenIDHex,
+	})
+	if change, _ := total.Sub(req.Amount); !change.IsZero() {
+		d.Outputs = append(d.Outputs, map[string]interface{}{
+			"type":     "asset",
+			"owner":    fromHex,
+			"amount":   change.String(),
+			"token_id": tokenIDHex,
+		})
+	}
+
+	draftJSON, err := d.marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	return draftJSON, inputIndices, nil
+}
+
+// Complete 校验赞助方响应，对付款方输入签名，finalize 并提交，返回交易哈希
+//
+// 校验内容：原草稿的输入、输出与 metadata 未被修改；赞助方仅追加了自己的原生币输出；
+// 节点按最终草稿构建的未签名交易与赞助方签名时一致。
+func Complete(ctx context.Context, c client.Client, w wallet.Wallet, original []byte, inputIndices []uint32, resp *Response) (string, error) {
+	if resp == nil {
+		return "", fmt.Errorf("sponsor response is nil")
+	}
+	if err := VerifyResponse(original, resp); err != nil {
+		return "", err
+	}
+
+	sighash, sigs, err := signInputs(ctx, c, w, resp.Draft, inputIndices)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(strings.TrimPrefix(sighash.UnsignedTx, "0x"), strings.TrimPrefix(resp.UnsignedTx, "0x")) {
+		return "", &utils.SighashMismatchError{Reason: "unsignedTx differs from the one signed by the sponsor"}
+	}
+
+	finalizeParams := map[string]interface{}{
+		"draft":      json.RawMessage(resp.Draft),
+		"unsignedTx": sighash.UnsignedTx,
+		"signatures": append(sigs, resp.Signatures...),
+	}
+	finalResult, err := c.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+
+	sendResult, err := c.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	return sendResult.TxHash, nil
+}
+
+// VerifyResponse 校验赞助方仅追加了手续费输入、原生币找零与 fee_payer 标记
+func VerifyResponse(original []byte, resp *Response) error {
+	orig, err := parseDraft(original)
+	if err != nil {
+		return err
+	}
+	final, err := parseDraft(resp.Draft)
+	if err != nil {
+		return fmt.Errorf("sponsor draft: %w", err)
+	}
+	sponsorHex := normalizeHex(resp.Sponsor)
+	if sponsorHex == "" {
+		return fmt.Errorf("sponsor address is missing")
+	}
+
+	// 1. 原输入保持不变，追加的输入与签名一一对应
+	if len(final.Inputs) <= len(orig.Inputs) {
+		return fmt.Errorf("sponsor did not add fee inputs")
+	}
+	for i, in := range orig.Inputs {
+		if final.Inputs[i] != in {
+			return fmt.Errorf("sponsor modified input %d", i)
+		}
+	}
+	added := len(final.Inputs) - len(orig.Inputs)
+	if len(resp.SponsorInputs) != added || len(resp.Signatures) != added {
+		return fmt.Errorf("sponsor inputs and signatures do not match added inputs")
+	}
+	for i, idx := range resp.SponsorInputs {
+		if int(idx) != len(orig.Inputs)+i || resp.Signatures[i].InputIndex != idx {
+			return fmt.Errorf("sponsor signature %d does not cover added input", i)
+		}
+	}
+
+	// 2. 原输出保持不变，追加的输出须为赞助方的原生币输出
+	if len(final.Outputs) < len(orig.Outputs) {
+		return fmt.Errorf("sponsor removed outputs")
+	}
+	for i, out := range orig.Outputs {
+		if !jsonEqual(final.Outputs[i], out) {
+			return fmt.Errorf("sponsor modified output %d", i)
+		}
+	}
+	for i, out := range final.Outputs[len(orig.Outputs):] {
+		if outputOwner(out) != sponsorHex || !isNativeOutput(out) {
+			return fmt.Errorf("sponsor added output %d not owned by sponsor", len(orig.Outputs)+i)
+		}
+	}
+
+	// 3. metadata 仅追加 fee_payer
+	feePayer, _ := final.Metadata["fee_payer"].(string)
+	if normalizeHex(feePayer) != sponsorHex {
+		return fmt.Errorf("draft fee_payer does not match sponsor")
+	}
+	delete(final.Metadata, "fee_payer")
+	if !jsonEqual(final.Metadata, orig.Metadata) || final.SignMode != orig.SignMode {
+		return fmt.Errorf("sponsor modified draft metadata")
+	}
+	return nil
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ra, rb)
+}
@@ -0,0 +1,91 @@
+package sponsor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+var (
+	// ErrPolicyRejected 草稿未通过赞助方策略检查
+	ErrPolicyRejected = errors.New("sponsorship rejected by policy")
+
+	// ErrUnauthorizedPayer 请求缺少付款方签名，或签名者与 Payer 不符
+	ErrUnauthorizedPayer = errors.New("sponsorship request is not signed by the payer")
+)
+
+// requestMessagePrefix 付款方签名消息的用途前缀（签名不能被挪作他用）
+const requestMessagePrefix = "WES sponsorship request:\n"
+
+// Request 代付请求（付款方 → 赞助方）
+type Request struct {
+	// Draft 付款方构建的交易草稿（仅包含付款方的输入与输出）
+	Draft json.RawMessage `json:"draft"`
+
+	// Payer 付款方地址（hex，须与草稿 metadata.caller_address 一致）
+	Payer string `json:"payer"`
+
+	// PayerSignature 付款方对草稿摘要的消息签名（65 字节可恢复签名 hex，见 SignRequest）
+	PayerSignature string `json:"payer_signature"`
+}
+
+// SignRequest 付款方签名草稿并生成代付请求
+//
+// 签名消息为 requestMessagePrefix || hex(SHA-256(draft))，经 wallet.SignMessageForChain（不绑定链 ID）签名；
+// 赞助方据此确认请求确实来自 Payer，而非冒用其地址。
+func SignRequest(w wallet.Wallet, draftJSON []byte) (*Request, error) {
+	sig, err := wallet.SignMessageForChain(w, "", requestMessage(draftJSON))
+	if err != nil {
+		return nil, fmt.Errorf("sign sponsorship request failed: %w", err)
+	}
+	return &Request{
+		Draft:          append(json.RawMessage(nil), draftJSON...),
+		Payer:          hex.EncodeToString(w.Address()),
+		PayerSignature: "0x" + hex.EncodeToString(sig),
+	}, nil
+}
+
+// requestMessage 付款方签名的消息
+func requestMessage(draftJSON []byte) []byte {
+	digest := sha256.Sum256(draftJSON)
+	return []byte(requestMessagePrefix + hex.EncodeToString(digest[:]))
+}
+
+// Response 代付响应（赞助方 → 付款方）
+type Response struct {
+	// Draft 追加赞助方手续费输入与找零输出后的草稿
+	Draft json.RawMessage `json:"draft"`
+
+	// UnsignedTx 节点按 Draft 构建的未签名交易（hex）
+	UnsignedTx string `json:"unsignedTx"`
+
+	// Sponsor 赞助方地址（hex）
+	Sponsor string `json:"sponsor"`
+
+	// SponsorInputs 赞助方追加的输入索引
+	SponsorInputs []uint32 `json:"sponsor_inputs"`
+
+	// Fee 赞助方承担的手续费（原生币，十进制字符串）
+	Fee string `json:"fee"`
+
+	// Signatures 赞助方对 SponsorInputs 的签名
+	Signatures []Signature `json:"signatures"`
+}
+
+// Signature 输入签名（字段与 wes_finalizeTransactionFromDraft 的 signatures 一致）
+type Signature struct {
+	InputIndex  uint32 `json:"input_index"`
+	SighashType string `json:"sighash_type"`
+	Pubkey      string `json:"pubkey"`
+	Signature   string `json:"signature"`
+}
+
+// Cosigner 代付联签接口（*Sponsor 与 *HTTPClient 均满足该接口）
+type Cosigner interface {
+	Cosign(ctx context.Context, req *Request) (*Response, error)
+}
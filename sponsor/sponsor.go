@@ -0,0 +1,433 @@
+// Package sponsor 提供手续费代付（第三方付费）
+//
+// 适用于只持有合约代币、没有原生币支付手续费的用户：交易草稿中付款方的代币输入由
+// 付款方签名，手续费由赞助方追加的原生币输入支付并由赞助方签名，双方各自找零。
+//
+// **流程**：
+//  1. 付款方构建草稿（Payer.Transfer 或 BuildTransferDraft），仅包含自己的输入与输出
+//  2. 付款方以 SignRequest 签名草稿，将 Request 发送给赞助方（进程内 *Sponsor 或 HTTPClient）
+//  3. 赞助方校验付款方签名及其输入归属（wes_getUTXO），执行策略检查，
+//     追加原生币输入与找零输出，对追加的输入签名后返回 Response
+//  4. 付款方校验赞助方仅追加了自己的输入与原生币输出，对自己的输入签名，finalize 并提交
+//
+// 所有签名均为 SIGHASH_ALL：赞助方签名承诺了付款方的输入与输出，付款方签名同样承诺了
+// 赞助方的输入与找零，任何一方事后修改草稿都会使对方签名失效。
+//
+// **手续费**：赞助方原生币输入总额与找零的差额即为手续费；草稿 metadata.fee_payer
+// 标记赞助方地址。付款方草稿不得包含原生币输出，否则其金额将由赞助方的输入支付。
+//
+// **使用示例**：
+//
+//	// 赞助方（服务端）
+//	s := sponsor.New(cli, sponsorWallet, sponsor.Options{Policy: &sponsor.BasicPolicy{MaxFee: types.NewAmount(1000)}})
+//	http.Handle("/sponsor", sponsor.Handler(s))
+//
+//	// 付款方（客户端）
+//	payer := sponsor.NewPayer(cli, sponsor.NewHTTPClient("https://sponsor.example.com/sponsor", nil), userWallet)
+//	result, err := payer.Transfer(ctx, &sponsor.TransferRequest{To: to, Amount: amount, TokenID: tokenID})
+package sponsor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// DefaultReserveTTL 已签出的手续费 UTXO 默认保留时长（期间不会分配给其他请求）
+const DefaultReserveTTL = 2 * time.Minute
+
+// ErrInsufficientFunds 赞助方原生币余额不足以支付手续费
+var ErrInsufficientFunds = errors.New("sponsor has insufficient native coin for fee")
+
+// Options 赞助方配置
+type Options struct {
+	// Fee 固定手续费（为零时调用 wes_estimateFee 估算）
+	Fee types.Amount
+
+	// Policy 策略检查（nil 表示仅执行内置检查）
+	Policy Policy
+
+	// ReserveTTL 手续费 UTXO 保留时长（0 表示 DefaultReserveTTL）
+	ReserveTTL time.Duration
+}
+
+// Output 草稿输出摘要
+type Output struct {
+	Type    string       // asset / state / resource
+	Owner   []byte       // 所有者地址
+	TokenID []byte       // 代币ID（nil 表示原生币或非资产输出）
+	Amount  types.Amount // 资产金额（非资产输出为零）
+}
+
+// Proposal 待赞助的草稿摘要（供 Policy 检查）
+type Proposal struct {
+	Payer   []byte       // 付款方地址
+	Inputs  int          // 付款方输入数
+	Outputs []Output     // 付款方输出
+	Fee     types.Amount // 赞助方将承担的手续费
+}
+
+// Policy 赞助策略
+type Policy interface {
+	// Check 返回非 nil 错误表示拒绝赞助
+	Check(ctx context.Context, p *Proposal) error
+}
+
+// PolicyFunc 函数形式的 Policy
+type PolicyFunc func(ctx context.Context, p *Proposal) error
+
+// Check 实现 Policy
+func (f PolicyFunc) Check(ctx context.Context, p *Proposal) error {
+	return f(ctx, p)
+}
+
+// BasicPolicy 常用策略（零值字段表示不限制）
+type BasicPolicy struct {
+	AllowedPayers [][]byte     // 允许的付款方地址
+	AllowedTokens [][]byte     // 允许转移的代币ID
+	MaxFee        types.Amount // 单笔手续费上限
+	MaxInputs     int          // 付款方输入数上限
+	MaxOutputs    int          // 付款方输出数上限
+}
+
+// Check 实现 Policy
+func (p *BasicPolicy) Check(ctx context.Context, prop *Proposal) error {
+	if len(p.AllowedPayers) > 0 && !containsBytes(p.AllowedPayers, prop.Payer) {
+		return fmt.Errorf("payer %x is not allowed", prop.Payer)
+	}
+	if !p.MaxFee.IsZero() && prop.Fee.Cmp(p.MaxFee) > 0 {
+		return fmt.Errorf("fee %s exceeds limit %s", prop.Fee, p.MaxFee)
+	}
+	if p.MaxInputs > 0 && prop.Inputs > p.MaxInputs {
+		return fmt.Errorf("too many inputs: %d > %d", prop.Inputs, p.MaxInputs)
+	}
+	if p.MaxOutputs > 0 && len(prop.Outputs) > p.MaxOutputs {
+		return fmt.Errorf("too many outputs: %d > %d", len(prop.Outputs), p.MaxOutputs)
+	}
+	if len(p.AllowedTokens) > 0 {
+		for _, out := range prop.Outputs {
+			if out.Type == "asset" && !containsBytes(p.AllowedTokens, out.TokenID) {
+				return fmt.Errorf("token %x is not allowed", out.TokenID)
+			}
+		}
+	}
+	return nil
+}
+
+// Sponsor 赞助方：为付款方草稿追加手续费输入并联签
+type Sponsor struct {
+	client client.Client
+	wallet wallet.Wallet
+	opts   Options
+	now    func() time.Time
+
+	mu       sync.Mutex
+	reserved map[string]time.Time // outpoint → 保留到期时间
+}
+
+// New 创建赞助方
+func New(c client.Client, w wallet.Wallet, opts Options) *Sponsor {
+	if opts.ReserveTTL <= 0 {
+		opts.ReserveTTL = DefaultReserveTTL
+	}
+	return &Sponsor{
+		client:   c,
+		wallet:   w,
+		opts:     opts,
+		now:      time.Now,
+		reserved: make(map[string]time.Time),
+	}
+}
+
+// Address 赞助方地址
+func (s *Sponsor) Address() []byte {
+	return s.wallet.Address()
+}
+
+// Cosign 检查付款方草稿，追加手续费输入与找零输出并对追加的输入签名
+//
+// 付款方签名缺失或无效时返回的错误满足 errors.Is(err, ErrUnauthorizedPayer)；
+// 策略拒绝（含付款方输入不属于付款方）时满足 errors.Is(err, ErrPolicyRejected)。
+func (s *Sponsor) Cosign(ctx context.Context, req *Request) (*Response, error) {
+	if req == nil || len(req.Draft) == 0 {
+		return nil, fmt.Errorf("draft is required")
+	}
+	d, err := parseDraft(req.Draft)
+	if err != nil {
+		return nil, err
+	}
+	sponsorHex := hex.EncodeToString(s.wallet.Address())
+
+	// 1. 内置检查：付款方签名、草稿结构与输入归属
+	proposal, err := s.propose(d, req.Payer, sponsorHex)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyPayerSignature(req, proposal.Payer); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPayerInputs(ctx, d, proposal.Payer); err != nil {
+		return nil, err
+	}
+
+	// 2. 选择手续费 UTXO 并确定手续费
+	selected, total, fee, err := s.selectFeeInputs(ctx, d, sponsorHex)
+	if err != nil {
+		return nil, err
+	}
+	proposal.Fee = fee
+
+	// 3. 策略检查
+	if s.opts.Policy != nil {
+		if err := s.opts.Policy.Check(ctx, proposal); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrPolicyRejected, err)
+		}
+	}
+
+	// 4. 追加输入与找零，签名
+	if !s.reserve(selected) {
+		return nil, fmt.Errorf("fee inputs reserved by a concurrent request, retry")
+	}
+	appendSponsorship(d, selected, total, fee, sponsorHex)
+	sponsorInputs := make([]uint32, len(selected))
+	for i := range selected {
+		sponsorInputs[i] = uint32(len(d.Inputs) - len(selected) + i)
+	}
+	draftJSON, err := d.marshal()
+	if err != nil {
+		s.release(selected)
+		return nil, err
+	}
+	sighash, sigs, err := signInputs(ctx, s.client, s.wallet, draftJSON, sponsorInputs)
+	if err != nil {
+		s.release(selected)
+		return nil, err
+	}
+
+	return &Response{
+		Draft:         draftJSON,
+		UnsignedTx:    sighash.UnsignedTx,
+		Sponsor:       sponsorHex,
+		SponsorInputs: sponsorInputs,
+		Fee:           fee.String(),
+		Signatures:    sigs,
+	}, nil
+}
+
+// propose 执行内置检查并生成草稿摘要
+func (s *Sponsor) propose(d *draft, payerHex, sponsorHex string) (*Proposal, error) {
+	payer, err := hex.DecodeString(normalizeHex(payerHex))
+	if err != nil || len(payer) == 0 {
+		return nil, fmt.Errorf("invalid payer address: %q", payerHex)
+	}
+	caller, _ := d.Metadata["caller_address"].(string)
+	if normalizeHex(caller) != hex.EncodeToString(payer) {
+		return nil, fmt.Errorf("draft caller_address does not match payer")
+	}
+	if _, ok := d.Metadata["fee_payer"]; ok {
+		return nil, fmt.Errorf("draft already has a fee payer")
+	}
+	if len(d.Inputs) == 0 {
+		return nil, fmt.Errorf("draft has no inputs")
+	}
+
+	proposal := &Proposal{Payer: payer, Inputs: len(d.Inputs)}
+	for i, out := range d.Outputs {
+		if outputOwner(out) == sponsorHex {
+			return nil, fmt.Errorf("%w: output %d is owned by the sponsor", ErrPolicyRejected, i)
+		}
+		if isNativeOutput(out) {
+			return nil, fmt.Errorf("%w: output %d transfers native coin", ErrPolicyRejected, i)
+		}
+		o, err := parseOutput(out)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		proposal.Outputs = append(proposal.Outputs, o)
+	}
+	return proposal, nil
+}
+
+// verifyPayerSignature 校验付款方对草稿的签名（见 SignRequest）
+func verifyPayerSignature(req *Request, payer []byte) error {
+	if req.PayerSignature == "" {
+		return fmt.Errorf("%w: payer_signature is required", ErrUnauthorizedPayer)
+	}
+	sig, err := hex.DecodeString(normalizeHex(req.PayerSignature))
+	if err != nil {
+		return fmt.Errorf("%w: invalid payer_signature: %v", ErrUnauthorizedPayer, err)
+	}
+	signer, err := wallet.RecoverMessageSigner("", requestMessage(req.Draft), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnauthorizedPayer, err)
+	}
+	if !bytes.Equal(signer, payer) {
+		return fmt.Errorf("%w: signed by %x, payer is %x", ErrUnauthorizedPayer, signer, payer)
+	}
+	return nil
+}
+
+// verifyPayerInputs 通过 wes_getUTXO 确认草稿的消费输入均为付款方当前可花费的 UTXO
+func (s *Sponsor) verifyPayerInputs(ctx context.Context, d *draft, payer []byte) error {
+	utxos, err := querySpendable(ctx, s.client, payer)
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]bool, len(utxos))
+	for _, u := range utxos {
+		owned[normalizeHex(u.input.outpoint())] = true
+	}
+	for i, in := range d.Inputs {
+		if in.IsReferenceOnly {
+			continue
+		}
+		if !owned[normalizeHex(in.outpoint())] {
+			return fmt.Errorf("%w: input %d (%s) is not a spendable utxo of the payer", ErrPolicyRejected, i, in.outpoint())
+		}
+	}
+	return nil
+}
+
+// selectFeeInputs 按金额从大到小选择赞助方的原生币 UTXO，返回所选 UTXO、总额与手续费
+func (s *Sponsor) selectFeeInputs(ctx context.Context, d *draft, sponsorHex string) ([]spendableUTXO, types.Amount, types.Amount, error) {
+	utxos, err := querySpendable(ctx, s.client, s.wallet.Address())
+	if err != nil {
+		return nil, types.Amount{}, types.Amount{}, err
+	}
+	used := make(map[string]bool, len(d.Inputs))
+	for _, in := range d.Inputs {
+		used[in.outpoint()] = true
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	var candidates []spendableUTXO
+	for _, u := range utxos {
+		key := u.input.outpoint()
+		if len(u.tokenID) > 0 || used[key] {
+			continue
+		}
+		if until, ok := s.reserved[key]; ok && now.Before(until) {
+			continue
+		}
+		candidates = append(candidates, u)
+	}
+	s.mu.Unlock()
+	if len(candidates) == 0 {
+		return nil, types.Amount{}, types.Amount{}, ErrInsufficientFunds
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].amount.Cmp(candidates[j].amount) > 0 })
+
+	fee := s.opts.Fee
+	if fee.IsZero() {
+		// 以单个手续费输入加找零估算
+		probe := *d
+		probe.Inputs = append(append([]draftInput{}, d.Inputs...), candidates[0].input)
+		probe.Outputs = append(append([]map[string]interface{}{}, d.Outputs...), changeOutput(sponsorHex, candidates[0].amount))
+		estimate, err := client.NewWESClientFromClient(s.client).EstimateFee(ctx, probe.toMap())
+		if err != nil {
+			return nil, types.Amount{}, types.Amount{}, fmt.Errorf("estimate fee failed: %w", err)
+		}
+		fee = types.NewAmount(estimate.EstimatedFee)
+	}
+
+	var selected []spendableUTXO
+	var total types.Amount
+	for _, u := range candidates {
+		selected = append(selected, u)
+		total = total.Add(u.amount)
+		if total.Cmp(fee) >= 0 {
+			return selected, total, fee, nil
+		}
+	}
+	return nil, types.Amount{}, types.Amount{}, ErrInsufficientFunds
+}
+
+// reserve 保留手续费 UTXO；已被其他请求保留时返回 false
+func (s *Sponsor) reserve(utxos []spendableUTXO) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for key, until := range s.reserved {
+		if !now.Before(until) {
+			delete(s.reserved, key)
+		}
+	}
+	for _, u := range utxos {
+		if _, ok := s.reserved[u.input.outpoint()]; ok {
+			return false
+		}
+	}
+	for _, u := range utxos {
+		s.reserved[u.input.outpoint()] = now.Add(s.opts.ReserveTTL)
+	}
+	return true
+}
+
+// release 释放保留的手续费 UTXO
+func (s *Sponsor) release(utxos []spendableUTXO) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range utxos {
+		delete(s.reserved, u.input.outpoint())
+	}
+}
+
+// appendSponsorship 向草稿追加手续费输入、找零输出与 fee_payer 标记
+func appendSponsorship(d *draft, selected []spendableUTXO, total, fee types.Amount, sponsorHex string) {
+	for _, u := range selected {
+		d.Inputs = append(d.Inputs, u.input)
+	}
+	if change, err := total.Sub(fee); err == nil && !change.IsZero() {
+		d.Outputs = append(d.Outputs, changeOutput(sponsorHex, change))
+	}
+	d.Metadata["fee_payer"] = sponsorHex
+}
+
+func changeOutput(ownerHex string, amount types.Amount) map[string]interface{} {
+	return map[string]interface{}{
+		"type":   "asset",
+		"owner":  ownerHex,
+		"amount": amount.String(),
+	}
+}
+
+// parseOutput 解析草稿输出摘要
+func parseOutput(out map[string]interface{}) (Output, error) {
+	o := Output{}
+	o.Type, _ = out["type"].(string)
+	owner, err := hex.DecodeString(outputOwner(out))
+	if err != nil {
+		return o, fmt.Errorf("invalid owner: %w", err)
+	}
+	o.Owner = owner
+	if tokenID, _ := out["token_id"].(string); tokenID != "" {
+		if o.TokenID, err = hex.DecodeString(normalizeHex(tokenID)); err != nil {
+			return o, fmt.Errorf("invalid token_id: %w", err)
+		}
+	}
+	if amount, _ := out["amount"].(string); amount != "" {
+		if o.Amount, err = types.ParseAmount(amount); err != nil {
+			return o, fmt.Errorf("invalid amount: %w", err)
+		}
+	}
+	return o, nil
+}
+
+func containsBytes(list [][]byte, b []byte) bool {
+	for _, item := range list {
+		if bytes.Equal(item, b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package sponsor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// sponsorMockClient 模拟节点：按地址返回 UTXO，按草稿计算签名哈希并记录 finalize 参数
+type sponsorMockClient struct {
+	utxos     map[string][]interface{} // Base58 地址 → UTXO 列表
+	fee       uint64
+	finalized []map[string]interface{}
+	sent      int
+}
+
+func (m *sponsorMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		address := params.([]interface{})[0].(string)
+		return map[string]interface{}{"utxos": m.utxos[address]}, nil
+	case "wes_blockNumber":
+		return "0x10", nil
+	case "wes_estimateFee":
+		return map[string]interface{}{"estimated_fee": float64(m.fee)}, nil
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_finalizeTransactionFromDraft":
+		m.finalized = append(m.finalized, params.(map[string]interface{}))
+		return map[string]interface{}{"tx": params.(map[string]interface{})["unsignedTx"]}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *sponsorMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent++
+	return &client.SendTxResult{TxHash: fmt.Sprintf("%064x", m.sent), Accepted: true}, nil
+}
+
+func (m *sponsorMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *sponsorMockClient) Close() error { return nil }
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+func base58(t *testing.T, address []byte) string {
+	t.Helper()
+	s, err := utils.AddressBytesToBase58(address)
+	if err != nil {
+		t.Fatalf("AddressBytesToBase58: %v", err)
+	}
+	return s
+}
+
+var testToken = bytes.Repeat([]byte{0x7a}, 32)
+
+// newTestNode 付款方持有代币 UTXO，赞助方持有原生币 UTXO
+func newTestNode(t *testing.T, user, sponsor wallet.Wallet) *sponsorMockClient {
+	return &sponsorMockClient{fee: 10, utxos: map[string][]interface{}{
+		base58(t, user.Address()): {
+			map[string]interface{}{"outpoint": strings.Repeat("aa", 32) + ":0", "amount": "70", "tokenID": hex.EncodeToString(testToken)},
+			map[string]interface{}{"outpoint": strings.Repeat("aa", 32) + ":1", "amount": "50", "tokenID": hex.EncodeToString(testToken)},
+		},
+		base58(t, sponsor.Address()): {
+			map[string]interface{}{"outpoint": strings.Repeat("bb", 32) + ":0", "amount": "5"},
+			map[string]interface{}{"outpoint": strings.Repeat("bb", 32) + ":1", "amount": "1000"},
+		},
+	}}
+}
+
+func TestSponsoredTransferOverHTTP(t *testing.T) {
+	user, sp, recipient := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	mc := newTestNode(t, user, sp)
+	server := httptest.NewServer(Handler(New(mc, sp, Options{Policy: &BasicPolicy{
+		AllowedTokens: [][]byte{testToken},
+		MaxFee:        types.NewAmount(100),
+	}})))
+	defer server.Close()
+
+	payer := NewPayer(mc, NewHTTPClient(server.URL, nil), user)
+	result, err := payer.Transfer(context.Background(), &TransferRequest{
+		To:      types.MustAddressFromBytes(recipient.Address()),
+		Amount:  types.NewAmount(100),
+		TokenID: testToken,
+	})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if result.Fee.String() != "10" || !bytes.Equal(result.Sponsor, sp.Address()) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	params := mc.finalized[0]
+	d, err := parseDraft(params["draft"].(json.RawMessage))
+	if err != nil {
+		t.Fatalf("parseDraft: %v", err)
+	}
+	// 付款方两个代币输入 + 赞助方最大的原生币输入
+	if len(d.Inputs) != 3 || d.Inputs[2].outpoint() != strings.Repeat("bb", 32)+":1" {
+		t.Fatalf("unexpected inputs %+v", d.Inputs)
+	}
+	var got []string
+	for _, out := range d.Outputs {
+		got = append(got, fmt.Sprintf("%s:%v", outputOwner(out)[:4], out["amount"]))
+	}
+	want := []string{
+		hex.EncodeToString(recipient.Address())[:4] + ":100",
+		hex.EncodeToString(user.Address())[:4] + ":20",
+		hex.EncodeToString(sp.Address())[:4] + ":990",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("outputs = %v, want %v", got, want)
+	}
+	if d.Metadata["fee_payer"] != hex.EncodeToString(sp.Address()) {
+		t.Fatalf("fee_payer = %v", d.Metadata["fee_payer"])
+	}
+
+	sigs := params["signatures"].([]Signature)
+	if len(sigs) != 3 || sigs[0].InputIndex != 0 || sigs[1].InputIndex != 1 || sigs[2].InputIndex != 2 {
+		t.Fatalf("unexpected signatures %+v", sigs)
+	}
+}
+
+func TestPolicyRejection(t *testing.T) {
+	user, sp, recipient := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	mc := newTestNode(t, user, sp)
+	server := httptest.NewServer(Handler(New(mc, sp, Options{Policy: &BasicPolicy{MaxFee: types.NewAmount(5)}})))
+	defer server.Close()
+
+	payer := NewPayer(mc, NewHTTPClient(server.URL, nil), user)
+	_, err := payer.Transfer(context.Background(), &TransferRequest{
+		To:      types.MustAddressFromBytes(recipient.Address()),
+		Amount:  types.NewAmount(10),
+		TokenID: testToken,
+	})
+	if !errors.Is(err, ErrPolicyRejected) || !strings.Contains(err.Error(), "exceeds limit") {
+		t.Fatalf("expected ErrPolicyRejected, got %v", err)
+	}
+
+	// 付款方草稿含原生币输出时拒绝（其金额将由赞助方输入支付）
+	s := New(mc, sp, Options{Fee: types.NewAmount(1)})
+	draftJSON, _ := json.Marshal(map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs":    []interface{}{map[string]interface{}{"tx_hash": strings.Repeat("aa", 32), "output_index": 0}},
+		"outputs":   []interface{}{map[string]interface{}{"type": "asset", "owner": hex.EncodeToString(user.Address()), "amount": "500"}},
+		"metadata":  map[string]interface{}{"caller_address": hex.EncodeToString(user.Address())},
+	})
+	req, _ := SignRequest(user, draftJSON)
+	if _, err := s.Cosign(context.Background(), req); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expected native output to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyResponseDetectsTampering(t *testing.T) {
+	user, sp, recipient := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	mc := newTestNode(t, user, sp)
+	ctx := context.Background()
+
+	original, _, err := BuildTransferDraft(ctx, mc, user.Address(), &TransferRequest{
+		To:      types.MustAddressFromBytes(recipient.Address()),
+		Amount:  types.NewAmount(100),
+		TokenID: testToken,
+	})
+	if err != nil {
+		t.Fatalf("BuildTransferDraft: %v", err)
+	}
+	s := New(mc, sp, Options{})
+	req, _ := SignRequest(user, original)
+	resp, err := s.Cosign(ctx, req)
+	if err != nil {
+		t.Fatalf("Cosign: %v", err)
+	}
+	if err := VerifyResponse(original, resp); err != nil {
+		t.Fatalf("VerifyResponse: %v", err)
+	}
+
+	// 赞助方将付款方找零改为自己的代币输出
+	d, _ := parseDraft(resp.Draft)
+	d.Outputs[1]["owner"] = hex.EncodeToString(sp.Address())
+	tampered := *resp
+	tampered.Draft, _ = d.marshal()
+	if err := VerifyResponse(original, &tampered); err == nil {
+		t.Fatalf("expected tampered output to be detected")
+	}
+
+	// 同一手续费 UTXO 在保留期内不会再次分配
+	if _, err := s.Cosign(ctx, req); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected reserved UTXOs to be skipped, got %v", err)
+	}
+}
+
+func TestCosignAuthenticatesPayer(t *testing.T) {
+	user, sp, recipient, attacker := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3), testWallet(t, 4)
+	mc := newTestNode(t, user, sp)
+	ctx := context.Background()
+	server := httptest.NewServer(Handler(New(mc, sp, Options{Policy: &BasicPolicy{AllowedPayers: [][]byte{user.Address()}}})))
+	defer server.Close()
+	remote := NewHTTPClient(server.URL, nil)
+
+	draftJSON, _, err := BuildTransferDraft(ctx, mc, user.Address(), &TransferRequest{
+		To:      types.MustAddressFromBytes(recipient.Address()),
+		Amount:  types.NewAmount(100),
+		TokenID: testToken,
+	})
+	if err != nil {
+		t.Fatalf("BuildTransferDraft: %v", err)
+	}
+
+	// 冒用白名单付款方地址：未签名或由他人签名
+	if _, err := remote.Cosign(ctx, &Request{Draft: draftJSON, Payer: hex.EncodeToString(user.Address())}); !errors.Is(err, ErrUnauthorizedPayer) {
+		t.Fatalf("expected ErrUnauthorizedPayer for unsigned request, got %v", err)
+	}
+	forged, _ := SignRequest(attacker, draftJSON)
+	forged.Payer = hex.EncodeToString(user.Address())
+	if _, err := remote.Cosign(ctx, forged); !errors.Is(err, ErrUnauthorizedPayer) {
+		t.Fatalf("expected ErrUnauthorizedPayer for forged signature, got %v", err)
+	}
+
+	// 签名后改写草稿
+	req, _ := SignRequest(user, draftJSON)
+	req.Draft = bytes.Replace(draftJSON, []byte(`"100"`), []byte(`"101"`), 1)
+	if _, err := remote.Cosign(ctx, req); !errors.Is(err, ErrUnauthorizedPayer) {
+		t.Fatalf("expected ErrUnauthorizedPayer for rewritten draft, got %v", err)
+	}
+
+	// 付款方签名了不属于自己的输入
+	d, _ := parseDraft(draftJSON)
+	d.Inputs[0].TxHash = strings.Repeat("cc", 32)
+	foreign, _ := d.marshal()
+	req, _ = SignRequest(user, foreign)
+	if _, err := remote.Cosign(ctx, req); !errors.Is(err, ErrPolicyRejected) || !strings.Contains(err.Error(), "not a spendable utxo of the payer") {
+		t.Fatalf("expected foreign input to be rejected, got %v", err)
+	}
+}
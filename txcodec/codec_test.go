@@ -0,0 +1,338 @@
+package txcodec
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func sampleTransaction() *Transaction {
+	owner := bytes.Repeat([]byte{0x11}, 20)
+	recipient := bytes.Repeat([]byte{0x22}, 20)
+
+	return &Transaction{
+		Version: 1,
+		Inputs: []*TxInput{
+			{
+				PreviousOutput: OutPoint{TxID: bytes.Repeat([]byte{0xaa}, 32), OutputIndex: 1},
+			},
+			{
+				PreviousOutput:  OutPoint{TxID: bytes.Repeat([]byte{0xbb}, 32)},
+				IsReferenceOnly: true,
+			},
+		},
+		Outputs: []*TxOutput{
+			{
+				Owner: recipient,
+				LockingConditions: []*LockingCondition{
+					{SingleKey: &SingleKeyLock{
+						RequiredAddressHash: recipient,
+						Algorithm:           SignatureAlgorithmECDSASecp256k1,
+						SighashType:         SighashAll,
+					}},
+				},
+				Asset: &AssetOutput{NativeCoin: &NativeCoinAsset{Amount: "1000"}},
+			},
+			{
+				Owner: owner,
+				LockingConditions: []*LockingCondition{
+					{TimeLock: &TimeLock{
+						UnlockTimestamp: 1700000000,
+						BaseLock: &LockingCondition{MultiKey: &MultiKeyLock{
+							RequiredSignatures: 2,
+							AuthorizedKeys:     [][]byte{{0x02, 0x01}, {0x03, 0x02}, {0x02, 0x03}},
+							Algorithm:          SignatureAlgorithmECDSASecp256k1,
+						}},
+					}},
+				},
+				Asset: &AssetOutput{ContractToken: &ContractTokenAsset{
+					ContractAddress: bytes.Repeat([]byte{0x33}, 20),
+					FungibleClassID: bytes.Repeat([]byte{0x44}, 32),
+					Amount:          "340282366920938463463374607431768211456",
+				}},
+			},
+			{
+				Owner: owner,
+				State: &StateOutput{
+					StateID:             []byte("state-1"),
+					StateVersion:        3,
+					ExecutionResultHash: bytes.Repeat([]byte{0x55}, 32),
+				},
+			},
+			{
+				Owner: owner,
+				Resource: &ResourceOutput{
+					Category:       ResourceCategoryExecutable,
+					ExecutableType: ExecutableTypeContract,
+					ContentHash:    bytes.Repeat([]byte{0x66}, 32),
+					Name:           "token.wasm",
+					Size:           4096,
+				},
+			},
+		},
+		Nonce:             7,
+		CreationTimestamp: 1700000123,
+		ChainID:           []byte("wes-testnet"),
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tx := sampleTransaction()
+
+	raw, err := Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := Decode(raw)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(tx, decoded) {
+		t.Errorf("Decode(Encode(tx)) mismatch\n got: %+v\nwant: %+v", decoded, tx)
+	}
+
+	if decoded.IsSigned() {
+		t.Errorf("IsSigned() = true for unsigned transaction")
+	}
+	if got := decoded.Outputs[1].TokenID(); !bytes.Equal(got, tx.Outputs[1].Asset.ContractToken.FungibleClassID) {
+		t.Errorf("TokenID() = %x", got)
+	}
+	if got := decoded.Outputs[0].Amount(); got != "1000" {
+		t.Errorf("Amount() = %q, want 1000", got)
+	}
+}
+
+func TestDecodeSignedTransaction(t *testing.T) {
+	tx := sampleTransaction()
+	tx.Inputs[0].UnlockingProof = &UnlockingProof{
+		SingleKey: &SingleKeyProof{
+			Signature:   bytes.Repeat([]byte{0x01}, 64),
+			PublicKey:   append([]byte{0x02}, bytes.Repeat([]byte{0x09}, 32)...),
+			Algorithm:   SignatureAlgorithmECDSASecp256k1,
+			SighashType: SighashAll,
+		},
+	}
+
+	txHex, err := EncodeHex(tx)
+	if err != nil {
+		t.Fatalf("EncodeHex() error = %v", err)
+	}
+
+	decoded, err := DecodeHex("0x" + txHex)
+	if err != nil {
+		t.Fatalf("DecodeHex() error = %v", err)
+	}
+
+	if !decoded.IsSigned() {
+		t.Errorf("IsSigned() = false, want true")
+	}
+	if !reflect.DeepEqual(tx.Inputs[0].UnlockingProof, decoded.Inputs[0].UnlockingProof) {
+		t.Errorf("unlocking proof mismatch: got %+v", decoded.Inputs[0].UnlockingProof.SingleKey)
+	}
+}
+
+func TestDecodeNestedProofs(t *testing.T) {
+	tx := sampleTransaction()
+	tx.Inputs[0].UnlockingProof = &UnlockingProof{
+		Time: &TimeProof{
+			CurrentTimestamp: 1700000500,
+			BaseProof: &UnlockingProof{MultiKey: &MultiKeyProof{
+				Signatures: []*MultiKeySignature{
+					{KeyIndex: 0, Signature: []byte{0x01}, SighashType: SighashAll},
+					{KeyIndex: 2, Signature: []byte{0x02}, SighashType: SighashAll},
+				},
+			}},
+		},
+	}
+
+	raw, err := Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := Decode(raw)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(tx.Inputs[0].UnlockingProof, decoded.Inputs[0].UnlockingProof) {
+		t.Errorf("nested proof mismatch")
+	}
+}
+
+// fullTransaction 覆盖所有锁定条件与解锁证明种类的交易
+func fullTransaction() *Transaction {
+	tx := sampleTransaction()
+	owner := tx.Outputs[1].Owner
+	tx.Outputs = append(tx.Outputs, &TxOutput{
+		Owner: owner,
+		LockingConditions: []*LockingCondition{
+			{Delegation: &DelegationLock{
+				OriginalOwner:        owner,
+				AllowedDelegates:     [][]byte{bytes.Repeat([]byte{0x77}, 20)},
+				AuthorizedOperations: []string{"transfer", "consume"},
+				ExpiryDurationBlocks: 100,
+				MaxValuePerOperation: 500,
+			}},
+			{Threshold: &ThresholdLock{
+				Threshold:             2,
+				TotalParties:          3,
+				PartyVerificationKeys: [][]byte{{0x01}, {0x02}, {0x03}},
+				SignatureScheme:       "BLS_THRESHOLD",
+				SecurityLevel:         128,
+			}},
+			{Contract: &ContractLock{
+				ContractAddress:    bytes.Repeat([]byte{0x88}, 20),
+				RequiredMethod:     "unlock",
+				ParameterSchema:    "{}",
+				StateRequirements:  []string{"active"},
+				MaxExecutionTimeMs: 1000,
+			}},
+			{HeightLock: &HeightLock{
+				UnlockHeight:       12345,
+				ConfirmationBlocks: 6,
+				BaseLock:           &LockingCondition{SingleKey: &SingleKeyLock{RequiredPublicKey: []byte{0x02, 0x09}}},
+			}},
+		},
+		Asset: &AssetOutput{NativeCoin: &NativeCoinAsset{Amount: "1"}},
+	})
+	tx.Inputs = append(tx.Inputs,
+		&TxInput{
+			PreviousOutput: OutPoint{TxID: bytes.Repeat([]byte{0xcc}, 32)},
+			Sequence:       1,
+			UnlockingProof: &UnlockingProof{Delegation: &DelegationProof{
+				DelegationTxID:        bytes.Repeat([]byte{0xcc}, 32),
+				DelegationOutputIndex: 4,
+				DelegateSignature:     []byte{0x30, 0x01},
+				OperationType:         "transfer",
+				ValueAmount:           10,
+				DelegateAddress:       bytes.Repeat([]byte{0x77}, 20),
+			}},
+		},
+		&TxInput{
+			PreviousOutput: OutPoint{TxID: bytes.Repeat([]byte{0xdd}, 32)},
+			UnlockingProof: &UnlockingProof{Threshold: &ThresholdProof{
+				Shares:            []*ThresholdShare{{PartyID: 1, SignatureShare: []byte{0x01}, VerificationKey: []byte{0x02}}},
+				CombinedSignature: []byte{0x03},
+				SignatureScheme:   "BLS_THRESHOLD",
+			}},
+		},
+		&TxInput{
+			PreviousOutput: OutPoint{TxID: bytes.Repeat([]byte{0xee}, 32)},
+			UnlockingProof: &UnlockingProof{Execution: &ExecutionProof{
+				ExecutionResultHash:  bytes.Repeat([]byte{0x99}, 32),
+				StateTransitionProof: []byte{0x01},
+				ExecutionTimeMs:      20,
+			}},
+		},
+		&TxInput{
+			PreviousOutput: OutPoint{TxID: bytes.Repeat([]byte{0xff}, 32)},
+			UnlockingProof: &UnlockingProof{Height: &HeightProof{
+				CurrentHeight: 12346,
+				BaseProof: &UnlockingProof{SingleKey: &SingleKeyProof{
+					Signature: []byte{0x30, 0x02},
+					PublicKey: []byte{0x02, 0x09},
+					Algorithm: SignatureAlgorithmECDSASecp256k1,
+				}},
+			}},
+		},
+	)
+	return tx
+}
+
+func TestEncodeDecodeRawRoundTrip(t *testing.T) {
+	raw, err := Encode(fullTransaction())
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := Decode(raw)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	again, err := Encode(decoded)
+	if err != nil {
+		t.Fatalf("Encode(Decode(raw)) error = %v", err)
+	}
+	if !bytes.Equal(again, raw) {
+		t.Errorf("Encode(Decode(raw)) != raw\n got: %x\nwant: %x", again, raw)
+	}
+}
+
+func TestDecodeRejectsUnknownFields(t *testing.T) {
+	tx := sampleTransaction()
+	raw, err := Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	out, err := encodeOutput(tx.Outputs[0])
+	if err != nil {
+		t.Fatalf("encodeOutput() error = %v", err)
+	}
+	lock := appendMessageField(nil, fieldLockSingleKey, appendVarintField(nil, 99, 1))
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "transaction", raw: appendVarintField(raw, 99, 42)},
+		{name: "output", raw: appendMessageField(nil, fieldTxOutputs, appendVarintField(out, 99, 1))},
+		{name: "locking condition", raw: appendMessageField(nil, fieldTxOutputs,
+			appendMessageField(nil, fieldOutputLockingConditions, lock))},
+		{name: "locking condition oneof", raw: appendMessageField(nil, fieldTxOutputs,
+			appendMessageField(nil, fieldOutputLockingConditions, appendVarintField(nil, 99, 1)))},
+		{name: "input", raw: appendMessageField(nil, fieldTxInputs, appendVarintField(nil, 99, 1))},
+		{name: "proof value wrapper", raw: appendMessageField(nil, fieldTxInputs,
+			appendMessageField(nil, fieldProofSingleKey, appendMessageField(nil, 1, appendVarintField(nil, 99, 1))))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.raw); !errors.Is(err, ErrUnknownField) {
+				t.Errorf("Decode() error = %v, want ErrUnknownField", err)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "empty", raw: nil},
+		{name: "truncated tag", raw: []byte{0x80}},
+		{name: "truncated bytes", raw: []byte{0x12, 0x05, 0x01}},
+		{name: "wrong wire type for inputs", raw: []byte{0x10, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.raw); err == nil {
+				t.Errorf("Decode() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := DecodeHex("zz"); err == nil {
+		t.Errorf("DecodeHex() error = nil for invalid hex")
+	}
+}
+
+func TestSighashTypeString(t *testing.T) {
+	tests := map[SighashType]string{
+		SighashAll:                          "SIGHASH_ALL",
+		SighashNone:                         "SIGHASH_NONE",
+		SighashSingle:                       "SIGHASH_SINGLE",
+		SighashAll | SighashAnyoneCanPay:    "SIGHASH_ALL_ANYONECANPAY",
+		SighashSingle | SighashAnyoneCanPay: "SIGHASH_SINGLE_ANYONECANPAY",
+		SighashType(9):                      "SIGHASH_UNKNOWN",
+	}
+	for st, want := range tests {
+		if got := st.String(); got != want {
+			t.Errorf("SighashType(%d).String() = %q, want %q", st, got, want)
+		}
+	}
+}
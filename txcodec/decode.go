@@ -0,0 +1,652 @@
+package txcodec
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrUnknownField 交易中含有 SDK 未识别的字段
+var ErrUnknownField = errors.New("unknown protobuf field")
+
+// DecodeHex 解码十六进制编码的交易（支持 0x 前缀）
+func DecodeHex(txHex string) (*Transaction, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(txHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode tx hex failed: %w", err)
+	}
+	return Decode(raw)
+}
+
+// Decode 解码 protobuf 线格式的交易
+//
+// **说明**：
+//   - 同时支持未签名交易（inputs 无解锁证明）与已签名交易
+//   - 出现未识别的字段时返回 ErrUnknownField：本地签名哈希与交易哈希依赖重新编码，
+//     丢弃未知字段会算出与节点不一致的哈希
+func Decode(raw []byte) (*Transaction, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty transaction bytes")
+	}
+
+	tx := &Transaction{}
+	err := forEachField(raw, func(f field) error {
+		var err error
+		switch f.num {
+		case fieldTxVersion:
+			tx.Version, err = f.uint32Val()
+		case fieldTxInputs:
+			var b []byte
+			if b, err = f.bytesVal(); err != nil {
+				return err
+			}
+			in, err := decodeInput(b)
+			if err != nil {
+				return fmt.Errorf("input %d: %w", len(tx.Inputs), err)
+			}
+			tx.Inputs = append(tx.Inputs, in)
+		case fieldTxOutputs:
+			var b []byte
+			if b, err = f.bytesVal(); err != nil {
+				return err
+			}
+			out, err := decodeOutput(b)
+			if err != nil {
+				return fmt.Errorf("output %d: %w", len(tx.Outputs), err)
+			}
+			tx.Outputs = append(tx.Outputs, out)
+		case fieldTxNonce:
+			tx.Nonce, err = f.varintVal()
+		case fieldTxCreationTimestamp:
+			tx.CreationTimestamp, err = f.varintVal()
+		case fieldTxChainID:
+			tx.ChainID, err = f.bytesVal()
+		default:
+			err = f.unknown()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction failed: %w", err)
+	}
+
+	return tx, nil
+}
+
+// decodeInput 解码交易输入
+func decodeInput(b []byte) (*TxInput, error) {
+	in := &TxInput{}
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.num {
+		case fieldInputPreviousOutput:
+			var ob []byte
+			if ob, err = f.bytesVal(); err != nil {
+				return err
+			}
+			err = forEachField(ob, func(of field) error {
+				var err error
+				switch of.num {
+				case fieldOutPointTxID:
+					in.PreviousOutput.TxID, err = of.bytesVal()
+				case fieldOutPointOutputIndex:
+					in.PreviousOutput.OutputIndex, err = of.uint32Val()
+				default:
+					err = of.unknown()
+				}
+				return err
+			})
+		case fieldInputIsReferenceOnly:
+			in.IsReferenceOnly, err = f.boolVal()
+		case fieldInputSequence:
+			in.Sequence, err = f.uint32Val()
+		default:
+			if isProofField(f.num) {
+				if in.UnlockingProof == nil {
+					in.UnlockingProof = &UnlockingProof{}
+				}
+				err = decodeProofField(f, in.UnlockingProof)
+			} else {
+				err = f.unknown()
+			}
+		}
+		return err
+	})
+	return in, err
+}
+
+// isProofField 判断字段是否属于解锁证明 oneof
+func isProofField(num protowire.Number) bool {
+	return num >= fieldProofSingleKey && num <= fieldProofHeight
+}
+
+// decodeProofField 解码解锁证明 oneof 中的某一种
+func decodeProofField(f field, p *UnlockingProof) error {
+	b, err := f.bytesVal()
+	if err != nil {
+		return err
+	}
+
+	switch f.num {
+	case fieldProofSingleKey:
+		p.SingleKey = &SingleKeyProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				p.SingleKey.Signature, err = f.valueWrapper()
+			case 2:
+				p.SingleKey.PublicKey, err = f.valueWrapper()
+			case 3:
+				var v int32
+				v, err = f.int32Val()
+				p.SingleKey.Algorithm = SignatureAlgorithm(v)
+			case 4:
+				var v int32
+				v, err = f.int32Val()
+				p.SingleKey.SighashType = SighashType(v)
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	case fieldProofMultiKey:
+		p.MultiKey = &MultiKeyProof{}
+		return forEachField(b, func(f field) error {
+			if f.num != 1 {
+				return f.unknown()
+			}
+			eb, err := f.bytesVal()
+			if err != nil {
+				return err
+			}
+			entry := &MultiKeySignature{}
+			err = forEachField(eb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					entry.KeyIndex, err = f.uint32Val()
+				case 2:
+					entry.Signature, err = f.valueWrapper()
+				case 3:
+					var v int32
+					v, err = f.int32Val()
+					entry.Algorithm = SignatureAlgorithm(v)
+				case 4:
+					var v int32
+					v, err = f.int32Val()
+					entry.SighashType = SighashType(v)
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+			p.MultiKey.Signatures = append(p.MultiKey.Signatures, entry)
+			return err
+		})
+	case fieldProofExecution:
+		p.Execution = &ExecutionProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				p.Execution.ExecutionResultHash, err = f.bytesVal()
+			case 2:
+				p.Execution.StateTransitionProof, err = f.bytesVal()
+			case 3:
+				p.Execution.ExecutionTimeMs, err = f.varintVal()
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	case fieldProofDelegation:
+		p.Delegation = &DelegationProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				p.Delegation.DelegationTxID, err = f.bytesVal()
+			case 2:
+				p.Delegation.DelegationOutputIndex, err = f.uint32Val()
+			case 3:
+				p.Delegation.DelegateSignature, err = f.valueWrapper()
+			case 4:
+				p.Delegation.OperationType, err = f.stringVal()
+			case 5:
+				p.Delegation.ValueAmount, err = f.varintVal()
+			case 6:
+				p.Delegation.DelegateAddress, err = f.bytesVal()
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	case fieldProofThreshold:
+		p.Threshold = &ThresholdProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				var sb []byte
+				if sb, err = f.bytesVal(); err != nil {
+					return err
+				}
+				share := &ThresholdShare{}
+				err = forEachField(sb, func(f field) error {
+					var err error
+					switch f.num {
+					case 1:
+						share.PartyID, err = f.uint32Val()
+					case 2:
+						share.SignatureShare, err = f.bytesVal()
+					case 3:
+						share.VerificationKey, err = f.bytesVal()
+					default:
+						err = f.unknown()
+					}
+					return err
+				})
+				p.Threshold.Shares = append(p.Threshold.Shares, share)
+			case 2:
+				p.Threshold.CombinedSignature, err = f.bytesVal()
+			case 3:
+				p.Threshold.SignatureScheme, err = f.stringVal()
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	case fieldProofTime:
+		p.Time = &TimeProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				p.Time.CurrentTimestamp, err = f.varintVal()
+			case 2:
+				p.Time.BaseProof, err = decodeWrappedProof(f)
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	case fieldProofHeight:
+		p.Height = &HeightProof{}
+		return forEachField(b, func(f field) error {
+			var err error
+			switch f.num {
+			case 1:
+				p.Height.CurrentHeight, err = f.varintVal()
+			case 2:
+				p.Height.BaseProof, err = decodeWrappedProof(f)
+			default:
+				err = f.unknown()
+			}
+			return err
+		})
+	}
+	return nil
+}
+
+// decodeWrappedProof 解码嵌套的基础证明（时间/高度锁内层）
+func decodeWrappedProof(f field) (*UnlockingProof, error) {
+	b, err := f.bytesVal()
+	if err != nil {
+		return nil, err
+	}
+	p := &UnlockingProof{}
+	err = forEachField(b, func(f field) error {
+		if !isProofField(f.num) {
+			return f.unknown()
+		}
+		return decodeProofField(f, p)
+	})
+	return p, err
+}
+
+// decodeOutput 解码交易输出
+func decodeOutput(b []byte) (*TxOutput, error) {
+	out := &TxOutput{}
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.num {
+		case fieldOutputOwner:
+			out.Owner, err = f.bytesVal()
+		case fieldOutputLockingConditions:
+			var lb []byte
+			if lb, err = f.bytesVal(); err != nil {
+				return err
+			}
+			lock, err := decodeLockingCondition(lb)
+			if err != nil {
+				return fmt.Errorf("locking condition %d: %w", len(out.LockingConditions), err)
+			}
+			out.LockingConditions = append(out.LockingConditions, lock)
+		case fieldOutputAsset:
+			var ab []byte
+			if ab, err = f.bytesVal(); err != nil {
+				return err
+			}
+			out.Asset, err = decodeAsset(ab)
+		case fieldOutputResource:
+			var rb []byte
+			if rb, err = f.bytesVal(); err != nil {
+				return err
+			}
+			out.Resource, err = decodeResource(rb)
+		case fieldOutputState:
+			var sb []byte
+			if sb, err = f.bytesVal(); err != nil {
+				return err
+			}
+			out.State, err = decodeState(sb)
+		default:
+			err = f.unknown()
+		}
+		return err
+	})
+	return out, err
+}
+
+// decodeAsset 解码资产输出
+func decodeAsset(b []byte) (*AssetOutput, error) {
+	asset := &AssetOutput{}
+	err := forEachField(b, func(f field) error {
+		switch f.num {
+		case fieldAssetNativeCoin:
+			nb, err := f.bytesVal()
+			if err != nil {
+				return err
+			}
+			asset.NativeCoin = &NativeCoinAsset{}
+			return forEachField(nb, func(f field) error {
+				if f.num != fieldNativeAmount {
+					return f.unknown()
+				}
+				var err error
+				asset.NativeCoin.Amount, err = f.stringVal()
+				return err
+			})
+		case fieldAssetContractToken:
+			tb, err := f.bytesVal()
+			if err != nil {
+				return err
+			}
+			asset.ContractToken = &ContractTokenAsset{}
+			return forEachField(tb, func(f field) error {
+				var err error
+				switch f.num {
+				case fieldTokenContractAddress:
+					asset.ContractToken.ContractAddress, err = f.bytesVal()
+				case fieldTokenFungibleClassID:
+					asset.ContractToken.FungibleClassID, err = f.bytesVal()
+				case fieldTokenNFTUniqueID:
+					asset.ContractToken.NFTUniqueID, err = f.bytesVal()
+				case fieldTokenAmount:
+					asset.ContractToken.Amount, err = f.stringVal()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		}
+		return f.unknown()
+	})
+	return asset, err
+}
+
+// decodeResource 解码资源输出
+func decodeResource(b []byte) (*ResourceOutput, error) {
+	res := &ResourceOutput{}
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.num {
+		case fieldResourceResource:
+			var rb []byte
+			if rb, err = f.bytesVal(); err != nil {
+				return err
+			}
+			err = forEachField(rb, func(f field) error {
+				var err error
+				switch f.num {
+				case fieldResCategory:
+					var v int32
+					v, err = f.int32Val()
+					res.Category = ResourceCategory(v)
+				case fieldResExecutableType:
+					var v int32
+					v, err = f.int32Val()
+					res.ExecutableType = ExecutableType(v)
+				case fieldResContentHash:
+					res.ContentHash, err = f.bytesVal()
+				case fieldResMimeType:
+					res.MimeType, err = f.stringVal()
+				case fieldResSize:
+					res.Size, err = f.varintVal()
+				case fieldResName:
+					res.Name, err = f.stringVal()
+				case fieldResVersion:
+					res.Version, err = f.stringVal()
+				case fieldResDescription:
+					res.Description, err = f.stringVal()
+				case fieldResCreatorAddress:
+					res.CreatorAddress, err = f.bytesVal()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldResourceCreationTimestamp:
+			res.CreationTimestamp, err = f.varintVal()
+		case fieldResourceIsImmutable:
+			res.IsImmutable, err = f.boolVal()
+		default:
+			err = f.unknown()
+		}
+		return err
+	})
+	return res, err
+}
+
+// decodeState 解码状态输出
+func decodeState(b []byte) (*StateOutput, error) {
+	state := &StateOutput{}
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.num {
+		case fieldStateID:
+			state.StateID, err = f.bytesVal()
+		case fieldStateVersion:
+			state.StateVersion, err = f.varintVal()
+		case fieldStateExecutionResultHash:
+			state.ExecutionResultHash, err = f.bytesVal()
+		case fieldStateParentStateHash:
+			state.ParentStateHash, err = f.bytesVal()
+		case fieldStateZKProof:
+			state.ZKProof, err = f.bytesVal()
+		default:
+			err = f.unknown()
+		}
+		return err
+	})
+	return state, err
+}
+
+// decodeLockingCondition 解码锁定条件
+func decodeLockingCondition(b []byte) (*LockingCondition, error) {
+	lock := &LockingCondition{}
+	err := forEachField(b, func(f field) error {
+		if f.num < fieldLockSingleKey || f.num > fieldLockHeight {
+			return f.unknown()
+		}
+		lb, err := f.bytesVal()
+		if err != nil {
+			return err
+		}
+
+		switch f.num {
+		case fieldLockSingleKey:
+			lock.SingleKey = &SingleKeyLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.SingleKey.RequiredAddressHash, err = f.bytesVal()
+				case 2:
+					lock.SingleKey.RequiredPublicKey, err = f.valueWrapper()
+				case 3:
+					var v int32
+					v, err = f.int32Val()
+					lock.SingleKey.Algorithm = SignatureAlgorithm(v)
+				case 4:
+					var v int32
+					v, err = f.int32Val()
+					lock.SingleKey.SighashType = SighashType(v)
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockMultiKey:
+			lock.MultiKey = &MultiKeyLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.MultiKey.RequiredSignatures, err = f.uint32Val()
+				case 2:
+					var key []byte
+					key, err = f.valueWrapper()
+					lock.MultiKey.AuthorizedKeys = append(lock.MultiKey.AuthorizedKeys, key)
+				case 3:
+					var v int32
+					v, err = f.int32Val()
+					lock.MultiKey.Algorithm = SignatureAlgorithm(v)
+				case 4:
+					lock.MultiKey.RequireOrderedSignatures, err = f.boolVal()
+				case 5:
+					var v int32
+					v, err = f.int32Val()
+					lock.MultiKey.SighashType = SighashType(v)
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockContract:
+			lock.Contract = &ContractLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.Contract.ContractAddress, err = f.bytesVal()
+				case 2:
+					lock.Contract.RequiredMethod, err = f.stringVal()
+				case 3:
+					lock.Contract.ParameterSchema, err = f.stringVal()
+				case 4:
+					var s string
+					s, err = f.stringVal()
+					lock.Contract.StateRequirements = append(lock.Contract.StateRequirements, s)
+				case 5:
+					lock.Contract.MaxExecutionTimeMs, err = f.varintVal()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockDelegation:
+			lock.Delegation = &DelegationLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.Delegation.OriginalOwner, err = f.bytesVal()
+				case 2:
+					var d []byte
+					d, err = f.bytesVal()
+					lock.Delegation.AllowedDelegates = append(lock.Delegation.AllowedDelegates, d)
+				case 3:
+					var s string
+					s, err = f.stringVal()
+					lock.Delegation.AuthorizedOperations = append(lock.Delegation.AuthorizedOperations, s)
+				case 4:
+					lock.Delegation.ExpiryDurationBlocks, err = f.varintVal()
+				case 5:
+					lock.Delegation.MaxValuePerOperation, err = f.varintVal()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockThreshold:
+			lock.Threshold = &ThresholdLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.Threshold.Threshold, err = f.uint32Val()
+				case 2:
+					lock.Threshold.TotalParties, err = f.uint32Val()
+				case 3:
+					var k []byte
+					k, err = f.bytesVal()
+					lock.Threshold.PartyVerificationKeys = append(lock.Threshold.PartyVerificationKeys, k)
+				case 4:
+					lock.Threshold.SignatureScheme, err = f.stringVal()
+				case 5:
+					lock.Threshold.SecurityLevel, err = f.uint32Val()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockTime:
+			lock.TimeLock = &TimeLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.TimeLock.UnlockTimestamp, err = f.varintVal()
+				case 2:
+					var bb []byte
+					if bb, err = f.bytesVal(); err != nil {
+						return err
+					}
+					lock.TimeLock.BaseLock, err = decodeLockingCondition(bb)
+				case 3:
+					lock.TimeLock.TimeSource, err = f.uint32Val()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		case fieldLockHeight:
+			lock.HeightLock = &HeightLock{}
+			return forEachField(lb, func(f field) error {
+				var err error
+				switch f.num {
+				case 1:
+					lock.HeightLock.UnlockHeight, err = f.varintVal()
+				case 2:
+					var bb []byte
+					if bb, err = f.bytesVal(); err != nil {
+						return err
+					}
+					lock.HeightLock.BaseLock, err = decodeLockingCondition(bb)
+				case 3:
+					lock.HeightLock.ConfirmationBlocks, err = f.uint32Val()
+				default:
+					err = f.unknown()
+				}
+				return err
+			})
+		}
+		return nil
+	})
+	return lock, err
+}
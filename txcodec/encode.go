@@ -0,0 +1,278 @@
+package txcodec
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Encode 将交易编码为 protobuf 线格式
+//
+// **说明**：
+//   - 字段按编号升序输出，零值省略（与 proto3 确定性序列化一致）
+//   - Decode 拒绝未识别字段，对节点的确定性编码 Decode→Encode 可逐字节还原
+func Encode(tx *Transaction) ([]byte, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+
+	var b []byte
+	b = appendVarintField(b, fieldTxVersion, uint64(tx.Version))
+	for i, in := range tx.Inputs {
+		if in == nil {
+			return nil, fmt.Errorf("input %d is nil", i)
+		}
+		b = appendMessageField(b, fieldTxInputs, encodeInput(in))
+	}
+	for i, out := range tx.Outputs {
+		if out == nil {
+			return nil, fmt.Errorf("output %d is nil", i)
+		}
+		ob, err := encodeOutput(out)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		b = appendMessageField(b, fieldTxOutputs, ob)
+	}
+	b = appendVarintField(b, fieldTxNonce, tx.Nonce)
+	b = appendVarintField(b, fieldTxCreationTimestamp, tx.CreationTimestamp)
+	b = appendBytesField(b, fieldTxChainID, tx.ChainID)
+	return b, nil
+}
+
+// EncodeHex 将交易编码为十六进制字符串（不带 0x 前缀）
+func EncodeHex(tx *Transaction) (string, error) {
+	b, err := Encode(tx)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// encodeInput 编码交易输入
+func encodeInput(in *TxInput) []byte {
+	var op []byte
+	op = appendBytesField(op, fieldOutPointTxID, in.PreviousOutput.TxID)
+	op = appendVarintField(op, fieldOutPointOutputIndex, uint64(in.PreviousOutput.OutputIndex))
+
+	var b []byte
+	b = appendMessageField(b, fieldInputPreviousOutput, op)
+	b = appendBoolField(b, fieldInputIsReferenceOnly, in.IsReferenceOnly)
+	b = appendVarintField(b, fieldInputSequence, uint64(in.Sequence))
+	return appendProof(b, in.UnlockingProof)
+}
+
+// appendProof 追加解锁证明 oneof（nil 表示未签名）
+func appendProof(b []byte, p *UnlockingProof) []byte {
+	if p == nil {
+		return b
+	}
+
+	switch {
+	case p.SingleKey != nil:
+		var m []byte
+		m = appendValueWrapper(m, 1, p.SingleKey.Signature)
+		m = appendValueWrapper(m, 2, p.SingleKey.PublicKey)
+		m = appendVarintField(m, 3, uint64(p.SingleKey.Algorithm))
+		m = appendVarintField(m, 4, uint64(p.SingleKey.SighashType))
+		b = appendMessageField(b, fieldProofSingleKey, m)
+	case p.MultiKey != nil:
+		var m []byte
+		for _, sig := range p.MultiKey.Signatures {
+			var e []byte
+			e = appendVarintField(e, 1, uint64(sig.KeyIndex))
+			e = appendValueWrapper(e, 2, sig.Signature)
+			e = appendVarintField(e, 3, uint64(sig.Algorithm))
+			e = appendVarintField(e, 4, uint64(sig.SighashType))
+			m = appendMessageField(m, 1, e)
+		}
+		b = appendMessageField(b, fieldProofMultiKey, m)
+	case p.Execution != nil:
+		var m []byte
+		m = appendBytesField(m, 1, p.Execution.ExecutionResultHash)
+		m = appendBytesField(m, 2, p.Execution.StateTransitionProof)
+		m = appendVarintField(m, 3, p.Execution.ExecutionTimeMs)
+		b = appendMessageField(b, fieldProofExecution, m)
+	case p.Delegation != nil:
+		var m []byte
+		m = appendBytesField(m, 1, p.Delegation.DelegationTxID)
+		m = appendVarintField(m, 2, uint64(p.Delegation.DelegationOutputIndex))
+		m = appendValueWrapper(m, 3, p.Delegation.DelegateSignature)
+		m = appendStringField(m, 4, p.Delegation.OperationType)
+		m = appendVarintField(m, 5, p.Delegation.ValueAmount)
+		m = appendBytesField(m, 6, p.Delegation.DelegateAddress)
+		b = appendMessageField(b, fieldProofDelegation, m)
+	case p.Threshold != nil:
+		var m []byte
+		for _, share := range p.Threshold.Shares {
+			var s []byte
+			s = appendVarintField(s, 1, uint64(share.PartyID))
+			s = appendBytesField(s, 2, share.SignatureShare)
+			s = appendBytesField(s, 3, share.VerificationKey)
+			m = appendMessageField(m, 1, s)
+		}
+		m = appendBytesField(m, 2, p.Threshold.CombinedSignature)
+		m = appendStringField(m, 3, p.Threshold.SignatureScheme)
+		b = appendMessageField(b, fieldProofThreshold, m)
+	case p.Time != nil:
+		var m []byte
+		m = appendVarintField(m, 1, p.Time.CurrentTimestamp)
+		if p.Time.BaseProof != nil {
+			m = appendMessageField(m, 2, appendProof(nil, p.Time.BaseProof))
+		}
+		b = appendMessageField(b, fieldProofTime, m)
+	case p.Height != nil:
+		var m []byte
+		m = appendVarintField(m, 1, p.Height.CurrentHeight)
+		if p.Height.BaseProof != nil {
+			m = appendMessageField(m, 2, appendProof(nil, p.Height.BaseProof))
+		}
+		b = appendMessageField(b, fieldProofHeight, m)
+	}
+	return b
+}
+
+// encodeOutput 编码交易输出
+func encodeOutput(out *TxOutput) ([]byte, error) {
+	var b []byte
+	b = appendBytesField(b, fieldOutputOwner, out.Owner)
+	for i, lock := range out.LockingConditions {
+		lb, err := encodeLockingCondition(lock)
+		if err != nil {
+			return nil, fmt.Errorf("locking condition %d: %w", i, err)
+		}
+		b = appendMessageField(b, fieldOutputLockingConditions, lb)
+	}
+
+	switch {
+	case out.Asset != nil:
+		var m []byte
+		if out.Asset.NativeCoin != nil {
+			m = appendMessageField(m, fieldAssetNativeCoin,
+				appendStringField(nil, fieldNativeAmount, out.Asset.NativeCoin.Amount))
+		} else if out.Asset.ContractToken != nil {
+			ct := out.Asset.ContractToken
+			var t []byte
+			t = appendBytesField(t, fieldTokenContractAddress, ct.ContractAddress)
+			t = appendBytesField(t, fieldTokenFungibleClassID, ct.FungibleClassID)
+			t = appendBytesField(t, fieldTokenNFTUniqueID, ct.NFTUniqueID)
+			t = appendStringField(t, fieldTokenAmount, ct.Amount)
+			m = appendMessageField(m, fieldAssetContractToken, t)
+		}
+		b = appendMessageField(b, fieldOutputAsset, m)
+	case out.Resource != nil:
+		r := out.Resource
+		var res []byte
+		res = appendVarintField(res, fieldResCategory, uint64(r.Category))
+		res = appendVarintField(res, fieldResExecutableType, uint64(r.ExecutableType))
+		res = appendBytesField(res, fieldResContentHash, r.ContentHash)
+		res = appendStringField(res, fieldResMimeType, r.MimeType)
+		res = appendVarintField(res, fieldResSize, r.Size)
+		res = appendStringField(res, fieldResName, r.Name)
+		res = appendStringField(res, fieldResVersion, r.Version)
+		res = appendStringField(res, fieldResDescription, r.Description)
+		res = appendBytesField(res, fieldResCreatorAddress, r.CreatorAddress)
+
+		var m []byte
+		m = appendMessageField(m, fieldResourceResource, res)
+		m = appendVarintField(m, fieldResourceCreationTimestamp, r.CreationTimestamp)
+		m = appendBoolField(m, fieldResourceIsImmutable, r.IsImmutable)
+		b = appendMessageField(b, fieldOutputResource, m)
+	case out.State != nil:
+		s := out.State
+		var m []byte
+		m = appendBytesField(m, fieldStateID, s.StateID)
+		m = appendVarintField(m, fieldStateVersion, s.StateVersion)
+		m = appendBytesField(m, fieldStateExecutionResultHash, s.ExecutionResultHash)
+		m = appendBytesField(m, fieldStateParentStateHash, s.ParentStateHash)
+		m = appendBytesField(m, fieldStateZKProof, s.ZKProof)
+		b = appendMessageField(b, fieldOutputState, m)
+	default:
+		return nil, fmt.Errorf("output has no content")
+	}
+	return b, nil
+}
+
+// encodeLockingCondition 编码锁定条件
+func encodeLockingCondition(lock *LockingCondition) ([]byte, error) {
+	if lock == nil {
+		return nil, fmt.Errorf("locking condition is nil")
+	}
+
+	var m []byte
+	switch {
+	case lock.SingleKey != nil:
+		l := lock.SingleKey
+		m = appendBytesField(m, 1, l.RequiredAddressHash)
+		m = appendValueWrapper(m, 2, l.RequiredPublicKey)
+		m = appendVarintField(m, 3, uint64(l.Algorithm))
+		m = appendVarintField(m, 4, uint64(l.SighashType))
+		return appendMessageField(nil, fieldLockSingleKey, m), nil
+	case lock.MultiKey != nil:
+		l := lock.MultiKey
+		m = appendVarintField(m, 1, uint64(l.RequiredSignatures))
+		for _, key := range l.AuthorizedKeys {
+			m = appendMessageField(m, 2, appendBytesField(nil, fieldValue, key))
+		}
+		m = appendVarintField(m, 3, uint64(l.Algorithm))
+		m = appendBoolField(m, 4, l.RequireOrderedSignatures)
+		m = appendVarintField(m, 5, uint64(l.SighashType))
+		return appendMessageField(nil, fieldLockMultiKey, m), nil
+	case lock.Contract != nil:
+		l := lock.Contract
+		m = appendBytesField(m, 1, l.ContractAddress)
+		m = appendStringField(m, 2, l.RequiredMethod)
+		m = appendStringField(m, 3, l.ParameterSchema)
+		for _, req := range l.StateRequirements {
+			m = appendMessageField(m, 4, []byte(req))
+		}
+		m = appendVarintField(m, 5, l.MaxExecutionTimeMs)
+		return appendMessageField(nil, fieldLockContract, m), nil
+	case lock.Delegation != nil:
+		l := lock.Delegation
+		m = appendBytesField(m, 1, l.OriginalOwner)
+		for _, d := range l.AllowedDelegates {
+			m = appendMessageField(m, 2, d)
+		}
+		for _, op := range l.AuthorizedOperations {
+			m = appendMessageField(m, 3, []byte(op))
+		}
+		m = appendVarintField(m, 4, l.ExpiryDurationBlocks)
+		m = appendVarintField(m, 5, l.MaxValuePerOperation)
+		return appendMessageField(nil, fieldLockDelegation, m), nil
+	case lock.Threshold != nil:
+		l := lock.Threshold
+		m = appendVarintField(m, 1, uint64(l.Threshold))
+		m = appendVarintField(m, 2, uint64(l.TotalParties))
+		for _, k := range l.PartyVerificationKeys {
+			m = appendMessageField(m, 3, k)
+		}
+		m = appendStringField(m, 4, l.SignatureScheme)
+		m = appendVarintField(m, 5, uint64(l.SecurityLevel))
+		return appendMessageField(nil, fieldLockThreshold, m), nil
+	case lock.TimeLock != nil:
+		l := lock.TimeLock
+		m = appendVarintField(m, 1, l.UnlockTimestamp)
+		if l.BaseLock != nil {
+			base, err := encodeLockingCondition(l.BaseLock)
+			if err != nil {
+				return nil, fmt.Errorf("time lock base: %w", err)
+			}
+			m = appendMessageField(m, 2, base)
+		}
+		m = appendVarintField(m, 3, uint64(l.TimeSource))
+		return appendMessageField(nil, fieldLockTime, m), nil
+	case lock.HeightLock != nil:
+		l := lock.HeightLock
+		m = appendVarintField(m, 1, l.UnlockHeight)
+		if l.BaseLock != nil {
+			base, err := encodeLockingCondition(l.BaseLock)
+			if err != nil {
+				return nil, fmt.Errorf("height lock base: %w", err)
+			}
+			m = appendMessageField(m, 2, base)
+		}
+		m = appendVarintField(m, 3, uint64(l.ConfirmationBlocks))
+		return appendMessageField(nil, fieldLockHeight, m), nil
+	}
+	return nil, fmt.Errorf("locking condition has no type")
+}
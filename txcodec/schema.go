@@ -0,0 +1,157 @@
+package txcodec
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// 字段编号（与节点 transaction.proto 对齐）
+//
+// **注意**：
+// - 修改任何编号都会导致与节点编码不兼容
+// - 解锁证明与锁定条件均为 oneof，编号按种类顺序排列
+// - 各锁定条件/证明消息内部的字段编号较为零散，直接写在 decode.go / encode.go 中
+const (
+	// Transaction
+	fieldTxVersion           protowire.Number = 1
+	fieldTxInputs            protowire.Number = 2
+	fieldTxOutputs           protowire.Number = 3
+	fieldTxNonce             protowire.Number = 4
+	fieldTxCreationTimestamp protowire.Number = 5
+	fieldTxChainID           protowire.Number = 6
+
+	// OutPoint
+	fieldOutPointTxID        protowire.Number = 1
+	fieldOutPointOutputIndex protowire.Number = 2
+
+	// TxInput（解锁证明 oneof 占用 10-16）
+	fieldInputPreviousOutput  protowire.Number = 1
+	fieldInputIsReferenceOnly protowire.Number = 2
+	fieldInputSequence        protowire.Number = 3
+	fieldProofSingleKey       protowire.Number = 10
+	fieldProofMultiKey        protowire.Number = 11
+	fieldProofExecution       protowire.Number = 12
+	fieldProofDelegation      protowire.Number = 13
+	fieldProofThreshold       protowire.Number = 14
+	fieldProofTime            protowire.Number = 15
+	fieldProofHeight          protowire.Number = 16
+
+	// TxOutput（输出内容 oneof 占用 10-12）
+	fieldOutputOwner             protowire.Number = 1
+	fieldOutputLockingConditions protowire.Number = 2
+	fieldOutputAsset             protowire.Number = 10
+	fieldOutputResource          protowire.Number = 11
+	fieldOutputState             protowire.Number = 12
+
+	// AssetOutput
+	fieldAssetNativeCoin    protowire.Number = 1
+	fieldAssetContractToken protowire.Number = 2
+
+	// NativeCoinAsset
+	fieldNativeAmount protowire.Number = 1
+
+	// ContractTokenAsset
+	fieldTokenContractAddress protowire.Number = 1
+	fieldTokenFungibleClassID protowire.Number = 2
+	fieldTokenNFTUniqueID     protowire.Number = 3
+	fieldTokenAmount          protowire.Number = 5
+
+	// ResourceOutput
+	fieldResourceResource          protowire.Number = 1
+	fieldResourceCreationTimestamp protowire.Number = 2
+	fieldResourceIsImmutable       protowire.Number = 4
+
+	// Resource
+	fieldResCategory       protowire.Number = 1
+	fieldResExecutableType protowire.Number = 2
+	fieldResContentHash    protowire.Number = 3
+	fieldResMimeType       protowire.Number = 4
+	fieldResSize           protowire.Number = 5
+	fieldResName           protowire.Number = 6
+	fieldResVersion        protowire.Number = 7
+	fieldResDescription    protowire.Number = 8
+	fieldResCreatorAddress protowire.Number = 9
+
+	// StateOutput
+	fieldStateID                  protowire.Number = 1
+	fieldStateVersion             protowire.Number = 2
+	fieldStateExecutionResultHash protowire.Number = 3
+	fieldStateParentStateHash     protowire.Number = 4
+	fieldStateZKProof             protowire.Number = 5
+
+	// LockingCondition（oneof 1-7）
+	fieldLockSingleKey  protowire.Number = 1
+	fieldLockMultiKey   protowire.Number = 2
+	fieldLockContract   protowire.Number = 3
+	fieldLockDelegation protowire.Number = 4
+	fieldLockThreshold  protowire.Number = 5
+	fieldLockTime       protowire.Number = 6
+	fieldLockHeight     protowire.Number = 7
+
+	// 通用：PublicKey / SignatureData 的 value 字段
+	fieldValue protowire.Number = 1
+)
+
+// SignatureAlgorithm 签名算法
+type SignatureAlgorithm int32
+
+const (
+	SignatureAlgorithmUnknown        SignatureAlgorithm = 0
+	SignatureAlgorithmECDSASecp256k1 SignatureAlgorithm = 1
+	SignatureAlgorithmEd25519        SignatureAlgorithm = 2
+)
+
+// String 返回与节点 JSON 一致的算法名称
+func (a SignatureAlgorithm) String() string {
+	switch a {
+	case SignatureAlgorithmECDSASecp256k1:
+		return "ECDSA_SECP256K1"
+	case SignatureAlgorithmEd25519:
+		return "ED25519"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SighashType 签名哈希类型（语义与 Bitcoin 一致）
+type SighashType int32
+
+const (
+	SighashAll          SighashType = 1
+	SighashNone         SighashType = 2
+	SighashSingle       SighashType = 3
+	SighashAnyoneCanPay SighashType = 0x80 // 标志位，可与上面三者组合
+)
+
+// String 返回与节点 JSON 一致的签名哈希类型名称
+func (t SighashType) String() string {
+	base := "SIGHASH_ALL"
+	switch t &^ SighashAnyoneCanPay {
+	case SighashNone:
+		base = "SIGHASH_NONE"
+	case SighashSingle:
+		base = "SIGHASH_SINGLE"
+	case SighashAll, 0:
+	default:
+		return "SIGHASH_UNKNOWN"
+	}
+	if t&SighashAnyoneCanPay != 0 {
+		return base + "_ANYONECANPAY"
+	}
+	return base
+}
+
+// ResourceCategory 资源类别
+type ResourceCategory int32
+
+const (
+	ResourceCategoryUnknown    ResourceCategory = 0
+	ResourceCategoryExecutable ResourceCategory = 1
+	ResourceCategoryStatic     ResourceCategory = 2
+)
+
+// ExecutableType 可执行资源类型
+type ExecutableType int32
+
+const (
+	ExecutableTypeUnknown  ExecutableType = 0
+	ExecutableTypeContract ExecutableType = 1
+	ExecutableTypeAIModel  ExecutableType = 2
+)
@@ -0,0 +1,127 @@
+package txcodec
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// ParseSighashType 解析节点 JSON 中的签名哈希类型名称（如 "SIGHASH_ALL"）
+func ParseSighashType(name string) (SighashType, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return SighashAll, nil
+	}
+
+	var t SighashType
+	if strings.HasSuffix(name, "_ANYONECANPAY") {
+		t |= SighashAnyoneCanPay
+		name = strings.TrimSuffix(name, "_ANYONECANPAY")
+	}
+
+	switch name {
+	case "SIGHASH_ALL":
+		t |= SighashAll
+	case "SIGHASH_NONE":
+		t |= SighashNone
+	case "SIGHASH_SINGLE":
+		t |= SighashSingle
+	default:
+		return 0, fmt.Errorf("unsupported sighash type: %s", name)
+	}
+	return t, nil
+}
+
+// ComputeSighash 在本地计算指定输入的签名哈希
+//
+// **算法**（与节点 SignatureHashCalculator 一致）：
+//  1. 复制交易并清除所有输入的解锁证明
+//  2. 按签名哈希类型裁剪：
+//     - SIGHASH_ALL：保留全部输入和输出
+//     - SIGHASH_NONE：不包含输出，其他输入的 sequence 置 0
+//     - SIGHASH_SINGLE：仅包含与输入同下标的输出，其他输入的 sequence 置 0
+//     - ANYONECANPAY 标志：仅包含当前签名的输入
+//  3. preimage = Encode(裁剪后交易) || uint32LE(inputIndex) || uint32LE(sighashType)
+//  4. hash = SHA-256(SHA-256(preimage))
+func ComputeSighash(tx *Transaction, inputIndex uint32, sighashType SighashType) ([]byte, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	if int(inputIndex) >= len(tx.Inputs) {
+		return nil, fmt.Errorf("input index %d out of range (inputs: %d)", inputIndex, len(tx.Inputs))
+	}
+	if sighashType.String() == "SIGHASH_UNKNOWN" {
+		return nil, fmt.Errorf("unsupported sighash type: %d", sighashType)
+	}
+
+	base := sighashType &^ SighashAnyoneCanPay
+	if base == 0 {
+		base = SighashAll
+	}
+
+	// 1. 浅拷贝交易，输入逐个复制以便清除证明 / 调整 sequence
+	stripped := *tx
+	stripped.Inputs = make([]*TxInput, len(tx.Inputs))
+	for i, in := range tx.Inputs {
+		cp := *in
+		cp.UnlockingProof = nil
+		if uint32(i) != inputIndex && base != SighashAll {
+			cp.Sequence = 0
+		}
+		stripped.Inputs[i] = &cp
+	}
+
+	// 2. 按类型裁剪输出
+	switch base {
+	case SighashNone:
+		stripped.Outputs = nil
+	case SighashSingle:
+		if int(inputIndex) >= len(tx.Outputs) {
+			return nil, fmt.Errorf("SIGHASH_SINGLE: no output at index %d", inputIndex)
+		}
+		stripped.Outputs = []*TxOutput{tx.Outputs[inputIndex]}
+	}
+
+	// 3. ANYONECANPAY 仅保留当前输入
+	if sighashType&SighashAnyoneCanPay != 0 {
+		stripped.Inputs = []*TxInput{stripped.Inputs[inputIndex]}
+	}
+
+	encoded, err := Encode(&stripped)
+	if err != nil {
+		return nil, fmt.Errorf("encode sighash preimage failed: %w", err)
+	}
+
+	preimage := make([]byte, 0, len(encoded)+8)
+	preimage = append(preimage, encoded...)
+	preimage = binary.LittleEndian.AppendUint32(preimage, inputIndex)
+	preimage = binary.LittleEndian.AppendUint32(preimage, uint32(sighashType))
+
+	first := sha256.Sum256(preimage)
+	second := sha256.Sum256(first[:])
+	return second[:], nil
+}
+
+// ComputeTxHash 在本地计算交易哈希
+//
+// hash = SHA-256(Encode(清除所有输入解锁证明后的交易))，与签名无关，
+// 可在广播前确定交易哈希并按哈希查询上链状态。
+func ComputeTxHash(tx *Transaction) ([]byte, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	stripped := *tx
+	stripped.Inputs = make([]*TxInput, len(tx.Inputs))
+	for i, in := range tx.Inputs {
+		cp := *in
+		cp.UnlockingProof = nil
+		stripped.Inputs[i] = &cp
+	}
+	encoded, err := Encode(&stripped)
+	if err != nil {
+		return nil, fmt.Errorf("encode transaction failed: %w", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return sum[:], nil
+}
@@ -0,0 +1,108 @@
+package txcodec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestComputeSighashTypes(t *testing.T) {
+	tx := sampleTransaction()
+	tx.Inputs[1].Sequence = 5
+
+	all, err := ComputeSighash(tx, 0, SighashAll)
+	if err != nil {
+		t.Fatalf("SIGHASH_ALL error = %v", err)
+	}
+
+	// SIGHASH_NONE 不覆盖输出：修改输出金额不影响哈希
+	none, _ := ComputeSighash(tx, 0, SighashNone)
+	modified := *tx
+	modified.Outputs = []*TxOutput{tx.Outputs[1]}
+	noneModified, _ := ComputeSighash(&modified, 0, SighashNone)
+	if !bytes.Equal(none, noneModified) {
+		t.Errorf("SIGHASH_NONE should not commit to outputs")
+	}
+	allModified, _ := ComputeSighash(&modified, 0, SighashAll)
+	if bytes.Equal(all, allModified) {
+		t.Errorf("SIGHASH_ALL should commit to outputs")
+	}
+
+	// ANYONECANPAY 不覆盖其他输入
+	acp, _ := ComputeSighash(tx, 0, SighashAll|SighashAnyoneCanPay)
+	fewerInputs := *tx
+	fewerInputs.Inputs = tx.Inputs[:1]
+	acpFewer, _ := ComputeSighash(&fewerInputs, 0, SighashAll|SighashAnyoneCanPay)
+	if !bytes.Equal(acp, acpFewer) {
+		t.Errorf("ANYONECANPAY should not commit to other inputs")
+	}
+
+	// SIGHASH_SINGLE 需要同下标输出
+	if _, err := ComputeSighash(tx, 1, SighashSingle); err != nil {
+		t.Errorf("SIGHASH_SINGLE input 1 error = %v", err)
+	}
+	tx.Outputs = tx.Outputs[:1]
+	if _, err := ComputeSighash(tx, 1, SighashSingle); err == nil {
+		t.Errorf("SIGHASH_SINGLE without matching output should fail")
+	}
+
+	if _, err := ComputeSighash(tx, 5, SighashAll); err == nil {
+		t.Errorf("out of range input index should fail")
+	}
+}
+
+func TestComputeSighashIgnoresProofs(t *testing.T) {
+	tx := sampleTransaction()
+	unsigned, err := ComputeSighash(tx, 0, SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeSighash() error = %v", err)
+	}
+
+	tx.Inputs[0].UnlockingProof = &UnlockingProof{SingleKey: &SingleKeyProof{Signature: []byte{0x01}}}
+	signed, err := ComputeSighash(tx, 0, SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeSighash() error = %v", err)
+	}
+	if !bytes.Equal(unsigned, signed) {
+		t.Errorf("sighash should not depend on unlocking proofs")
+	}
+}
+
+func TestComputeTxHash(t *testing.T) {
+	tx := sampleTransaction()
+	unsigned, err := ComputeTxHash(tx)
+	if err != nil || len(unsigned) != 32 {
+		t.Fatalf("ComputeTxHash() = %x, %v", unsigned, err)
+	}
+
+	tx.Inputs[0].UnlockingProof = &UnlockingProof{SingleKey: &SingleKeyProof{Signature: []byte{0x01}}}
+	signed, _ := ComputeTxHash(tx)
+	if !bytes.Equal(unsigned, signed) {
+		t.Errorf("tx hash should not depend on unlocking proofs")
+	}
+	if tx.Inputs[0].UnlockingProof == nil {
+		t.Errorf("ComputeTxHash() should not modify the transaction")
+	}
+
+	tx.Nonce++
+	if changed, _ := ComputeTxHash(tx); bytes.Equal(unsigned, changed) {
+		t.Errorf("tx hash should commit to the nonce")
+	}
+}
+
+func TestParseSighashType(t *testing.T) {
+	tests := map[string]SighashType{
+		"":                            SighashAll,
+		"SIGHASH_ALL":                 SighashAll,
+		"sighash_none":                SighashNone,
+		"SIGHASH_SINGLE_ANYONECANPAY": SighashSingle | SighashAnyoneCanPay,
+	}
+	for name, want := range tests {
+		got, err := ParseSighashType(name)
+		if err != nil || got != want {
+			t.Errorf("ParseSighashType(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseSighashType("SIGHASH_FOO"); err == nil {
+		t.Errorf("ParseSighashType() error = nil for unknown type")
+	}
+}
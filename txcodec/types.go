@@ -0,0 +1,270 @@
+// Package txcodec 在 SDK 本地编解码 WES 交易的 protobuf 线格式
+//
+// **设计目的**：
+// - 无需回调节点即可检查 `wes_computeSignatureHashFromDraft` 返回的 unsignedTx
+// - 签名前核对输入、输出、锁定条件与预期一致，而不是盲目信任节点
+//
+// **说明**：
+// - SDK 不依赖 WES 的 pb 包，字段编号在 schema.go 中按节点 transaction.proto 对齐
+// - 解码遇到未识别的字段直接报错，保证 Decode→Encode 逐字节还原，本地计算的哈希与节点一致
+package txcodec
+
+// Transaction 交易（未签名或已签名）
+type Transaction struct {
+	Version           uint32
+	Inputs            []*TxInput
+	Outputs           []*TxOutput
+	Nonce             uint64
+	CreationTimestamp uint64
+	ChainID           []byte
+}
+
+// OutPoint UTXO 引用点
+type OutPoint struct {
+	TxID        []byte // 32 字节交易哈希
+	OutputIndex uint32
+}
+
+// TxInput 交易输入
+type TxInput struct {
+	PreviousOutput  OutPoint
+	IsReferenceOnly bool
+	Sequence        uint32
+	UnlockingProof  *UnlockingProof // nil 表示未签名
+}
+
+// TxOutput 交易输出
+type TxOutput struct {
+	Owner             []byte // 20 字节地址
+	LockingConditions []*LockingCondition
+
+	// 输出内容（三选一）
+	Asset    *AssetOutput
+	Resource *ResourceOutput
+	State    *StateOutput
+}
+
+// AssetOutput 资产输出（原生币或合约代币二选一）
+type AssetOutput struct {
+	NativeCoin    *NativeCoinAsset
+	ContractToken *ContractTokenAsset
+}
+
+// NativeCoinAsset 原生币
+type NativeCoinAsset struct {
+	Amount string // 十进制字符串
+}
+
+// ContractTokenAsset 合约代币
+type ContractTokenAsset struct {
+	ContractAddress []byte
+	FungibleClassID []byte // 同质化代币 ID
+	NFTUniqueID     []byte // 非同质化代币 ID
+	Amount          string // 十进制字符串
+}
+
+// ResourceOutput 资源输出
+type ResourceOutput struct {
+	Category          ResourceCategory
+	ExecutableType    ExecutableType
+	ContentHash       []byte // 32 字节
+	MimeType          string
+	Size              uint64
+	Name              string
+	Version           string
+	Description       string
+	CreatorAddress    []byte
+	CreationTimestamp uint64
+	IsImmutable       bool
+}
+
+// StateOutput 状态输出
+type StateOutput struct {
+	StateID             []byte
+	StateVersion        uint64
+	ExecutionResultHash []byte
+	ParentStateHash     []byte
+	ZKProof             []byte // ZKStateProof 原始编码（SDK 不展开）
+}
+
+// LockingCondition 锁定条件（七选一）
+type LockingCondition struct {
+	SingleKey  *SingleKeyLock
+	MultiKey   *MultiKeyLock
+	Contract   *ContractLock
+	Delegation *DelegationLock
+	Threshold  *ThresholdLock
+	TimeLock   *TimeLock
+	HeightLock *HeightLock
+}
+
+// SingleKeyLock 单密钥锁
+type SingleKeyLock struct {
+	RequiredAddressHash []byte // 与 RequiredPublicKey 二选一
+	RequiredPublicKey   []byte
+	Algorithm           SignatureAlgorithm
+	SighashType         SighashType
+}
+
+// MultiKeyLock 多密钥锁（M-of-N）
+type MultiKeyLock struct {
+	RequiredSignatures       uint32
+	AuthorizedKeys           [][]byte
+	Algorithm                SignatureAlgorithm
+	RequireOrderedSignatures bool
+	SighashType              SighashType
+}
+
+// ContractLock 合约锁
+type ContractLock struct {
+	ContractAddress    []byte
+	RequiredMethod     string
+	ParameterSchema    string
+	StateRequirements  []string
+	MaxExecutionTimeMs uint64
+}
+
+// DelegationLock 委托锁
+type DelegationLock struct {
+	OriginalOwner        []byte
+	AllowedDelegates     [][]byte
+	AuthorizedOperations []string
+	ExpiryDurationBlocks uint64
+	MaxValuePerOperation uint64
+}
+
+// ThresholdLock 门限锁
+type ThresholdLock struct {
+	Threshold             uint32
+	TotalParties          uint32
+	PartyVerificationKeys [][]byte
+	SignatureScheme       string
+	SecurityLevel         uint32
+}
+
+// TimeLock 时间锁
+type TimeLock struct {
+	UnlockTimestamp uint64
+	BaseLock        *LockingCondition
+	TimeSource      uint32
+}
+
+// HeightLock 高度锁
+type HeightLock struct {
+	UnlockHeight       uint64
+	BaseLock           *LockingCondition
+	ConfirmationBlocks uint32
+}
+
+// UnlockingProof 解锁证明（七选一，与 LockingCondition 一一对应）
+type UnlockingProof struct {
+	SingleKey  *SingleKeyProof
+	MultiKey   *MultiKeyProof
+	Execution  *ExecutionProof
+	Delegation *DelegationProof
+	Threshold  *ThresholdProof
+	Time       *TimeProof
+	Height     *HeightProof
+}
+
+// SingleKeyProof 单密钥证明
+type SingleKeyProof struct {
+	Signature   []byte
+	PublicKey   []byte // 33 字节压缩公钥
+	Algorithm   SignatureAlgorithm
+	SighashType SighashType
+}
+
+// MultiKeyProof 多密钥证明
+type MultiKeyProof struct {
+	Signatures []*MultiKeySignature
+}
+
+// MultiKeySignature 多密钥证明中的单个签名
+type MultiKeySignature struct {
+	KeyIndex    uint32 // 对应 MultiKeyLock.AuthorizedKeys 的下标
+	Signature   []byte
+	Algorithm   SignatureAlgorithm
+	SighashType SighashType
+}
+
+// ExecutionProof 合约执行证明
+type ExecutionProof struct {
+	ExecutionResultHash  []byte
+	StateTransitionProof []byte
+	ExecutionTimeMs      uint64
+}
+
+// DelegationProof 委托证明
+type DelegationProof struct {
+	DelegationTxID        []byte
+	DelegationOutputIndex uint32
+	DelegateSignature     []byte
+	OperationType         string
+	ValueAmount           uint64
+	DelegateAddress       []byte
+}
+
+// ThresholdProof 门限证明
+type ThresholdProof struct {
+	Shares            []*ThresholdShare
+	CombinedSignature []byte
+	SignatureScheme   string
+}
+
+// ThresholdShare 门限签名分片
+type ThresholdShare struct {
+	PartyID         uint32
+	SignatureShare  []byte
+	VerificationKey []byte
+}
+
+// TimeProof 时间锁证明
+type TimeProof struct {
+	CurrentTimestamp uint64
+	BaseProof        *UnlockingProof
+}
+
+// HeightProof 高度锁证明
+type HeightProof struct {
+	CurrentHeight uint64
+	BaseProof     *UnlockingProof
+}
+
+// IsSigned 判断交易是否所有消费型输入都已附带解锁证明
+func (tx *Transaction) IsSigned() bool {
+	for _, in := range tx.Inputs {
+		if in.IsReferenceOnly {
+			continue
+		}
+		if in.UnlockingProof == nil {
+			return false
+		}
+	}
+	return len(tx.Inputs) > 0
+}
+
+// TokenID 返回输出中的代币 ID（原生币或非资产输出返回 nil）
+func (o *TxOutput) TokenID() []byte {
+	if o.Asset == nil || o.Asset.ContractToken == nil {
+		return nil
+	}
+	if len(o.Asset.ContractToken.FungibleClassID) > 0 {
+		return o.Asset.ContractToken.FungibleClassID
+	}
+	return o.Asset.ContractToken.NFTUniqueID
+}
+
+// Amount 返回资产输出的金额字符串（非资产输出返回空字符串）
+func (o *TxOutput) Amount() string {
+	if o.Asset == nil {
+		return ""
+	}
+	if o.Asset.NativeCoin != nil {
+		return o.Asset.NativeCoin.Amount
+	}
+	if o.Asset.ContractToken != nil {
+		return o.Asset.ContractToken.Amount
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package txcodec
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// field 一个已切分的 protobuf 字段
+type field struct {
+	num protowire.Number
+	typ protowire.Type
+	v   uint64 // varint / fixed32 / fixed64 的值
+	b   []byte // length-delimited 的内容
+	raw []byte // 包含 tag 在内的原始编码
+}
+
+// forEachField 依次切分 b 中的每个字段并回调 fn
+func forEachField(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := field{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			f.v = uint64(v)
+		case protowire.Fixed64Type:
+			f.v, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+		f.raw = start[:len(start)-len(b)]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wireTypeError 字段线类型与预期不符
+func (f field) wireTypeError(want protowire.Type) error {
+	return fmt.Errorf("field %d: unexpected wire type %d, want %d", f.num, f.typ, want)
+}
+
+// unknown 返回未识别字段错误
+func (f field) unknown() error {
+	return fmt.Errorf("field %d: %w", f.num, ErrUnknownField)
+}
+
+// bytesVal 读取 bytes 字段（返回副本）
+func (f field) bytesVal() ([]byte, error) {
+	if f.typ != protowire.BytesType {
+		return nil, f.wireTypeError(protowire.BytesType)
+	}
+	return append([]byte(nil), f.b...), nil
+}
+
+// stringVal 读取 string 字段
+func (f field) stringVal() (string, error) {
+	if f.typ != protowire.BytesType {
+		return "", f.wireTypeError(protowire.BytesType)
+	}
+	return string(f.b), nil
+}
+
+// varintVal 读取 uint64 字段
+func (f field) varintVal() (uint64, error) {
+	if f.typ != protowire.VarintType {
+		return 0, f.wireTypeError(protowire.VarintType)
+	}
+	return f.v, nil
+}
+
+// uint32Val 读取 uint32 字段
+func (f field) uint32Val() (uint32, error) {
+	v, err := f.varintVal()
+	return uint32(v), err
+}
+
+// int32Val 读取 int32 / enum 字段
+func (f field) int32Val() (int32, error) {
+	v, err := f.varintVal()
+	return int32(v), err
+}
+
+// boolVal 读取 bool 字段
+func (f field) boolVal() (bool, error) {
+	v, err := f.varintVal()
+	return v != 0, err
+}
+
+// valueWrapper 读取 { bytes value = 1; } 形式的包装消息（PublicKey / SignatureData）
+func (f field) valueWrapper() ([]byte, error) {
+	b, err := f.bytesVal()
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	err = forEachField(b, func(f field) error {
+		if f.num != fieldValue {
+			return f.unknown()
+		}
+		var err error
+		value, err = f.bytesVal()
+		return err
+	})
+	return value, err
+}
+
+// ========== 编码辅助 ==========
+
+// appendBytesField 追加 bytes 字段（空值省略，与 proto3 语义一致）
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// appendStringField 追加 string 字段（空值省略）
+func appendStringField(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+// appendVarintField 追加 varint 字段（零值省略）
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBoolField 追加 bool 字段（false 省略）
+func appendBoolField(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	return appendVarintField(b, num, 1)
+}
+
+// appendMessageField 追加嵌套消息字段（即使内容为空也保留，用于表达 oneof 选择）
+func appendMessageField(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// appendValueWrapper 追加 { bytes value = 1; } 形式的包装消息
+func appendValueWrapper(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendMessageField(b, num, appendBytesField(nil, fieldValue, v))
+}
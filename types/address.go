@@ -0,0 +1,196 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// AddressLength 地址长度（HASH160(compressed_pubkey)）
+const AddressLength = 20
+
+// AddressVersion WES P2PKH 地址的 Base58Check 版本字节
+const AddressVersion byte = 0x1C
+
+var (
+	// ErrInvalidAddress 地址格式无法识别或长度不正确
+	ErrInvalidAddress = errors.New("invalid address")
+
+	// ErrInvalidChecksum Base58Check 校验和不匹配
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+)
+
+// Address 20 字节地址
+//
+// **设计说明**：
+//   - 定长数组，值语义，可比较、可作为 map 键；零值 Address{} 表示未设置
+//   - String 返回规范的 Base58Check 编码（与节点 API 一致）
+//   - ParseAddress 自动识别 Base58Check、"0x" 十六进制、无前缀十六进制与 Base64（节点返回的 owner 字段）
+//   - JSON / 文本序列化为 Base58Check 字符串，零值序列化为空字符串
+type Address [AddressLength]byte
+
+// NewAddressFromBytes 从 20 字节切片创建地址
+func NewAddressFromBytes(b []byte) (Address, error) {
+	var a Address
+	if len(b) != AddressLength {
+		return a, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidAddress, AddressLength, len(b))
+	}
+	copy(a[:], b)
+	return a, nil
+}
+
+// MustAddressFromBytes 从 20 字节切片创建地址，失败时 panic（用于常量初始化与测试）
+func MustAddressFromBytes(b []byte) Address {
+	a, err := NewAddressFromBytes(b)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// ParseAddress 解析地址字符串，自动识别格式：
+//   - 40 位十六进制（可带 "0x" 前缀）
+//   - Base64（节点返回的 owner 字段，带或不带填充）
+//   - Base58Check（校验版本字节与校验和）
+//
+// "0x" 开头但其后不是 40 位十六进制的串不按十六进制处理：Base64 owner 也可能以 "0x" 开头。
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return Address{}, fmt.Errorf("%w: empty address", ErrInvalidAddress)
+	case (strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) && len(s) == 2+2*AddressLength && isHex(s[2:]):
+		return parseHexAddress(s[2:])
+	case len(s) == 2*AddressLength && isHex(s):
+		return parseHexAddress(s)
+	}
+
+	// 前导零字节较多的 Base58Check 地址与 Base64 长度相同：校验和通过时按 Base58Check 解析
+	if !isBase58Check(s) {
+		switch {
+		case len(s) == base64.StdEncoding.EncodedLen(AddressLength) && strings.HasSuffix(s, "="):
+			return parseBase64Address(base64.StdEncoding, s)
+		case len(s) == base64.RawStdEncoding.EncodedLen(AddressLength):
+			return parseBase64Address(base64.RawStdEncoding, s)
+		}
+	}
+	return parseBase58Address(s)
+}
+
+// MustParseAddress 解析地址字符串，失败时 panic（用于常量初始化与测试）
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func parseHexAddress(s string) (Address, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: invalid hex: %v", ErrInvalidAddress, err)
+	}
+	return NewAddressFromBytes(b)
+}
+
+func parseBase64Address(enc *base64.Encoding, s string) (Address, error) {
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: invalid base64: %v", ErrInvalidAddress, err)
+	}
+	return NewAddressFromBytes(b)
+}
+
+func parseBase58Address(s string) (Address, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) != 1+AddressLength+4 {
+		return Address{}, fmt.Errorf("%w: unrecognized format %q", ErrInvalidAddress, s)
+	}
+	payload, checksum := decoded[:1+AddressLength], decoded[1+AddressLength:]
+	if !bytes.Equal(checksum, base58Checksum(payload)) {
+		return Address{}, ErrInvalidChecksum
+	}
+	if payload[0] != AddressVersion {
+		return Address{}, fmt.Errorf("%w: unsupported version byte 0x%02x", ErrInvalidAddress, payload[0])
+	}
+	return NewAddressFromBytes(payload[1:])
+}
+
+// isBase58Check 是否为校验和正确的 25 字节 Base58Check 串（不检查版本字节）
+func isBase58Check(s string) bool {
+	decoded := base58.Decode(s)
+	return len(decoded) == 1+AddressLength+4 &&
+		bytes.Equal(decoded[1+AddressLength:], base58Checksum(decoded[:1+AddressLength]))
+}
+
+// base58Checksum 双重 SHA-256 的前 4 字节
+func base58Checksum(payload []byte) []byte {
+	h1 := sha256.Sum256(payload)
+	h2 := sha256.Sum256(h1[:])
+	return h2[:4]
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes 返回地址字节的副本
+func (a Address) Bytes() []byte {
+	return append([]byte(nil), a[:]...)
+}
+
+// IsZero 是否为零值（未设置）
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// String 返回 Base58Check 编码
+func (a Address) String() string {
+	payload := append([]byte{AddressVersion}, a[:]...)
+	return base58.Encode(append(payload, base58Checksum(payload)...))
+}
+
+// Hex 返回 "0x" 前缀的十六进制字符串
+func (a Address) Hex() string {
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+// Base64 返回标准 Base64 编码（节点 owner 字段格式）
+func (a Address) Base64() string {
+	return base64.StdEncoding.EncodeToString(a[:])
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (a Address) MarshalText() ([]byte, error) {
+	if a.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler（空字符串解析为零值）
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*a = Address{}
+		return nil
+	}
+	v, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
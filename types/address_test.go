@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	raw, _ := hex.DecodeString("aabbccddeeff00112233445566778899aabbccdd")
+	want := MustAddressFromBytes(raw)
+
+	formats := []string{
+		want.String(),
+		"0x" + hex.EncodeToString(raw),
+		"0XAABBCCDDEEFF00112233445566778899AABBCCDD",
+		hex.EncodeToString(raw),
+		base64.StdEncoding.EncodeToString(raw),
+		base64.RawStdEncoding.EncodeToString(raw),
+		" " + want.String() + " ",
+	}
+	for _, s := range formats {
+		got, err := ParseAddress(s)
+		if err != nil || got != want {
+			t.Errorf("ParseAddress(%q) = %s, %v", s, got.Hex(), err)
+		}
+	}
+
+	// 前导零较多的地址：Base58Check 与 Base64 长度相同时仍按 Base58Check 解析
+	small := MustAddressFromBytes(append(make([]byte, 19), 1))
+	if got, err := ParseAddress(small.String()); err != nil || got != small {
+		t.Errorf("ParseAddress(%q) = %s, %v", small.String(), got.Hex(), err)
+	}
+
+	// 以 "0x" 开头的 Base64 owner 不能被当作十六进制
+	prefixed, _ := base64.StdEncoding.DecodeString("0xoOFRwjKjE4P0ZNVFtiaXB3foU=")
+	for _, s := range []string{"0xoOFRwjKjE4P0ZNVFtiaXB3foU=", "0xoOFRwjKjE4P0ZNVFtiaXB3foU"} {
+		if got, err := ParseAddress(s); err != nil || got != MustAddressFromBytes(prefixed) {
+			t.Errorf("ParseAddress(%q) = %s, %v, want %x", s, got.Hex(), err, prefixed)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"0x1234",
+		"0x" + strings.Repeat("zz", 20),
+		hex.EncodeToString(make([]byte, 21)),
+		base64.StdEncoding.EncodeToString(make([]byte, 19)),
+		"not-an-address",
+	}
+	for _, s := range invalid {
+		if _, err := ParseAddress(s); !errors.Is(err, ErrInvalidAddress) && !errors.Is(err, ErrInvalidChecksum) {
+			t.Errorf("ParseAddress(%q) should fail, got %v", s, err)
+		}
+	}
+}
+
+func TestParseAddressChecksum(t *testing.T) {
+	s := MustAddressFromBytes(make([]byte, 20)).String()
+	// 修改最后一个字符，破坏校验和
+	last := s[len(s)-1]
+	replacement := byte('2')
+	if last == replacement {
+		replacement = '3'
+	}
+	tampered := s[:len(s)-1] + string(replacement)
+	if _, err := ParseAddress(tampered); !errors.Is(err, ErrInvalidChecksum) {
+		t.Fatalf("expected ErrInvalidChecksum, got %v", err)
+	}
+}
+
+func TestAddressJSON(t *testing.T) {
+	type payload struct {
+		From Address   `json:"from"`
+		To   Address   `json:"to"`
+		List []Address `json:"list"`
+	}
+	a := MustParseAddress("0xaabbccddeeff00112233445566778899aabbccdd")
+	data, err := json.Marshal(payload{From: a, List: []Address{a}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"from":"`+a.String()+`"`) || !strings.Contains(string(data), `"to":""`) {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+
+	var decoded payload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.From != a || !decoded.To.IsZero() || len(decoded.List) != 1 || decoded.List[0] != a {
+		t.Fatalf("round trip mismatch: %+v", decoded)
+	}
+
+	// 反序列化同样接受十六进制
+	if err := json.Unmarshal([]byte(`{"from":"`+a.Hex()+`"}`), &decoded); err != nil || decoded.From != a {
+		t.Fatalf("Unmarshal hex = %v, %v", decoded.From, err)
+	}
+	if err := json.Unmarshal([]byte(`{"from":"bogus"}`), &decoded); err == nil {
+		t.Fatalf("expected error for invalid address")
+	}
+}
@@ -0,0 +1,230 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount 任意精度的非负金额（以最小单位计）
+//
+// **设计说明**：
+//   - 底层为 *big.Int，避免 18 位小数代币在 uint64 下溢出
+//   - 值语义且不可变：所有运算返回新值，零值 Amount{} 表示 0
+//   - JSON / 文本序列化为十进制字符串（如 "1000000000000000000"），
+//     反序列化同时接受十进制字符串、"0x" 十六进制字符串与 JSON 数字
+type Amount struct {
+	v *big.Int
+}
+
+// NewAmount 从 uint64 创建金额（兼容原有 uint64 调用方）
+func NewAmount(v uint64) Amount {
+	return Amount{v: new(big.Int).SetUint64(v)}
+}
+
+// NewAmountFromBigInt 从 *big.Int 创建金额（复制入参），负数返回错误
+func NewAmountFromBigInt(v *big.Int) (Amount, error) {
+	if v == nil {
+		return Amount{}, nil
+	}
+	if v.Sign() < 0 {
+		return Amount{}, fmt.Errorf("amount must not be negative: %s", v)
+	}
+	return Amount{v: new(big.Int).Set(v)}, nil
+}
+
+// ParseAmount 解析金额字符串：十进制或 "0x" 前缀的十六进制
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Amount{}, fmt.Errorf("empty amount")
+	}
+
+	base := 10
+	digits := s
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		base = 16
+		digits = s[2:]
+		if digits == "" {
+			// 节点可能以 "0x" 表示 0
+			return Amount{}, nil
+		}
+	}
+	if strings.HasPrefix(digits, "+") || strings.HasPrefix(digits, "-") {
+		return Amount{}, fmt.Errorf("invalid amount: %q", s)
+	}
+
+	v, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount: %q", s)
+	}
+	return Amount{v: v}, nil
+}
+
+// MustParseAmount 解析金额字符串，失败时 panic（用于常量初始化与测试）
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// ParseUnits 按小数位数将十进制显示值（如 "1.25"）转换为最小单位金额
+//
+// 全程使用整数运算，不经过浮点数；小数位超过 decimals 时返回错误而不是截断。
+func ParseUnits(s string, decimals uint8) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Amount{}, fmt.Errorf("empty amount")
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Amount{}, fmt.Errorf("invalid amount: %q", s)
+	}
+	if !isDecimalDigits(intPart) || !isDecimalDigits(fracPart) {
+		return Amount{}, fmt.Errorf("invalid amount: %q", s)
+	}
+
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > int(decimals) {
+		return Amount{}, fmt.Errorf("amount %q has more than %d decimal places", s, decimals)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", int(decimals)-len(fracPart))
+	v, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount: %q", s)
+	}
+	return Amount{v: v}, nil
+}
+
+// isDecimalDigits 是否只包含十进制数字（空字符串视为合法）
+func isDecimalDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// BigInt 返回金额的 *big.Int 副本
+func (a Amount) BigInt() *big.Int {
+	if a.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.v)
+}
+
+// String 返回十进制字符串
+func (a Amount) String() string {
+	if a.v == nil {
+		return "0"
+	}
+	return a.v.String()
+}
+
+// Hex 返回 "0x" 前缀的十六进制字符串
+func (a Amount) Hex() string {
+	if a.v == nil {
+		return "0x0"
+	}
+	return "0x" + a.v.Text(16)
+}
+
+// FormatUnits 按小数位数格式化为十进制显示值（如 125000000, 8 → "1.25"），去除末尾多余的 0
+func (a Amount) FormatUnits(decimals uint8) string {
+	digits := a.String()
+	if decimals == 0 {
+		return digits
+	}
+	d := int(decimals)
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-d], strings.TrimRight(digits[len(digits)-d:], "0")
+	if fracPart == "" {
+		return intPart
+	}
+	return intPart + "." + fracPart
+}
+
+// IsZero 是否为 0
+func (a Amount) IsZero() bool {
+	return a.v == nil || a.v.Sign() == 0
+}
+
+// Uint64 转换为 uint64，超出范围时 ok 为 false
+func (a Amount) Uint64() (v uint64, ok bool) {
+	if a.v == nil {
+		return 0, true
+	}
+	if !a.v.IsUint64() {
+		return 0, false
+	}
+	return a.v.Uint64(), true
+}
+
+// Cmp 比较大小：a < b 返回 -1，相等返回 0，a > b 返回 1
+func (a Amount) Cmp(b Amount) int {
+	return a.BigInt().Cmp(b.BigInt())
+}
+
+// Add 返回 a + b
+func (a Amount) Add(b Amount) Amount {
+	return Amount{v: new(big.Int).Add(a.BigInt(), b.BigInt())}
+}
+
+// Sub 返回 a - b，结果为负时返回错误
+func (a Amount) Sub(b Amount) (Amount, error) {
+	r := new(big.Int).Sub(a.BigInt(), b.BigInt())
+	if r.Sign() < 0 {
+		return Amount{}, fmt.Errorf("amount underflow: %s - %s", a, b)
+	}
+	return Amount{v: r}, nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (a *Amount) UnmarshalText(text []byte) error {
+	v, err := ParseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// MarshalJSON 序列化为十进制字符串
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON 接受字符串或 JSON 数字
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		*a = Amount{}
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		s = str
+	} else if strings.ContainsAny(s, ".eE") {
+		return fmt.Errorf("invalid amount: %s (must be an integer)", s)
+	}
+	return a.UnmarshalText([]byte(s))
+}
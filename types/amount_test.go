@@ -0,0 +1,145 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "0", want: "0"},
+		{in: "1000000000000000000000", want: "1000000000000000000000"},
+		{in: "0x4a817c800", want: "20000000000"},
+		{in: "0x", want: "0"},
+		{in: " 42 ", want: "42"},
+		{in: "", wantErr: true},
+		{in: "-1", wantErr: true},
+		{in: "1.5", wantErr: true},
+		{in: "0xzz", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	// 18 位小数代币：超出 uint64 范围
+	a := MustParseAmount("20000000000000000000")
+	b := NewAmount(1)
+
+	if _, ok := a.Uint64(); ok {
+		t.Errorf("Uint64() ok = true for value beyond uint64")
+	}
+	if got := a.Add(b).String(); got != "20000000000000000001" {
+		t.Errorf("Add() = %s", got)
+	}
+	if _, err := b.Sub(a); err == nil {
+		t.Errorf("Sub() error = nil on underflow")
+	}
+	if a.Cmp(b) <= 0 || b.Cmp(a) >= 0 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp() ordering incorrect")
+	}
+
+	var zero Amount
+	if !zero.IsZero() || zero.String() != "0" || zero.Hex() != "0x0" {
+		t.Errorf("zero value = %s / %s", zero, zero.Hex())
+	}
+
+	// BigInt 返回副本，修改不影响原值
+	a.BigInt().SetInt64(0)
+	if a.IsZero() {
+		t.Errorf("BigInt() should return a copy")
+	}
+
+	if _, err := NewAmountFromBigInt(big.NewInt(-1)); err == nil {
+		t.Errorf("NewAmountFromBigInt() error = nil for negative value")
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	type payload struct {
+		Amount Amount  `json:"amount"`
+		Opt    *Amount `json:"opt,omitempty"`
+	}
+
+	data, err := json.Marshal(payload{Amount: MustParseAmount("123456789012345678901234567890")})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"amount":"123456789012345678901234567890"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	for _, in := range []string{`{"amount":"1000"}`, `{"amount":1000}`, `{"amount":"0x3e8"}`} {
+		var p payload
+		if err := json.Unmarshal([]byte(in), &p); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", in, err)
+		}
+		if p.Amount.String() != "1000" {
+			t.Errorf("Unmarshal(%s) = %s, want 1000", in, p.Amount)
+		}
+	}
+
+	var p payload
+	if err := json.Unmarshal([]byte(`{"amount":1.5}`), &p); err == nil {
+		t.Errorf("Unmarshal() error = nil for fractional number")
+	}
+}
+
+func TestParseAndFormatUnits(t *testing.T) {
+	tests := []struct {
+		in       string
+		decimals uint8
+		want     string
+		display  string
+		wantErr  bool
+	}{
+		{in: "1.25", decimals: 8, want: "125000000", display: "1.25"},
+		{in: "0.00000001", decimals: 8, want: "1", display: "0.00000001"},
+		{in: "100", decimals: 8, want: "10000000000", display: "100"},
+		{in: ".5", decimals: 2, want: "50", display: "0.5"},
+		{in: "1.", decimals: 2, want: "100", display: "1"},
+		{in: "0", decimals: 18, want: "0", display: "0"},
+		{in: "1.500", decimals: 2, want: "150", display: "1.5"},
+		{in: "123456789.123456789012345678", decimals: 18, want: "123456789123456789012345678", display: "123456789.123456789012345678"},
+		{in: "7", decimals: 0, want: "7", display: "7"},
+		{in: "0.001", decimals: 2, wantErr: true},
+		{in: "1.2.3", decimals: 8, wantErr: true},
+		{in: "-1", decimals: 8, wantErr: true},
+		{in: "1e3", decimals: 8, wantErr: true},
+		{in: ".", decimals: 8, wantErr: true},
+		{in: "", decimals: 8, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.in, tt.decimals)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseUnits(%q, %d) error = %v, wantErr %v", tt.in, tt.decimals, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseUnits(%q, %d) = %s, want %s", tt.in, tt.decimals, got, tt.want)
+		}
+		if d := got.FormatUnits(tt.decimals); d != tt.display {
+			t.Errorf("FormatUnits(%s, %d) = %q, want %q", got, tt.decimals, d, tt.display)
+		}
+	}
+
+	if got := (Amount{}).FormatUnits(8); got != "0" {
+		t.Errorf("zero Amount FormatUnits = %q, want 0", got)
+	}
+}
@@ -5,20 +5,22 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // BuildPayloadOptions Payload 构建选项
 // 遵循 WES ABI 规范：weisyn.git/docs/components/core/ispc/abi-and-payload.md
 type BuildPayloadOptions struct {
 	// 保留字段（Reserved Fields）
-	IncludeFrom    bool   // 是否包含调用者地址（from）
-	From           []byte // 调用者地址（20字节）
-	IncludeTo      bool   // 是否包含接收者地址（to）
-	To             []byte // 接收者地址（20字节）
-	IncludeAmount  bool   // 是否包含金额（amount）
-	Amount         uint64 // 转账金额
-	IncludeTokenID bool   // 是否包含代币ID（token_id）
-	TokenID        []byte // 代币ID（32字节）
+	IncludeFrom    bool         // 是否包含调用者地址（from）
+	From           []byte       // 调用者地址（20字节）
+	IncludeTo      bool         // 是否包含接收者地址（to）
+	To             []byte       // 接收者地址（20字节）
+	IncludeAmount  bool         // 是否包含金额（amount）
+	Amount         types.Amount // 转账金额
+	IncludeTokenID bool         // 是否包含代币ID（token_id）
+	TokenID        []byte       // 代币ID（32字节）
 
 	// 扩展字段（Extension Fields）- 方法参数
 	MethodParams map[string]interface{} // 方法参数（键值对）
@@ -45,7 +47,7 @@ func BuildPayload(options BuildPayloadOptions) (map[string]interface{}, error) {
 	}
 
 	if options.IncludeAmount {
-		payload["amount"] = fmt.Sprintf("%d", options.Amount)
+		payload["amount"] = options.Amount.String()
 	}
 
 	if options.IncludeTokenID && len(options.TokenID) > 0 {
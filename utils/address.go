@@ -1,11 +1,12 @@
 package utils
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 
 	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // AddressBytesToBase58 将 20 字节地址转换为 Base58Check 编码
@@ -18,76 +19,28 @@ import (
 // - SDK 独立实现，不依赖 WES 内部包
 // - 使用标准 Base58Check 编码（与 Bitcoin 兼容）
 func AddressBytesToBase58(addressBytes []byte) (string, error) {
-	if len(addressBytes) != 20 {
-		return "", fmt.Errorf("invalid address length: expected 20 bytes, got %d", len(addressBytes))
+	addr, err := types.NewAddressFromBytes(addressBytes)
+	if err != nil {
+		return "", err
 	}
-
-	// WES 地址版本字节
-	// WESP2PKHVersion = 0x1C (WES P2PKH 地址版本)
-	// 参考：internal/core/infrastructure/crypto/address/address.go
-	versionByte := byte(0x1C)
-
-	// 构建版本字节 + 地址哈希
-	versionedAddress := append([]byte{versionByte}, addressBytes...)
-
-	// 计算校验和（双重 SHA256，取前4字节）
-	hash1 := sha256.Sum256(versionedAddress)
-	hash2 := sha256.Sum256(hash1[:])
-	checksum := hash2[:4]
-
-	// 构建完整地址：版本字节 + 地址哈希 + 校验和
-	fullAddress := append(versionedAddress, checksum...)
-
-	// Base58 编码
-	base58Addr := base58.Encode(fullAddress)
-
-	return base58Addr, nil
+	return addr.String(), nil
 }
 
 // AddressBase58ToBytes 将 Base58Check 编码地址转换为 20 字节地址哈希
 //
 // **格式**：
 // - Base58Check 解码后：版本字节（1字节）+ 地址哈希（20字节）+ 校验和（4字节）
-// - 返回地址哈希（20字节）
+// - 校验版本字节（types.AddressVersion）与校验和，返回地址哈希（20字节）
 func AddressBase58ToBytes(base58Addr string) ([]byte, error) {
-	// Base58 解码
 	decoded := base58.Decode(base58Addr)
-
-	// 验证长度：版本字节（1）+ 地址哈希（20）+ 校验和（4）= 25 字节
 	if len(decoded) != 25 {
 		return nil, fmt.Errorf("invalid address length: expected 25 bytes after Base58 decode, got %d", len(decoded))
 	}
-
-	// 验证校验和
-	versionedAddress := decoded[:21] // 版本字节 + 地址哈希
-	checksum := decoded[21:]         // 校验和
-
-	hash1 := sha256.Sum256(versionedAddress)
-	hash2 := sha256.Sum256(hash1[:])
-	expectedChecksum := hash2[:4]
-
-	// 比较校验和
-	if !equalBytes(checksum, expectedChecksum) {
-		return nil, fmt.Errorf("invalid checksum")
-	}
-
-	// 返回地址哈希（跳过版本字节）
-	addressBytes := decoded[1:21]
-
-	return addressBytes, nil
-}
-
-// equalBytes 比较两个字节数组是否相等
-func equalBytes(a, b []byte) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
+	addr, err := types.ParseAddress(base58Addr)
+	if err != nil {
+		return nil, err
 	}
-	return true
+	return addr.Bytes(), nil
 }
 
 // AddressHexToBase58 将十六进制地址转换为 Base58Check 编码
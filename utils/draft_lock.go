@@ -0,0 +1,547 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+// DraftLockingConditions 将草稿输出的锁定条件转换为交易中的锁定条件
+//
+// 读取 locking_condition（单个）或 locking_conditions（数组），两者均缺省时返回 owner 的默认单签锁。
+// 支持 resource.LockingCondition.ToProto 格式（{"single_key_lock": {...}}）与
+// {"type": "single_key_lock", ...} 简写格式；草稿未给出的字段保持零值。
+func DraftLockingConditions(owner []byte, output map[string]interface{}) ([]*txcodec.LockingCondition, error) {
+	var raw []interface{}
+	if lc, ok := output["locking_condition"].(map[string]interface{}); ok {
+		raw = []interface{}{lc}
+	} else if arr, ok := output["locking_conditions"].([]interface{}); ok && len(arr) > 0 {
+		raw = arr
+	}
+	if len(raw) == 0 {
+		return []*txcodec.LockingCondition{{SingleKey: &txcodec.SingleKeyLock{RequiredAddressHash: owner}}}, nil
+	}
+
+	locks := make([]*txcodec.LockingCondition, 0, len(raw))
+	for i, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("locking condition %d: invalid format", i)
+		}
+		lock, err := draftLock(m)
+		if err != nil {
+			return nil, fmt.Errorf("locking condition %d: %w", i, err)
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+// draftLock 解析单个草稿锁定条件（草稿未给出的字段按零值处理）
+func draftLock(m map[string]interface{}) (*txcodec.LockingCondition, error) {
+	kind, body := "", m
+	if t, ok := m["type"].(string); ok {
+		kind = t
+	} else if len(m) == 1 {
+		for k, v := range m {
+			kind = k
+			body, _ = v.(map[string]interface{})
+		}
+	}
+	if body == nil {
+		return nil, fmt.Errorf("invalid %s", kind)
+	}
+
+	switch kind {
+	case "single_key_lock":
+		addrHex, _ := body["required_address_hash"].(string)
+		if addrHex == "" {
+			addrHex, _ = body["required_address"].(string)
+		}
+		addr, err := decodeLockBytes(addrHex)
+		if err != nil {
+			return nil, fmt.Errorf("single_key_lock: invalid required address: %w", err)
+		}
+		pubkey, err := decodeLockBytes(stringField(body, "required_public_key"))
+		if err != nil {
+			return nil, fmt.Errorf("single_key_lock: invalid required public key: %w", err)
+		}
+		return &txcodec.LockingCondition{SingleKey: &txcodec.SingleKeyLock{RequiredAddressHash: addr, RequiredPublicKey: pubkey}}, nil
+
+	case "multi_key_lock":
+		keys, err := lockKeyList(body["authorized_keys"])
+		if err != nil {
+			return nil, fmt.Errorf("multi_key_lock: %w", err)
+		}
+		required, err := lockUint32(body, "required_signatures")
+		if err != nil {
+			return nil, fmt.Errorf("multi_key_lock: %w", err)
+		}
+		ordered, _ := body["require_ordered_signatures"].(bool)
+		return &txcodec.LockingCondition{MultiKey: &txcodec.MultiKeyLock{
+			RequiredSignatures:       required,
+			AuthorizedKeys:           keys,
+			RequireOrderedSignatures: ordered,
+		}}, nil
+
+	case "threshold_lock":
+		keys, err := lockKeyList(body["party_verification_keys"])
+		if err != nil {
+			return nil, fmt.Errorf("threshold_lock: %w", err)
+		}
+		threshold, err := lockUint32(body, "threshold")
+		if err != nil {
+			return nil, fmt.Errorf("threshold_lock: %w", err)
+		}
+		total, err := lockUint32(body, "total_parties")
+		if err != nil {
+			return nil, fmt.Errorf("threshold_lock: %w", err)
+		}
+		return &txcodec.LockingCondition{Threshold: &txcodec.ThresholdLock{
+			Threshold:             threshold,
+			TotalParties:          total,
+			PartyVerificationKeys: keys,
+			SignatureScheme:       stringField(body, "signature_scheme"),
+		}}, nil
+
+	case "time_lock", "height_lock":
+		var base *txcodec.LockingCondition
+		if b, ok := body["base_lock"].(map[string]interface{}); ok {
+			var err error
+			if base, err = draftLock(b); err != nil {
+				return nil, fmt.Errorf("%s base_lock: %w", kind, err)
+			}
+		}
+		if kind == "time_lock" {
+			timestamp, err := lockUint(body, "unlock_timestamp")
+			if err != nil {
+				return nil, fmt.Errorf("time_lock: %w", err)
+			}
+			source, err := lockTimeSource(body["time_source"])
+			if err != nil {
+				return nil, fmt.Errorf("time_lock: %w", err)
+			}
+			return &txcodec.LockingCondition{TimeLock: &txcodec.TimeLock{UnlockTimestamp: timestamp, BaseLock: base, TimeSource: source}}, nil
+		}
+		height, err := lockUint(body, "unlock_height")
+		if err != nil {
+			return nil, fmt.Errorf("height_lock: %w", err)
+		}
+		confirmations, err := lockUint32(body, "confirmation_blocks")
+		if err != nil {
+			return nil, fmt.Errorf("height_lock: %w", err)
+		}
+		return &txcodec.LockingCondition{HeightLock: &txcodec.HeightLock{UnlockHeight: height, BaseLock: base, ConfirmationBlocks: confirmations}}, nil
+
+	case "delegation_lock":
+		owner, err := decodeLockBytes(stringField(body, "original_owner"))
+		if err != nil {
+			return nil, fmt.Errorf("delegation_lock: invalid original_owner: %w", err)
+		}
+		delegates, err := lockKeyList(body["allowed_delegates"])
+		if err != nil {
+			return nil, fmt.Errorf("delegation_lock: %w", err)
+		}
+		operations, err := lockStringList(body["authorized_operations"])
+		if err != nil {
+			return nil, fmt.Errorf("delegation_lock authorized_operations: %w", err)
+		}
+		expiry, err := lockUint(body, "expiry_duration_blocks")
+		if err != nil {
+			return nil, fmt.Errorf("delegation_lock: %w", err)
+		}
+		maxValue, err := lockUint(body, "max_value_per_operation")
+		if err != nil {
+			return nil, fmt.Errorf("delegation_lock: %w", err)
+		}
+		return &txcodec.LockingCondition{Delegation: &txcodec.DelegationLock{
+			OriginalOwner:        owner,
+			AllowedDelegates:     delegates,
+			AuthorizedOperations: operations,
+			ExpiryDurationBlocks: expiry,
+			MaxValuePerOperation: maxValue,
+		}}, nil
+
+	case "contract_lock":
+		addr, err := decodeLockBytes(stringField(body, "contract_address"))
+		if err != nil {
+			return nil, fmt.Errorf("contract_lock: invalid contract_address: %w", err)
+		}
+		requirements, err := lockStringList(body["state_requirements"])
+		if err != nil {
+			return nil, fmt.Errorf("contract_lock state_requirements: %w", err)
+		}
+		maxTime, err := lockUint(body, "max_execution_time_ms")
+		if err != nil {
+			return nil, fmt.Errorf("contract_lock: %w", err)
+		}
+		return &txcodec.LockingCondition{Contract: &txcodec.ContractLock{
+			ContractAddress:    addr,
+			RequiredMethod:     stringField(body, "required_method"),
+			ParameterSchema:    stringField(body, "parameter_schema"),
+			StateRequirements:  requirements,
+			MaxExecutionTimeMs: maxTime,
+		}}, nil
+	}
+	return nil, fmt.Errorf("unsupported locking condition %q", kind)
+}
+
+// matchLock 核对交易中的锁定条件与草稿一致（草稿未给出的字段按零值比较），不一致时返回原因
+//
+// 单签锁例外：草稿只给出地址或公钥之一时，只比较给出的一项（地址由公钥推导）。
+func matchLock(got, want *txcodec.LockingCondition) string {
+	switch {
+	case got == nil:
+		return "missing locking condition"
+
+	case want.SingleKey != nil:
+		if got.SingleKey == nil {
+			return "expected single_key_lock"
+		}
+		w, g := want.SingleKey, got.SingleKey
+		if len(w.RequiredPublicKey) > 0 && !sameLockKey(g.RequiredPublicKey, w.RequiredPublicKey) {
+			return "single_key_lock public key differs"
+		}
+		if len(w.RequiredAddressHash) > 0 && !bytes.Equal(singleKeyAddress(g), w.RequiredAddressHash) {
+			return fmt.Sprintf("single_key_lock address %x, draft has %x", singleKeyAddress(g), w.RequiredAddressHash)
+		}
+
+	case want.MultiKey != nil:
+		if got.MultiKey == nil {
+			return "expected multi_key_lock"
+		}
+		w, g := want.MultiKey, got.MultiKey
+		if g.RequiredSignatures != w.RequiredSignatures {
+			return fmt.Sprintf("multi_key_lock requires %d signatures, draft has %d", g.RequiredSignatures, w.RequiredSignatures)
+		}
+		if !sameLockKeys(g.AuthorizedKeys, w.AuthorizedKeys) {
+			return "multi_key_lock authorized keys differ"
+		}
+		if g.RequireOrderedSignatures != w.RequireOrderedSignatures {
+			return "multi_key_lock require_ordered_signatures differs"
+		}
+
+	case want.Threshold != nil:
+		if got.Threshold == nil {
+			return "expected threshold_lock"
+		}
+		w, g := want.Threshold, got.Threshold
+		if g.Threshold != w.Threshold {
+			return fmt.Sprintf("threshold_lock threshold %d, draft has %d", g.Threshold, w.Threshold)
+		}
+		if g.TotalParties != w.TotalParties {
+			return fmt.Sprintf("threshold_lock total_parties %d, draft has %d", g.TotalParties, w.TotalParties)
+		}
+		if !sameLockKeys(g.PartyVerificationKeys, w.PartyVerificationKeys) {
+			return "threshold_lock party verification keys differ"
+		}
+		if g.SignatureScheme != w.SignatureScheme {
+			return fmt.Sprintf("threshold_lock scheme %s, draft has %s", g.SignatureScheme, w.SignatureScheme)
+		}
+
+	case want.TimeLock != nil:
+		if got.TimeLock == nil {
+			return "expected time_lock"
+		}
+		w, g := want.TimeLock, got.TimeLock
+		if g.UnlockTimestamp != w.UnlockTimestamp {
+			return fmt.Sprintf("time_lock unlock_timestamp %d, draft has %d", g.UnlockTimestamp, w.UnlockTimestamp)
+		}
+		if g.TimeSource != w.TimeSource {
+			return fmt.Sprintf("time_lock time_source %d, draft has %d", g.TimeSource, w.TimeSource)
+		}
+		if reason := matchBaseLock(g.BaseLock, w.BaseLock); reason != "" {
+			return "time_lock base_lock: " + reason
+		}
+
+	case want.HeightLock != nil:
+		if got.HeightLock == nil {
+			return "expected height_lock"
+		}
+		w, g := want.HeightLock, got.HeightLock
+		if g.UnlockHeight != w.UnlockHeight {
+			return fmt.Sprintf("height_lock unlock_height %d, draft has %d", g.UnlockHeight, w.UnlockHeight)
+		}
+		if g.ConfirmationBlocks != w.ConfirmationBlocks {
+			return fmt.Sprintf("height_lock confirmation_blocks %d, draft has %d", g.ConfirmationBlocks, w.ConfirmationBlocks)
+		}
+		if reason := matchBaseLock(g.BaseLock, w.BaseLock); reason != "" {
+			return "height_lock base_lock: " + reason
+		}
+
+	case want.Delegation != nil:
+		if got.Delegation == nil {
+			return "expected delegation_lock"
+		}
+		w, g := want.Delegation, got.Delegation
+		if !bytes.Equal(g.OriginalOwner, w.OriginalOwner) {
+			return "delegation_lock original_owner differs"
+		}
+		if !sameByteLists(g.AllowedDelegates, w.AllowedDelegates) {
+			return "delegation_lock allowed_delegates differ"
+		}
+		if !sameStrings(g.AuthorizedOperations, w.AuthorizedOperations) {
+			return fmt.Sprintf("delegation_lock authorized_operations %v, draft has %v", g.AuthorizedOperations, w.AuthorizedOperations)
+		}
+		if g.ExpiryDurationBlocks != w.ExpiryDurationBlocks {
+			return fmt.Sprintf("delegation_lock expiry_duration_blocks %d, draft has %d", g.ExpiryDurationBlocks, w.ExpiryDurationBlocks)
+		}
+		if g.MaxValuePerOperation != w.MaxValuePerOperation {
+			return fmt.Sprintf("delegation_lock max_value_per_operation %d, draft has %d", g.MaxValuePerOperation, w.MaxValuePerOperation)
+		}
+
+	case want.Contract != nil:
+		if got.Contract == nil {
+			return "expected contract_lock"
+		}
+		w, g := want.Contract, got.Contract
+		if !bytes.Equal(g.ContractAddress, w.ContractAddress) {
+			return "contract_lock contract_address differs"
+		}
+		if g.RequiredMethod != w.RequiredMethod {
+			return "contract_lock required_method differs"
+		}
+		if g.ParameterSchema != w.ParameterSchema {
+			return "contract_lock parameter_schema differs"
+		}
+		if !sameStrings(g.StateRequirements, w.StateRequirements) {
+			return "contract_lock state_requirements differ"
+		}
+		if g.MaxExecutionTimeMs != w.MaxExecutionTimeMs {
+			return fmt.Sprintf("contract_lock max_execution_time_ms %d, draft has %d", g.MaxExecutionTimeMs, w.MaxExecutionTimeMs)
+		}
+	}
+	return ""
+}
+
+// matchBaseLock 核对时间锁 / 高度锁的基础锁（草稿未给出时交易中也不应有）
+func matchBaseLock(got, want *txcodec.LockingCondition) string {
+	if want == nil {
+		if got != nil {
+			return "unexpected base_lock"
+		}
+		return ""
+	}
+	return matchLock(got, want)
+}
+
+// chainIDMatches 比较交易 chain_id 字节与草稿中的链 ID（十六进制 / 十进制数值或原始字符串）
+func chainIDMatches(got []byte, want string) bool {
+	if string(got) == want {
+		return true
+	}
+	n, ok := new(big.Int), false
+	if strings.HasPrefix(want, "0x") || strings.HasPrefix(want, "0X") {
+		n, ok = n.SetString(want[2:], 16)
+	} else {
+		n, ok = n.SetString(want, 10)
+	}
+	return ok && n.Cmp(new(big.Int).SetBytes(got)) == 0
+}
+
+// singleKeyAddress 单签锁对应的地址（锁中只有公钥时按 RIPEMD160(SHA256(压缩公钥)) 计算）
+func singleKeyAddress(l *txcodec.SingleKeyLock) []byte {
+	if len(l.RequiredAddressHash) > 0 {
+		return l.RequiredAddressHash
+	}
+	compressed := compressLockKey(l.RequiredPublicKey)
+	if compressed == nil {
+		return nil
+	}
+	sha := sha256.Sum256(compressed)
+	r := ripemd160.New()
+	_, _ = r.Write(sha[:])
+	return r.Sum(nil)
+}
+
+// compressLockKey 将 secp256k1 公钥统一为压缩格式（无法解析时返回 nil）
+func compressLockKey(key []byte) []byte {
+	switch len(key) {
+	case 33:
+		if pub, err := ethcrypto.DecompressPubkey(key); err == nil {
+			return ethcrypto.CompressPubkey(pub)
+		}
+	case 65:
+		if pub, err := ethcrypto.UnmarshalPubkey(key); err == nil {
+			return ethcrypto.CompressPubkey(pub)
+		}
+	}
+	return nil
+}
+
+// sameLockKey 比较公钥（压缩 / 非压缩格式视为相同；非 secp256k1 公钥逐字节比较）
+func sameLockKey(a, b []byte) bool {
+	if ca, cb := compressLockKey(a), compressLockKey(b); ca != nil && cb != nil {
+		return bytes.Equal(ca, cb)
+	}
+	return bytes.Equal(a, b)
+}
+
+func sameLockKeys(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameLockKey(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameByteLists(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lockKeyList 解析公钥 / 地址列表（字符串或 {"value": ...} 对象）
+func lockKeyList(v interface{}) ([][]byte, error) {
+	items, _ := v.([]interface{})
+	keys := make([][]byte, 0, len(items))
+	for i, item := range items {
+		var encoded string
+		switch k := item.(type) {
+		case string:
+			encoded = k
+		case map[string]interface{}:
+			encoded, _ = k["value"].(string)
+		}
+		key, err := decodeLockBytes(encoded)
+		if err != nil || len(key) == 0 {
+			return nil, fmt.Errorf("invalid key %d", i)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// lockStringList 解析字符串列表（缺省为空）
+func lockStringList(v interface{}) ([]string, error) {
+	switch items := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return items, nil
+	case []interface{}:
+		list := make([]string, 0, len(items))
+		for i, item := range items {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid item %d", i)
+			}
+			list = append(list, str)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("invalid list")
+}
+
+// lockUint 解析数值字段（缺省为 0；十进制 / 0x 十六进制字符串或 JSON 数值，非法值返回错误）
+func lockUint(body map[string]interface{}, key string) (uint64, error) {
+	switch v := body[key].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v >= math.MaxUint64 {
+			return 0, fmt.Errorf("invalid %s", key)
+		}
+		return uint64(v), nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid %s", key)
+		}
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		var n uint64
+		var err error
+		if strings.HasPrefix(v, "0x") {
+			n, err = strconv.ParseUint(v[2:], 16, 64)
+		} else {
+			n, err = strconv.ParseUint(v, 10, 64)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid %s", key)
+}
+
+func lockUint32(body map[string]interface{}, key string) (uint32, error) {
+	n, err := lockUint(body, key)
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxUint32 {
+		return 0, fmt.Errorf("%s out of range", key)
+	}
+	return uint32(n), nil
+}
+
+// timeSources 时间锁 time_source 枚举名（SDK 构建的时间锁均使用区块时间戳，即枚举值 0）
+var timeSources = map[string]uint32{
+	"TIME_SOURCE_BLOCK_TIMESTAMP": 0,
+}
+
+// lockTimeSource 解析 time_source（枚举名或数值，缺省为 0）
+func lockTimeSource(v interface{}) (uint32, error) {
+	if name, ok := v.(string); ok {
+		if source, ok := timeSources[name]; ok {
+			return source, nil
+		}
+	}
+	return lockUint32(map[string]interface{}{"time_source": v}, "time_source")
+}
+
+// decodeLockBytes 解码 hex（可带 0x 前缀），兼容 protobuf JSON 的 Base64
+func decodeLockBytes(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if b, err := hex.DecodeString(strings.TrimPrefix(s, "0x")); err == nil {
+		return b, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
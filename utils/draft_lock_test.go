@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+func TestVerifyTxMatchesDraft_LockFields(t *testing.T) {
+	to := bytes.Repeat([]byte{0x02}, 20)
+	owner := bytes.Repeat([]byte{0x03}, 20)
+	delegate := bytes.Repeat([]byte{0x04}, 20)
+	contract := bytes.Repeat([]byte{0x05}, 20)
+	base := map[string]interface{}{"single_key_lock": map[string]interface{}{"required_address_hash": hex.EncodeToString(to)}}
+
+	cases := []struct {
+		name   string
+		lock   map[string]interface{}
+		mutate []func(l *txcodec.LockingCondition)
+	}{
+		{
+			name: "delegation_lock",
+			lock: map[string]interface{}{"delegation_lock": map[string]interface{}{
+				"original_owner":          hex.EncodeToString(owner),
+				"allowed_delegates":       []string{hex.EncodeToString(delegate)},
+				"authorized_operations":   []string{"transfer"},
+				"expiry_duration_blocks":  100,
+				"max_value_per_operation": "1000",
+			}},
+			mutate: []func(l *txcodec.LockingCondition){
+				func(l *txcodec.LockingCondition) { l.Delegation.OriginalOwner = delegate },
+				func(l *txcodec.LockingCondition) { l.Delegation.AllowedDelegates = append(l.Delegation.AllowedDelegates, owner) },
+				func(l *txcodec.LockingCondition) { l.Delegation.AuthorizedOperations = []string{"transfer", "burn"} },
+				func(l *txcodec.LockingCondition) { l.Delegation.ExpiryDurationBlocks = 0 },
+				func(l *txcodec.LockingCondition) { l.Delegation.MaxValuePerOperation = 0 },
+			},
+		},
+		{
+			name: "time_lock",
+			lock: map[string]interface{}{"time_lock": map[string]interface{}{
+				"unlock_timestamp": 1700000000,
+				"base_lock":        base,
+				"time_source":      "TIME_SOURCE_BLOCK_TIMESTAMP",
+			}},
+			mutate: []func(l *txcodec.LockingCondition){
+				func(l *txcodec.LockingCondition) { l.TimeLock.UnlockTimestamp++ },
+				func(l *txcodec.LockingCondition) { l.TimeLock.TimeSource = 1 },
+				func(l *txcodec.LockingCondition) { l.TimeLock.BaseLock = nil },
+			},
+		},
+		{
+			name: "height_lock",
+			lock: map[string]interface{}{"height_lock": map[string]interface{}{
+				"unlock_height":       500,
+				"base_lock":           base,
+				"confirmation_blocks": 6,
+			}},
+			mutate: []func(l *txcodec.LockingCondition){
+				func(l *txcodec.LockingCondition) { l.HeightLock.UnlockHeight = 1 },
+				func(l *txcodec.LockingCondition) { l.HeightLock.ConfirmationBlocks = 0 },
+			},
+		},
+		{
+			name: "contract_lock",
+			lock: map[string]interface{}{"contract_lock": map[string]interface{}{
+				"contract_address":      hex.EncodeToString(contract),
+				"required_method":       "unlock",
+				"parameter_schema":      "{}",
+				"state_requirements":    []string{"paused=false"},
+				"max_execution_time_ms": 5000,
+			}},
+			mutate: []func(l *txcodec.LockingCondition){
+				func(l *txcodec.LockingCondition) { l.Contract.RequiredMethod = "drain" },
+				func(l *txcodec.LockingCondition) { l.Contract.ParameterSchema = "" },
+				func(l *txcodec.LockingCondition) { l.Contract.StateRequirements = nil },
+				func(l *txcodec.LockingCondition) { l.Contract.MaxExecutionTimeMs = 0 },
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			draftJSON, _ := sighashFixture()
+			var draft map[string]interface{}
+			if err := json.Unmarshal(draftJSON, &draft); err != nil {
+				t.Fatal(err)
+			}
+			output := draft["outputs"].([]interface{})[0].(map[string]interface{})
+			lockJSON, _ := json.Marshal(tc.lock)
+			var lock map[string]interface{}
+			_ = json.Unmarshal(lockJSON, &lock)
+			output["locking_condition"] = lock
+			draftJSON, _ = json.Marshal(draft)
+
+			build := func() *txcodec.Transaction {
+				_, tx := sighashFixture()
+				locks, err := DraftLockingConditions(to, output)
+				if err != nil {
+					t.Fatalf("DraftLockingConditions: %v", err)
+				}
+				tx.Outputs[0].LockingConditions = locks
+				return tx
+			}
+			if err := VerifyTxMatchesDraft(build(), draftJSON); err != nil {
+				t.Fatalf("matching lock: %v", err)
+			}
+			for i, mutate := range tc.mutate {
+				tx := build()
+				mutate(tx.Outputs[0].LockingConditions[0])
+				if err := VerifyTxMatchesDraft(tx, draftJSON); !errors.Is(err, ErrSighashMismatch) {
+					t.Errorf("mutation %d: error = %v, want ErrSighashMismatch", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDraftLockingConditions_AbsentFieldsAreZero(t *testing.T) {
+	locks, err := DraftLockingConditions(nil, map[string]interface{}{
+		"locking_condition": map[string]interface{}{"delegation_lock": map[string]interface{}{
+			"original_owner": hex.EncodeToString(bytes.Repeat([]byte{0x03}, 20)),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("DraftLockingConditions: %v", err)
+	}
+	d := locks[0].Delegation
+	if d.ExpiryDurationBlocks != 0 || d.MaxValuePerOperation != 0 || len(d.AuthorizedOperations) != 0 {
+		t.Fatalf("absent fields should be zero, got %+v", d)
+	}
+
+	// 节点为草稿未限制的委托补上宽松的操作列表也视为不一致
+	got := &txcodec.LockingCondition{Delegation: &txcodec.DelegationLock{OriginalOwner: d.OriginalOwner, AuthorizedOperations: []string{"*"}}}
+	if reason := matchLock(got, locks[0]); reason == "" {
+		t.Fatalf("added authorized_operations should not match")
+	}
+
+	if _, err := DraftLockingConditions(nil, map[string]interface{}{
+		"locking_condition": map[string]interface{}{"delegation_lock": map[string]interface{}{"max_value_per_operation": "1.5"}},
+	}); err == nil {
+		t.Fatalf("invalid max_value_per_operation should be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/client"
+)
+
+// LockState UTXO 锁定状态
+type LockState int
+
+const (
+	// LockSpendable 可直接花费（单签或锁定已到期）
+	LockSpendable LockState = iota
+	// LockTime 时间锁未到期
+	LockTime
+	// LockHeight 高度锁未到期
+	LockHeight
+	// LockDelegated 委托锁定
+	LockDelegated
+	// LockOther 其他锁定（多签、合约锁、门限锁等，需额外条件才能花费）
+	LockOther
+)
+
+// String 返回锁定状态名称
+func (s LockState) String() string {
+	switch s {
+	case LockSpendable:
+		return "spendable"
+	case LockTime:
+		return "time_locked"
+	case LockHeight:
+		return "height_locked"
+	case LockDelegated:
+		return "delegated"
+	default:
+		return "locked"
+	}
+}
+
+// ClassifyLock 根据锁定条件判断锁定状态
+//
+// **规则**（按最外层锁定条件）：
+// - time_lock：unlock_timestamp 晚于 now → LockTime，否则按 base_lock 判断
+// - height_lock：unlock_height 高于 height → LockHeight，否则按 base_lock 判断
+// - delegation_lock → LockDelegated
+// - multi_key / contract / threshold → LockOther
+// - single_key 或无锁定信息 → LockSpendable
+func ClassifyLock(lock map[string]interface{}, height uint64, now int64) LockState {
+	if lock == nil {
+		return LockSpendable
+	}
+	if tl, ok := lock["time_lock"].(map[string]interface{}); ok {
+		if int64(ParseUint(tl["unlock_timestamp"])) > now {
+			return LockTime
+		}
+		return ClassifyLock(baseLock(tl), height, now)
+	}
+	if hl, ok := lock["height_lock"].(map[string]interface{}); ok {
+		if ParseUint(hl["unlock_height"]) > height {
+			return LockHeight
+		}
+		return ClassifyLock(baseLock(hl), height, now)
+	}
+	if _, ok := lock["delegation_lock"]; ok {
+		return LockDelegated
+	}
+	for _, key := range []string{"multi_key_lock", "contract_lock", "threshold_lock"} {
+		if _, ok := lock[key]; ok {
+			return LockOther
+		}
+	}
+	return LockSpendable
+}
+
+// baseLock 提取时间锁 / 高度锁解锁后的基础锁
+func baseLock(m map[string]interface{}) map[string]interface{} {
+	base, _ := m["base_lock"].(map[string]interface{})
+	return base
+}
+
+// LockingConditionOf 提取 wes_getUTXO 条目的最外层锁定条件（兼容多种字段命名）
+func LockingConditionOf(m map[string]interface{}) map[string]interface{} {
+	for _, key := range []string{"locking_condition", "lockingCondition"} {
+		if lc, ok := m[key].(map[string]interface{}); ok {
+			return lc
+		}
+	}
+	for _, key := range []string{"locking_conditions", "lockingConditions"} {
+		if arr, ok := m[key].([]interface{}); ok && len(arr) > 0 {
+			if lc, ok := arr[0].(map[string]interface{}); ok {
+				return lc
+			}
+		}
+	}
+	return nil
+}
+
+// ParseUint 解析高度 / 时间戳（支持 "0x" 十六进制字符串、十进制字符串与 JSON 数字）
+func ParseUint(v interface{}) uint64 {
+	switch h := v.(type) {
+	case float64:
+		return uint64(h)
+	case uint64:
+		return h
+	case string:
+		if strings.HasPrefix(h, "0x") {
+			n, _ := strconv.ParseUint(h[2:], 16, 64)
+			return n
+		}
+		n, _ := strconv.ParseUint(h, 10, 64)
+		return n
+	}
+	return 0
+}
+
+// QueryUTXOLock 通过 wes_getUTXO 查询 owner 名下指定 outpoint 的最外层锁定条件
+//
+// 返回 nil 表示单签或节点未返回锁定信息；owner 名下不存在该 outpoint（已花费或所有者不符）时返回错误。
+func QueryUTXOLock(ctx context.Context, c client.Client, owner []byte, txHash []byte, outputIndex uint32) (map[string]interface{}, error) {
+	addressBase58, err := AddressBytesToBase58(owner)
+	if err != nil {
+		return nil, fmt.Errorf("convert address to Base58 failed: %w", err)
+	}
+	result, err := c.Call(ctx, "wes_getUTXO", []interface{}{addressBase58})
+	if err != nil {
+		return nil, fmt.Errorf("query UTXO failed: %w", err)
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid UTXO response format")
+	}
+	utxos, _ := resultMap["utxos"].([]interface{})
+
+	want := fmt.Sprintf("%x:%d", txHash, outputIndex)
+	for _, item := range utxos {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		outpoint, _ := m["outpoint"].(string)
+		if strings.EqualFold(strings.TrimPrefix(outpoint, "0x"), want) {
+			return LockingConditionOf(m), nil
+		}
+	}
+	return nil, fmt.Errorf("utxo %s not found for owner %s", want, addressBase58)
+}
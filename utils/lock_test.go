@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func TestClassifyLock(t *testing.T) {
+	single := map[string]interface{}{"single_key_lock": map[string]interface{}{}}
+	cases := []struct {
+		name string
+		lock map[string]interface{}
+		want LockState
+	}{
+		{"nil", nil, LockSpendable},
+		{"single_key", single, LockSpendable},
+		{"time_locked", map[string]interface{}{"time_lock": map[string]interface{}{"unlock_timestamp": "2000"}}, LockTime},
+		{"time_expired", map[string]interface{}{"time_lock": map[string]interface{}{"unlock_timestamp": float64(500), "base_lock": single}}, LockSpendable},
+		{"height_locked", map[string]interface{}{"height_lock": map[string]interface{}{"unlock_height": "0x80"}}, LockHeight},
+		{"height_expired_multi_key", map[string]interface{}{"height_lock": map[string]interface{}{"unlock_height": "10", "base_lock": map[string]interface{}{"multi_key_lock": map[string]interface{}{}}}}, LockOther},
+		{"delegated", map[string]interface{}{"delegation_lock": map[string]interface{}{}}, LockDelegated},
+	}
+	for _, tc := range cases {
+		if got := ClassifyLock(tc.lock, 100, 1000); got != tc.want {
+			t.Errorf("%s: ClassifyLock = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestLockingConditionOfAndParseUint(t *testing.T) {
+	lock := map[string]interface{}{"delegation_lock": map[string]interface{}{}}
+	if got := LockingConditionOf(map[string]interface{}{"lockingConditions": []interface{}{lock}}); got == nil || got["delegation_lock"] == nil {
+		t.Fatalf("LockingConditionOf = %v", got)
+	}
+	if got := LockingConditionOf(map[string]interface{}{}); got != nil {
+		t.Fatalf("expected nil locking condition, got %v", got)
+	}
+	for v, want := range map[interface{}]uint64{"0x10": 16, "42": 42, float64(7): 7, uint64(9): 9, "bad": 0, nil: 0} {
+		if got := ParseUint(v); got != want {
+			t.Errorf("ParseUint(%v) = %d, want %d", v, got, want)
+		}
+	}
+}
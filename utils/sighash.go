@@ -0,0 +1,378 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+// SighashVerifyMode 签名哈希校验模式
+type SighashVerifyMode int
+
+const (
+	// SighashVerifyCrossCheck 调用节点计算签名哈希，并与 SDK 本地计算结果交叉校验（默认）
+	SighashVerifyCrossCheck SighashVerifyMode = iota
+
+	// SighashVerifyLocalOnly 不调用 wes_computeSignatureHashFromDraft，
+	// 仅通过 wes_buildTransaction 获取未签名交易，签名哈希完全由 SDK 本地计算
+	SighashVerifyLocalOnly
+
+	// SighashVerifyNodeOnly 直接信任节点返回的签名哈希（旧行为，不推荐）
+	SighashVerifyNodeOnly
+)
+
+// ErrSighashMismatch 节点返回的签名哈希或未签名交易与本地计算不一致
+var ErrSighashMismatch = errors.New("signature hash mismatch")
+
+// SighashMismatchError 签名哈希校验失败详情
+type SighashMismatchError struct {
+	InputIndex uint32
+	NodeHash   []byte // 节点返回的哈希（草稿不一致时为 nil）
+	LocalHash  []byte // SDK 本地计算的哈希（草稿不一致时为 nil）
+	Reason     string
+}
+
+func (e *SighashMismatchError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("signature hash mismatch at input %d: %s", e.InputIndex, e.Reason)
+	}
+	return fmt.Sprintf("signature hash mismatch at input %d: node=%x local=%x", e.InputIndex, e.NodeHash, e.LocalHash)
+}
+
+// Is 支持 errors.Is(err, ErrSighashMismatch)
+func (e *SighashMismatchError) Is(target error) bool {
+	return target == ErrSighashMismatch
+}
+
+type sighashVerifyModeKey struct{}
+
+// WithSighashVerifyMode 返回携带签名哈希校验模式的 context，所有业务服务的签名流程都会遵循该模式
+func WithSighashVerifyMode(ctx context.Context, mode SighashVerifyMode) context.Context {
+	return context.WithValue(ctx, sighashVerifyModeKey{}, mode)
+}
+
+// sighashVerifyModeFromContext 读取 context 中的校验模式（默认交叉校验）
+func sighashVerifyModeFromContext(ctx context.Context) SighashVerifyMode {
+	if mode, ok := ctx.Value(sighashVerifyModeKey{}).(SighashVerifyMode); ok {
+		return mode
+	}
+	return SighashVerifyCrossCheck
+}
+
+// DraftSighash 草稿签名哈希计算结果
+type DraftSighash struct {
+	// UnsignedTx 未签名交易（hex，节点原样返回，供 wes_finalizeTransactionFromDraft 使用）
+	UnsignedTx string
+
+	// Tx 本地解码的未签名交易（SighashVerifyNodeOnly 模式下为 nil）
+	Tx *txcodec.Transaction
+
+	// SighashType 签名哈希类型
+	SighashType txcodec.SighashType
+
+	hashes map[uint32][]byte
+}
+
+// Hash 返回指定输入的签名哈希（未计算时返回 nil）
+func (d *DraftSighash) Hash(inputIndex uint32) []byte {
+	return d.hashes[inputIndex]
+}
+
+// ComputeDraftSighash 计算草稿中各输入的签名哈希
+//
+// **流程**（默认交叉校验模式）：
+// 1. 为每个输入调用 `wes_computeSignatureHashFromDraft`
+// 2. 本地解码节点返回的 unsignedTx，并核对输入/输出与草稿一致
+// 3. 本地计算签名哈希，与节点返回值逐一比对，不一致立即中止
+//
+// **说明**：
+// - 校验模式通过 WithSighashVerifyMode 设置，默认 SighashVerifyCrossCheck
+// - 校验失败返回 *SighashMismatchError（可用 errors.Is(err, ErrSighashMismatch) 判断）
+func ComputeDraftSighash(
+	ctx context.Context,
+	c client.Client,
+	draftJSON []byte,
+	inputIndices []uint32,
+	sighashType txcodec.SighashType,
+) (*DraftSighash, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	if len(inputIndices) == 0 {
+		return nil, fmt.Errorf("no inputs to sign")
+	}
+
+	mode := sighashVerifyModeFromContext(ctx)
+	result := &DraftSighash{
+		SighashType: sighashType,
+		hashes:      make(map[uint32][]byte, len(inputIndices)),
+	}
+
+	// 1. 本地模式：仅向节点获取未签名交易
+	if mode == SighashVerifyLocalOnly {
+		unsignedTxHex, err := buildUnsignedTx(ctx, c, draftJSON)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.decodeAndCheck(unsignedTxHex, draftJSON); err != nil {
+			return nil, err
+		}
+		for _, idx := range inputIndices {
+			localHash, err := txcodec.ComputeSighash(result.Tx, idx, sighashType)
+			if err != nil {
+				return nil, fmt.Errorf("compute local signature hash for input %d failed: %w", idx, err)
+			}
+			result.hashes[idx] = localHash
+		}
+		return result, nil
+	}
+
+	// 2. 节点计算（交叉校验 / 仅节点）
+	for _, idx := range inputIndices {
+		nodeHash, unsignedTxHex, err := computeNodeSighash(ctx, c, draftJSON, idx, sighashType)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.UnsignedTx == "" {
+			result.UnsignedTx = unsignedTxHex
+		} else if unsignedTxHex != "" && !strings.EqualFold(strings.TrimPrefix(unsignedTxHex, "0x"), strings.TrimPrefix(result.UnsignedTx, "0x")) {
+			return nil, &SighashMismatchError{InputIndex: idx, Reason: "node returned different unsignedTx for the same draft"}
+		}
+
+		if mode == SighashVerifyCrossCheck {
+			if result.Tx == nil {
+				if unsignedTxHex == "" {
+					return nil, &SighashMismatchError{InputIndex: idx, Reason: "node did not return unsignedTx, cannot verify"}
+				}
+				if err := result.decodeAndCheck(unsignedTxHex, draftJSON); err != nil {
+					return nil, err
+				}
+			}
+			localHash, err := txcodec.ComputeSighash(result.Tx, idx, sighashType)
+			if err != nil {
+				return nil, fmt.Errorf("compute local signature hash for input %d failed: %w", idx, err)
+			}
+			if !bytes.Equal(localHash, nodeHash) {
+				return nil, &SighashMismatchError{InputIndex: idx, NodeHash: nodeHash, LocalHash: localHash}
+			}
+		}
+
+		result.hashes[idx] = nodeHash
+	}
+
+	return result, nil
+}
+
+// DraftSighashFromUnsignedTx 不访问节点，直接由草稿及其未签名交易在本地计算签名哈希
+//
+// 用于收到他人转交的未签名草稿（如 wallet.UnsignedDraft）的签名方：核对未签名交易与草稿一致后
+// 自行计算哈希，不信任转交方给出的签名哈希。
+func DraftSighashFromUnsignedTx(draftJSON []byte, unsignedTxHex string, inputIndices []uint32, sighashType txcodec.SighashType) (*DraftSighash, error) {
+	if len(inputIndices) == 0 {
+		return nil, fmt.Errorf("no inputs to sign")
+	}
+	result := &DraftSighash{
+		SighashType: sighashType,
+		hashes:      make(map[uint32][]byte, len(inputIndices)),
+	}
+	if err := result.decodeAndCheck(unsignedTxHex, draftJSON); err != nil {
+		return nil, err
+	}
+	for _, idx := range inputIndices {
+		localHash, err := txcodec.ComputeSighash(result.Tx, idx, sighashType)
+		if err != nil {
+			return nil, fmt.Errorf("compute local signature hash for input %d failed: %w", idx, err)
+		}
+		result.hashes[idx] = localHash
+	}
+	return result, nil
+}
+
+// decodeAndCheck 解码未签名交易并核对其与草稿一致
+func (d *DraftSighash) decodeAndCheck(unsignedTxHex string, draftJSON []byte) error {
+	tx, err := txcodec.DecodeHex(unsignedTxHex)
+	if err != nil {
+		return fmt.Errorf("decode unsignedTx failed: %w", err)
+	}
+	if err := VerifyTxMatchesDraft(tx, draftJSON); err != nil {
+		return err
+	}
+	d.UnsignedTx = unsignedTxHex
+	d.Tx = tx
+	return nil
+}
+
+// computeNodeSighash 调用 wes_computeSignatureHashFromDraft 获取单个输入的签名哈希
+func computeNodeSighash(
+	ctx context.Context,
+	c client.Client,
+	draftJSON []byte,
+	inputIndex uint32,
+	sighashType txcodec.SighashType,
+) ([]byte, string, error) {
+	hashParams := map[string]interface{}{
+		"draft":        json.RawMessage(draftJSON),
+		"input_index":  inputIndex,
+		"sighash_type": sighashType.String(),
+	}
+	hashResult, err := c.Call(ctx, "wes_computeSignatureHashFromDraft", hashParams)
+	if err != nil {
+		return nil, "", fmt.Errorf("call wes_computeSignatureHashFromDraft for input %d failed: %w", inputIndex, err)
+	}
+
+	hashMap, ok := hashResult.(map[string]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("invalid response format from wes_computeSignatureHashFromDraft for input %d", inputIndex)
+	}
+	hashHex, ok := hashMap["hash"].(string)
+	if !ok || hashHex == "" {
+		return nil, "", fmt.Errorf("missing hash in wes_computeSignatureHashFromDraft response for input %d", inputIndex)
+	}
+
+	hashBytes, err := hex.DecodeString(strings.TrimPrefix(hashHex, "0x"))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode signature hash for input %d failed: %w", inputIndex, err)
+	}
+
+	unsignedTxHex, _ := hashMap["unsignedTx"].(string)
+	return hashBytes, unsignedTxHex, nil
+}
+
+// buildUnsignedTx 调用 wes_buildTransaction 获取草稿对应的未签名交易
+func buildUnsignedTx(ctx context.Context, c client.Client, draftJSON []byte) (string, error) {
+	result, err := c.Call(ctx, "wes_buildTransaction", map[string]interface{}{
+		"draft": json.RawMessage(draftJSON),
+	})
+	if err != nil {
+		return "", fmt.Errorf("call wes_buildTransaction failed: %w", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_buildTransaction")
+	}
+	unsignedTxHex, ok := resultMap["unsignedTx"].(string)
+	if !ok || unsignedTxHex == "" {
+		return "", fmt.Errorf("missing unsignedTx in wes_buildTransaction response")
+	}
+	return unsignedTxHex, nil
+}
+
+// VerifyTxMatchesDraft 核对节点构建的未签名交易与 SDK 草稿一致
+//
+// **检查项**：
+// - 输入数量、顺序、引用的 outpoint 及 is_reference_only 完全一致
+// - 输出数量一致；草稿中给出 owner / amount / token_id 的输出，解码结果必须逐一相同
+// - 输出锁定条件与草稿 locking_condition(s) 逐字段一致（草稿未给出的字段按零值比较）；
+//   草稿未给出锁定条件时须为 owner 的单签锁（见 DraftLockingConditions）
+// - 草稿给出 chain_id 时，交易的 chain_id 必须相同
+//
+// 用于防止节点替换收款人、金额、锁定条件或目标链，以及额外花费用户的 UTXO。
+func VerifyTxMatchesDraft(tx *txcodec.Transaction, draftJSON []byte) error {
+	var draft struct {
+		Inputs []struct {
+			TxHash          string `json:"tx_hash"`
+			OutputIndex     uint32 `json:"output_index"`
+			IsReferenceOnly bool   `json:"is_reference_only"`
+		} `json:"inputs"`
+		Outputs []map[string]interface{} `json:"outputs"`
+		ChainID interface{}              `json:"chain_id"`
+	}
+	if err := json.Unmarshal(draftJSON, &draft); err != nil {
+		return fmt.Errorf("parse draft failed: %w", err)
+	}
+
+	mismatch := func(format string, args ...interface{}) error {
+		return &SighashMismatchError{Reason: fmt.Sprintf("unsignedTx does not match draft: "+format, args...)}
+	}
+
+	// 0. 链 ID
+	var chainID string
+	switch v := draft.ChainID.(type) {
+	case string:
+		chainID = v
+	case float64:
+		chainID = fmt.Sprintf("%d", uint64(v))
+	}
+	if chainID != "" && !chainIDMatches(tx.ChainID, chainID) {
+		return mismatch("chain_id %x, draft has %s", tx.ChainID, chainID)
+	}
+
+	// 1. 输入
+	if len(tx.Inputs) != len(draft.Inputs) {
+		return mismatch("inputs count %d, draft has %d", len(tx.Inputs), len(draft.Inputs))
+	}
+	for i, want := range draft.Inputs {
+		got := tx.Inputs[i]
+		wantTxID, err := hex.DecodeString(strings.TrimPrefix(want.TxHash, "0x"))
+		if err != nil {
+			return fmt.Errorf("draft input %d: invalid tx_hash: %w", i, err)
+		}
+		if !bytes.Equal(got.PreviousOutput.TxID, wantTxID) ||
+			got.PreviousOutput.OutputIndex != want.OutputIndex ||
+			got.IsReferenceOnly != want.IsReferenceOnly {
+			return mismatch("input %d spends %x:%d, draft has %s:%d", i,
+				got.PreviousOutput.TxID, got.PreviousOutput.OutputIndex, want.TxHash, want.OutputIndex)
+		}
+	}
+
+	// 2. 输出
+	if len(tx.Outputs) != len(draft.Outputs) {
+		return mismatch("outputs count %d, draft has %d", len(tx.Outputs), len(draft.Outputs))
+	}
+	for i, want := range draft.Outputs {
+		got := tx.Outputs[i]
+
+		if ownerHex, ok := want["owner"].(string); ok && ownerHex != "" {
+			wantOwner, err := hex.DecodeString(strings.TrimPrefix(ownerHex, "0x"))
+			if err != nil {
+				return fmt.Errorf("draft output %d: invalid owner: %w", i, err)
+			}
+			if !bytes.Equal(got.Owner, wantOwner) {
+				return mismatch("output %d owner %x, draft has %s", i, got.Owner, ownerHex)
+			}
+		}
+
+		if amountStr, ok := want["amount"].(string); ok && amountStr != "" {
+			wantAmount, ok1 := new(big.Int).SetString(amountStr, 10)
+			gotAmount, ok2 := new(big.Int).SetString(got.Amount(), 10)
+			if !ok1 || !ok2 || wantAmount.Cmp(gotAmount) != 0 {
+				return mismatch("output %d amount %q, draft has %q", i, got.Amount(), amountStr)
+			}
+		}
+
+		if tokenHex, ok := want["token_id"].(string); ok && tokenHex != "" {
+			wantToken, err := hex.DecodeString(strings.TrimPrefix(tokenHex, "0x"))
+			if err != nil {
+				return fmt.Errorf("draft output %d: invalid token_id: %w", i, err)
+			}
+			if !bytes.Equal(got.TokenID(), wantToken) {
+				return mismatch("output %d token %x, draft has %s", i, got.TokenID(), tokenHex)
+			}
+		}
+
+		wantLocks, err := DraftLockingConditions(got.Owner, want)
+		if err != nil {
+			return fmt.Errorf("draft output %d: %w", i, err)
+		}
+		if len(got.LockingConditions) != len(wantLocks) {
+			return mismatch("output %d has %d locking conditions, draft has %d", i, len(got.LockingConditions), len(wantLocks))
+		}
+		for j, wantLock := range wantLocks {
+			if reason := matchLock(got.LockingConditions[j], wantLock); reason != "" {
+				return mismatch("output %d locking condition %d: %s", i, j, reason)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+// sighashMockClient 模拟节点的签名哈希相关接口
+type sighashMockClient struct {
+	unsignedTx *txcodec.Transaction
+	tamperHash bool
+	calls      map[string]int
+}
+
+func (m *sighashMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+
+	txHex, err := txcodec.EncodeHex(m.unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "wes_buildTransaction":
+		return map[string]interface{}{"unsignedTx": txHex}, nil
+	case "wes_computeSignatureHashFromDraft":
+		idx := params.(map[string]interface{})["input_index"].(uint32)
+		hash, err := txcodec.ComputeSighash(m.unsignedTx, idx, txcodec.SighashAll)
+		if err != nil {
+			return nil, err
+		}
+		if m.tamperHash {
+			hash[0] ^= 0xff
+		}
+		return map[string]interface{}{"hash": hex.EncodeToString(hash), "unsignedTx": txHex}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *sighashMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *sighashMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *sighashMockClient) Close() error { return nil }
+
+// sighashFixture 构造一份草稿及其对应的未签名交易
+func sighashFixture() ([]byte, *txcodec.Transaction) {
+	prevTx := bytes.Repeat([]byte{0xab}, 32)
+	from := bytes.Repeat([]byte{0x01}, 20)
+	to := bytes.Repeat([]byte{0x02}, 20)
+
+	draft := map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs": []map[string]interface{}{
+			{"tx_hash": hex.EncodeToString(prevTx), "output_index": 0, "is_reference_only": false},
+		},
+		"outputs": []map[string]interface{}{
+			{"type": "asset", "owner": hex.EncodeToString(to), "amount": "100"},
+			{"type": "asset", "owner": hex.EncodeToString(from), "amount": "900"},
+		},
+		"chain_id": "0x1",
+	}
+	draftJSON, _ := json.Marshal(draft)
+
+	tx := &txcodec.Transaction{
+		Version: 1,
+		Inputs:  []*txcodec.TxInput{{PreviousOutput: txcodec.OutPoint{TxID: prevTx}}},
+		Outputs: []*txcodec.TxOutput{
+			{Owner: to, Asset: &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: "100"}}, LockingConditions: singleKeyLocks(to)},
+			{Owner: from, Asset: &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: "900"}}, LockingConditions: singleKeyLocks(from)},
+		},
+		ChainID: []byte{0x01},
+	}
+	return draftJSON, tx
+}
+
+func singleKeyLocks(owner []byte) []*txcodec.LockingCondition {
+	return []*txcodec.LockingCondition{{SingleKey: &txcodec.SingleKeyLock{RequiredAddressHash: owner}}}
+}
+
+func TestComputeDraftSighash_CrossCheck(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	mock := &sighashMockClient{unsignedTx: tx}
+
+	result, err := ComputeDraftSighash(context.Background(), mock, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeDraftSighash() error = %v", err)
+	}
+
+	want, _ := txcodec.ComputeSighash(tx, 0, txcodec.SighashAll)
+	if !bytes.Equal(result.Hash(0), want) {
+		t.Errorf("Hash(0) = %x, want %x", result.Hash(0), want)
+	}
+	if result.Tx == nil || result.UnsignedTx == "" {
+		t.Errorf("expected decoded tx and unsignedTx in result")
+	}
+}
+
+func TestComputeDraftSighash_HashMismatch(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	mock := &sighashMockClient{unsignedTx: tx, tamperHash: true}
+
+	_, err := ComputeDraftSighash(context.Background(), mock, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if !errors.Is(err, ErrSighashMismatch) {
+		t.Fatalf("ComputeDraftSighash() error = %v, want ErrSighashMismatch", err)
+	}
+
+	// 仅信任节点模式不做校验
+	ctx := WithSighashVerifyMode(context.Background(), SighashVerifyNodeOnly)
+	if _, err := ComputeDraftSighash(ctx, mock, draftJSON, []uint32{0}, txcodec.SighashAll); err != nil {
+		t.Errorf("node-only mode error = %v", err)
+	}
+}
+
+func TestComputeDraftSighash_DraftMismatch(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	// 节点将收款人替换为其他地址
+	tx.Outputs[0].Owner = bytes.Repeat([]byte{0x66}, 20)
+	mock := &sighashMockClient{unsignedTx: tx}
+
+	_, err := ComputeDraftSighash(context.Background(), mock, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if !errors.Is(err, ErrSighashMismatch) {
+		t.Fatalf("ComputeDraftSighash() error = %v, want ErrSighashMismatch", err)
+	}
+}
+
+func TestComputeDraftSighash_LocalOnly(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	mock := &sighashMockClient{unsignedTx: tx}
+
+	ctx := WithSighashVerifyMode(context.Background(), SighashVerifyLocalOnly)
+	result, err := ComputeDraftSighash(ctx, mock, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeDraftSighash() error = %v", err)
+	}
+	if mock.calls["wes_computeSignatureHashFromDraft"] != 0 {
+		t.Errorf("local-only mode should not call wes_computeSignatureHashFromDraft")
+	}
+	if len(result.Hash(0)) != 32 {
+		t.Errorf("Hash(0) length = %d, want 32", len(result.Hash(0)))
+	}
+}
+
+func TestDraftSighashFromUnsignedTx(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	txHex, _ := txcodec.EncodeHex(tx)
+
+	result, err := DraftSighashFromUnsignedTx(draftJSON, txHex, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		t.Fatalf("DraftSighashFromUnsignedTx() error = %v", err)
+	}
+	want, _ := txcodec.ComputeSighash(tx, 0, txcodec.SighashAll)
+	if !bytes.Equal(result.Hash(0), want) || result.Tx == nil {
+		t.Errorf("Hash(0) = %x, want %x", result.Hash(0), want)
+	}
+
+	// 未签名交易与草稿不符
+	tx.Outputs[0].Owner = bytes.Repeat([]byte{0x03}, 20)
+	tamperedHex, _ := txcodec.EncodeHex(tx)
+	if _, err := DraftSighashFromUnsignedTx(draftJSON, tamperedHex, []uint32{0}, txcodec.SighashAll); !errors.Is(err, ErrSighashMismatch) {
+		t.Fatalf("DraftSighashFromUnsignedTx() error = %v, want ErrSighashMismatch", err)
+	}
+}
+
+func TestVerifyTxMatchesDraft_LocksAndChainID(t *testing.T) {
+	draftJSON, tx := sighashFixture()
+	if err := VerifyTxMatchesDraft(tx, draftJSON); err != nil {
+		t.Fatalf("VerifyTxMatchesDraft() error = %v", err)
+	}
+
+	// 收款人不变，但锁定到其他地址
+	_, locked := sighashFixture()
+	locked.Outputs[0].LockingConditions = singleKeyLocks(bytes.Repeat([]byte{0x66}, 20))
+	if err := VerifyTxMatchesDraft(locked, draftJSON); !errors.Is(err, ErrSighashMismatch) {
+		t.Errorf("replaced single key lock: error = %v, want ErrSighashMismatch", err)
+	}
+
+	// 草稿指定时间锁，节点改写解锁时间
+	var draft map[string]interface{}
+	json.Unmarshal(draftJSON, &draft)
+	to := bytes.Repeat([]byte{0x02}, 20)
+	draft["outputs"].([]interface{})[0].(map[string]interface{})["locking_condition"] = map[string]interface{}{
+		"time_lock": map[string]interface{}{
+			"unlock_timestamp": 1700000000,
+			"base_lock":        map[string]interface{}{"single_key_lock": map[string]interface{}{"required_address_hash": hex.EncodeToString(to)}},
+		},
+	}
+	timeLocked, _ := json.Marshal(draft)
+	_, tlTx := sighashFixture()
+	tlTx.Outputs[0].LockingConditions = []*txcodec.LockingCondition{{TimeLock: &txcodec.TimeLock{UnlockTimestamp: 1700000000, BaseLock: singleKeyLocks(to)[0]}}}
+	if err := VerifyTxMatchesDraft(tlTx, timeLocked); err != nil {
+		t.Fatalf("time lock: error = %v", err)
+	}
+	tlTx.Outputs[0].LockingConditions[0].TimeLock.UnlockTimestamp = 1800000000
+	if err := VerifyTxMatchesDraft(tlTx, timeLocked); !errors.Is(err, ErrSighashMismatch) {
+		t.Errorf("rewritten time lock: error = %v, want ErrSighashMismatch", err)
+	}
+	if err := VerifyTxMatchesDraft(tx, timeLocked); !errors.Is(err, ErrSighashMismatch) {
+		t.Errorf("dropped time lock: error = %v, want ErrSighashMismatch", err)
+	}
+
+	// 目标链不同
+	tx.ChainID = []byte{0x02}
+	if err := VerifyTxMatchesDraft(tx, draftJSON); !errors.Is(err, ErrSighashMismatch) {
+		t.Errorf("chain id: error = %v, want ErrSighashMismatch", err)
+	}
+}
@@ -2,13 +2,13 @@ package utils
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strconv"
 
 	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // ParsedTx 解析后的交易信息
@@ -41,16 +41,17 @@ type ParsedOutput struct {
 	Outpoint  string // 格式: "txHash:index"
 }
 
-// parseOwnerAddress 解析 owner 地址，仅支持 Base64 格式（WES 标准格式）
+// parseOwnerAddress 解析 owner 地址（types.ParseAddress 支持的任意格式），失败时返回 nil
 func parseOwnerAddress(ownerStr string) []byte {
 	if ownerStr == "" {
 		return nil
 	}
-	// 仅支持 Base64 解码（WES API 返回的标准格式）
-	if ownerBytes, err := base64.StdEncoding.DecodeString(ownerStr); err == nil && len(ownerBytes) == 20 {
-		return ownerBytes
+	// WES API 返回 Base64，兼容 hex / Base58 等其他地址格式
+	addr, err := types.ParseAddress(ownerStr)
+	if err != nil {
+		return nil
 	}
-	return nil
+	return addr.Bytes()
 }
 
 // FetchAndParseTx 获取并解析交易
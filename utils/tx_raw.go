@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+)
+
+// ParseRawTx 在本地解析原始交易（hex，支持 0x 前缀），无需调用节点
+//
+// **用途**：
+// - 签名前检查 `wes_computeSignatureHashFromDraft` 返回的 unsignedTx
+// - 解析离线保存的已签名交易
+//
+// **说明**：
+// - 返回结果与 FetchAndParseTx 结构一致，但 Status 固定为 "unsigned" 或 "signed"
+// - 区块相关字段（BlockHeight / BlockHash / TxIndex）与 Hash 无法从原始字节得到，保持零值
+// - txHash 仅用于计算输出的 outpoint，可传空字符串
+func ParseRawTx(rawTxHex string, txHash string) (*ParsedTx, error) {
+	tx, err := txcodec.DecodeHex(rawTxHex)
+	if err != nil {
+		return nil, err
+	}
+	return ParsedTxFromDecoded(tx, txHash), nil
+}
+
+// ParsedTxFromDecoded 将 txcodec 解码结果转换为 ParsedTx
+func ParsedTxFromDecoded(tx *txcodec.Transaction, txHash string) *ParsedTx {
+	status := "unsigned"
+	if tx.IsSigned() {
+		status = "signed"
+	}
+
+	parsed := &ParsedTx{
+		Hash:   txHash,
+		Status: status,
+	}
+
+	for _, in := range tx.Inputs {
+		parsed.Inputs = append(parsed.Inputs, ParsedInput{
+			TxHash:      hex.EncodeToString(in.PreviousOutput.TxID),
+			OutputIndex: in.PreviousOutput.OutputIndex,
+			IsReference: in.IsReferenceOnly,
+		})
+	}
+
+	for idx, out := range tx.Outputs {
+		output := ParsedOutput{
+			Index:    uint32(idx),
+			Owner:    out.Owner,
+			TokenID:  out.TokenID(),
+			Outpoint: fmt.Sprintf("%s:%d", txHash, idx),
+		}
+
+		switch {
+		case out.Asset != nil:
+			output.Type = "asset"
+			if amountStr := out.Amount(); amountStr != "" {
+				if amount, ok := new(big.Int).SetString(amountStr, 10); ok {
+					output.Amount = amount
+				}
+			}
+		case out.State != nil:
+			output.Type = "state"
+			output.StateID = out.State.StateID
+			output.StateData = out.State.ExecutionResultHash
+		case out.Resource != nil:
+			output.Type = "resource"
+		}
+
+		parsed.Outputs = append(parsed.Outputs, output)
+	}
+
+	return parsed
+}
@@ -0,0 +1,138 @@
+package utxoindex
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// indexedClient 由本地索引应答 wes_getUTXO 的 Client 包装
+//
+// 除监视地址的最新 UTXO 查询与 SendRawTransaction 外，其余调用直接透传给底层 Client。
+type indexedClient struct {
+	ix *Index
+}
+
+// Client 返回由本地索引加速的 client.Client
+//
+//   - wes_getUTXO(address)：监视地址由本地索引应答（排除已被本地提交交易花费的条目）；
+//     未监视地址、指定区块高度或按 outpoint 查询时透传给节点
+//   - SendRawTransaction：节点接受后标记输入已花费并记录监视地址的未确认输出；
+//     节点拒绝时立即从节点重同步相关地址
+func (ix *Index) Client() client.Client {
+	return &indexedClient{ix: ix}
+}
+
+// Call 应答监视地址的 wes_getUTXO，其余调用透传
+func (c *indexedClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if method == "wes_getUTXO" {
+		if address, ok := c.watchedAddressParam(params); ok {
+			return map[string]interface{}{"utxos": c.ix.wireUTXOs(address)}, nil
+		}
+	}
+	return c.ix.client.Call(ctx, method, params)
+}
+
+// watchedAddressParam 解析 wes_getUTXO 的单个 Base58 地址参数，并判断是否为监视地址
+func (c *indexedClient) watchedAddressParam(params interface{}) ([]byte, bool) {
+	args, ok := params.([]interface{})
+	if !ok || len(args) != 1 {
+		// 带区块参数的历史查询由节点应答
+		return nil, false
+	}
+	addressBase58, ok := args[0].(string)
+	if !ok {
+		return nil, false
+	}
+	address, err := utils.AddressBase58ToBytes(addressBase58)
+	if err != nil || !c.ix.IsWatched(address) {
+		return nil, false
+	}
+	return address, true
+}
+
+// SendRawTransaction 广播交易并更新本地索引
+func (c *indexedClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	result, err := c.ix.client.SendRawTransaction(ctx, signedTxHex)
+	if err != nil {
+		return result, err
+	}
+
+	tx, decodeErr := txcodec.DecodeHex(signedTxHex)
+	if decodeErr != nil {
+		// 无法解析的交易不影响广播结果，由后续同步修正索引
+		return result, nil
+	}
+	inputs := make([]string, 0, len(tx.Inputs))
+	for _, in := range tx.Inputs {
+		if in.IsReferenceOnly {
+			continue
+		}
+		inputs = append(inputs, fmt.Sprintf("%s:%d", hex.EncodeToString(in.PreviousOutput.TxID), in.PreviousOutput.OutputIndex))
+	}
+
+	if !result.Accepted {
+		// 节点拒绝：本地视图可能已过期（如输入已被花费），以节点为准重同步
+		for _, address := range c.ix.owners(inputs) {
+			_ = c.ix.Sync(ctx, address)
+		}
+		return result, nil
+	}
+
+	txHash := strings.TrimPrefix(result.TxHash, "0x")
+	var outputs []*Entry
+	for i, out := range tx.Outputs {
+		if out.Asset == nil || !c.ix.IsWatched(out.Owner) {
+			continue
+		}
+		e, err := entryFromTxOutput(txHash, i, out)
+		if err != nil {
+			continue
+		}
+		outputs = append(outputs, e)
+	}
+	c.ix.markSubmitted(ctx, txHash, inputs, outputs)
+	return result, nil
+}
+
+// Subscribe 透传事件订阅
+func (c *indexedClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return c.ix.client.Subscribe(ctx, filter)
+}
+
+// Close 关闭底层 Client
+func (c *indexedClient) Close() error {
+	return c.ix.client.Close()
+}
+
+// wireUTXOs 以 wes_getUTXO 扁平格式返回监视地址的可见条目
+//
+// 排除已被本地提交交易花费的条目与未达到 MinConfirmations 的条目
+// （开启 SpendUnconfirmed 时保留本地未确认输出）；锁定条目原样返回，由调用方按锁定条件判断。
+func (ix *Index) wireUTXOs(address []byte) []interface{} {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	state, ok := ix.addrs[hex.EncodeToString(address)]
+	if !ok {
+		return []interface{}{}
+	}
+	utxos := make([]interface{}, 0, len(state.entries))
+	for _, e := range sortedEntries(state.entries) {
+		if e.SpentBy != "" {
+			continue
+		}
+		if !e.Confirmed() && !ix.opts.SpendUnconfirmed {
+			continue
+		}
+		if e.Confirmed() && e.Confirmations(ix.tip) < ix.opts.MinConfirmations {
+			continue
+		}
+		utxos = append(utxos, e.wire())
+	}
+	return utxos
+}
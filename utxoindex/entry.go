@@ -0,0 +1,178 @@
+package utxoindex
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// Entry 索引中的单个 UTXO
+type Entry struct {
+	Outpoint         string                 `json:"outpoint"`                    // "txHash:outputIndex"
+	Address          []byte                 `json:"address"`                     // 所属地址（20字节）
+	Amount           types.Amount           `json:"amount"`                      // 金额
+	TokenID          []byte                 `json:"token_id,omitempty"`          // 代币ID（nil 表示原生币）
+	Height           uint64                 `json:"height"`                      // 所在区块高度（0 表示未知）
+	LockingCondition map[string]interface{} `json:"locking_condition,omitempty"` // 最外层锁定条件（nil 表示单签）
+	SpentBy          string                 `json:"spent_by,omitempty"`          // 已被本地提交但尚未确认的交易花费
+	Local            bool                   `json:"local,omitempty"`             // 由本地提交、尚未被节点返回的输出
+}
+
+// clone 复制条目（对外返回副本，避免调用方修改索引内部状态）
+func (e *Entry) clone() *Entry {
+	c := *e
+	return &c
+}
+
+// wire 转换为 wes_getUTXO 返回的扁平条目格式
+func (e *Entry) wire() map[string]interface{} {
+	m := map[string]interface{}{
+		"outpoint": e.Outpoint,
+		"amount":   e.Amount.String(),
+		"height":   float64(e.Height), // 与 JSON 解码后的数字类型保持一致
+	}
+	if len(e.TokenID) > 0 {
+		m["tokenID"] = hex.EncodeToString(e.TokenID)
+	}
+	if e.LockingCondition != nil {
+		m["locking_condition"] = e.LockingCondition
+	}
+	return m
+}
+
+// entryFromUTXO 由 WESClient.ListUTXOs 的结果构造条目
+func entryFromUTXO(address []byte, u *client.UTXO) (*Entry, error) {
+	if u.OutPoint.TxID == "" {
+		return nil, fmt.Errorf("utxo without outpoint")
+	}
+	amountStr, _ := u.Output["amount"].(string)
+	amount, err := types.ParseAmount(amountStr)
+	if err != nil {
+		return nil, fmt.Errorf("utxo %s:%d: invalid amount %q: %w", u.OutPoint.TxID, u.OutPoint.OutputIndex, amountStr, err)
+	}
+
+	e := &Entry{
+		Outpoint: fmt.Sprintf("%s:%d", strings.TrimPrefix(u.OutPoint.TxID, "0x"), u.OutPoint.OutputIndex),
+		Address:  address,
+		Amount:   amount,
+		Height:   ParseUint(u.Output["height"]),
+	}
+	for _, key := range []string{"tokenID", "token_id"} {
+		if s, ok := u.Output[key].(string); ok && s != "" {
+			tokenID, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("utxo %s: invalid token ID: %w", e.Outpoint, err)
+			}
+			e.TokenID = tokenID
+			break
+		}
+	}
+	if len(u.LockingCondition) > 0 {
+		e.LockingCondition = u.LockingCondition
+	}
+	return e, nil
+}
+
+// entryFromTxOutput 由本地提交交易的输出构造未确认条目
+func entryFromTxOutput(txHash string, index int, out *txcodec.TxOutput) (*Entry, error) {
+	amount, err := types.ParseAmount(out.Amount())
+	if err != nil {
+		return nil, fmt.Errorf("output %d: invalid amount %q: %w", index, out.Amount(), err)
+	}
+	e := &Entry{
+		Outpoint: fmt.Sprintf("%s:%d", txHash, index),
+		Address:  out.Owner,
+		Amount:   amount,
+		TokenID:  out.TokenID(),
+		Local:    true,
+	}
+	if len(out.LockingConditions) > 0 {
+		e.LockingCondition = lockingConditionMap(out.LockingConditions[0])
+	}
+	return e, nil
+}
+
+// lockingConditionMap 将编码层锁定条件转换为 wes_getUTXO 的 map 形式（仅保留锁定状态判断所需字段）
+func lockingConditionMap(lock *txcodec.LockingCondition) map[string]interface{} {
+	switch {
+	case lock == nil, lock.SingleKey != nil:
+		return nil
+	case lock.TimeLock != nil:
+		return map[string]interface{}{"time_lock": map[string]interface{}{
+			"unlock_timestamp": float64(lock.TimeLock.UnlockTimestamp),
+			"base_lock":        lockingConditionMap(lock.TimeLock.BaseLock),
+		}}
+	case lock.HeightLock != nil:
+		return map[string]interface{}{"height_lock": map[string]interface{}{
+			"unlock_height": float64(lock.HeightLock.UnlockHeight),
+			"base_lock":     lockingConditionMap(lock.HeightLock.BaseLock),
+		}}
+	case lock.Delegation != nil:
+		return map[string]interface{}{"delegation_lock": map[string]interface{}{}}
+	case lock.MultiKey != nil:
+		return map[string]interface{}{"multi_key_lock": map[string]interface{}{}}
+	case lock.Contract != nil:
+		return map[string]interface{}{"contract_lock": map[string]interface{}{}}
+	case lock.Threshold != nil:
+		return map[string]interface{}{"threshold_lock": map[string]interface{}{}}
+	}
+	return nil
+}
+
+// Confirmed 是否已上链（节点返回的条目均视为已上链）
+func (e *Entry) Confirmed() bool {
+	return !e.Local
+}
+
+// Confirmations 在指定链高下的确认数
+//
+// 本地未确认输出为 0；节点未返回高度时按 1 计。
+func (e *Entry) Confirmations(tip uint64) uint64 {
+	switch {
+	case e.Local:
+		return 0
+	case e.Height == 0:
+		return 1
+	case tip < e.Height:
+		return 0
+	}
+	return tip - e.Height + 1
+}
+
+// LockState 在指定链高与时间下的锁定状态
+func (e *Entry) LockState(tip uint64, now time.Time) LockState {
+	return ClassifyLock(e.LockingCondition, tip, now.Unix())
+}
+
+// LockState UTXO 锁定状态（见 utils.LockState）
+type LockState = utils.LockState
+
+// 锁定状态取值（见 utils.LockSpendable 等）
+const (
+	LockSpendable = utils.LockSpendable
+	LockTime      = utils.LockTime
+	LockHeight    = utils.LockHeight
+	LockDelegated = utils.LockDelegated
+	LockOther     = utils.LockOther
+)
+
+// ClassifyLock 根据锁定条件判断锁定状态（见 utils.ClassifyLock）
+func ClassifyLock(lock map[string]interface{}, height uint64, now int64) LockState {
+	return utils.ClassifyLock(lock, height, now)
+}
+
+// LockingConditionOf 提取 wes_getUTXO 条目的最外层锁定条件（见 utils.LockingConditionOf）
+func LockingConditionOf(m map[string]interface{}) map[string]interface{} {
+	return utils.LockingConditionOf(m)
+}
+
+// ParseUint 解析高度 / 时间戳（见 utils.ParseUint）
+func ParseUint(v interface{}) uint64 {
+	return utils.ParseUint(v)
+}
@@ -0,0 +1,575 @@
+// Package utxoindex 提供钱包侧的本地 UTXO 索引
+//
+// **问题**：
+// 转账、质押、托管等构建器每次都调用 wes_getUTXO 并从头解析完整 UTXO 集合，
+// 地址 UTXO 较多时既慢又给节点带来重复负载。
+//
+// **方案**：
+// Index 为监视地址维护本地 UTXO 集合：通过 WESClient.ListUTXOs 初始化，
+// 随区块 / 交易订阅事件增量重同步，记录确认数与锁定状态，并可持久化到可插拔的 Store。
+// Index.Client() 返回包装后的 client.Client：对监视地址的 wes_getUTXO 直接由本地索引应答，
+// 本地提交的交易会立即标记已花费输入并记录找零输出，使构建器可以即时在本地选币。
+//
+// **一致性**：
+// 节点始终是权威数据源。每次同步都以节点返回的集合整体替换本地集合，
+// 本地仅保留尚在有效期内的"已提交未确认"标记；节点拒绝交易时立即重同步相关地址。
+//
+// **使用示例**：
+//
+//	store, _ := utxoindex.NewFileStore("./data/utxo-index.json")
+//	ix := utxoindex.New(cli, store, nil)
+//	_ = ix.Load(ctx)                               // 恢复上次的快照
+//	_ = ix.Watch(ctx, address)                     // 监视地址（首次从节点全量同步）
+//	go ix.Run(ctx)                                 // 订阅区块 / 交易事件保持同步
+//	tokenService := token.NewService(ix.Client()) // 构建器从本地索引选币
+package utxoindex
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+const (
+	// DefaultBlockTopic 默认的新区块订阅主题
+	DefaultBlockTopic = "newHeads"
+	// DefaultTxTopic 默认的交易订阅主题（按监视地址过滤）
+	DefaultTxTopic = "newTransactions"
+	// DefaultPendingTTL 本地"已提交未确认"标记的默认有效期
+	DefaultPendingTTL = 10 * time.Minute
+)
+
+var (
+	// ErrNotWatched 地址未被监视
+	ErrNotWatched = errors.New("address is not watched")
+	// ErrInsufficientFunds 可花费 UTXO 不足
+	ErrInsufficientFunds = errors.New("insufficient spendable utxos")
+)
+
+// Options 索引配置
+type Options struct {
+	// MinConfirmations 选币所需的最少确认数（默认 1，即已上链即可）
+	MinConfirmations uint64
+	// SpendUnconfirmed 是否允许花费本地提交交易产生的未确认输出（如找零）
+	SpendUnconfirmed bool
+	// PendingTTL 已提交交易在节点仍返回其输入时保留"已花费"标记的时长（默认 DefaultPendingTTL）
+	PendingTTL time.Duration
+	// BlockTopic 新区块订阅主题（默认 DefaultBlockTopic）
+	BlockTopic string
+	// TxTopic 交易订阅主题（默认 DefaultTxTopic）
+	TxTopic string
+}
+
+// addressState 单个监视地址的索引状态
+type addressState struct {
+	address  []byte
+	syncedAt time.Time
+	entries  map[string]*Entry // outpoint → entry
+}
+
+// Index 本地 UTXO 索引
+//
+// 所有方法都是并发安全的。
+type Index struct {
+	client client.Client
+	wes    client.WESClient
+	store  Store
+	opts   Options
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	tip     uint64
+	addrs   map[string]*addressState // 地址 hex → 状态
+	pending map[string]time.Time     // 本地已提交交易哈希 → 提交时间
+}
+
+// New 创建索引
+//
+// store 为 nil 时使用内存存储；opts 为 nil 时使用默认配置。
+func New(c client.Client, store Store, opts *Options) *Index {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinConfirmations == 0 {
+		o.MinConfirmations = 1
+	}
+	if o.PendingTTL <= 0 {
+		o.PendingTTL = DefaultPendingTTL
+	}
+	if o.BlockTopic == "" {
+		o.BlockTopic = DefaultBlockTopic
+	}
+	if o.TxTopic == "" {
+		o.TxTopic = DefaultTxTopic
+	}
+	return &Index{
+		client:  c,
+		wes:     client.NewWESClientFromClient(c),
+		store:   store,
+		opts:    o,
+		now:     time.Now,
+		addrs:   make(map[string]*addressState),
+		pending: make(map[string]time.Time),
+	}
+}
+
+// Load 从存储恢复快照（存储为空时不报错）
+//
+// 恢复的数据可能已过期，需要最新状态时应随后调用 SyncAll 或 Run。
+func (ix *Index) Load(ctx context.Context) error {
+	snapshot, err := ix.store.Load(ctx)
+	if errors.Is(err, ErrNoSnapshot) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load utxo index: %w", err)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.tip = snapshot.Tip
+	for _, as := range snapshot.Addresses {
+		state := &addressState{
+			address:  as.Address,
+			syncedAt: as.SyncedAt,
+			entries:  make(map[string]*Entry, len(as.Entries)),
+		}
+		for _, e := range as.Entries {
+			state.entries[e.Outpoint] = e
+		}
+		ix.addrs[hex.EncodeToString(as.Address)] = state
+	}
+	for txHash, at := range snapshot.Pending {
+		ix.pending[txHash] = at
+	}
+	return nil
+}
+
+// Watch 监视地址并从节点全量同步
+func (ix *Index) Watch(ctx context.Context, address []byte) error {
+	if len(address) != 20 {
+		return fmt.Errorf("address must be 20 bytes")
+	}
+	key := hex.EncodeToString(address)
+	ix.mu.Lock()
+	if _, ok := ix.addrs[key]; !ok {
+		ix.addrs[key] = &addressState{
+			address: append([]byte(nil), address...),
+			entries: make(map[string]*Entry),
+		}
+	}
+	ix.mu.Unlock()
+	return ix.Sync(ctx, address)
+}
+
+// Unwatch 取消监视地址并删除其本地数据
+func (ix *Index) Unwatch(ctx context.Context, address []byte) error {
+	ix.mu.Lock()
+	delete(ix.addrs, hex.EncodeToString(address))
+	ix.mu.Unlock()
+	return ix.save(ctx)
+}
+
+// Watched 返回所有监视地址
+func (ix *Index) Watched() [][]byte {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	keys := make([]string, 0, len(ix.addrs))
+	for key := range ix.addrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	addresses := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		addresses = append(addresses, append([]byte(nil), ix.addrs[key].address...))
+	}
+	return addresses
+}
+
+// IsWatched 地址是否被监视
+func (ix *Index) IsWatched(address []byte) bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	_, ok := ix.addrs[hex.EncodeToString(address)]
+	return ok
+}
+
+// Tip 返回索引已知的最新区块高度
+func (ix *Index) Tip() uint64 {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.tip
+}
+
+// Sync 从节点全量同步单个监视地址
+//
+// **冲突处理**（节点权威）：
+// - 节点未返回的已确认条目一律删除
+// - 节点仍返回、且被本地已提交交易花费的条目保留 SpentBy 标记，超过 PendingTTL 后清除
+// - 本地未确认输出仅在其所属交易仍有未清除的已花费输入时保留
+func (ix *Index) Sync(ctx context.Context, address []byte) error {
+	key := hex.EncodeToString(address)
+	if !ix.IsWatched(address) {
+		return ErrNotWatched
+	}
+
+	utxos, err := ix.wes.ListUTXOs(ctx, address)
+	if err != nil {
+		return fmt.Errorf("list utxos: %w", err)
+	}
+	tip, tipErr := ix.queryTip(ctx)
+
+	entries := make(map[string]*Entry, len(utxos))
+	for _, u := range utxos {
+		e, err := entryFromUTXO(address, u)
+		if err != nil {
+			// 与 ListUTXOs 一致：跳过无法解析的条目
+			continue
+		}
+		entries[e.Outpoint] = e
+	}
+
+	ix.mu.Lock()
+	state, ok := ix.addrs[key]
+	if !ok {
+		// 同步期间被取消监视
+		ix.mu.Unlock()
+		return ErrNotWatched
+	}
+	if tipErr == nil && tip > ix.tip {
+		ix.tip = tip
+	}
+	now := ix.now()
+	for outpoint, e := range entries {
+		old, ok := state.entries[outpoint]
+		if !ok || old.SpentBy == "" {
+			continue
+		}
+		if at, ok := ix.pending[old.SpentBy]; ok && now.Sub(at) < ix.opts.PendingTTL {
+			e.SpentBy = old.SpentBy
+		}
+	}
+	for outpoint, old := range state.entries {
+		if _, ok := entries[outpoint]; !ok && !old.Confirmed() {
+			entries[outpoint] = old
+		}
+	}
+	state.entries = entries
+	state.syncedAt = now
+	ix.prunePendingLocked()
+	ix.mu.Unlock()
+
+	return ix.save(ctx)
+}
+
+// SyncAll 同步所有监视地址（遇到错误继续同步其余地址，返回第一个错误）
+func (ix *Index) SyncAll(ctx context.Context) error {
+	var firstErr error
+	for _, address := range ix.Watched() {
+		if err := ix.Sync(ctx, address); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("sync %x: %w", address, err)
+		}
+	}
+	return firstErr
+}
+
+// prunePendingLocked 清理已失效的本地提交交易及其未确认输出（调用方持有写锁）
+//
+// 交易仍有效的条件：至少一个输入仍被节点返回且带有该交易的 SpentBy 标记。
+// 交易上链后其输入会从节点结果中消失，此时其输出已由节点以已确认条目返回。
+func (ix *Index) prunePendingLocked() {
+	live := make(map[string]bool)
+	for _, state := range ix.addrs {
+		for _, e := range state.entries {
+			if e.SpentBy != "" {
+				live[e.SpentBy] = true
+			}
+		}
+	}
+	for txHash := range ix.pending {
+		if !live[txHash] {
+			delete(ix.pending, txHash)
+		}
+	}
+	for _, state := range ix.addrs {
+		for outpoint, e := range state.entries {
+			if e.Confirmed() {
+				continue
+			}
+			if _, ok := ix.pending[outpointTxHash(outpoint)]; !ok {
+				delete(state.entries, outpoint)
+			}
+		}
+	}
+}
+
+// UTXOs 返回地址在本地索引中的全部条目（含已标记花费与未确认条目），按 outpoint 排序
+func (ix *Index) UTXOs(address []byte) ([]*Entry, error) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	state, ok := ix.addrs[hex.EncodeToString(address)]
+	if !ok {
+		return nil, ErrNotWatched
+	}
+	return sortedEntries(state.entries), nil
+}
+
+// Spendable 返回地址当前可用于选币的条目
+//
+// 条件：未被本地已提交交易花费、锁定状态为 LockSpendable、
+// 确认数不少于 MinConfirmations（开启 SpendUnconfirmed 时允许本地未确认输出）。
+// tokenID 为 nil 时返回原生币条目。
+func (ix *Index) Spendable(address []byte, tokenID []byte) ([]*Entry, error) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	state, ok := ix.addrs[hex.EncodeToString(address)]
+	if !ok {
+		return nil, ErrNotWatched
+	}
+	now := ix.now()
+	var result []*Entry
+	for _, e := range sortedEntries(state.entries) {
+		if !ix.spendableLocked(e, now) || !sameToken(e.TokenID, tokenID) {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// Balance 返回地址当前可用于选币的余额
+func (ix *Index) Balance(address []byte, tokenID []byte) (types.Amount, error) {
+	entries, err := ix.Spendable(address, tokenID)
+	if err != nil {
+		return types.Amount{}, err
+	}
+	total := types.Amount{}
+	for _, e := range entries {
+		total = total.Add(e.Amount)
+	}
+	return total, nil
+}
+
+// Select 在本地选币：按金额从大到小选择，直到总额不少于 amount
+//
+// 返回选中的条目与其总额；可用余额不足时返回 ErrInsufficientFunds。
+func (ix *Index) Select(address []byte, tokenID []byte, amount types.Amount) ([]*Entry, types.Amount, error) {
+	candidates, err := ix.Spendable(address, tokenID)
+	if err != nil {
+		return nil, types.Amount{}, err
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Amount.Cmp(candidates[j].Amount) > 0
+	})
+
+	var selected []*Entry
+	total := types.Amount{}
+	for _, e := range candidates {
+		if total.Cmp(amount) >= 0 && len(selected) > 0 {
+			break
+		}
+		selected = append(selected, e)
+		total = total.Add(e.Amount)
+	}
+	if total.Cmp(amount) < 0 || len(selected) == 0 {
+		return nil, types.Amount{}, fmt.Errorf("%w: need %s, have %s", ErrInsufficientFunds, amount, total)
+	}
+	return selected, total, nil
+}
+
+// spendableLocked 判断条目是否可用于选币（调用方持有读锁）
+func (ix *Index) spendableLocked(e *Entry, now time.Time) bool {
+	if e.SpentBy != "" {
+		return false
+	}
+	if !e.Confirmed() {
+		return ix.opts.SpendUnconfirmed
+	}
+	if e.Confirmations(ix.tip) < ix.opts.MinConfirmations {
+		return false
+	}
+	return e.LockState(ix.tip, now) == LockSpendable
+}
+
+// Run 订阅区块与交易事件，保持索引与节点同步，直到 ctx 取消
+//
+// - 新区块：更新链高并重同步所有监视地址
+// - 监视地址相关交易：重同步该地址
+//
+// Run 启动后新增的监视地址由下一个区块事件覆盖。
+func (ix *Index) Run(ctx context.Context) error {
+	blocks, err := ix.client.Subscribe(ctx, &client.EventFilter{Topics: []string{ix.opts.BlockTopic}})
+	if err != nil {
+		return fmt.Errorf("subscribe blocks: %w", err)
+	}
+
+	type addressEvent struct {
+		address []byte
+		event   *client.Event
+	}
+	txEvents := make(chan addressEvent)
+	for _, address := range ix.Watched() {
+		ch, err := ix.client.Subscribe(ctx, &client.EventFilter{Topics: []string{ix.opts.TxTopic}, To: address})
+		if err != nil {
+			return fmt.Errorf("subscribe transactions for %x: %w", address, err)
+		}
+		go func(address []byte, ch <-chan *client.Event) {
+			for ev := range ch {
+				select {
+				case txEvents <- addressEvent{address: address, event: ev}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(address, ch)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-blocks:
+			if !ok {
+				return fmt.Errorf("block subscription closed")
+			}
+			if height, ok := eventHeight(ev); ok {
+				ix.mu.Lock()
+				if height > ix.tip {
+					ix.tip = height
+				}
+				ix.mu.Unlock()
+			}
+			// 同步失败时保留旧数据，等待下一个区块重试
+			_ = ix.SyncAll(ctx)
+		case ev := <-txEvents:
+			_ = ix.Sync(ctx, ev.address)
+		}
+	}
+}
+
+// markSubmitted 记录本地已提交交易：标记其输入已花费，并为监视地址记录未确认输出
+func (ix *Index) markSubmitted(ctx context.Context, txHash string, inputs []string, outputs []*Entry) {
+	ix.mu.Lock()
+	ix.pending[txHash] = ix.now()
+	for _, state := range ix.addrs {
+		for _, outpoint := range inputs {
+			if e, ok := state.entries[outpoint]; ok {
+				e.SpentBy = txHash
+			}
+		}
+	}
+	for _, e := range outputs {
+		if state, ok := ix.addrs[hex.EncodeToString(e.Address)]; ok {
+			state.entries[e.Outpoint] = e
+		}
+	}
+	ix.mu.Unlock()
+	_ = ix.save(ctx)
+}
+
+// owners 返回持有指定 outpoint 的监视地址
+func (ix *Index) owners(outpoints []string) [][]byte {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	var result [][]byte
+	for _, state := range ix.addrs {
+		for _, outpoint := range outpoints {
+			if _, ok := state.entries[outpoint]; ok {
+				result = append(result, state.address)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// save 持久化当前快照
+func (ix *Index) save(ctx context.Context) error {
+	ix.mu.RLock()
+	snapshot := &Snapshot{
+		Tip:     ix.tip,
+		SavedAt: ix.now(),
+		Pending: make(map[string]time.Time, len(ix.pending)),
+	}
+	for txHash, at := range ix.pending {
+		snapshot.Pending[txHash] = at
+	}
+	for _, state := range ix.addrs {
+		snapshot.Addresses = append(snapshot.Addresses, &AddressSnapshot{
+			Address:  state.address,
+			SyncedAt: state.syncedAt,
+			Entries:  sortedEntries(state.entries),
+		})
+	}
+	ix.mu.RUnlock()
+
+	sort.Slice(snapshot.Addresses, func(i, j int) bool {
+		return hex.EncodeToString(snapshot.Addresses[i].Address) < hex.EncodeToString(snapshot.Addresses[j].Address)
+	})
+	if err := ix.store.Save(ctx, snapshot); err != nil {
+		return fmt.Errorf("save utxo index: %w", err)
+	}
+	return nil
+}
+
+// queryTip 查询节点当前区块高度
+func (ix *Index) queryTip(ctx context.Context) (uint64, error) {
+	result, err := ix.client.Call(ctx, "wes_blockNumber", []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+	return ParseUint(result), nil
+}
+
+// eventHeight 从区块事件中解析高度（兼容 height / number 字段）
+func eventHeight(ev *client.Event) (uint64, bool) {
+	if ev == nil || len(ev.Data) == 0 {
+		return 0, false
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(ev.Data, &data); err != nil {
+		return 0, false
+	}
+	for _, key := range []string{"height", "number", "blockNumber"} {
+		if v, ok := data[key]; ok {
+			return ParseUint(v), true
+		}
+	}
+	return 0, false
+}
+
+// sortedEntries 按 outpoint 排序并复制条目
+func sortedEntries(entries map[string]*Entry) []*Entry {
+	result := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.clone())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Outpoint < result[j].Outpoint })
+	return result
+}
+
+// outpointTxHash 提取 outpoint 中的交易哈希
+func outpointTxHash(outpoint string) string {
+	if i := strings.LastIndex(outpoint, ":"); i >= 0 {
+		return outpoint[:i]
+	}
+	return outpoint
+}
+
+// sameToken 判断两个代币ID是否相同（nil 与空切片均表示原生币）
+func sameToken(a, b []byte) bool {
+	return hex.EncodeToString(a) == hex.EncodeToString(b)
+}
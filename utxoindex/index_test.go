@@ -0,0 +1,271 @@
+package utxoindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// indexMockClient 模拟节点：返回固定的 UTXO 集合与链高，记录调用次数
+type indexMockClient struct {
+	utxos    []interface{}
+	height   uint64
+	reject   bool
+	getCalls int
+	sent     []string
+}
+
+func (m *indexMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		m.getCalls++
+		return map[string]interface{}{"utxos": m.utxos}, nil
+	case "wes_blockNumber":
+		return fmt.Sprintf("0x%x", m.height), nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *indexMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent = append(m.sent, signedTxHex)
+	if m.reject {
+		return &client.SendTxResult{Accepted: false, Reason: "input already spent"}, nil
+	}
+	return &client.SendTxResult{TxHash: "0x" + strings.Repeat("ee", 32), Accepted: true}, nil
+}
+
+func (m *indexMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *indexMockClient) Close() error { return nil }
+
+func nodeUTXO(txByte byte, amount string, height uint64, tokenID string, lock map[string]interface{}) map[string]interface{} {
+	u := map[string]interface{}{
+		"outpoint": strings.Repeat(fmt.Sprintf("%02x", txByte), 32) + ":0",
+		"amount":   amount,
+		"height":   float64(height),
+	}
+	if tokenID != "" {
+		u["tokenID"] = tokenID
+	}
+	if lock != nil {
+		u["locking_condition"] = lock
+	}
+	return u
+}
+
+var testAddress = bytes.Repeat([]byte{0x01}, 20)
+
+func newTestIndex(t *testing.T, mc *indexMockClient, store Store, opts *Options) *Index {
+	t.Helper()
+	ix := New(mc, store, opts)
+	if err := ix.Watch(context.Background(), testAddress); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	return ix
+}
+
+func amounts(entries []*Entry) string {
+	var parts []string
+	for _, e := range entries {
+		parts = append(parts, e.Amount.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestWatchAndSelect(t *testing.T) {
+	future := float64(time.Now().Add(time.Hour).Unix())
+	mc := &indexMockClient{height: 100, utxos: []interface{}{
+		nodeUTXO(0xa1, "100", 90, "", nil),
+		nodeUTXO(0xa2, "60", 95, "", nil),
+		nodeUTXO(0xa3, "50", 100, "", nil), // 仅 1 个确认
+		nodeUTXO(0xa4, "500", 80, "", map[string]interface{}{"time_lock": map[string]interface{}{"unlock_timestamp": future}}),
+		nodeUTXO(0xa5, "7", 80, strings.Repeat("cd", 32), nil),
+	}}
+	ix := newTestIndex(t, mc, nil, &Options{MinConfirmations: 2})
+
+	if ix.Tip() != 100 {
+		t.Fatalf("tip = %d, want 100", ix.Tip())
+	}
+	all, err := ix.UTXOs(testAddress)
+	if err != nil || len(all) != 5 {
+		t.Fatalf("UTXOs = %d entries, err %v", len(all), err)
+	}
+	if state := all[3].LockState(ix.Tip(), time.Now()); state != LockTime {
+		t.Fatalf("lock state = %s, want time_locked", state)
+	}
+
+	balance, err := ix.Balance(testAddress, nil)
+	if err != nil || balance.String() != "160" {
+		t.Fatalf("balance = %s, err %v", balance, err)
+	}
+
+	selected, total, err := ix.Select(testAddress, nil, types.NewAmount(120))
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if amounts(selected) != "100,60" || total.String() != "160" {
+		t.Fatalf("selected %s (total %s)", amounts(selected), total)
+	}
+	if _, _, err := ix.Select(testAddress, nil, types.NewAmount(200)); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+	if _, err := ix.Spendable(bytes.Repeat([]byte{0x02}, 20), nil); !errors.Is(err, ErrNotWatched) {
+		t.Fatalf("expected ErrNotWatched, got %v", err)
+	}
+}
+
+func TestClientTracksSubmittedTransactions(t *testing.T) {
+	mc := &indexMockClient{height: 100, utxos: []interface{}{
+		nodeUTXO(0xa1, "100", 90, "", nil),
+		nodeUTXO(0xa2, "60", 95, "", nil),
+	}}
+	ix := newTestIndex(t, mc, nil, &Options{SpendUnconfirmed: true})
+	c := ix.Client()
+	ctx := context.Background()
+
+	addressBase58, err := utils.AddressBytesToBase58(testAddress)
+	if err != nil {
+		t.Fatalf("AddressBytesToBase58: %v", err)
+	}
+	getUTXOs := func() []interface{} {
+		t.Helper()
+		result, err := c.Call(ctx, "wes_getUTXO", []interface{}{addressBase58})
+		if err != nil {
+			t.Fatalf("wes_getUTXO: %v", err)
+		}
+		return result.(map[string]interface{})["utxos"].([]interface{})
+	}
+
+	callsBefore := mc.getCalls
+	if utxos := getUTXOs(); len(utxos) != 2 {
+		t.Fatalf("utxos = %v", utxos)
+	}
+	if mc.getCalls != callsBefore {
+		t.Fatalf("wes_getUTXO for a watched address should be served locally")
+	}
+
+	// 花费 0xa1，找零 40 给自己，70 转给未监视地址
+	spent, _ := hex.DecodeString(strings.Repeat("a1", 32))
+	tx := &txcodec.Transaction{
+		Version: 1,
+		Inputs:  []*txcodec.TxInput{{PreviousOutput: txcodec.OutPoint{TxID: spent, OutputIndex: 0}}},
+		Outputs: []*txcodec.TxOutput{
+			{Owner: bytes.Repeat([]byte{0x09}, 20), Asset: &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: "60"}}},
+			{Owner: testAddress, Asset: &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: "40"}}},
+		},
+	}
+	txHex, err := txcodec.EncodeHex(tx)
+	if err != nil {
+		t.Fatalf("EncodeHex: %v", err)
+	}
+	if _, err := c.SendRawTransaction(ctx, txHex); err != nil {
+		t.Fatalf("SendRawTransaction: %v", err)
+	}
+
+	utxos := getUTXOs()
+	if len(utxos) != 2 {
+		t.Fatalf("utxos after send = %v", utxos)
+	}
+	change := strings.Repeat("ee", 32) + ":1"
+	if utxos[1].(map[string]interface{})["outpoint"] != change {
+		t.Fatalf("expected local change output %s, got %v", change, utxos)
+	}
+	balance, _ := ix.Balance(testAddress, nil)
+	if balance.String() != "100" {
+		t.Fatalf("balance after send = %s, want 100", balance)
+	}
+
+	// 节点尚未打包：0xa1 仍被返回，本地已花费标记保留
+	if err := ix.Sync(ctx, testAddress); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(getUTXOs()) != 2 {
+		t.Fatalf("pending spend should survive a sync while the node still reports the input")
+	}
+
+	// 交易上链：节点不再返回 0xa1，找零以已确认条目返回
+	confirmedChange := nodeUTXO(0xee, "40", 101, "", nil)
+	confirmedChange["outpoint"] = change
+	mc.utxos = []interface{}{nodeUTXO(0xa2, "60", 95, "", nil), confirmedChange}
+	mc.height = 101
+	if err := ix.Sync(ctx, testAddress); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	all, _ := ix.UTXOs(testAddress)
+	if amounts(all) != "60,40" || !all[1].Confirmed() || all[1].Height != 101 {
+		t.Fatalf("entries after confirmation = %+v", all)
+	}
+	if len(ix.pending) != 0 {
+		t.Fatalf("pending transactions should be cleared, got %v", ix.pending)
+	}
+}
+
+func TestClientResyncsOnRejection(t *testing.T) {
+	mc := &indexMockClient{height: 100, utxos: []interface{}{nodeUTXO(0xa1, "100", 90, "", nil)}}
+	ix := newTestIndex(t, mc, nil, nil)
+
+	// 节点侧该 UTXO 已被其他交易花费
+	mc.utxos = []interface{}{}
+	mc.reject = true
+
+	spent, _ := hex.DecodeString(strings.Repeat("a1", 32))
+	txHex, err := txcodec.EncodeHex(&txcodec.Transaction{
+		Version: 1,
+		Inputs:  []*txcodec.TxInput{{PreviousOutput: txcodec.OutPoint{TxID: spent}}},
+		Outputs: []*txcodec.TxOutput{{Owner: testAddress, Asset: &txcodec.AssetOutput{NativeCoin: &txcodec.NativeCoinAsset{Amount: "100"}}}},
+	})
+	if err != nil {
+		t.Fatalf("EncodeHex: %v", err)
+	}
+	result, err := ix.Client().SendRawTransaction(context.Background(), txHex)
+	if err != nil || result.Accepted {
+		t.Fatalf("expected rejection, got %+v, %v", result, err)
+	}
+	if all, _ := ix.UTXOs(testAddress); len(all) != 0 {
+		t.Fatalf("rejected transaction should trigger a resync, entries = %+v", all)
+	}
+}
+
+func TestFileStorePersistence(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "index", "utxos.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	mc := &indexMockClient{height: 100, utxos: []interface{}{
+		nodeUTXO(0xa1, "100", 90, "", nil),
+		nodeUTXO(0xa5, "7", 80, strings.Repeat("cd", 32), map[string]interface{}{"height_lock": map[string]interface{}{"unlock_height": float64(200)}}),
+	}}
+	newTestIndex(t, mc, store, nil)
+
+	restored := New(mc, store, nil)
+	if err := restored.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if restored.Tip() != 100 || !restored.IsWatched(testAddress) {
+		t.Fatalf("restored tip = %d, watched = %v", restored.Tip(), restored.IsWatched(testAddress))
+	}
+	all, _ := restored.UTXOs(testAddress)
+	if amounts(all) != "100,7" || hex.EncodeToString(all[1].TokenID) != strings.Repeat("cd", 32) {
+		t.Fatalf("restored entries = %+v", all)
+	}
+	if state := all[1].LockState(restored.Tip(), time.Now()); state != LockHeight {
+		t.Fatalf("lock state = %s, want height_locked", state)
+	}
+
+	if err := New(mc, nil, nil).Load(context.Background()); err != nil {
+		t.Fatalf("Load from empty store: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package utxoindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNoSnapshot 存储中没有快照
+var ErrNoSnapshot = errors.New("utxo index snapshot not found")
+
+// Snapshot 索引快照（持久化格式）
+type Snapshot struct {
+	Tip       uint64               `json:"tip"`
+	Addresses []*AddressSnapshot   `json:"addresses"`
+	Pending   map[string]time.Time `json:"pending,omitempty"` // 本地已提交未确认的交易哈希 → 提交时间
+	SavedAt   time.Time            `json:"saved_at"`
+}
+
+// AddressSnapshot 单个监视地址的快照
+type AddressSnapshot struct {
+	Address  []byte    `json:"address"`
+	SyncedAt time.Time `json:"synced_at"` // 最近一次与节点全量同步的时间
+	Entries  []*Entry  `json:"entries"`
+}
+
+// Store 快照存储接口
+type Store interface {
+	// Load 加载快照，不存在时返回 ErrNoSnapshot
+	Load(ctx context.Context) (*Snapshot, error)
+	// Save 原子地保存快照
+	Save(ctx context.Context, snapshot *Snapshot) error
+}
+
+// MemoryStore 内存快照存储
+type MemoryStore struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+// NewMemoryStore 创建内存快照存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Load 加载快照
+func (s *MemoryStore) Load(ctx context.Context) (*Snapshot, error) {
+	s.mu.Lock()
+	data := s.data
+	s.mu.Unlock()
+	if data == nil {
+		return nil, ErrNoSnapshot
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Save 保存快照
+func (s *MemoryStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	return nil
+}
+
+// FileStore 文件快照存储（单个 JSON 文件）
+//
+// 写入采用"临时文件 + fsync + rename"，保证崩溃时不会留下半写的快照。
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore 创建文件快照存储，父目录不存在时自动创建
+func NewFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create utxo index directory: %w", err)
+	}
+	return &FileStore{path: path}, nil
+}
+
+// Load 加载快照
+func (s *FileStore) Load(ctx context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read utxo index snapshot: %w", err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("decode utxo index snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Save 保存快照
+func (s *FileStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("marshal utxo index snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(s.path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // rename 成功后为空操作
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, s.path); err != nil {
+		return fmt.Errorf("rename utxo index snapshot: %w", err)
+	}
+	if d, err := os.Open(dir); err == nil {
+		// 部分平台（如 Windows）不支持目录 fsync，忽略该错误
+		_ = d.Sync()
+		d.Close()
+	}
+	return nil
+}
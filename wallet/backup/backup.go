@@ -0,0 +1,219 @@
+// Package backup 提供基于 Shamir 秘密共享的钱包私钥 / HD 种子离线备份与恢复
+//
+// 秘密按字节在 GF(256) 上拆分为 N 份，任意 K 份即可恢复，少于 K 份不泄露任何信息。
+// 每份额带版本号、随机份额组标识与校验和，可编码为十六进制或助记词（每字节一个单词）便于抄写。
+// 被拆分的是 secret || digest（参照 SLIP-39 的摘要份额），恢复后以内嵌摘要校验结果，
+// 份额本身不公开任何与秘密相关的信息。
+//
+// **流程**：
+//
+//	shares, _ := backup.SplitWallet(w, 3, 5)      // 3-of-5
+//	for _, s := range shares {
+//		fmt.Println(s.Words())                     // 分别交给不同的保管人
+//	}
+//
+//	// 恢复
+//	s1, _ := backup.ParseShare(words1)
+//	s2, _ := backup.ParseShare(words2)
+//	s3, _ := backup.ParseShare(words3)
+//	w, _ := backup.RecoverWallet([]*backup.Share{s1, s2, s3})
+//	addr, _ := backup.RecoverToKeystore(km, []*backup.Share{s1, s2, s3}, "password")
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+var (
+	// ErrNotEnoughShares 份额数量少于门限
+	ErrNotEnoughShares = errors.New("not enough shares")
+
+	// ErrShareMismatch 份额来自不同的拆分，或恢复结果与内嵌摘要不符
+	ErrShareMismatch = errors.New("shares do not belong to the same set")
+)
+
+// Split 将秘密拆分为 total 份，任意 threshold 份可恢复
+//
+// 要求 2 ≤ threshold ≤ total ≤ 255。random 为 nil 时使用 crypto/rand；
+// 先读取 4 字节份额组标识，再读取多项式系数，传入固定的随机源会得到确定性的拆分结果（用于测试向量）。
+func Split(kind SecretKind, secret []byte, threshold, total int, random io.Reader) ([]*Share, error) {
+	if err := validateSecret(kind, secret); err != nil {
+		return nil, err
+	}
+	if threshold < 2 || threshold > total || total > 255 {
+		return nil, fmt.Errorf("invalid threshold %d of %d: require 2 <= threshold <= total <= 255", threshold, total)
+	}
+
+	if random == nil {
+		random = rand.Reader
+	}
+	var setID [4]byte
+	if _, err := io.ReadFull(random, setID[:]); err != nil {
+		return nil, fmt.Errorf("read random set id: %w", err)
+	}
+	payload := append(append(make([]byte, 0, len(secret)+secretDigestLen), secret...), secretDigest(kind, setID, secret)...)
+	defer zero(payload)
+
+	values, err := splitBytes(payload, threshold, total, random)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([]*Share, total)
+	for i, v := range values {
+		shares[i] = &Share{
+			Version:   ShareVersion,
+			Kind:      kind,
+			SetID:     setID,
+			Threshold: byte(threshold),
+			Index:     byte(i + 1),
+			Value:     v,
+		}
+	}
+	return shares, nil
+}
+
+// SplitPrivateKey 拆分 32 字节私钥
+func SplitPrivateKey(privateKey []byte, threshold, total int) ([]*Share, error) {
+	return Split(KindPrivateKey, privateKey, threshold, total, nil)
+}
+
+// SplitSeed 拆分 HD 种子（16-64 字节）
+func SplitSeed(seed []byte, threshold, total int) ([]*Share, error) {
+	return Split(KindSeed, seed, threshold, total, nil)
+}
+
+// SplitWallet 拆分钱包私钥（观察钱包、已锁定钱包不持有私钥，返回错误）
+func SplitWallet(w wallet.Wallet, threshold, total int) ([]*Share, error) {
+	if w == nil || w.PrivateKey() == nil {
+		return nil, fmt.Errorf("wallet has no private key")
+	}
+	key := ethcrypto.FromECDSA(w.PrivateKey())
+	defer zero(key)
+	return SplitPrivateKey(key, threshold, total)
+}
+
+// Combine 从至少 threshold 份份额恢复秘密
+//
+// 所有份额必须来自同一次拆分（类型、组标识、门限、长度一致）且序号互不相同；
+// 恢复结果会与内嵌摘要核对，不符时返回 ErrShareMismatch。
+func Combine(shares []*Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	first := shares[0]
+	seen := make(map[byte]bool, len(shares))
+	for i, s := range shares {
+		if s == nil {
+			return nil, fmt.Errorf("share %d is nil", i)
+		}
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if s.Version != first.Version || s.Kind != first.Kind || s.SetID != first.SetID ||
+			s.Threshold != first.Threshold || len(s.Value) != len(first.Value) {
+			return nil, fmt.Errorf("%w: share %d differs from share 0", ErrShareMismatch, i)
+		}
+		if seen[s.Index] {
+			return nil, fmt.Errorf("duplicate share index %d", s.Index)
+		}
+		seen[s.Index] = true
+	}
+	if len(shares) < int(first.Threshold) {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrNotEnoughShares, len(shares), first.Threshold)
+	}
+
+	used := shares[:first.Threshold]
+	xs := make([]byte, len(used))
+	ys := make([][]byte, len(used))
+	for i, s := range used {
+		xs[i] = s.Index
+		ys[i] = s.Value
+	}
+	payload := combineBytes(xs, ys)
+	defer zero(payload)
+	secret, digest := payload[:len(payload)-secretDigestLen], payload[len(payload)-secretDigestLen:]
+	want := secretDigest(first.Kind, first.SetID, secret)
+	if !hmac.Equal(digest, want) {
+		return nil, fmt.Errorf("%w: recovered secret does not match its digest", ErrShareMismatch)
+	}
+	return append([]byte(nil), secret...), nil
+}
+
+// RecoverWallet 从份额恢复钱包（仅支持私钥类型的份额）
+func RecoverWallet(shares []*Share) (wallet.Wallet, error) {
+	key, err := combinePrivateKey(shares)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(key)
+	return wallet.NewWalletFromPrivateKey(hex.EncodeToString(key))
+}
+
+// RecoverToKeystore 从份额恢复私钥并加密保存到 Keystore，返回 Base58 地址
+func RecoverToKeystore(km *wallet.KeystoreManager, shares []*Share, password string) (string, error) {
+	if km == nil {
+		return "", fmt.Errorf("keystore manager is nil")
+	}
+	w, err := RecoverWallet(shares)
+	if err != nil {
+		return "", err
+	}
+	address := types.MustAddressFromBytes(w.Address()).String()
+	key := ethcrypto.FromECDSA(w.PrivateKey())
+	defer zero(key)
+	if _, err := km.Save(address, key, password); err != nil {
+		return "", fmt.Errorf("save keystore: %w", err)
+	}
+	return address, nil
+}
+
+func combinePrivateKey(shares []*Share) ([]byte, error) {
+	if len(shares) > 0 && shares[0] != nil && shares[0].Kind != KindPrivateKey {
+		return nil, fmt.Errorf("shares hold a %s, not a private key", shares[0].Kind)
+	}
+	return Combine(shares)
+}
+
+// validateSecret 按类型检查秘密长度
+func validateSecret(kind SecretKind, secret []byte) error {
+	switch kind {
+	case KindPrivateKey:
+		if len(secret) != 32 {
+			return fmt.Errorf("private key must be 32 bytes, got %d", len(secret))
+		}
+		if _, err := wallet.NewWalletFromPrivateKey(hex.EncodeToString(secret)); err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+	case KindSeed:
+		if len(secret) < 16 || len(secret) > 64 {
+			return fmt.Errorf("seed must be 16-64 bytes, got %d", len(secret))
+		}
+	default:
+		return fmt.Errorf("unknown secret kind %d", byte(kind))
+	}
+	return nil
+}
+
+// secretDigestLen 内嵌摘要长度
+const secretDigestLen = 4
+
+// secretDigest 内嵌摘要：HMAC-SHA256(key = set_id, kind || secret) 的前 4 字节
+//
+// 摘要与秘密一起被拆分，少于门限的份额不泄露摘要；set_id 随机生成，不同拆分的摘要互不相关。
+func secretDigest(kind SecretKind, setID [4]byte, secret []byte) []byte {
+	mac := hmac.New(sha256.New, setID[:])
+	mac.Write([]byte{byte(kind)})
+	mac.Write(secret)
+	return mac.Sum(nil)[:secretDigestLen]
+}
@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// 确定性拆分测试向量：私钥 0x0102...20，3-of-5，随机流 byte(i*37+11)（前 4 字节为组标识，其后为多项式系数）
+var splitVectors = []string{
+	"02010b30557a03015ae568db2e21dc67f26d203326e974ff4a35384bfe71cc37223d70e37639240f9e392abcbb5f5080",
+	"02010b30557a030219f31e400dfc875c9c1b8068d3e22f89e495633ddd1a7a21fa6666b8358452cff02f7127bd471603",
+	"02010b30557a03034214759f26db5c33677cab57f8055466bfb24862367da10ec1410d475ea369e02b489a78a138a1d6",
+	"02010b30557a0304bf6b3825760fa8bec3795facbc293a52f70e6b2c8a2be0eda62a7b50984c691754765583e3fbec43",
+	"02010b30557a0305e48c53fa5d2873d1381e749397ce41bdac294073614c3bc29d0d10aff36b52388f11bedce81039fe",
+}
+
+func vectorSecret() []byte {
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i + 1)
+	}
+	return secret
+}
+
+func TestSplitVectors(t *testing.T) {
+	random := make([]byte, 4+36*2)
+	for i := range random {
+		random[i] = byte(i*37 + 11)
+	}
+	shares, err := Split(KindPrivateKey, vectorSecret(), 3, 5, bytes.NewReader(random))
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	for i, s := range shares {
+		if got := s.Hex(); got != splitVectors[i] {
+			t.Errorf("share %d = %s, want %s", i+1, got, splitVectors[i])
+		}
+	}
+
+	// 任意 3 份都能恢复，顺序无关
+	for _, idx := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		subset := []*Share{shares[idx[0]], shares[idx[1]], shares[idx[2]]}
+		secret, err := Combine(subset)
+		if err != nil || !bytes.Equal(secret, vectorSecret()) {
+			t.Fatalf("Combine(%v) = %x, %v", idx, secret, err)
+		}
+	}
+	if _, err := Combine(shares[:2]); !errors.Is(err, ErrNotEnoughShares) {
+		t.Fatalf("expected ErrNotEnoughShares, got %v", err)
+	}
+	if _, err := Combine([]*Share{shares[0], shares[0], shares[1]}); err == nil {
+		t.Fatalf("expected error for duplicate index")
+	}
+
+	// 份额组标识随机生成，同一秘密的两次拆分互不相同
+	again, _ := SplitPrivateKey(vectorSecret(), 3, 5)
+	other, _ := SplitPrivateKey(vectorSecret(), 3, 5)
+	if again[0].SetID == other[0].SetID {
+		t.Fatalf("set ids should be random, both are %x", again[0].SetID)
+	}
+}
+
+func TestShareEncoding(t *testing.T) {
+	data, _ := hex.DecodeString(splitVectors[1])
+	share, err := DecodeShare(data)
+	if err != nil {
+		t.Fatalf("DecodeShare: %v", err)
+	}
+	if share.Kind != KindPrivateKey || share.Threshold != 3 || share.Index != 2 || len(share.Value) != 36 {
+		t.Fatalf("unexpected share %+v", share)
+	}
+
+	words := share.Words()
+	if len(strings.Fields(words)) != len(data) {
+		t.Fatalf("expected one word per byte, got %q", words)
+	}
+	for _, text := range []string{words, strings.ToUpper(words), "0x" + splitVectors[1], "  " + splitVectors[1] + "\n"} {
+		parsed, err := ParseShare(text)
+		if err != nil || parsed.Hex() != splitVectors[1] {
+			t.Fatalf("ParseShare(%q) = %v, %v", text, parsed, err)
+		}
+	}
+
+	// 抄错一个单词：校验和不匹配
+	fields := strings.Fields(words)
+	fields[10] = wordList[(int(wordIndex[fields[10]])+1)%256]
+	if _, err := ParseShare(strings.Join(fields, " ")); !errors.Is(err, ErrShareChecksum) {
+		t.Fatalf("expected ErrShareChecksum, got %v", err)
+	}
+	if _, err := ParseShare("acid acorn unknownword"); !errors.Is(err, ErrInvalidShare) {
+		t.Fatalf("expected ErrInvalidShare, got %v", err)
+	}
+}
+
+func TestCombineRejectsMixedSets(t *testing.T) {
+	seedA := bytes.Repeat([]byte{0xAA}, 16)
+	seedB := bytes.Repeat([]byte{0xBB}, 16)
+	a, err := SplitSeed(seedA, 2, 3)
+	if err != nil {
+		t.Fatalf("SplitSeed: %v", err)
+	}
+	b, _ := SplitSeed(seedB, 2, 3)
+	if _, err := Combine([]*Share{a[0], b[1]}); !errors.Is(err, ErrShareMismatch) {
+		t.Fatalf("expected ErrShareMismatch, got %v", err)
+	}
+
+	// 组标识被篡改为另一组：插值结果与标识不符
+	forged := *b[1]
+	forged.SetID = a[0].SetID
+	if _, err := Combine([]*Share{a[0], &forged}); !errors.Is(err, ErrShareMismatch) {
+		t.Fatalf("expected ErrShareMismatch for forged share, got %v", err)
+	}
+
+	if _, err := RecoverWallet(a[:2]); err == nil {
+		t.Fatalf("seed shares should not recover a wallet")
+	}
+	if _, err := Split(KindSeed, seedA, 1, 3, nil); err == nil {
+		t.Fatalf("expected error for threshold 1")
+	}
+	if _, err := Split(KindSeed, seedA, 4, 3, nil); err == nil {
+		t.Fatalf("expected error for threshold above total")
+	}
+}
+
+func TestRecoverWalletAndKeystore(t *testing.T) {
+	w, err := wallet.NewWallet()
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+	shares, err := SplitWallet(w, 2, 3)
+	if err != nil {
+		t.Fatalf("SplitWallet: %v", err)
+	}
+
+	recovered, err := RecoverWallet([]*Share{shares[2], shares[0]})
+	if err != nil || !bytes.Equal(recovered.Address(), w.Address()) {
+		t.Fatalf("RecoverWallet = %v, %v", recovered, err)
+	}
+
+	km, _ := wallet.NewKeystoreManager(t.TempDir())
+	address, err := RecoverToKeystore(km, shares[1:], "pw")
+	if err != nil {
+		t.Fatalf("RecoverToKeystore: %v", err)
+	}
+	unlocked, err := km.Unlock(address, "pw", 0)
+	if err != nil || !bytes.Equal(unlocked.Address(), w.Address()) {
+		t.Fatalf("Unlock recovered keystore = %v, %v", unlocked, err)
+	}
+
+	watch, _ := wallet.NewWatchOnlyWallet(w.Address())
+	if _, err := SplitWallet(watch, 2, 3); err == nil {
+		t.Fatalf("expected error for watch-only wallet")
+	}
+}
@@ -0,0 +1,102 @@
+package backup
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// GF(256) 运算，约简多项式 x^8 + x^4 + x^3 + x + 1（0x11B，与 AES 相同）
+//
+// 乘法使用逐位移位实现，不依赖查表，避免与秘密相关的内存访问模式。
+
+// gfMul GF(256) 乘法
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		// mask 为 0xFF 或 0x00，避免按秘密值分支
+		mask := -(b & 1)
+		p ^= a & mask
+		carry := -(a >> 7)
+		a = (a << 1) ^ (0x1B & carry)
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv GF(256) 乘法逆元（a^254），gfInv(0) 返回 0
+func gfInv(a byte) byte {
+	// 254 = 0b11111110
+	result := byte(1)
+	base := a
+	for e := 254; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = gfMul(result, base)
+		}
+		base = gfMul(base, base)
+	}
+	return result
+}
+
+// splitBytes 对秘密逐字节做 Shamir 拆分
+//
+// 每个字节独立构造 threshold-1 次随机多项式 f(x)，f(0) 为秘密字节；
+// 第 i 份（x = i，1 ≤ i ≤ total）为 f(i)。随机系数按字节顺序从 random 依次读取，
+// 每个字节读取 threshold-1 个，因此固定的随机源会得到确定性的拆分结果。
+func splitBytes(secret []byte, threshold, total int, random io.Reader) ([][]byte, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	coeffs := make([]byte, len(secret)*(threshold-1))
+	if _, err := io.ReadFull(random, coeffs); err != nil {
+		return nil, fmt.Errorf("read random coefficients: %w", err)
+	}
+	defer zero(coeffs)
+
+	ys := make([][]byte, total)
+	for i := range ys {
+		ys[i] = make([]byte, len(secret))
+	}
+	for pos, s := range secret {
+		c := coeffs[pos*(threshold-1) : (pos+1)*(threshold-1)]
+		for i := 0; i < total; i++ {
+			x := byte(i + 1)
+			// Horner 法求值：((c[k-2]·x + c[k-3])·x + ... + c[0])·x + s
+			var y byte
+			for j := len(c) - 1; j >= 0; j-- {
+				y = gfMul(y, x) ^ c[j]
+			}
+			ys[i][pos] = gfMul(y, x) ^ s
+		}
+	}
+	return ys, nil
+}
+
+// combineBytes 拉格朗日插值求 f(0)
+//
+// xs 必须互不相同且非零，ys 长度一致。
+func combineBytes(xs []byte, ys [][]byte) []byte {
+	secret := make([]byte, len(ys[0]))
+	for i, xi := range xs {
+		// 基函数 l_i(0) = Π_{j≠i} x_j / (x_j - x_i)，GF(2^8) 中减法即异或
+		num, den := byte(1), byte(1)
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			num = gfMul(num, xj)
+			den = gfMul(den, xj^xi)
+		}
+		li := gfMul(num, gfInv(den))
+		for pos, y := range ys[i] {
+			secret[pos] ^= gfMul(y, li)
+		}
+	}
+	return secret
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,168 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ShareVersion 当前份额编码版本
+//
+// 版本 2：份额组标识随机生成，份额值为 secret || digest 的拆分结果。
+const ShareVersion byte = 2
+
+// shareHeaderLen 版本(1) + 类型(1) + 份额组标识(4) + 门限(1) + 序号(1)
+const shareHeaderLen = 8
+
+// shareChecksumLen 末尾 SHA-256 校验和长度
+const shareChecksumLen = 4
+
+var (
+	// ErrInvalidShare 份额编码无法解析
+	ErrInvalidShare = errors.New("invalid share")
+
+	// ErrShareChecksum 份额校验和不匹配（抄写错误或数据损坏）
+	ErrShareChecksum = errors.New("share checksum mismatch")
+)
+
+// SecretKind 被拆分的秘密类型
+type SecretKind byte
+
+const (
+	// KindPrivateKey 32 字节 secp256k1 私钥
+	KindPrivateKey SecretKind = 1
+	// KindSeed HD 种子（16-64 字节）
+	KindSeed SecretKind = 2
+)
+
+// String 返回类型名称
+func (k SecretKind) String() string {
+	switch k {
+	case KindPrivateKey:
+		return "private-key"
+	case KindSeed:
+		return "seed"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// Share 一份 Shamir 份额
+//
+// **编码格式**（版本 2）：
+//
+//	version(1) | kind(1) | set_id(4) | threshold(1) | index(1) | value(n+4) | checksum(4)
+//
+// set_id 为拆分时随机生成的 4 字节，用于识别同一次拆分的份额；
+// value 为 secret || digest 的份额，digest 在恢复后用于校验结果（见 Combine）；
+// checksum 为前面所有字节的 SHA-256 前 4 字节，用于发现抄写错误。
+type Share struct {
+	Version   byte       // 编码版本
+	Kind      SecretKind // 秘密类型
+	SetID     [4]byte    // 份额组标识
+	Threshold byte       // 恢复所需份额数
+	Index     byte       // 份额序号（1-255，即多项式的 x 坐标）
+	Value     []byte     // 份额值（比秘密长 4 字节的内嵌摘要）
+}
+
+// Encode 编码为带校验和的字节串
+func (s *Share) Encode() []byte {
+	buf := make([]byte, 0, shareHeaderLen+len(s.Value)+shareChecksumLen)
+	buf = append(buf, s.Version, byte(s.Kind))
+	buf = append(buf, s.SetID[:]...)
+	buf = append(buf, s.Threshold, s.Index)
+	buf = append(buf, s.Value...)
+	sum := sha256.Sum256(buf)
+	return append(buf, sum[:shareChecksumLen]...)
+}
+
+// Hex 返回十六进制编码
+func (s *Share) Hex() string {
+	return hex.EncodeToString(s.Encode())
+}
+
+// Words 返回助记词编码（每个字节一个单词，空格分隔）
+func (s *Share) Words() string {
+	data := s.Encode()
+	words := make([]string, len(data))
+	for i, b := range data {
+		words[i] = wordList[b]
+	}
+	return strings.Join(words, " ")
+}
+
+// String 返回助记词编码
+func (s *Share) String() string {
+	return s.Words()
+}
+
+// DecodeShare 解码份额字节串并校验版本与校验和
+func DecodeShare(data []byte) (*Share, error) {
+	if len(data) < shareHeaderLen+secretDigestLen+1+shareChecksumLen {
+		return nil, fmt.Errorf("%w: too short (%d bytes)", ErrInvalidShare, len(data))
+	}
+	body, checksum := data[:len(data)-shareChecksumLen], data[len(data)-shareChecksumLen:]
+	sum := sha256.Sum256(body)
+	if string(sum[:shareChecksumLen]) != string(checksum) {
+		return nil, ErrShareChecksum
+	}
+	if body[0] != ShareVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidShare, body[0])
+	}
+	s := &Share{
+		Version:   body[0],
+		Kind:      SecretKind(body[1]),
+		Threshold: body[6],
+		Index:     body[7],
+		Value:     append([]byte(nil), body[shareHeaderLen:]...),
+	}
+	copy(s.SetID[:], body[2:6])
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseShare 解析助记词或十六进制（可带 0x 前缀）编码的份额
+//
+// 助记词不区分大小写，单词之间可用任意空白分隔。
+func ParseShare(text string) (*Share, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty input", ErrInvalidShare)
+	}
+	if len(fields) == 1 {
+		data, err := hex.DecodeString(strings.TrimPrefix(fields[0], "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid hex: %v", ErrInvalidShare, err)
+		}
+		return DecodeShare(data)
+	}
+
+	data := make([]byte, len(fields))
+	for i, w := range fields {
+		b, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q at position %d", ErrInvalidShare, w, i+1)
+		}
+		data[i] = b
+	}
+	return DecodeShare(data)
+}
+
+// validate 检查份额头部字段
+func (s *Share) validate() error {
+	switch {
+	case s.Kind != KindPrivateKey && s.Kind != KindSeed:
+		return fmt.Errorf("%w: unknown secret kind %d", ErrInvalidShare, byte(s.Kind))
+	case s.Threshold < 2:
+		return fmt.Errorf("%w: threshold must be at least 2, got %d", ErrInvalidShare, s.Threshold)
+	case s.Index == 0:
+		return fmt.Errorf("%w: index must not be zero", ErrInvalidShare)
+	case len(s.Value) <= secretDigestLen:
+		return fmt.Errorf("%w: value too short (%d bytes)", ErrInvalidShare, len(s.Value))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package backup
+
+// wordList 份额助记词表：每个单词对应一个字节（0x00-0xFF），按下标编码
+//
+// 单词均为常见英文名词，互不重复，便于手抄与口头核对。
+var wordList = [256]string{
+	"acid", "acorn", "actor", "adobe", "agent", "alarm", "album", "alley",
+	"amber", "angle", "ankle", "apple", "apron", "arena", "arrow", "atlas",
+	"attic", "audio", "autumn", "award", "bacon", "badge", "bagel", "baker",
+	"bamboo", "banjo", "barn", "basket", "beach", "beaver", "bench", "berry",
+	"bicycle", "bishop", "blanket", "blossom", "boat", "bonus", "bottle", "boxer",
+	"brave", "bread", "brick", "bridge", "bronze", "brush", "bubble", "bucket",
+	"buffalo", "button", "cabin", "cactus", "camel", "camera", "candle", "canoe",
+	"canvas", "carbon", "carpet", "castle", "cattle", "cedar", "chalk", "cherry",
+	"chess", "chimney", "circle", "citrus", "clock", "cloud", "cobra", "coconut",
+	"coffee", "comet", "copper", "coral", "cotton", "cousin", "crab", "crayon",
+	"cricket", "crown", "crystal", "cupboard", "curtain", "cycle", "daisy", "dance",
+	"deer", "delta", "denim", "desert", "diamond", "dinner", "dolphin", "domino",
+	"donkey", "dragon", "drum", "eagle", "echo", "eclipse", "elbow", "elephant",
+	"ember", "engine", "falcon", "feather", "fence", "ferry", "fiber", "fiddle",
+	"finger", "flame", "flute", "forest", "fossil", "fountain", "fox", "frost",
+	"galaxy", "garden", "garlic", "ghost", "ginger", "giraffe", "glacier", "globe",
+	"glove", "goat", "gold", "gorilla", "grape", "gravel", "guitar", "hammer",
+	"harbor", "harvest", "hazel", "helmet", "hero", "hockey", "honey", "horizon",
+	"hotel", "island", "ivory", "jacket", "jaguar", "jelly", "jewel", "jungle",
+	"kayak", "kettle", "kiwi", "koala", "ladder", "lagoon", "lamp", "lantern",
+	"laptop", "lemon", "leopard", "lettuce", "library", "lily", "lion", "lizard",
+	"lobster", "locket", "lotus", "magnet", "mango", "maple", "marble", "meadow",
+	"melon", "mermaid", "meteor", "mirror", "mitten", "monkey", "moose", "mosaic",
+	"mountain", "muffin", "museum", "napkin", "nectar", "needle", "nest", "noodle",
+	"oasis", "ocean", "olive", "onion", "orange", "orbit", "orchid", "otter",
+	"owl", "oyster", "paddle", "palace", "panda", "paper", "parrot", "peach",
+	"peanut", "pebble", "pelican", "pencil", "pepper", "piano", "pigeon", "pillow",
+	"pilot", "pine", "planet", "plum", "pocket", "polar", "pony", "potato",
+	"pumpkin", "puzzle", "pyramid", "quartz", "quilt", "rabbit", "radar", "radio",
+	"rainbow", "raven", "ribbon", "river", "robot", "rocket", "rose", "ruby",
+	"saddle", "sailor", "salmon", "sandal", "saturn", "scarf", "shadow", "shell",
+	"shovel", "silver", "siren", "skate", "sketch", "sloth", "snail", "socket",
+}
+
+// wordIndex 单词到字节值的反查表
+var wordIndex = func() map[string]byte {
+	m := make(map[string]byte, len(wordList))
+	for i, w := range wordList {
+		m[w] = byte(i)
+	}
+	return m
+}()
@@ -7,9 +7,16 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/types"
 )
 
 // Keystore Keystore文件结构（参考client/core/wallet/keystore.go）
@@ -36,8 +43,38 @@ type CipherParams struct {
 }
 
 // KeystoreManager Keystore管理器
+//
+// 写操作（Save / Delete / ChangePassword / Import）持有目录级文件锁，可在多个进程间安全并发；
+// 文件通过临时文件 + fsync + rename 原子替换，进程崩溃不会留下写了一半的 Keystore。
 type KeystoreManager struct {
 	keystoreDir string
+	mu          sync.Mutex
+}
+
+var (
+	// ErrKeystoreNotFound Keystore 文件不存在
+	ErrKeystoreNotFound = errors.New("keystore not found")
+
+	// ErrKeystoreExists 导入时同一地址的 Keystore 已存在
+	ErrKeystoreExists = errors.New("keystore already exists")
+
+	// ErrInvalidPassword 密码错误（MAC 校验失败）
+	ErrInvalidPassword = errors.New("invalid password")
+
+	// ErrKeystoreAddressMismatch 导入的 Keystore 声明的地址与私钥推导的地址不一致
+	ErrKeystoreAddressMismatch = errors.New("keystore address does not match private key")
+)
+
+// lockFileName 目录级锁文件名
+const lockFileName = ".keystore.lock"
+
+// KeystoreInfo Keystore 元数据（不含密钥材料）
+type KeystoreInfo struct {
+	Address  string
+	ID       string
+	Version  int
+	Path     string
+	Modified time.Time
 }
 
 // NewKeystoreManager 创建Keystore管理器
@@ -51,16 +88,292 @@ func NewKeystoreManager(keystoreDir string) (*KeystoreManager, error) {
 	}, nil
 }
 
-// Save 保存私钥到Keystore
+// Save 保存私钥到Keystore（同一地址已存在时覆盖）
 func (km *KeystoreManager) Save(address string, privateKey []byte, password string) (string, error) {
+	keystorePath, err := km.path(address)
+	if err != nil {
+		return "", err
+	}
+	keystore, err := encryptKeystore(address, privateKey, password)
+	if err != nil {
+		return "", err
+	}
+
+	unlock, err := km.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	if err := km.writeKeystore(keystorePath, keystore); err != nil {
+		return "", err
+	}
+	return keystorePath, nil
+}
+
+// Load 从Keystore加载私钥
+func (km *KeystoreManager) Load(address string, password string) ([]byte, error) {
+	keystore, err := km.read(address)
+	if err != nil {
+		return nil, err
+	}
+	return decryptKeystore(keystore, password)
+}
+
+// List 列出目录中的全部 Keystore（按地址排序，跳过无法解析的文件）
+func (km *KeystoreManager) List() ([]KeystoreInfo, error) {
+	entries, err := os.ReadDir(km.keystoreDir)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore dir: %w", err)
+	}
+
+	var infos []KeystoreInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		keystorePath := filepath.Join(km.keystoreDir, name)
+		data, err := os.ReadFile(keystorePath)
+		if err != nil {
+			continue
+		}
+		var keystore Keystore
+		if err := json.Unmarshal(data, &keystore); err != nil || keystore.Address == "" {
+			continue
+		}
+		info := KeystoreInfo{
+			Address: keystore.Address,
+			ID:      keystore.ID,
+			Version: keystore.Version,
+			Path:    keystorePath,
+		}
+		if fi, err := entry.Info(); err == nil {
+			info.Modified = fi.ModTime()
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Address < infos[j].Address })
+	return infos, nil
+}
+
+// Delete 删除 Keystore 文件
+func (km *KeystoreManager) Delete(address string) error {
+	keystorePath, err := km.path(address)
+	if err != nil {
+		return err
+	}
+	unlock, err := km.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(keystorePath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrKeystoreNotFound, address)
+		}
+		return fmt.Errorf("delete keystore file: %w", err)
+	}
+	syncDir(km.keystoreDir)
+	return nil
+}
+
+// ChangePassword 使用新密码重新加密 Keystore（原子替换，失败时原文件保持不变）
+func (km *KeystoreManager) ChangePassword(address, oldPassword, newPassword string) error {
+	keystorePath, err := km.path(address)
+	if err != nil {
+		return err
+	}
+	unlock, err := km.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	keystore, err := km.read(address)
+	if err != nil {
+		return err
+	}
+	privateKey, err := decryptKeystore(keystore, oldPassword)
+	if err != nil {
+		return err
+	}
+	defer zeroBytes(privateKey)
+
+	updated, err := encryptKeystore(address, privateKey, newPassword)
+	if err != nil {
+		return err
+	}
+	updated.ID = keystore.ID
+	return km.writeKeystore(keystorePath, updated)
+}
+
+// Export 导出 Keystore 文件内容（仍为加密状态）
+func (km *KeystoreManager) Export(address string) ([]byte, error) {
+	keystorePath, err := km.path(address)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(keystorePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrKeystoreNotFound, address)
+		}
+		return nil, fmt.Errorf("read keystore file: %w", err)
+	}
+	return data, nil
+}
+
+// Import 导入单个 Keystore 文件，返回其地址
+//
+// 导入前使用 password 校验可解密，并由私钥推导地址与文件声明的 address 比对，不一致时返回
+// ErrKeystoreAddressMismatch；同一地址已存在时返回 ErrKeystoreExists。
+func (km *KeystoreManager) Import(data []byte, password string) (string, error) {
+	var keystore Keystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return "", fmt.Errorf("parse keystore: %w", err)
+	}
+	keystorePath, err := km.path(keystore.Address)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := decryptKeystore(&keystore, password)
+	if err != nil {
+		return "", err
+	}
+	w, err := NewWalletFromPrivateKey(hex.EncodeToString(privateKey))
+	zeroBytes(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key in keystore: %w", err)
+	}
+	derived := types.MustAddressFromBytes(w.Address())
+	if claimed, err := types.ParseAddress(keystore.Address); err != nil || claimed != derived {
+		return "", fmt.Errorf("%w: file declares %q, private key derives %s", ErrKeystoreAddressMismatch, keystore.Address, derived)
+	}
+
+	unlock, err := km.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	if _, err := os.Stat(keystorePath); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrKeystoreExists, keystore.Address)
+	}
+	if err := km.writeKeystore(keystorePath, &keystore); err != nil {
+		return "", err
+	}
+	return keystore.Address, nil
+}
+
+// Unlock 解密 Keystore 并返回在 ttl 后自动锁定的 Wallet（ttl <= 0 表示不自动锁定）
+//
+// 锁定后私钥被清零，签名方法返回 ErrWalletLocked，PrivateKey 返回 nil。
+func (km *KeystoreManager) Unlock(address, password string, ttl time.Duration) (*UnlockedWallet, error) {
+	privateKey, err := km.Load(address, password)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(privateKey)
+
+	w, err := NewWalletFromPrivateKey(hex.EncodeToString(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	return newUnlockedWallet(w.(*SimpleWallet), ttl), nil
+}
+
+// path 返回 Keystore 文件路径（地址不得包含路径分隔符，防止路径穿越）
+func (km *KeystoreManager) path(address string) (string, error) {
+	if address == "" || address != filepath.Base(address) || strings.HasPrefix(address, ".") {
+		return "", fmt.Errorf("invalid keystore address: %q", address)
+	}
+	return filepath.Join(km.keystoreDir, fmt.Sprintf("%s.json", address)), nil
+}
+
+// read 读取并解析 Keystore 文件
+func (km *KeystoreManager) read(address string) (*Keystore, error) {
+	keystorePath, err := km.path(address)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(keystorePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrKeystoreNotFound, address)
+		}
+		return nil, fmt.Errorf("read keystore file: %w", err)
+	}
+	var keystore Keystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, fmt.Errorf("parse keystore: %w", err)
+	}
+	return &keystore, nil
+}
+
+// lock 获取进程内互斥锁与目录级文件锁
+func (km *KeystoreManager) lock() (func(), error) {
+	km.mu.Lock()
+	release, err := lockFile(filepath.Join(km.keystoreDir, lockFileName))
+	if err != nil {
+		km.mu.Unlock()
+		return nil, fmt.Errorf("lock keystore dir: %w", err)
+	}
+	return func() {
+		release()
+		km.mu.Unlock()
+	}, nil
+}
+
+// writeKeystore 原子写入 Keystore 文件（临时文件 + fsync + rename）
+func (km *KeystoreManager) writeKeystore(keystorePath string, keystore *Keystore) error {
+	data, err := json.MarshalIndent(keystore, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode keystore: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(km.keystoreDir, ".tmp-keystore-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // rename 成功后为空操作
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, keystorePath); err != nil {
+		return fmt.Errorf("rename keystore file: %w", err)
+	}
+	syncDir(km.keystoreDir)
+	return nil
+}
+
+// syncDir fsync 目录（部分平台如 Windows 不支持目录 fsync，忽略该错误）
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+}
+
+// encryptKeystore 使用密码加密私钥，生成 Keystore 结构
+func encryptKeystore(address string, privateKey []byte, password string) (*Keystore, error) {
 	// 1. 生成随机salt和IV
 	salt := make([]byte, 32)
 	iv := make([]byte, 16)
 	if _, err := rand.Read(salt); err != nil {
-		return "", fmt.Errorf("generate salt: %w", err)
+		return nil, fmt.Errorf("generate salt: %w", err)
 	}
 	if _, err := rand.Read(iv); err != nil {
-		return "", fmt.Errorf("generate iv: %w", err)
+		return nil, fmt.Errorf("generate iv: %w", err)
 	}
 
 	// 2. 派生密钥（使用PBKDF2）
@@ -69,14 +382,14 @@ func (km *KeystoreManager) Save(address string, privateKey []byte, password stri
 	// 3. 加密私钥
 	ciphertext, err := encryptAES(key, privateKey, iv)
 	if err != nil {
-		return "", fmt.Errorf("encrypt private key: %w", err)
+		return nil, fmt.Errorf("encrypt private key: %w", err)
 	}
 
 	// 4. 计算MAC
 	mac := computeMAC(key, ciphertext)
 
 	// 5. 构建Keystore结构
-	keystore := &Keystore{
+	return &Keystore{
 		Version: 1,
 		ID:      generateID(),
 		Address: address,
@@ -95,41 +408,12 @@ func (km *KeystoreManager) Save(address string, privateKey []byte, password stri
 			},
 			MAC: hex.EncodeToString(mac),
 		},
-	}
-
-	// 6. 保存到文件
-	keystorePath := filepath.Join(km.keystoreDir, fmt.Sprintf("%s.json", address))
-	file, err := os.Create(keystorePath)
-	if err != nil {
-		return "", fmt.Errorf("create keystore file: %w", err)
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(keystore); err != nil {
-		return "", fmt.Errorf("encode keystore: %w", err)
-	}
-
-	return keystorePath, nil
+	}, nil
 }
 
-// Load 从Keystore加载私钥
-func (km *KeystoreManager) Load(address string, password string) ([]byte, error) {
-	// 1. 读取Keystore文件
-	keystorePath := filepath.Join(km.keystoreDir, fmt.Sprintf("%s.json", address))
-	data, err := os.ReadFile(keystorePath)
-	if err != nil {
-		return nil, fmt.Errorf("read keystore file: %w", err)
-	}
-
-	// 2. 解析Keystore
-	var keystore Keystore
-	if err := json.Unmarshal(data, &keystore); err != nil {
-		return nil, fmt.Errorf("parse keystore: %w", err)
-	}
-
-	// 3. 提取参数
+// decryptKeystore 使用密码解密 Keystore 中的私钥
+func decryptKeystore(keystore *Keystore, password string) ([]byte, error) {
+	// 1. 提取参数
 	saltHex, ok := keystore.Crypto.KDFParams["salt"].(string)
 	if !ok {
 		return nil, fmt.Errorf("invalid salt")
@@ -149,20 +433,20 @@ func (km *KeystoreManager) Load(address string, password string) ([]byte, error)
 		return nil, fmt.Errorf("decode ciphertext: %w", err)
 	}
 
-	// 4. 派生密钥
+	// 2. 派生密钥
 	key := deriveKey(password, salt)
 
-	// 5. 验证MAC
+	// 3. 验证MAC
 	expectedMAC := computeMAC(key, ciphertext)
 	actualMAC, err := hex.DecodeString(keystore.Crypto.MAC)
 	if err != nil {
 		return nil, fmt.Errorf("decode mac: %w", err)
 	}
 	if !equalMAC(expectedMAC, actualMAC) {
-		return nil, fmt.Errorf("invalid password")
+		return nil, ErrInvalidPassword
 	}
 
-	// 6. 解密私钥
+	// 4. 解密私钥
 	privateKey, err := decryptAES(key, ciphertext, iv)
 	if err != nil {
 		return nil, fmt.Errorf("decrypt private key: %w", err)
@@ -171,6 +455,12 @@ func (km *KeystoreManager) Load(address string, password string) ([]byte, error)
 	return privateKey, nil
 }
 
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
 // deriveKey 派生密钥（PBKDF2）
 func deriveKey(password string, salt []byte) []byte {
 	// TODO: 实现PBKDF2密钥派生
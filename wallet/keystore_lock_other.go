@@ -0,0 +1,32 @@
+//go:build !unix
+
+package wallet
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// lockFile 获取文件排他锁，返回释放函数
+//
+// 非 unix 平台没有 flock：以 O_EXCL 创建锁文件表示持有锁，轮询等待；
+// 超过 staleLockAge 的锁文件视为持有进程已崩溃，直接清理。
+func lockFile(path string) (func(), error) {
+	const staleLockAge = 30 * time.Second
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if fi, statErr := os.Stat(path); statErr == nil && time.Since(fi.ModTime()) > staleLockAge {
+			_ = os.Remove(path)
+			continue
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
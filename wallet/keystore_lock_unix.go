@@ -0,0 +1,24 @@
+//go:build unix
+
+package wallet
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile 获取文件排他锁（flock，阻塞直到获得），返回释放函数
+func lockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,205 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/types"
+)
+
+func testPrivateKey(b byte) []byte {
+	key := make([]byte, 32)
+	key[31] = b
+	return key
+}
+
+// testKeyAddress testPrivateKey(b) 对应的 Base58 地址
+func testKeyAddress(b byte) string {
+	w, _ := NewWalletFromPrivateKey(hex.EncodeToString(testPrivateKey(b)))
+	return types.MustAddressFromBytes(w.Address()).String()
+}
+
+func TestKeystoreLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	km, err := NewKeystoreManager(dir)
+	if err != nil {
+		t.Fatalf("NewKeystoreManager: %v", err)
+	}
+	if _, err := km.Save("alice", testPrivateKey(1), "pw1"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := km.Save("bob", testPrivateKey(2), "pw2"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := km.Save("../escape", testPrivateKey(3), "pw"); err == nil {
+		t.Fatalf("expected path traversal to be rejected")
+	}
+
+	infos, err := km.List()
+	if err != nil || len(infos) != 2 || infos[0].Address != "alice" || infos[1].Address != "bob" || infos[0].ID == "" {
+		t.Fatalf("List = %+v, %v", infos, err)
+	}
+
+	// 修改密码：旧密码失效，ID 保持不变
+	if err := km.ChangePassword("alice", "wrong", "pw3"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected ErrInvalidPassword, got %v", err)
+	}
+	if err := km.ChangePassword("alice", "pw1", "pw3"); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	if _, err := km.Load("alice", "pw1"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("old password should no longer work, got %v", err)
+	}
+	if key, err := km.Load("alice", "pw3"); err != nil || !bytes.Equal(key, testPrivateKey(1)) {
+		t.Fatalf("Load with new password = %x, %v", key, err)
+	}
+	if after, _ := km.List(); after[0].ID != infos[0].ID {
+		t.Fatalf("ChangePassword should keep the keystore ID")
+	}
+
+	// 导出后导入到另一个目录（导入要求 address 与私钥一致，使用真实地址保存）
+	bobAddress := testKeyAddress(2)
+	if _, err := km.Save(bobAddress, testPrivateKey(2), "pw2"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := km.Export(bobAddress)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	other, _ := NewKeystoreManager(t.TempDir())
+	if _, err := other.Import(data, "wrong"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected import with wrong password to fail, got %v", err)
+	}
+	if address, err := other.Import(data, "pw2"); err != nil || address != bobAddress {
+		t.Fatalf("Import = %q, %v", address, err)
+	}
+	if _, err := other.Import(data, "pw2"); !errors.Is(err, ErrKeystoreExists) {
+		t.Fatalf("expected ErrKeystoreExists, got %v", err)
+	}
+
+	if err := km.Delete("bob"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := km.Delete("bob"); !errors.Is(err, ErrKeystoreNotFound) {
+		t.Fatalf("expected ErrKeystoreNotFound, got %v", err)
+	}
+	if _, err := km.Load("bob", "pw2"); !errors.Is(err, ErrKeystoreNotFound) {
+		t.Fatalf("expected ErrKeystoreNotFound, got %v", err)
+	}
+
+	// 原子写入不留下临时文件
+	matches, _ := filepath.Glob(filepath.Join(dir, ".tmp-*"))
+	if len(matches) != 0 {
+		t.Fatalf("temp files left behind: %v", matches)
+	}
+}
+
+func TestKeystoreConcurrentChangePassword(t *testing.T) {
+	km, _ := NewKeystoreManager(t.TempDir())
+	if _, err := km.Save("alice", testPrivateKey(1), "pw0"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// 多个管理器实例（模拟多个进程）轮流修改密码，每次都必须基于上一次的结果
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	current := "pw0"
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m, _ := NewKeystoreManager(km.keystoreDir)
+			for {
+				mu.Lock()
+				old := current
+				mu.Unlock()
+				next := fmt.Sprintf("pw%d", i)
+				err := m.ChangePassword("alice", old, next)
+				if err == nil {
+					mu.Lock()
+					current = next
+					mu.Unlock()
+					return
+				}
+				if !errors.Is(err, ErrInvalidPassword) {
+					t.Errorf("ChangePassword: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if key, err := km.Load("alice", current); err != nil || !bytes.Equal(key, testPrivateKey(1)) {
+		t.Fatalf("Load after concurrent updates = %x, %v", key, err)
+	}
+}
+
+func TestUnlockRelocksAfterTTL(t *testing.T) {
+	km, _ := NewKeystoreManager(t.TempDir())
+	if _, err := km.Save("alice", testPrivateKey(1), "pw"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := km.Unlock("alice", "wrong", time.Second); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected ErrInvalidPassword, got %v", err)
+	}
+
+	var w Wallet
+	unlocked, err := km.Unlock("alice", "pw", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	w = unlocked
+	hash := make([]byte, 32)
+	if _, err := w.SignHash(hash); err != nil {
+		t.Fatalf("SignHash while unlocked: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !unlocked.IsLocked() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := w.SignHash(hash); !errors.Is(err, ErrWalletLocked) {
+		t.Fatalf("expected ErrWalletLocked after TTL, got %v", err)
+	}
+	if w.PrivateKey() != nil || len(w.Address()) != 20 {
+		t.Fatalf("locked wallet should keep its address but not its key")
+	}
+	if _, err := os.Stat(filepath.Join(km.keystoreDir, "alice.json")); err != nil {
+		t.Fatalf("keystore file should remain: %v", err)
+	}
+}
+
+func TestKeystoreImportRejectsAddressMismatch(t *testing.T) {
+	km, _ := NewKeystoreManager(t.TempDir())
+	if _, err := km.Save("bob", testPrivateKey(2), "pw"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	labelled, _ := km.Export("bob")
+
+	// 声明为他人地址：导入后会以错误地址出现在 List 中并被用于签名
+	var keystore Keystore
+	if err := json.Unmarshal(labelled, &keystore); err != nil {
+		t.Fatal(err)
+	}
+	keystore.Address = testKeyAddress(1)
+	forged, _ := json.Marshal(&keystore)
+
+	other, _ := NewKeystoreManager(t.TempDir())
+	for name, data := range map[string][]byte{"label": labelled, "forged": forged} {
+		if _, err := other.Import(data, "pw"); !errors.Is(err, ErrKeystoreAddressMismatch) {
+			t.Errorf("%s: Import error = %v, want ErrKeystoreAddressMismatch", name, err)
+		}
+	}
+	if infos, _ := other.List(); len(infos) != 0 {
+		t.Fatalf("mismatched keystores should not be imported, got %+v", infos)
+	}
+}
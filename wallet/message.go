@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// MessagePrefix 消息签名前缀
+//
+// 以 0x19 开头，保证消息签名的预映像不会是合法的交易编码，
+// 避免"签名消息"被用作交易签名（钓鱼攻击）。
+const MessagePrefix = "\x19WES Signed Message:\n"
+
+// HashMessage 计算带域分隔的消息哈希
+//
+// **格式**：
+//
+//	SHA-256( MessagePrefix || uint64BE(len(chainID)) || chainID || uint64BE(len(msg)) || msg )
+//
+// chainID 为节点 wes_chainId 返回的链 ID（如 "0x1"），为空表示不绑定具体链。
+func HashMessage(chainID string, msg []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(MessagePrefix)
+	_ = binary.Write(&buf, binary.BigEndian, uint64(len(chainID)))
+	buf.WriteString(chainID)
+	_ = binary.Write(&buf, binary.BigEndian, uint64(len(msg)))
+	buf.Write(msg)
+
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
+// ChainMessageSigner 自行处理消息签名的钱包（如策略钱包、远程钱包不接受任意哈希签名）
+type ChainMessageSigner interface {
+	SignMessageForChain(chainID string, msg []byte) ([]byte, error)
+}
+
+// SignMessageForChain 使用钱包对绑定链 ID 的消息签名
+// 返回 65 字节可恢复签名 r || s || v；钱包实现 ChainMessageSigner 时交由其签名
+func SignMessageForChain(w Wallet, chainID string, msg []byte) ([]byte, error) {
+	if w == nil {
+		return nil, fmt.Errorf("wallet is nil")
+	}
+	if s, ok := w.(ChainMessageSigner); ok {
+		return s.SignMessageForChain(chainID, msg)
+	}
+	return w.SignHashRecoverable(HashMessage(chainID, msg))
+}
+
+// RecoverMessageSigner 从消息签名中恢复签名者地址（20 字节）
+func RecoverMessageSigner(chainID string, msg, sig []byte) ([]byte, error) {
+	return RecoverAddress(HashMessage(chainID, msg), sig)
+}
+
+// VerifyMessage 验证消息签名是否由指定地址签出
+// sig 必须为 65 字节可恢复签名
+func VerifyMessage(address []byte, chainID string, msg, sig []byte) bool {
+	signer, err := RecoverMessageSigner(chainID, msg, sig)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(signer, address)
+}
@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSignMessageDomainSeparation(t *testing.T) {
+	w, _ := vectorWallet(t)
+	msg := []byte("login nonce 42")
+
+	sig, err := w.SignMessage(msg)
+	if err != nil {
+		t.Fatalf("SignMessage() error = %v", err)
+	}
+	if len(sig) != RecoverableSignatureLength {
+		t.Fatalf("SignMessage() length = %d, want %d", len(sig), RecoverableSignatureLength)
+	}
+	if !VerifyMessage(w.Address(), "", msg, sig) {
+		t.Errorf("VerifyMessage() = false for own signature")
+	}
+
+	// 签名不能被当作对原始哈希的签名
+	raw := sha256.Sum256(msg)
+	if addr, err := RecoverAddress(raw[:], sig); err == nil && bytes.Equal(addr, w.Address()) {
+		t.Errorf("message signature should not verify against unprefixed hash")
+	}
+
+	// 链 ID 不同则签名不可互用
+	mainnet, err := SignMessageForChain(w, "0x1", msg)
+	if err != nil {
+		t.Fatalf("SignMessageForChain() error = %v", err)
+	}
+	if !VerifyMessage(w.Address(), "0x1", msg, mainnet) {
+		t.Errorf("VerifyMessage() = false on matching chain")
+	}
+	if VerifyMessage(w.Address(), "0x2", msg, mainnet) {
+		t.Errorf("VerifyMessage() = true on different chain")
+	}
+	if VerifyMessage(w.Address(), "0x1", []byte("other"), mainnet) {
+		t.Errorf("VerifyMessage() = true for different message")
+	}
+}
+
+func TestHashMessageLengthDelimited(t *testing.T) {
+	// chainID 与消息的边界不可混淆
+	a := HashMessage("0x1", []byte("2abc"))
+	b := HashMessage("0x12", []byte("abc"))
+	if bytes.Equal(a, b) {
+		t.Errorf("HashMessage() collides across chainID/message boundary")
+	}
+
+	want := "\x19WES Signed Message:\n" +
+		"\x00\x00\x00\x00\x00\x00\x00\x03" + "0x1" +
+		"\x00\x00\x00\x00\x00\x00\x00\x02" + "hi"
+	sum := sha256.Sum256([]byte(want))
+	if got := HashMessage("0x1", []byte("hi")); !bytes.Equal(got, sum[:]) {
+		t.Errorf("HashMessage() = %s, want %s", hex.EncodeToString(got), hex.EncodeToString(sum[:]))
+	}
+}
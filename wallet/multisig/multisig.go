@@ -0,0 +1,178 @@
+// Package multisig 提供 M-of-N 多签钱包与部分签名收集
+//
+// MultiKeyLockCondition 锁定的输出需要 M 个授权公钥分别签名才能花费。MultisigWallet
+// 描述公钥集合与门限 M；NewSession 为交易草稿创建可分享的签名会话（草稿 + 未签名交易 +
+// 各输入签名哈希），各签名方可异步、离线地签名并返回部分签名，协调方逐个校验后收集，
+// 凑齐 M 个签名即可 finalize 并提交。
+//
+// **流程**：
+//
+//	ms, _ := multisig.NewMultisigWallet(2, [][]byte{pubA, pubB, pubC})
+//	session, _ := ms.NewSession(ctx, cli, draftJSON, []uint32{0})
+//	data, _ := json.Marshal(session)             // 分发给各签名方
+//
+//	// 签名方
+//	var s multisig.Session
+//	_ = json.Unmarshal(data, &s)
+//	partials, _ := multisig.Sign(ctx, cli, &s, walletB) // 本地重算签名哈希、核对 UTXO 锁定条件后签名
+//
+//	// 协调方
+//	_ = session.AddSignatures(partials...)
+//	if session.IsComplete() {
+//		txHash, _ := session.Submit(ctx, cli)
+//	}
+package multisig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// MultisigWallet M-of-N 多签钱包（仅持有公钥集合，不持有私钥）
+type MultisigWallet struct {
+	required uint32
+	keys     [][]byte // 33 字节压缩公钥
+	ordered  bool
+}
+
+// NewMultisigWallet 创建多签钱包
+//
+// pubkeys 支持 33 字节压缩或 65 字节未压缩格式，统一保存为压缩格式；不允许重复公钥。
+func NewMultisigWallet(required uint32, pubkeys [][]byte) (*MultisigWallet, error) {
+	if len(pubkeys) == 0 {
+		return nil, fmt.Errorf("pubkeys cannot be empty")
+	}
+	if required == 0 || required > uint32(len(pubkeys)) {
+		return nil, fmt.Errorf("required signatures must be between 1 and %d, got %d", len(pubkeys), required)
+	}
+
+	keys := make([][]byte, 0, len(pubkeys))
+	for i, pub := range pubkeys {
+		compressed, err := compressPubkey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("pubkey %d: %w", i, err)
+		}
+		for _, k := range keys {
+			if bytes.Equal(k, compressed) {
+				return nil, fmt.Errorf("duplicate pubkey %x", compressed)
+			}
+		}
+		keys = append(keys, compressed)
+	}
+	return &MultisigWallet{required: required, keys: keys}, nil
+}
+
+// FromLockingCondition 从 MultiKeyLockCondition 创建多签钱包
+func FromLockingCondition(lock *resource.MultiKeyLockCondition) (*MultisigWallet, error) {
+	if lock == nil {
+		return nil, fmt.Errorf("locking condition is nil")
+	}
+	if err := lock.Validate(); err != nil {
+		return nil, err
+	}
+	m, err := NewMultisigWallet(lock.RequiredSignatures, lock.AuthorizedKeys)
+	if err != nil {
+		return nil, err
+	}
+	m.ordered = lock.RequireOrderedSignatures
+	return m, nil
+}
+
+// WithOrderedSignatures 返回要求签名按公钥顺序排列的副本
+func (m *MultisigWallet) WithOrderedSignatures() *MultisigWallet {
+	c := *m
+	c.ordered = true
+	return &c
+}
+
+// Required 门限 M
+func (m *MultisigWallet) Required() uint32 {
+	return m.required
+}
+
+// Keys 授权公钥列表（压缩格式）
+func (m *MultisigWallet) Keys() [][]byte {
+	keys := make([][]byte, len(m.keys))
+	for i, k := range m.keys {
+		keys[i] = append([]byte(nil), k...)
+	}
+	return keys
+}
+
+// LockingCondition 对应的锁定条件（用于创建多签锁定的输出）
+func (m *MultisigWallet) LockingCondition() *resource.MultiKeyLockCondition {
+	return &resource.MultiKeyLockCondition{
+		RequiredSignatures:       m.required,
+		AuthorizedKeys:           m.Keys(),
+		RequireOrderedSignatures: m.ordered,
+	}
+}
+
+// KeyIndex 公钥在授权列表中的位置（不存在时返回 -1）
+func (m *MultisigWallet) KeyIndex(pubkey []byte) int {
+	compressed, err := compressPubkey(pubkey)
+	if err != nil {
+		return -1
+	}
+	return keyIndex(m.keys, compressed)
+}
+
+// Contains 钱包公钥是否在授权列表中
+func (m *MultisigWallet) Contains(w wallet.Wallet) bool {
+	pub, err := walletPubkey(w)
+	return err == nil && keyIndex(m.keys, pub) >= 0
+}
+
+func keyIndex(keys [][]byte, compressed []byte) int {
+	for i, k := range keys {
+		if bytes.Equal(k, compressed) {
+			return i
+		}
+	}
+	return -1
+}
+
+// compressPubkey 校验并转换为 33 字节压缩公钥
+func compressPubkey(pub []byte) ([]byte, error) {
+	switch len(pub) {
+	case 33:
+		key, err := ethcrypto.DecompressPubkey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compressed pubkey: %w", err)
+		}
+		return ethcrypto.CompressPubkey(key), nil
+	case 65:
+		key, err := ethcrypto.UnmarshalPubkey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uncompressed pubkey: %w", err)
+		}
+		return ethcrypto.CompressPubkey(key), nil
+	default:
+		return nil, fmt.Errorf("invalid pubkey length %d", len(pub))
+	}
+}
+
+// walletPubkey 钱包的压缩公钥
+func walletPubkey(w wallet.Wallet) ([]byte, error) {
+	if w == nil {
+		return nil, fmt.Errorf("wallet is nil")
+	}
+	priv := w.PrivateKey()
+	if priv == nil {
+		return nil, fmt.Errorf("wallet private key is nil")
+	}
+	return ethcrypto.CompressPubkey(&priv.PublicKey), nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,238 @@
+package multisig
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// mockClientFor 返回以 m 的锁定条件应答 wes_getUTXO 的模拟节点
+func mockClientFor(t *testing.T, m *MultisigWallet) *multisigMockClient {
+	t.Helper()
+	lock, err := m.LockingCondition().ToProto()
+	if err != nil {
+		t.Fatalf("ToProto: %v", err)
+	}
+	// 模拟 JSON 往返后的类型
+	data, _ := json.Marshal(lock)
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	return &multisigMockClient{lock: decoded}
+}
+
+// multisigMockClient 模拟节点：按草稿计算签名哈希、返回多签 UTXO 并记录 finalize 参数
+type multisigMockClient struct {
+	lock      map[string]interface{} // 草稿输入对应 UTXO 的锁定条件
+	finalized []map[string]interface{}
+	sent      int
+}
+
+func (m *multisigMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_getUTXO":
+		return map[string]interface{}{"utxos": []interface{}{
+			map[string]interface{}{"outpoint": strings.Repeat("aa", 32) + ":0", "amount": "300", "locking_condition": m.lock},
+			map[string]interface{}{"outpoint": strings.Repeat("bb", 32) + ":1", "amount": "300", "locking_condition": m.lock},
+		}}, nil
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_finalizeTransactionFromDraft":
+		m.finalized = append(m.finalized, params.(map[string]interface{}))
+		return map[string]interface{}{"tx": params.(map[string]interface{})["unsignedTx"]}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *multisigMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	m.sent++
+	return &client.SendTxResult{TxHash: fmt.Sprintf("%064x", m.sent), Accepted: true}, nil
+}
+
+func (m *multisigMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *multisigMockClient) Close() error { return nil }
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+func pubkey(w wallet.Wallet) []byte {
+	return ethcrypto.CompressPubkey(&w.PrivateKey().PublicKey)
+}
+
+func testDraft(to []byte) []byte {
+	draft, _ := json.Marshal(map[string]interface{}{
+		"sign_mode": "defer_sign",
+		"inputs": []interface{}{
+			map[string]interface{}{"tx_hash": strings.Repeat("aa", 32), "output_index": 0, "is_reference_only": false},
+			map[string]interface{}{"tx_hash": strings.Repeat("bb", 32), "output_index": 1, "is_reference_only": false},
+		},
+		"outputs":  []interface{}{map[string]interface{}{"type": "asset", "owner": hex.EncodeToString(to), "amount": "500"}},
+		"metadata": map[string]interface{}{"caller_address": strings.Repeat("11", 20)},
+	})
+	return draft
+}
+
+func TestNewMultisigWallet(t *testing.T) {
+	a, b := testWallet(t, 1), testWallet(t, 2)
+	if _, err := NewMultisigWallet(3, [][]byte{pubkey(a), pubkey(b)}); err == nil {
+		t.Fatalf("expected error when M > N")
+	}
+	if _, err := NewMultisigWallet(1, [][]byte{pubkey(a), pubkey(a)}); err == nil {
+		t.Fatalf("expected error for duplicate pubkey")
+	}
+
+	uncompressed := ethcrypto.FromECDSAPub(&b.PrivateKey().PublicKey)
+	m, err := NewMultisigWallet(2, [][]byte{pubkey(a), uncompressed})
+	if err != nil {
+		t.Fatalf("NewMultisigWallet: %v", err)
+	}
+	if m.KeyIndex(pubkey(b)) != 1 || !m.Contains(a) || m.Contains(testWallet(t, 3)) {
+		t.Fatalf("unexpected key set")
+	}
+
+	lock := m.WithOrderedSignatures().LockingCondition()
+	if err := lock.Validate(); err != nil {
+		t.Fatalf("LockingCondition: %v", err)
+	}
+	restored, err := FromLockingCondition(lock)
+	if err != nil || restored.Required() != 2 || !restored.ordered || len(restored.Keys()) != 2 {
+		t.Fatalf("FromLockingCondition = %+v, %v", restored, err)
+	}
+}
+
+func TestSessionCollectAndFinalize(t *testing.T) {
+	a, b, c, outsider := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3), testWallet(t, 4)
+	m, err := NewMultisigWallet(2, [][]byte{pubkey(a), pubkey(b), pubkey(c)})
+	if err != nil {
+		t.Fatalf("NewMultisigWallet: %v", err)
+	}
+	mc := mockClientFor(t, m)
+	ctx := context.Background()
+
+	session, err := m.NewSession(ctx, mc, testDraft(outsider.Address()), []uint32{0, 1})
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	// 会话序列化后分发给签名方
+	data, err := json.Marshal(session)
+	if err != nil {
+		t.Fatalf("marshal session: %v", err)
+	}
+	var shared Session
+	if err := json.Unmarshal(data, &shared); err != nil {
+		t.Fatalf("unmarshal session: %v", err)
+	}
+
+	if _, err := Sign(ctx, mc, &shared, outsider); !errors.Is(err, ErrUnauthorizedKey) {
+		t.Fatalf("expected ErrUnauthorizedKey, got %v", err)
+	}
+	partialsC, err := Sign(ctx, mc, &shared, c)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := session.AddSignatures(partialsC...); err != nil {
+		t.Fatalf("AddSignatures: %v", err)
+	}
+	if _, err := session.Finalize(ctx, mc); !errors.Is(err, ErrIncomplete) {
+		t.Fatalf("expected ErrIncomplete, got %v", err)
+	}
+	if missing := session.Missing(); missing[0] != 1 || missing[1] != 1 {
+		t.Fatalf("Missing = %v", missing)
+	}
+
+	// 签名与输入不匹配时拒绝
+	partialsA, err := Sign(ctx, mc, &shared, a)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	swapped := partialsA[0]
+	swapped.InputIndex = 1
+	if err := session.AddSignatures(swapped); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	if err := session.AddSignatures(append(partialsA, partialsC...)...); err != nil {
+		t.Fatalf("AddSignatures: %v", err)
+	}
+	if !session.IsComplete() {
+		t.Fatalf("session should be complete")
+	}
+	txHash, err := session.Submit(ctx, mc)
+	if err != nil || txHash == "" {
+		t.Fatalf("Submit = %q, %v", txHash, err)
+	}
+
+	// 每个输入两个签名，按授权公钥顺序（a 在 c 之前）
+	sigs := mc.finalized[0]["signatures"].([]map[string]interface{})
+	if len(sigs) != 4 {
+		t.Fatalf("expected 4 signatures, got %d", len(sigs))
+	}
+	wantA, wantC := "0x"+hex.EncodeToString(pubkey(a)), "0x"+hex.EncodeToString(pubkey(c))
+	if sigs[0]["pubkey"] != wantA || sigs[1]["pubkey"] != wantC || sigs[2]["input_index"] != uint32(1) {
+		t.Fatalf("unexpected signature order %v", sigs)
+	}
+	if sigs[0]["key_index"] != 0 || sigs[1]["key_index"] != 2 {
+		t.Fatalf("unexpected key indices %v", sigs)
+	}
+}
+
+func TestSignRejectsTamperedSession(t *testing.T) {
+	ctx := context.Background()
+	a, b, evil := testWallet(t, 1), testWallet(t, 2), testWallet(t, 3)
+	m, _ := NewMultisigWallet(2, [][]byte{pubkey(a), pubkey(b)})
+	mc := mockClientFor(t, m)
+	session, err := m.NewSession(ctx, mc, testDraft(b.Address()), []uint32{0})
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	clone := func() *Session {
+		data, _ := json.Marshal(session)
+		var c Session
+		json.Unmarshal(data, &c)
+		return &c
+	}
+
+	// 协调方篡改草稿（改变收款方）后，签名方本地校验失败
+	tampered := clone()
+	tampered.Draft = testDraft(a.Address())
+	if _, err := Sign(ctx, mc, tampered, b); err == nil {
+		t.Fatalf("expected tampered draft to be rejected")
+	}
+
+	// 协调方伪造授权公钥集合与门限（1-of-2，替换为自己的公钥）：与 UTXO 真实锁定条件不符
+	forged, _ := NewMultisigWallet(1, [][]byte{pubkey(evil), pubkey(b)})
+	tampered = clone()
+	tampered.Required = forged.required
+	tampered.AuthorizedKeys = []string{"0x" + hex.EncodeToString(pubkey(evil)), "0x" + hex.EncodeToString(pubkey(b))}
+	if _, err := Sign(ctx, mc, tampered, b); !errors.Is(err, ErrUnauthorizedKey) {
+		t.Fatalf("expected ErrUnauthorizedKey for forged key set, got %v", err)
+	}
+
+	// UTXO 不是多签锁定
+	if _, err := Sign(ctx, &multisigMockClient{}, session, b); err == nil {
+		t.Fatalf("expected error for utxo without multi_key_lock")
+	}
+	if _, err := Sign(ctx, mc, session, b); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+}
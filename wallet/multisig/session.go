@@ -0,0 +1,428 @@
+package multisig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/services/resource"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+var (
+	// ErrUnauthorizedKey 签名公钥不在授权列表中
+	ErrUnauthorizedKey = errors.New("pubkey is not an authorized key")
+
+	// ErrInvalidSignature 部分签名校验失败
+	ErrInvalidSignature = errors.New("invalid partial signature")
+
+	// ErrIncomplete 签名数量未达到门限
+	ErrIncomplete = errors.New("not enough signatures")
+)
+
+// PartialSignature 单个签名方对单个输入的签名
+type PartialSignature struct {
+	InputIndex uint32 `json:"input_index"`
+	Pubkey     string `json:"pubkey"`    // 0x 前缀压缩公钥
+	Signature  string `json:"signature"` // 0x 前缀 r || s
+}
+
+// SessionInput 会话中待签名的输入
+type SessionInput struct {
+	InputIndex uint32             `json:"input_index"`
+	Sighash    string             `json:"sighash"` // 签名哈希（hex）
+	Signatures []PartialSignature `json:"signatures"`
+}
+
+// Session 可分享的多签签名会话（JSON 序列化后分发给各签名方）
+//
+// 会话方法并发安全，可在多个 goroutine 中同时收集签名。
+type Session struct {
+	ID             string          `json:"id"` // SHA-256(unsignedTx)（hex）
+	Draft          json.RawMessage `json:"draft"`
+	UnsignedTx     string          `json:"unsignedTx"`
+	Required       uint32          `json:"required_signatures"`
+	AuthorizedKeys []string        `json:"authorized_keys"` // 0x 前缀压缩公钥
+	RequireOrdered bool            `json:"require_ordered_signatures"`
+	Inputs         []*SessionInput `json:"inputs"`
+
+	mu sync.Mutex
+}
+
+// NewSession 为草稿创建签名会话（inputIndices 为多签锁定的输入）
+func (m *MultisigWallet) NewSession(ctx context.Context, c client.Client, draftJSON []byte, inputIndices []uint32) (*Session, error) {
+	sighash, err := utils.ComputeDraftSighash(ctx, c, draftJSON, inputIndices, txcodec.SighashAll)
+	if err != nil {
+		return nil, fmt.Errorf("compute signature hash failed: %w", err)
+	}
+	txBytes, err := decodeHex(sighash.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unsignedTx: %w", err)
+	}
+	id := sha256.Sum256(txBytes)
+
+	s := &Session{
+		ID:             hex.EncodeToString(id[:]),
+		Draft:          append(json.RawMessage(nil), draftJSON...),
+		UnsignedTx:     sighash.UnsignedTx,
+		Required:       m.required,
+		RequireOrdered: m.ordered,
+	}
+	for _, k := range m.keys {
+		s.AuthorizedKeys = append(s.AuthorizedKeys, "0x"+hex.EncodeToString(k))
+	}
+	for _, idx := range inputIndices {
+		s.Inputs = append(s.Inputs, &SessionInput{
+			InputIndex: idx,
+			Sighash:    hex.EncodeToString(sighash.Hash(idx)),
+		})
+	}
+	return s, nil
+}
+
+// Wallet 会话对应的多签钱包
+func (s *Session) Wallet() (*MultisigWallet, error) {
+	keys := make([][]byte, 0, len(s.AuthorizedKeys))
+	for _, k := range s.AuthorizedKeys {
+		b, err := decodeHex(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authorized key %q: %w", k, err)
+		}
+		keys = append(keys, b)
+	}
+	m, err := NewMultisigWallet(s.Required, keys)
+	if err != nil {
+		return nil, err
+	}
+	m.ordered = s.RequireOrdered
+	return m, nil
+}
+
+// Verify 本地校验会话：未签名交易与草稿一致，且各输入签名哈希与本地计算结果一致
+//
+// 签名方收到会话后应先调用 Verify（Sign 会自动调用），不应信任会话中的签名哈希。
+func (s *Session) Verify() error {
+	tx, err := txcodec.DecodeHex(s.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("decode unsignedTx failed: %w", err)
+	}
+	if err := utils.VerifyTxMatchesDraft(tx, s.Draft); err != nil {
+		return err
+	}
+	if _, err := s.Wallet(); err != nil {
+		return err
+	}
+	for _, in := range s.Inputs {
+		local, err := txcodec.ComputeSighash(tx, in.InputIndex, txcodec.SighashAll)
+		if err != nil {
+			return fmt.Errorf("compute signature hash for input %d failed: %w", in.InputIndex, err)
+		}
+		if hex.EncodeToString(local) != in.Sighash {
+			return &utils.SighashMismatchError{InputIndex: in.InputIndex, LocalHash: local, Reason: "session sighash does not match unsignedTx"}
+		}
+	}
+	return nil
+}
+
+// VerifyLock 对照节点返回的 UTXO 锁定条件校验会话的授权公钥与门限
+//
+// 会话中的 AuthorizedKeys / Required 来自协调方，签名方不应直接信任：逐个输入通过 wes_getUTXO
+// 查询被花费 UTXO 的锁定条件（草稿 metadata.caller_address 为 UTXO 所有者），要求其为
+// multi_key_lock（可包在已到期的时间锁 / 高度锁内），且公钥顺序、门限与有序签名要求均与会话一致。
+func (s *Session) VerifyLock(ctx context.Context, c client.Client) error {
+	tx, err := txcodec.DecodeHex(s.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("decode unsignedTx failed: %w", err)
+	}
+	var draft struct {
+		Metadata struct {
+			CallerAddress string `json:"caller_address"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(s.Draft, &draft); err != nil {
+		return fmt.Errorf("parse draft failed: %w", err)
+	}
+	owner, err := decodeHex(draft.Metadata.CallerAddress)
+	if err != nil || len(owner) == 0 {
+		return fmt.Errorf("draft metadata.caller_address is required to look up the multisig utxo")
+	}
+	m, err := s.Wallet()
+	if err != nil {
+		return err
+	}
+
+	for _, in := range s.Inputs {
+		if int(in.InputIndex) >= len(tx.Inputs) {
+			return fmt.Errorf("input %d out of range", in.InputIndex)
+		}
+		prev := tx.Inputs[in.InputIndex].PreviousOutput
+		lock, err := utils.QueryUTXOLock(ctx, c, owner, prev.TxID, prev.OutputIndex)
+		if err != nil {
+			return fmt.Errorf("input %d: %w", in.InputIndex, err)
+		}
+		actual, err := multiKeyLockFromMap(lock)
+		if err != nil {
+			return fmt.Errorf("input %d: %w", in.InputIndex, err)
+		}
+		if actual.RequiredSignatures != m.required || actual.RequireOrderedSignatures != m.ordered || len(actual.AuthorizedKeys) != len(m.keys) {
+			return fmt.Errorf("%w: input %d is locked by a %d-of-%d key set, session claims %d-of-%d",
+				ErrUnauthorizedKey, in.InputIndex, actual.RequiredSignatures, len(actual.AuthorizedKeys), m.required, len(m.keys))
+		}
+		for i, k := range actual.AuthorizedKeys {
+			if !bytes.Equal(k, m.keys[i]) {
+				return fmt.Errorf("%w: input %d authorized key %d does not match the session", ErrUnauthorizedKey, in.InputIndex, i)
+			}
+		}
+	}
+	return nil
+}
+
+// multiKeyLockFromMap 解析 wes_getUTXO 返回的 multi_key_lock（公钥统一为压缩格式）
+func multiKeyLockFromMap(lock map[string]interface{}) (*resource.MultiKeyLockCondition, error) {
+	for _, key := range []string{"time_lock", "height_lock"} {
+		if inner, ok := lock[key].(map[string]interface{}); ok {
+			base, _ := inner["base_lock"].(map[string]interface{})
+			return multiKeyLockFromMap(base)
+		}
+	}
+	mk, ok := lock["multi_key_lock"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("utxo is not locked by a multi_key_lock")
+	}
+	ordered, _ := mk["require_ordered_signatures"].(bool)
+	result := &resource.MultiKeyLockCondition{
+		RequiredSignatures:       uint32(utils.ParseUint(mk["required_signatures"])),
+		RequireOrderedSignatures: ordered,
+	}
+	keys, _ := mk["authorized_keys"].([]interface{})
+	for i, k := range keys {
+		var encoded string
+		switch v := k.(type) {
+		case string:
+			encoded = v
+		case map[string]interface{}:
+			encoded, _ = v["value"].(string)
+		}
+		raw, err := decodeKeyBytes(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("authorized key %d: %w", i, err)
+		}
+		pub, err := compressPubkey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("authorized key %d: %w", i, err)
+		}
+		result.AuthorizedKeys = append(result.AuthorizedKeys, pub)
+	}
+	return result, nil
+}
+
+// decodeKeyBytes 解码公钥（hex，可带 0x 前缀；兼容 protobuf JSON 的 Base64）
+func decodeKeyBytes(s string) ([]byte, error) {
+	if b, err := decodeHex(s); err == nil {
+		return b, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// Sign 校验会话后使用 w 对全部输入签名，返回部分签名（需交回协调方）
+//
+// 除本地校验（Verify）外，还通过 c 查询被花费 UTXO 的真实锁定条件（VerifyLock），
+// 防止协调方伪造授权公钥集合或门限。
+func Sign(ctx context.Context, c client.Client, s *Session, w wallet.Wallet) ([]PartialSignature, error) {
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	if err := s.VerifyLock(ctx, c); err != nil {
+		return nil, err
+	}
+	pub, err := walletPubkey(w)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := s.Wallet()
+	if keyIndex(m.keys, pub) < 0 {
+		return nil, ErrUnauthorizedKey
+	}
+
+	partials := make([]PartialSignature, 0, len(s.Inputs))
+	for _, in := range s.Inputs {
+		hash, _ := hex.DecodeString(in.Sighash)
+		sig, err := w.SignHash(hash)
+		if err != nil {
+			return nil, fmt.Errorf("sign input %d failed: %w", in.InputIndex, err)
+		}
+		partials = append(partials, PartialSignature{
+			InputIndex: in.InputIndex,
+			Pubkey:     "0x" + hex.EncodeToString(pub),
+			Signature:  "0x" + hex.EncodeToString(sig),
+		})
+	}
+	return partials, nil
+}
+
+// AddSignatures 校验并加入部分签名
+//
+// 公钥须在授权列表中，签名须能通过对应输入签名哈希的验证；同一公钥对同一输入重复提交时忽略。
+// 任一签名校验失败即返回错误，此前已通过校验的签名仍会保留。
+func (s *Session) AddSignatures(partials ...PartialSignature) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.Wallet()
+	if err != nil {
+		return err
+	}
+	for _, p := range partials {
+		in := s.input(p.InputIndex)
+		if in == nil {
+			return fmt.Errorf("%w: input %d is not part of the session", ErrInvalidSignature, p.InputIndex)
+		}
+		pub, err := decodeHex(p.Pubkey)
+		if err != nil {
+			return fmt.Errorf("%w: invalid pubkey: %v", ErrInvalidSignature, err)
+		}
+		pub, err = compressPubkey(pub)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		if keyIndex(m.keys, pub) < 0 {
+			return fmt.Errorf("%w: %x", ErrUnauthorizedKey, pub)
+		}
+		sig, err := decodeHex(p.Signature)
+		if err != nil {
+			return fmt.Errorf("%w: invalid signature: %v", ErrInvalidSignature, err)
+		}
+		hash, _ := hex.DecodeString(in.Sighash)
+		if !wallet.VerifySignature(pub, hash, sig) {
+			return fmt.Errorf("%w: signature by %x does not verify for input %d", ErrInvalidSignature, pub, p.InputIndex)
+		}
+		if in.hasSigner(pub) {
+			continue
+		}
+		in.Signatures = append(in.Signatures, PartialSignature{
+			InputIndex: p.InputIndex,
+			Pubkey:     "0x" + hex.EncodeToString(pub),
+			Signature:  "0x" + hex.EncodeToString(sig[:wallet.SignatureLength]),
+		})
+	}
+	return nil
+}
+
+// Missing 各输入尚缺的签名数量（已达门限的输入不出现在结果中）
+func (s *Session) Missing() map[uint32]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	missing := make(map[uint32]int)
+	for _, in := range s.Inputs {
+		if n := int(s.Required) - len(in.Signatures); n > 0 {
+			missing[in.InputIndex] = n
+		}
+	}
+	return missing
+}
+
+// IsComplete 所有输入是否均已收集到 M 个签名
+func (s *Session) IsComplete() bool {
+	return len(s.Missing()) == 0
+}
+
+// Finalize 组装签名并调用 wes_finalizeTransactionFromDraft，返回已签名交易（hex）
+//
+// 每个输入取按授权公钥顺序排列的前 M 个签名，key_index 为签名公钥在锁定条件 AuthorizedKeys 中的位置。
+func (s *Session) Finalize(ctx context.Context, c client.Client) (string, error) {
+	if missing := s.Missing(); len(missing) > 0 {
+		return "", fmt.Errorf("%w: %v", ErrIncomplete, missing)
+	}
+
+	s.mu.Lock()
+	var signatures []map[string]interface{}
+	for _, in := range s.Inputs {
+		sigs := append([]PartialSignature(nil), in.Signatures...)
+		sort.SliceStable(sigs, func(i, j int) bool {
+			return s.keyPosition(sigs[i].Pubkey) < s.keyPosition(sigs[j].Pubkey)
+		})
+		for _, p := range sigs[:s.Required] {
+			signatures = append(signatures, map[string]interface{}{
+				"input_index":  in.InputIndex,
+				"key_index":    s.keyPosition(p.Pubkey),
+				"sighash_type": "SIGHASH_ALL",
+				"pubkey":       p.Pubkey,
+				"signature":    p.Signature,
+			})
+		}
+	}
+	finalizeParams := map[string]interface{}{
+		"draft":      s.Draft,
+		"unsignedTx": s.UnsignedTx,
+		"signatures": signatures,
+	}
+	s.mu.Unlock()
+
+	finalResult, err := c.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+	return txHex, nil
+}
+
+// Submit Finalize 后提交交易，返回交易哈希
+func (s *Session) Submit(ctx context.Context, c client.Client) (string, error) {
+	txHex, err := s.Finalize(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	sendResult, err := c.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	return sendResult.TxHash, nil
+}
+
+func (s *Session) input(index uint32) *SessionInput {
+	for _, in := range s.Inputs {
+		if in.InputIndex == index {
+			return in
+		}
+	}
+	return nil
+}
+
+// keyPosition 公钥在授权列表中的位置
+func (s *Session) keyPosition(pubkey string) int {
+	for i, k := range s.AuthorizedKeys {
+		if k == pubkey {
+			return i
+		}
+	}
+	return len(s.AuthorizedKeys)
+}
+
+func (in *SessionInput) hasSigner(pub []byte) bool {
+	for _, p := range in.Signatures {
+		if b, err := decodeHex(p.Pubkey); err == nil && bytes.Equal(b, pub) {
+			return true
+		}
+	}
+	return false
+}
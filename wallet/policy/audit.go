@@ -0,0 +1,245 @@
+package policy
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// 审计记录的决定
+const (
+	DecisionApproved = "approved"
+	DecisionDenied   = "denied"
+)
+
+// 审计记录的请求类型
+const (
+	KindDraft       = "draft"       // 业务服务签名前的草稿审查
+	KindTransaction = "transaction" // SignTransaction（本地解码交易后审查）
+	KindHash        = "hash"        // SignHash / SignHashRecoverable
+	KindMessage     = "message"     // 消息 / 结构化数据签名
+)
+
+// ErrAuditChainBroken 审计日志哈希链校验失败（记录被删除、插入或篡改）
+var ErrAuditChainBroken = errors.New("audit log chain broken")
+
+// AuditEntry 审计记录
+//
+// 每条记录包含上一条记录的哈希，Hash = SHA-256(prev_hash || 本条记录去掉 hash 字段的 JSON)，
+// 形成只能追加的哈希链。
+type AuditEntry struct {
+	Seq          uint64            `json:"seq"`
+	Time         time.Time         `json:"time"`
+	Kind         string            `json:"kind"`
+	Signer       string            `json:"signer"` // 签名方地址（Base58）
+	Operation    string            `json:"operation,omitempty"`
+	Outflows     map[string]string `json:"outflows,omitempty"` // 代币 → 外流金额
+	Destinations []string          `json:"destinations,omitempty"`
+	Hashes       []string          `json:"hashes,omitempty"` // 签名哈希（hex）
+	Decision     string            `json:"decision"`
+	Reason       string            `json:"reason,omitempty"`
+	PrevHash     string            `json:"prev_hash"`
+	Hash         string            `json:"hash"`
+}
+
+// AuditLog 只能追加的审计日志
+//
+// Append 负责填写 Seq、PrevHash 与 Hash；返回错误时策略钱包拒绝签名（fail closed）。
+type AuditLog interface {
+	Append(entry *AuditEntry) error
+}
+
+// AuditHistory 可读取历史记录的审计日志
+//
+// NewWallet 据此回放 24 小时内获准的外流，重建日限额窗口，进程重启后已用额度不会清零。
+// MemoryAuditLog 与 FileAuditLog 均实现该接口。
+type AuditHistory interface {
+	History() ([]AuditEntry, error)
+}
+
+// link 根据上一条记录填写序号与哈希
+func link(prev *AuditEntry, e *AuditEntry) {
+	e.Seq, e.PrevHash = 0, ""
+	if prev != nil {
+		e.Seq, e.PrevHash = prev.Seq+1, prev.Hash
+	}
+	e.Hash = entryHash(e)
+}
+
+func entryHash(e *AuditEntry) string {
+	c := *e
+	c.Hash = ""
+	data, _ := json.Marshal(&c)
+	h := sha256.New()
+	h.Write([]byte(e.PrevHash))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAuditChain 校验审计记录的序号与哈希链
+func VerifyAuditChain(entries []AuditEntry) error {
+	for i := range entries {
+		e := &entries[i]
+		var prev *AuditEntry
+		if i > 0 {
+			prev = &entries[i-1]
+		}
+		switch {
+		case prev == nil && (e.Seq != 0 || e.PrevHash != ""):
+			return fmt.Errorf("%w: entry %d does not start the chain", ErrAuditChainBroken, e.Seq)
+		case prev != nil && (e.Seq != prev.Seq+1 || e.PrevHash != prev.Hash):
+			return fmt.Errorf("%w: entry %d does not follow entry %d", ErrAuditChainBroken, e.Seq, prev.Seq)
+		case entryHash(e) != e.Hash:
+			return fmt.Errorf("%w: entry %d hash mismatch", ErrAuditChainBroken, e.Seq)
+		}
+	}
+	return nil
+}
+
+// MemoryAuditLog 内存审计日志（默认实现，进程退出后丢失）
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// NewMemoryAuditLog 创建内存审计日志
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{}
+}
+
+// Append 实现 AuditLog
+func (l *MemoryAuditLog) Append(entry *AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var prev *AuditEntry
+	if n := len(l.entries); n > 0 {
+		prev = &l.entries[n-1]
+	}
+	link(prev, entry)
+	l.entries = append(l.entries, *entry)
+	return nil
+}
+
+// Entries 返回全部记录的副本
+func (l *MemoryAuditLog) Entries() []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]AuditEntry(nil), l.entries...)
+}
+
+// History 实现 AuditHistory
+func (l *MemoryAuditLog) History() ([]AuditEntry, error) {
+	return l.Entries(), nil
+}
+
+// FileAuditLog JSON Lines 文件审计日志
+//
+// 以 O_APPEND 方式写入，每条记录写入后 fsync；打开已有文件时校验哈希链并从最后一条继续。
+type FileAuditLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	last *AuditEntry
+}
+
+// OpenFileAuditLog 打开（不存在时创建）审计日志文件
+func OpenFileAuditLog(path string) (*FileAuditLog, error) {
+	entries, err := ReadAuditLog(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := VerifyAuditChain(entries); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	l := &FileAuditLog{path: path, file: f}
+	if n := len(entries); n > 0 {
+		l.last = &entries[n-1]
+	}
+	return l, nil
+}
+
+// Append 实现 AuditLog
+func (l *FileAuditLog) Append(entry *AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+	link(l.last, entry)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write audit entry: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("sync audit log: %w", err)
+	}
+	e := *entry
+	l.last = &e
+	return nil
+}
+
+// History 实现 AuditHistory（重新读取文件并校验哈希链）
+func (l *FileAuditLog) History() ([]AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries, err := ReadAuditLog(l.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyAuditChain(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Close 关闭文件
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// ReadAuditLog 读取审计日志文件中的全部记录（不校验哈希链，见 VerifyAuditChain）
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("parse audit log line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
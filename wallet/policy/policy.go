@@ -0,0 +1,775 @@
+// Package policy 提供在签名前强制执行签名策略的钱包包装器
+//
+// 持有 wallet.Wallet 的代码可以对节点返回的任何内容签名。策略钱包包装内层钱包，在签名前
+// 检查本地解码的未签名交易：
+//   - 按代币的单笔 / 24 小时滚动窗口外流限额（原生币含隐含手续费，需 Options.Client 查询输入金额）
+//   - 收款地址白名单
+//   - 禁止的操作（如 "transfer_ownership"，按草稿 metadata.operation 与交易中的资源输出判断）
+//   - 需要若干审批回调批准
+//
+// 每次签名请求及其决定都写入只能追加的审计日志（带哈希链）。
+//
+// **接入方式**：各业务服务在签名前调用 wallet.RequireSigner，策略钱包通过 wallet.DraftReviewer
+// 审查草稿并记录获准的签名哈希；SignHash 只对已获准的哈希签名，其他哈希一律拒绝。
+//
+//	pw, _ := policy.NewWallet(w, &policy.Policy{
+//		PerTxLimit: map[string]types.Amount{policy.NativeToken: types.NewAmount(1_000_000)},
+//		Allowlist:  []types.Address{treasury},
+//		ForbiddenOperations: []string{"transfer_ownership"},
+//	}, policy.Options{AuditLog: auditLog, Client: cli})
+//	tokenService := token.NewServiceWithWallet(cli, pw)
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// NativeToken 原生币在限额表中的键
+const NativeToken = "native"
+
+// dailyWindow 日限额的滚动窗口
+const dailyWindow = 24 * time.Hour
+
+var (
+	// ErrPolicyViolation 签名请求违反策略
+	ErrPolicyViolation = errors.New("signing policy violation")
+
+	// ErrNotReviewed 待签名哈希未经草稿审查（或已被使用）
+	ErrNotReviewed = errors.New("hash was not approved by policy review")
+)
+
+// Policy 签名策略（零值表示不做限制）
+//
+// 可由 JSON 配置文件解析（地址支持 Base58 / hex，金额为十进制字符串）。
+type Policy struct {
+	PerTxLimit            map[string]types.Amount `json:"per_tx_limit,omitempty"`           // 代币 → 单笔外流上限（键见 TokenKey）
+	DailyLimit            map[string]types.Amount `json:"daily_limit,omitempty"`            // 代币 → 24 小时滚动窗口外流上限
+	Allowlist             []types.Address         `json:"allowlist,omitempty"`              // 收款地址白名单（空表示不限制；找零到自身地址总是允许）
+	ForbiddenOperations   []string                `json:"forbidden_operations,omitempty"`   // 禁止的操作（见 derivedOperations；其他操作要求草稿给出 metadata.operation）；配置后 SignTransaction 一律拒绝
+	RequiredConfirmations int                     `json:"required_confirmations,omitempty"` // 需要多少个审批回调批准（0 表示无需审批）
+	ConfirmAbove          map[string]types.Amount `json:"confirm_above,omitempty"`          // 可选：仅当某代币外流超过该金额时才需要审批；为空时每次都需要
+	AllowMessages         bool                    `json:"allow_messages,omitempty"`         // 允许签名消息 / 结构化数据（哈希带域分隔，不会与交易签名哈希混用）
+}
+
+// SignRequest 交给审批回调的签名请求
+type SignRequest struct {
+	Kind         string                  // KindDraft / KindTransaction
+	Signer       types.Address           // 签名方地址
+	Operation    string                  // 草稿 metadata.operation（可能为空）
+	Outflows     map[string]types.Amount // 按代币汇总的外流金额（不含找零；原生币含隐含手续费）
+	Destinations []types.Address         // 除自身外的输出所有者
+	Draft        json.RawMessage         // 交易草稿（KindTransaction 时为 nil）
+	Tx           *txcodec.Transaction    // 本地解码的未签名交易
+	InputIndices []uint32                // 本钱包签名的输入（KindTransaction 时为全部非引用输入）
+}
+
+// Approver 审批回调：返回 nil 表示批准，返回错误表示拒绝（错误信息写入审计日志）
+type Approver func(ctx context.Context, req *SignRequest) error
+
+// Options 策略钱包选项
+type Options struct {
+	Approvers       []Approver       // 审批回调，按顺序调用直到批准数满足 RequiredConfirmations
+	ApprovalTimeout time.Duration    // 单个审批回调的超时（0 表示不限）
+	AuditLog        AuditLog         // 审计日志（nil 时使用 MemoryAuditLog；实现 AuditHistory 时据此重建日限额窗口）
+	Client          client.Client    // 节点客户端，用于查询输入金额以计入隐含手续费（配置原生币限额时必填）
+	Now             func() time.Time // 时钟（测试用，nil 时使用 time.Now）
+}
+
+// Wallet 策略钱包
+type Wallet struct {
+	inner   wallet.Wallet
+	address types.Address
+	policy  Policy
+	opts    Options
+
+	reviewMu sync.Mutex // 串行化审查，保证日限额的检查与记录原子完成
+	mu       sync.Mutex
+	approved map[string]bool // 已获准、尚未使用的签名哈希（hex）
+	spends   []spend         // 滚动窗口内的外流记录
+}
+
+type spend struct {
+	at     time.Time
+	token  string
+	amount types.Amount
+}
+
+// NewWallet 创建策略钱包
+func NewWallet(inner wallet.Wallet, p *Policy, opts Options) (*Wallet, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("wallet is nil")
+	}
+	if wallet.IsWatchOnly(inner) {
+		return nil, fmt.Errorf("policy wallet requires a signing wallet")
+	}
+	address, err := types.NewAddressFromBytes(inner.Address())
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		p = &Policy{}
+	}
+	if p.RequiredConfirmations > len(opts.Approvers) {
+		return nil, fmt.Errorf("required confirmations %d exceeds %d approvers", p.RequiredConfirmations, len(opts.Approvers))
+	}
+	if opts.Client == nil && limitsNativeToken(p) {
+		return nil, fmt.Errorf("native token limits require Options.Client to look up input values")
+	}
+	if opts.AuditLog == nil {
+		opts.AuditLog = NewMemoryAuditLog()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	w := &Wallet{
+		inner:    inner,
+		address:  address,
+		policy:   *p,
+		opts:     opts,
+		approved: make(map[string]bool),
+	}
+	if err := w.replaySpends(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// replaySpends 从审计日志回放 24 小时内本地址获准的外流，重建日限额窗口
+//
+// 审计日志不支持读取历史（未实现 AuditHistory）时窗口只在内存中累计。
+func (w *Wallet) replaySpends() error {
+	history, ok := w.opts.AuditLog.(AuditHistory)
+	if !ok || len(w.policy.DailyLimit) == 0 {
+		return nil
+	}
+	entries, err := history.History()
+	if err != nil {
+		return fmt.Errorf("read audit history: %w", err)
+	}
+	now := w.opts.Now()
+	signer := w.address.String()
+	for _, e := range entries {
+		if e.Decision != DecisionApproved || e.Signer != signer || (e.Kind != KindDraft && e.Kind != KindTransaction) {
+			continue
+		}
+		if now.Sub(e.Time) >= dailyWindow {
+			continue
+		}
+		for token, s := range e.Outflows {
+			if _, ok := w.policy.DailyLimit[token]; !ok {
+				continue
+			}
+			amount, err := types.ParseAmount(s)
+			if err != nil {
+				return fmt.Errorf("audit entry %d: invalid %s outflow: %w", e.Seq, token, err)
+			}
+			if !amount.IsZero() {
+				w.spends = append(w.spends, spend{at: e.Time, token: token, amount: amount})
+			}
+		}
+	}
+	return nil
+}
+
+// TokenKey 合约代币在限额表中的键："<合约地址 hex>/<代币 ID hex>"
+func TokenKey(contractAddress, tokenID []byte) string {
+	return hex.EncodeToString(contractAddress) + "/" + hex.EncodeToString(tokenID)
+}
+
+// ReviewDraft 实现 wallet.DraftReviewer：审查草稿对应的未签名交易，获准后记录待签名哈希
+//
+// 要求签名哈希已在本地校验（SighashVerifyCrossCheck 或 SighashVerifyLocalOnly），
+// 否则无法确认哈希与交易内容对应，直接拒绝。
+func (w *Wallet) ReviewDraft(draftJSON []byte, sighash *utils.DraftSighash, inputIndices []uint32) error {
+	entry := &AuditEntry{Kind: KindDraft}
+	for _, idx := range inputIndices {
+		if sighash != nil {
+			entry.Hashes = append(entry.Hashes, hex.EncodeToString(sighash.Hash(idx)))
+		}
+	}
+	if sighash == nil || sighash.Tx == nil {
+		return w.deny(entry, "draft sighash was not verified locally; use SighashVerifyCrossCheck or SighashVerifyLocalOnly")
+	}
+
+	var draft struct {
+		Metadata struct {
+			Operation string `json:"operation"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(draftJSON, &draft); err != nil {
+		return w.deny(entry, fmt.Sprintf("parse draft: %v", err))
+	}
+	req := &SignRequest{
+		Kind:         KindDraft,
+		Signer:       w.address,
+		Operation:    draft.Metadata.Operation,
+		Draft:        append(json.RawMessage(nil), draftJSON...),
+		Tx:           sighash.Tx,
+		InputIndices: append([]uint32(nil), inputIndices...),
+	}
+	if err := w.review(req, entry); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	for _, h := range entry.Hashes {
+		w.approved[h] = true
+	}
+	w.mu.Unlock()
+	return nil
+}
+
+// review 执行策略检查与审批，获准时记录外流并写审计日志
+//
+// 审查串行执行（包括等待审批回调），避免并发请求同时通过日限额检查。
+func (w *Wallet) review(req *SignRequest, entry *AuditEntry) error {
+	w.reviewMu.Lock()
+	defer w.reviewMu.Unlock()
+
+	entry.Operation = req.Operation
+	outflows, destinations, err := w.outflows(req.Tx, req.InputIndices)
+	if err != nil {
+		return w.deny(entry, err.Error())
+	}
+	req.Outflows, req.Destinations = outflows, destinations
+	entry.Outflows = make(map[string]string, len(outflows))
+	for token, amount := range outflows {
+		entry.Outflows[token] = amount.String()
+	}
+	for _, d := range destinations {
+		entry.Destinations = append(entry.Destinations, d.String())
+	}
+
+	// 1. 禁止的操作：metadata.operation 由调用方提供，不受签名保护，因此同时按交易内容推断操作
+	derived, err := w.derivedOperations(req.Tx)
+	if err != nil {
+		return w.deny(entry, err.Error())
+	}
+	for _, op := range w.policy.ForbiddenOperations {
+		if req.Operation == op {
+			return w.deny(entry, fmt.Sprintf("operation %q is forbidden", op))
+		}
+		if contains(derived, op) {
+			return w.deny(entry, fmt.Sprintf("operation %q is forbidden (derived from transaction outputs)", op))
+		}
+		if req.Operation == "" && !derivableOperations[op] {
+			return w.deny(entry, fmt.Sprintf("draft has no operation; cannot rule out forbidden operation %q", op))
+		}
+	}
+
+	// 2. 收款地址白名单
+	if len(w.policy.Allowlist) > 0 {
+		for _, d := range destinations {
+			if !containsAddress(w.policy.Allowlist, d) {
+				return w.deny(entry, fmt.Sprintf("destination %s is not in the allowlist", d))
+			}
+		}
+	}
+
+	// 3. 单笔限额
+	for _, token := range sortedTokens(outflows) {
+		amount := outflows[token]
+		if limit, ok := w.policy.PerTxLimit[token]; ok && amount.Cmp(limit) > 0 {
+			return w.deny(entry, fmt.Sprintf("%s outflow %s exceeds per-tx limit %s", token, amount, limit))
+		}
+	}
+
+	// 4. 日限额
+	now := w.opts.Now()
+	w.mu.Lock()
+	w.pruneSpends(now)
+	for _, token := range sortedTokens(outflows) {
+		amount := outflows[token]
+		limit, ok := w.policy.DailyLimit[token]
+		if !ok {
+			continue
+		}
+		spent := w.spentSince(token)
+		if spent.Add(amount).Cmp(limit) > 0 {
+			w.mu.Unlock()
+			return w.deny(entry, fmt.Sprintf("%s outflow %s exceeds daily limit %s (spent %s in the last 24h)", token, amount, limit, spent))
+		}
+	}
+	w.mu.Unlock()
+
+	// 5. 审批
+	if w.needsConfirmation(outflows) {
+		if err := w.confirm(req); err != nil {
+			return w.deny(entry, err.Error())
+		}
+	}
+
+	w.mu.Lock()
+	for token, amount := range outflows {
+		if _, ok := w.policy.DailyLimit[token]; ok && !amount.IsZero() {
+			w.spends = append(w.spends, spend{at: now, token: token, amount: amount})
+		}
+	}
+	w.mu.Unlock()
+
+	entry.Decision = DecisionApproved
+	return w.audit(entry)
+}
+
+// outflows 汇总离开本钱包控制的资产，并收集除自身外的输出所有者
+//
+// 所有者为自身且只有指向自身的单签锁（或无锁定条件）的输出视为找零，其余输出均计入外流。
+// 配置了 Options.Client 时，原生币外流取「非找零输出之和」与「本钱包输入 - 找零」中的较大者，
+// 后者包含未写入输出的隐含手续费（省略找零即可把余额作为手续费烧掉）。
+func (w *Wallet) outflows(tx *txcodec.Transaction, inputIndices []uint32) (map[string]types.Amount, []types.Address, error) {
+	outflows := make(map[string]types.Amount)
+	change := types.NewAmount(0)
+	var destinations []types.Address
+	for i, out := range tx.Outputs {
+		var owner types.Address
+		if len(out.Owner) > 0 {
+			var err error
+			if owner, err = types.NewAddressFromBytes(out.Owner); err != nil {
+				return nil, nil, fmt.Errorf("output %d: invalid owner: %v", i, err)
+			}
+		} else if out.Asset != nil {
+			return nil, nil, fmt.Errorf("asset output %d has no owner", i)
+		}
+		if w.isChange(owner, out) {
+			if out.Asset != nil && out.Asset.NativeCoin != nil && out.Asset.NativeCoin.Amount != "" {
+				amount, err := types.ParseAmount(out.Asset.NativeCoin.Amount)
+				if err != nil {
+					return nil, nil, fmt.Errorf("output %d: %v", i, err)
+				}
+				change = change.Add(amount)
+			}
+			continue
+		}
+		if !owner.IsZero() && owner != w.address && !containsAddress(destinations, owner) {
+			destinations = append(destinations, owner)
+		}
+		if out.Asset == nil {
+			continue
+		}
+
+		token, amountStr := NativeToken, ""
+		switch {
+		case out.Asset.NativeCoin != nil:
+			amountStr = out.Asset.NativeCoin.Amount
+		case out.Asset.ContractToken != nil:
+			ct := out.Asset.ContractToken
+			id := ct.FungibleClassID
+			if len(ct.NFTUniqueID) > 0 {
+				id = ct.NFTUniqueID
+			}
+			token, amountStr = TokenKey(ct.ContractAddress, id), ct.Amount
+			if amountStr == "" && len(ct.NFTUniqueID) > 0 {
+				amountStr = "1"
+			}
+		default:
+			return nil, nil, fmt.Errorf("output %d: unknown asset type", i)
+		}
+		amount := types.NewAmount(0)
+		if amountStr != "" {
+			var err error
+			if amount, err = types.ParseAmount(amountStr); err != nil {
+				return nil, nil, fmt.Errorf("output %d: %v", i, err)
+			}
+		}
+		outflows[token] = outflows[token].Add(amount)
+	}
+
+	if w.opts.Client != nil {
+		inputs, err := w.nativeInputs(tx, inputIndices)
+		if err != nil {
+			return nil, nil, err
+		}
+		if spent, err := inputs.Sub(change); err == nil && spent.Cmp(outflows[NativeToken]) > 0 {
+			outflows[NativeToken] = spent
+		}
+	}
+	return outflows, destinations, nil
+}
+
+// nativeInputs 通过 wes_getUTXO 查询本钱包签名的输入中的原生币总额
+//
+// 签名的输入不在本钱包的 UTXO 列表中时无法确定金额，返回错误。
+func (w *Wallet) nativeInputs(tx *txcodec.Transaction, inputIndices []uint32) (types.Amount, error) {
+	total := types.NewAmount(0)
+	addressBase58, err := utils.AddressBytesToBase58(w.address[:])
+	if err != nil {
+		return total, err
+	}
+	result, err := w.opts.Client.Call(context.Background(), "wes_getUTXO", []interface{}{addressBase58})
+	if err != nil {
+		return total, fmt.Errorf("query input values: %v", err)
+	}
+	resultMap, _ := result.(map[string]interface{})
+	items, _ := resultMap["utxos"].([]interface{})
+	utxos := make(map[string]map[string]interface{}, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			outpoint, _ := m["outpoint"].(string)
+			utxos[strings.ToLower(strings.TrimPrefix(outpoint, "0x"))] = m
+		}
+	}
+
+	for _, idx := range inputIndices {
+		if int(idx) >= len(tx.Inputs) {
+			return total, fmt.Errorf("input %d out of range", idx)
+		}
+		in := tx.Inputs[idx]
+		if in.IsReferenceOnly {
+			continue
+		}
+		outpoint := fmt.Sprintf("%x:%d", in.PreviousOutput.TxID, in.PreviousOutput.OutputIndex)
+		u, ok := utxos[outpoint]
+		if !ok {
+			return total, fmt.Errorf("input %d (%s) is not a known utxo of the signer; cannot determine its value", idx, outpoint)
+		}
+		if tokenID, _ := u["tokenID"].(string); tokenID != "" {
+			continue
+		}
+		amountStr, _ := u["amount"].(string)
+		amount, err := types.ParseAmount(amountStr)
+		if err != nil {
+			return total, fmt.Errorf("input %d: invalid amount: %v", idx, err)
+		}
+		total = total.Add(amount)
+	}
+	return total, nil
+}
+
+// limitsNativeToken 策略是否对原生币外流设置了限额或审批阈值
+func limitsNativeToken(p *Policy) bool {
+	_, perTx := p.PerTxLimit[NativeToken]
+	_, daily := p.DailyLimit[NativeToken]
+	_, confirm := p.ConfirmAbove[NativeToken]
+	return perTx || daily || confirm
+}
+
+// derivableOperations 可由 derivedOperations 从交易内容推断的操作
+//
+// 禁止其他操作时，草稿必须给出 metadata.operation，否则无法排除而直接拒绝。
+var derivableOperations = map[string]bool{
+	"transfer_ownership":   true,
+	"update_collaborators": true,
+	"grant_delegation":     true,
+	"set_time_lock":        true,
+	"update_lock":          true,
+}
+
+// derivedOperations 按交易内容推断资源权限操作
+//
+// 非找零的资源输出：所有者或单签锁不是自身为 transfer_ownership；否则按锁定条件归类为
+// update_collaborators（多签锁）、grant_delegation（委托锁）、set_time_lock（时间 / 高度锁），
+// 其他锁定条件为 update_lock。
+func (w *Wallet) derivedOperations(tx *txcodec.Transaction) ([]string, error) {
+	var ops []string
+	add := func(op string) {
+		if !contains(ops, op) {
+			ops = append(ops, op)
+		}
+	}
+	for i, out := range tx.Outputs {
+		if out.Resource == nil {
+			continue
+		}
+		owner, err := types.NewAddressFromBytes(out.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("resource output %d: invalid owner: %v", i, err)
+		}
+		if w.isChange(owner, out) {
+			continue
+		}
+		if owner != w.address {
+			add("transfer_ownership")
+			continue
+		}
+		for _, lock := range out.LockingConditions {
+			switch {
+			case lock.MultiKey != nil:
+				add("update_collaborators")
+			case lock.Delegation != nil:
+				add("grant_delegation")
+			case lock.TimeLock != nil, lock.HeightLock != nil:
+				add("set_time_lock")
+			case lock.SingleKey != nil:
+				if !w.isSelfLock(lock) {
+					add("transfer_ownership")
+				}
+			default:
+				add("update_lock")
+			}
+		}
+	}
+	return ops, nil
+}
+
+// isChange 输出是否仍完全由本钱包控制
+func (w *Wallet) isChange(owner types.Address, out *txcodec.TxOutput) bool {
+	if owner != w.address {
+		return false
+	}
+	for _, lock := range out.LockingConditions {
+		if !w.isSelfLock(lock) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSelfLock 是否为指向本钱包的单签锁
+func (w *Wallet) isSelfLock(lock *txcodec.LockingCondition) bool {
+	single := lock.SingleKey
+	if single == nil {
+		return false
+	}
+	if bytes.Equal(single.RequiredAddressHash, w.address[:]) {
+		return true
+	}
+	priv := w.inner.PrivateKey()
+	return priv != nil && bytes.Equal(single.RequiredPublicKey, ethcrypto.CompressPubkey(&priv.PublicKey))
+}
+
+// needsConfirmation 是否需要审批回调批准
+func (w *Wallet) needsConfirmation(outflows map[string]types.Amount) bool {
+	if w.policy.RequiredConfirmations == 0 {
+		return false
+	}
+	if len(w.policy.ConfirmAbove) == 0 {
+		return true
+	}
+	for token, amount := range outflows {
+		if threshold, ok := w.policy.ConfirmAbove[token]; ok && amount.Cmp(threshold) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// confirm 依次调用审批回调，直到批准数达到 RequiredConfirmations
+func (w *Wallet) confirm(req *SignRequest) error {
+	approvals := 0
+	var reasons []string
+	for i, approve := range w.opts.Approvers {
+		if err := w.callApprover(approve, req); err != nil {
+			reasons = append(reasons, fmt.Sprintf("approver %d: %v", i, err))
+			continue
+		}
+		if approvals++; approvals >= w.policy.RequiredConfirmations {
+			return nil
+		}
+	}
+	return fmt.Errorf("got %d of %d required confirmations %v", approvals, w.policy.RequiredConfirmations, reasons)
+}
+
+func (w *Wallet) callApprover(approve Approver, req *SignRequest) error {
+	ctx := context.Background()
+	if w.opts.ApprovalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.opts.ApprovalTimeout)
+		defer cancel()
+	}
+	return approve(ctx, req)
+}
+
+func (w *Wallet) pruneSpends(now time.Time) {
+	kept := w.spends[:0]
+	for _, s := range w.spends {
+		if now.Sub(s.at) < dailyWindow {
+			kept = append(kept, s)
+		}
+	}
+	w.spends = kept
+}
+
+func (w *Wallet) spentSince(token string) types.Amount {
+	total := types.NewAmount(0)
+	for _, s := range w.spends {
+		if s.token == token {
+			total = total.Add(s.amount)
+		}
+	}
+	return total
+}
+
+// deny 写入拒绝记录并返回 ErrPolicyViolation
+func (w *Wallet) deny(entry *AuditEntry, reason string) error {
+	entry.Decision, entry.Reason = DecisionDenied, reason
+	if err := w.audit(entry); err != nil {
+		return fmt.Errorf("%w: %s (%v)", ErrPolicyViolation, reason, err)
+	}
+	return fmt.Errorf("%w: %s", ErrPolicyViolation, reason)
+}
+
+func (w *Wallet) audit(entry *AuditEntry) error {
+	entry.Time = w.opts.Now().UTC()
+	entry.Signer = w.address.String()
+	if err := w.opts.AuditLog.Append(entry); err != nil {
+		return fmt.Errorf("append audit log: %w", err)
+	}
+	return nil
+}
+
+// takeApproved 取出（并作废）已获准的签名哈希
+func (w *Wallet) takeApproved(hash []byte) bool {
+	key := hex.EncodeToString(hash)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.approved[key] {
+		return false
+	}
+	delete(w.approved, key)
+	return true
+}
+
+// signApproved 只对已获准的哈希签名
+func (w *Wallet) signApproved(hash []byte, sign func([]byte) ([]byte, error)) ([]byte, error) {
+	entry := &AuditEntry{Kind: KindHash, Hashes: []string{hex.EncodeToString(hash)}}
+	if !w.takeApproved(hash) {
+		entry.Decision, entry.Reason = DecisionDenied, ErrNotReviewed.Error()
+		if err := w.audit(entry); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotReviewed
+	}
+	entry.Decision = DecisionApproved
+	if err := w.audit(entry); err != nil {
+		return nil, err
+	}
+	return sign(hash)
+}
+
+// Address 获取钱包地址
+func (w *Wallet) Address() []byte {
+	return w.inner.Address()
+}
+
+// SignHash 签名已通过 ReviewDraft 获准的哈希（每个哈希只能使用一次）
+func (w *Wallet) SignHash(hash []byte) ([]byte, error) {
+	return w.signApproved(hash, w.inner.SignHash)
+}
+
+// SignHashRecoverable 签名已通过 ReviewDraft 获准的哈希
+func (w *Wallet) SignHashRecoverable(hash []byte) ([]byte, error) {
+	return w.signApproved(hash, w.inner.SignHashRecoverable)
+}
+
+// SignTransaction 本地解码交易并按策略审查后签名
+//
+// 原始交易不携带草稿 metadata.operation，配置了 ForbiddenOperations 时无法判断是否为禁止操作，直接拒绝。
+func (w *Wallet) SignTransaction(tx []byte) ([]byte, error) {
+	entry := &AuditEntry{Kind: KindTransaction}
+	decoded, err := txcodec.Decode(tx)
+	if err != nil {
+		return nil, w.deny(entry, fmt.Sprintf("decode transaction: %v", err))
+	}
+	if len(w.policy.ForbiddenOperations) > 0 {
+		return nil, w.deny(entry, "raw transactions carry no operation; signing is denied while forbidden operations are configured")
+	}
+	inputIndices := make([]uint32, 0, len(decoded.Inputs))
+	for i, in := range decoded.Inputs {
+		if !in.IsReferenceOnly {
+			inputIndices = append(inputIndices, uint32(i))
+		}
+	}
+	if err := w.review(&SignRequest{Kind: KindTransaction, Signer: w.address, Tx: decoded, InputIndices: inputIndices}, entry); err != nil {
+		return nil, err
+	}
+	return w.inner.SignTransaction(tx)
+}
+
+// SignMessage 签名消息（需 Policy.AllowMessages）
+func (w *Wallet) SignMessage(msg []byte) ([]byte, error) {
+	return w.SignMessageForChain("", msg)
+}
+
+// SignMessageForChain 签名绑定链 ID 的消息（需 Policy.AllowMessages）
+//
+// 实现 wallet.ChainMessageSigner，wallet.SignMessageForChain(pw, ...) 会调用本方法。
+func (w *Wallet) SignMessageForChain(chainID string, msg []byte) ([]byte, error) {
+	return w.signMessageHash(wallet.HashMessage(chainID, msg))
+}
+
+// SignTypedData 签名结构化数据（需 Policy.AllowMessages）
+func (w *Wallet) SignTypedData(td *wallet.TypedData) ([]byte, error) {
+	if td == nil {
+		return nil, fmt.Errorf("typed data is nil")
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return w.signMessageHash(hash)
+}
+
+func (w *Wallet) signMessageHash(hash []byte) ([]byte, error) {
+	entry := &AuditEntry{Kind: KindMessage, Hashes: []string{hex.EncodeToString(hash)}}
+	if !w.policy.AllowMessages {
+		return nil, w.deny(entry, "message signing is not allowed")
+	}
+	entry.Decision = DecisionApproved
+	if err := w.audit(entry); err != nil {
+		return nil, err
+	}
+	return w.inner.SignHashRecoverable(hash)
+}
+
+// PrivateKey 返回只含公钥的副本（D 为 0），业务服务可据此取公钥，但无法绕过策略直接签名
+func (w *Wallet) PrivateKey() *ecdsa.PrivateKey {
+	priv := w.inner.PrivateKey()
+	if priv == nil {
+		return nil
+	}
+	return &ecdsa.PrivateKey{PublicKey: priv.PublicKey, D: new(big.Int)}
+}
+
+// PendingApprovals 已获准但尚未签名的哈希数量
+func (w *Wallet) PendingApprovals() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.approved)
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddress(list []types.Address, a types.Address) bool {
+	for _, x := range list {
+		if x == a {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedTokens 按字典序返回代币键（用于稳定的错误信息）
+func sortedTokens(m map[string]types.Amount) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
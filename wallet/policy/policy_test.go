@@ -0,0 +1,470 @@
+package policy
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// draftMockClient 模拟节点：按草稿构造未签名交易并返回签名哈希，wes_getUTXO 返回 testDraft 花费的输入
+type draftMockClient struct{}
+
+func (m *draftMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_getUTXO":
+		testInputsMu.Lock()
+		defer testInputsMu.Unlock()
+		utxos := make([]interface{}, 0, len(testInputs))
+		for outpoint, amount := range testInputs {
+			utxos = append(utxos, map[string]interface{}{"outpoint": outpoint, "amount": amount})
+		}
+		return map[string]interface{}{"utxos": utxos}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *draftMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *draftMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *draftMockClient) Close() error { return nil }
+
+type testOutput struct {
+	owner  []byte
+	amount string
+}
+
+// testInputs testDraft 花费的输入（outpoint → 原生币金额）
+var (
+	testInputsMu sync.Mutex
+	testInputs   = make(map[string]string)
+)
+
+// testDraft 构造花费一个输入的草稿，输入金额等于输出之和（无手续费）
+func testDraft(operation string, outputs ...testOutput) []byte {
+	total := types.NewAmount(0)
+	for _, o := range outputs {
+		amount, _ := types.ParseAmount(o.amount)
+		total = total.Add(amount)
+	}
+	return testDraftSpending(total, operation, outputs...)
+}
+
+// testDraftSpending 构造花费一个指定金额输入的草稿（输入金额超出输出之和的部分即隐含手续费）
+func testDraftSpending(input types.Amount, operation string, outputs ...testOutput) []byte {
+	testInputsMu.Lock()
+	txHash := fmt.Sprintf("%064x", len(testInputs)+1)
+	testInputs[txHash+":0"] = input.String()
+	testInputsMu.Unlock()
+
+	draft := map[string]interface{}{
+		"inputs":   []map[string]interface{}{{"tx_hash": txHash, "output_index": 0}},
+		"metadata": map[string]interface{}{"operation": operation},
+	}
+	outs := make([]map[string]interface{}, 0, len(outputs))
+	for _, o := range outputs {
+		outs = append(outs, map[string]interface{}{"type": "asset", "owner": hex.EncodeToString(o.owner), "amount": o.amount})
+	}
+	draft["outputs"] = outs
+	data, _ := json.Marshal(draft)
+	return data
+}
+
+func testWallet(t *testing.T, key byte) wallet.Wallet {
+	t.Helper()
+	w, err := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + fmt.Sprintf("%02x", key))
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	return w
+}
+
+// signDraft 走业务服务的签名流程：计算签名哈希 → RequireSigner → SignHash
+func signDraft(ctx context.Context, w wallet.Wallet, draftJSON []byte) ([]byte, error) {
+	sighash, err := utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		return nil, err
+	}
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{0}); err != nil {
+		return nil, err
+	}
+	return w.SignHash(sighash.Hash(0))
+}
+
+func TestPolicyWallet_Limits(t *testing.T) {
+	ctx := context.Background()
+	inner := testWallet(t, 1)
+	self := inner.Address()
+	friend := testWallet(t, 2).Address()
+	stranger := testWallet(t, 3).Address()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	audit := NewMemoryAuditLog()
+	pw, err := NewWallet(inner, &Policy{
+		PerTxLimit:          map[string]types.Amount{NativeToken: types.NewAmount(100)},
+		DailyLimit:          map[string]types.Amount{NativeToken: types.NewAmount(150)},
+		Allowlist:           []types.Address{types.MustAddressFromBytes(friend)},
+		ForbiddenOperations: []string{"transfer_ownership"},
+	}, Options{AuditLog: audit, Client: &draftMockClient{}, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+
+	// 找零不计入外流
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "80"}, testOutput{self, "5000"})); err != nil {
+		t.Fatalf("expected approval, got %v", err)
+	}
+
+	denied := []struct {
+		name   string
+		draft  []byte
+		reason string
+	}{
+		{"per-tx", testDraft("transfer", testOutput{friend, "101"}), "per-tx limit"},
+		{"daily", testDraft("transfer", testOutput{friend, "80"}), "daily limit"},
+		{"allowlist", testDraft("transfer", testOutput{stranger, "1"}), "not in the allowlist"},
+		{"forbidden", testDraft("transfer_ownership", testOutput{self, "1"}), "forbidden"},
+	}
+	for _, tc := range denied {
+		_, err := signDraft(ctx, pw, tc.draft)
+		if !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), tc.reason) {
+			t.Errorf("%s: expected policy violation %q, got %v", tc.name, tc.reason, err)
+		}
+	}
+
+	// 滚动窗口过期后额度恢复
+	now = now.Add(dailyWindow)
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "80"})); err != nil {
+		t.Fatalf("expected approval after window, got %v", err)
+	}
+
+	entries := audit.Entries()
+	if err := VerifyAuditChain(entries); err != nil {
+		t.Fatalf("VerifyAuditChain: %v", err)
+	}
+	var approved, deniedCount int
+	for _, e := range entries {
+		if e.Kind != KindDraft {
+			continue
+		}
+		if e.Decision == DecisionApproved {
+			approved++
+		} else {
+			deniedCount++
+		}
+	}
+	if approved != 2 || deniedCount != len(denied) {
+		t.Fatalf("unexpected draft audit entries: %d approved, %d denied", approved, deniedCount)
+	}
+}
+
+func TestPolicyWallet_DailyLimitSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	inner := testWallet(t, 1)
+	friend := testWallet(t, 2).Address()
+	p := &Policy{DailyLimit: map[string]types.Amount{NativeToken: types.NewAmount(150)}}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := Options{Client: &draftMockClient{}, Now: func() time.Time { return now }}
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, _ := OpenFileAuditLog(path)
+	clock.AuditLog = log
+	pw, _ := NewWallet(inner, p, clock)
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "100"})); err != nil {
+		t.Fatalf("expected approval, got %v", err)
+	}
+	log.Close()
+
+	// 重启：从审计日志重建窗口，已用额度不清零
+	log, err := OpenFileAuditLog(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer log.Close()
+	clock.AuditLog = log
+	pw, err = NewWallet(inner, p, clock)
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "60"})); !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "spent 100") {
+		t.Fatalf("expected daily limit violation after restart, got %v", err)
+	}
+
+	// 窗口之外的记录不再计入
+	now = now.Add(dailyWindow)
+	pw, _ = NewWallet(inner, p, clock)
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "60"})); err != nil {
+		t.Fatalf("expected approval after window, got %v", err)
+	}
+}
+
+func TestPolicyWallet_ImplicitFeeCountsAsOutflow(t *testing.T) {
+	ctx := context.Background()
+	inner := testWallet(t, 1)
+	self := inner.Address()
+	friend := testWallet(t, 2).Address()
+	p := &Policy{PerTxLimit: map[string]types.Amount{NativeToken: types.NewAmount(100)}}
+
+	if _, err := NewWallet(inner, p, Options{}); err == nil {
+		t.Fatalf("expected error for native limits without a client")
+	}
+	pw, err := NewWallet(inner, p, Options{Client: &draftMockClient{}})
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+
+	// 花费 1000、只向白名单地址转 1 且省略找零：其余 999 作为手续费烧掉，超出单笔限额
+	if _, err := signDraft(ctx, pw, testDraftSpending(types.NewAmount(1000), "transfer", testOutput{friend, "1"})); !errors.Is(err, ErrPolicyViolation) ||
+		!strings.Contains(err.Error(), "outflow 1000 exceeds per-tx limit") {
+		t.Fatalf("expected implicit fee to count against the limit, got %v", err)
+	}
+	// 找零完整时只计入实际转出与少量手续费
+	if _, err := signDraft(ctx, pw, testDraftSpending(types.NewAmount(1000), "transfer", testOutput{friend, "1"}, testOutput{self, "990"})); err != nil {
+		t.Fatalf("expected approval with change, got %v", err)
+	}
+
+	// 签名的输入不属于本钱包：无法确定金额
+	draftJSON := []byte(fmt.Sprintf(`{"inputs":[{"tx_hash":"%s","output_index":0}],"outputs":[{"type":"asset","owner":"%x","amount":"1"}]}`,
+		strings.Repeat("cd", 32), friend))
+	if _, err := signDraft(ctx, pw, draftJSON); !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "not a known utxo") {
+		t.Fatalf("expected unknown input to be denied, got %v", err)
+	}
+}
+
+func TestPolicyWallet_ForbiddenOperationsDerivedFromTx(t *testing.T) {
+	ctx := context.Background()
+	inner := testWallet(t, 1)
+	self := inner.Address()
+	stranger := testWallet(t, 3).Address()
+	resourceDraft := func(operation string, owner []byte, lock map[string]interface{}) []byte {
+		out := map[string]interface{}{"owner": hex.EncodeToString(owner), "output_type": "resource", "resource_output": map[string]interface{}{}}
+		if lock != nil {
+			out["locking_condition"] = lock
+		}
+		draft := map[string]interface{}{
+			"inputs":   []map[string]interface{}{{"tx_hash": strings.Repeat("ab", 32), "output_index": 0}},
+			"outputs":  []map[string]interface{}{out},
+			"metadata": map[string]interface{}{"operation": operation},
+		}
+		data, _ := json.Marshal(draft)
+		return data
+	}
+
+	pw, _ := NewWallet(inner, &Policy{ForbiddenOperations: []string{"transfer_ownership", "grant_delegation"}}, Options{})
+	denied := []struct {
+		name  string
+		draft []byte
+	}{
+		{"new owner without operation", resourceDraft("", stranger, nil)},
+		{"new owner with misleading operation", resourceDraft("transfer", stranger, nil)},
+		{"lock to another key", resourceDraft("", self, map[string]interface{}{"single_key_lock": map[string]interface{}{"required_address_hash": hex.EncodeToString(stranger)}})},
+		{"delegation", resourceDraft("", self, map[string]interface{}{"delegation_lock": map[string]interface{}{
+			"original_owner": hex.EncodeToString(self), "allowed_delegates": []string{hex.EncodeToString(stranger)},
+		}})},
+	}
+	for _, tc := range denied {
+		if _, err := signDraft(ctx, pw, tc.draft); !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "derived") {
+			t.Errorf("%s: expected derived operation violation, got %v", tc.name, err)
+		}
+	}
+
+	// 资源仍由自身单签控制、资产转账：无需 metadata.operation
+	if _, err := signDraft(ctx, pw, resourceDraft("", self, nil)); err != nil {
+		t.Fatalf("unchanged resource: %v", err)
+	}
+	if _, err := signDraft(ctx, pw, testDraft("", testOutput{stranger, "1"})); err != nil {
+		t.Fatalf("asset transfer without operation: %v", err)
+	}
+
+	// 无法从交易推断的禁止操作要求草稿给出 operation
+	pw, _ = NewWallet(inner, &Policy{ForbiddenOperations: []string{"revoke_delegation"}}, Options{})
+	if _, err := signDraft(ctx, pw, testDraft("", testOutput{stranger, "1"})); !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "no operation") {
+		t.Fatalf("expected missing operation violation, got %v", err)
+	}
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{stranger, "1"})); err != nil {
+		t.Fatalf("declared operation: %v", err)
+	}
+}
+
+func TestPolicyWallet_SignTransactionWithForbiddenOperations(t *testing.T) {
+	inner := testWallet(t, 1)
+	tx, _ := testnode.DraftToTx(testDraft("transfer", testOutput{testWallet(t, 2).Address(), "10"}))
+	raw, _ := txcodec.Encode(tx)
+
+	pw, _ := NewWallet(inner, &Policy{ForbiddenOperations: []string{"transfer_ownership"}}, Options{})
+	if _, err := pw.SignTransaction(raw); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation, got %v", err)
+	}
+	pw, _ = NewWallet(inner, nil, Options{})
+	if _, err := pw.SignTransaction(raw); err != nil {
+		t.Fatalf("SignTransaction: %v", err)
+	}
+}
+
+func TestPolicyWallet_SignHashRequiresReview(t *testing.T) {
+	ctx := context.Background()
+	inner := testWallet(t, 1)
+	pw, err := NewWallet(inner, nil, Options{})
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+	if pw.PrivateKey().D.Sign() != 0 {
+		t.Fatalf("PrivateKey must not expose the secret scalar")
+	}
+
+	if _, err := pw.SignHash(make([]byte, 32)); !errors.Is(err, ErrNotReviewed) {
+		t.Fatalf("expected ErrNotReviewed, got %v", err)
+	}
+
+	draftJSON := testDraft("transfer", testOutput{testWallet(t, 2).Address(), "10"})
+	sighash, err := utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeDraftSighash: %v", err)
+	}
+	if err := wallet.RequireSigner(pw, draftJSON, sighash, []uint32{0}); err != nil {
+		t.Fatalf("RequireSigner: %v", err)
+	}
+	if pw.PendingApprovals() != 1 {
+		t.Fatalf("expected 1 pending approval, got %d", pw.PendingApprovals())
+	}
+	sig, err := pw.SignHash(sighash.Hash(0))
+	if err != nil {
+		t.Fatalf("SignHash: %v", err)
+	}
+	want, _ := inner.SignHash(sighash.Hash(0))
+	if hex.EncodeToString(sig) != hex.EncodeToString(want) {
+		t.Fatalf("signature differs from inner wallet")
+	}
+	// 获准的哈希只能使用一次
+	if _, err := pw.SignHash(sighash.Hash(0)); !errors.Is(err, ErrNotReviewed) {
+		t.Fatalf("expected ErrNotReviewed on reuse, got %v", err)
+	}
+
+	// 仅信任节点的签名哈希无法审查
+	nodeOnly := utils.WithSighashVerifyMode(ctx, utils.SighashVerifyNodeOnly)
+	if _, err := signDraft(nodeOnly, pw, draftJSON); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation in NodeOnly mode, got %v", err)
+	}
+
+	if _, err := pw.SignMessage([]byte("hello")); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected message signing to be denied, got %v", err)
+	}
+
+	watch, _ := wallet.NewWatchOnlyWallet(inner.Address())
+	if _, err := NewWallet(watch, nil, Options{}); err == nil {
+		t.Fatalf("expected error for watch-only wallet")
+	}
+}
+
+func TestPolicyWallet_Approvers(t *testing.T) {
+	ctx := context.Background()
+	friend := testWallet(t, 2).Address()
+
+	var seen []*SignRequest
+	approve := func(ctx context.Context, req *SignRequest) error {
+		seen = append(seen, req)
+		return nil
+	}
+	reject := func(ctx context.Context, req *SignRequest) error {
+		return errors.New("rejected by operator")
+	}
+
+	if _, err := NewWallet(testWallet(t, 1), &Policy{RequiredConfirmations: 2}, Options{Approvers: []Approver{approve}}); err == nil {
+		t.Fatalf("expected error when confirmations exceed approvers")
+	}
+
+	pw, err := NewWallet(testWallet(t, 1), &Policy{
+		RequiredConfirmations: 2,
+		ConfirmAbove:          map[string]types.Amount{NativeToken: types.NewAmount(50)},
+	}, Options{Approvers: []Approver{approve, reject, approve}, Client: &draftMockClient{}})
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+
+	// 低于 ConfirmAbove 无需审批
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "50"})); err != nil || len(seen) != 0 {
+		t.Fatalf("expected approval without confirmation, got %v (%d approver calls)", err, len(seen))
+	}
+	// 一个拒绝、两个批准：满足 2 个确认
+	if _, err := signDraft(ctx, pw, testDraft("transfer", testOutput{friend, "51"})); err != nil {
+		t.Fatalf("expected approval with 2 confirmations, got %v", err)
+	}
+	if len(seen) != 2 || seen[0].Outflows[NativeToken].String() != "51" || len(seen[0].Destinations) != 1 {
+		t.Fatalf("unexpected approver requests %+v", seen)
+	}
+
+	strict, _ := NewWallet(testWallet(t, 1), &Policy{RequiredConfirmations: 2}, Options{Approvers: []Approver{approve, reject}})
+	if _, err := signDraft(ctx, strict, testDraft("transfer", testOutput{friend, "1"})); !errors.Is(err, ErrPolicyViolation) ||
+		!strings.Contains(err.Error(), "rejected by operator") {
+		t.Fatalf("expected denial with approver reason, got %v", err)
+	}
+}
+
+func TestFileAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := OpenFileAuditLog(path)
+	if err != nil {
+		t.Fatalf("OpenFileAuditLog: %v", err)
+	}
+	pw, _ := NewWallet(testWallet(t, 1), nil, Options{AuditLog: log})
+	pw.SignHash(make([]byte, 32))
+	pw.SignMessage([]byte("hello"))
+	log.Close()
+
+	// 重新打开后继续哈希链
+	log, err = OpenFileAuditLog(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if err := log.Append(&AuditEntry{Kind: KindHash, Decision: DecisionDenied}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	log.Close()
+
+	entries, err := ReadAuditLog(path)
+	if err != nil {
+		t.Fatalf("ReadAuditLog: %v", err)
+	}
+	if len(entries) != 3 || entries[2].Seq != 2 {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if err := VerifyAuditChain(entries); err != nil {
+		t.Fatalf("VerifyAuditChain: %v", err)
+	}
+
+	// 篡改任一记录都会破坏哈希链
+	data, _ := os.ReadFile(path)
+	tampered := strings.Replace(string(data), `"decision":"denied"`, `"decision":"approved"`, 1)
+	os.WriteFile(path, []byte(tampered), 0600)
+	if _, err := OpenFileAuditLog(path); !errors.Is(err, ErrAuditChainBroken) {
+		t.Fatalf("expected ErrAuditChainBroken, got %v", err)
+	}
+
+	// 删除中间记录同样被发现
+	lines := strings.SplitAfter(string(data), "\n")
+	os.WriteFile(path, []byte(lines[0]+lines[2]), 0600)
+	if _, err := OpenFileAuditLog(path); !errors.Is(err, ErrAuditChainBroken) {
+		t.Fatalf("expected ErrAuditChainBroken after deletion, got %v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+	"github.com/weisyn/client-sdk-go/wallet/policy"
+)
+
+// Client 签名服务 HTTP 客户端
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient 创建签名服务客户端
+//
+// endpoint 为 "unix:/path/to.sock"、"http://127.0.0.1:7750" 或 "127.0.0.1:7750"；
+// httpClient 为 nil 时使用 60 秒超时的默认客户端（审批回调可能需要人工确认，超时不宜过短）。
+func NewClient(endpoint, token string, httpClient *http.Client) (*Client, error) {
+	if token == "" {
+		return nil, fmt.Errorf("auth token is required")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	c := &Client{token: token}
+	if path, ok := strings.CutPrefix(endpoint, "unix:"); ok {
+		hc := *httpClient
+		hc.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		}
+		c.baseURL, c.client = "http://wes-signer", &hc
+		return c, nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	c.baseURL, c.client = strings.TrimSuffix(endpoint, "/"), httpClient
+	return c, nil
+}
+
+// Keys 列出签名服务加载的密钥
+func (c *Client) Keys(ctx context.Context) ([]KeyInfo, error) {
+	var resp KeysResponse
+	if err := c.do(ctx, http.MethodGet, PathKeys, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// SignDraft 请求签名服务审查并签名未签名草稿
+//
+// 策略拒绝时 errors.Is(err, policy.ErrPolicyViolation) 为 true。
+func (c *Client) SignDraft(ctx context.Context, d *wallet.UnsignedDraft) ([]wallet.DraftSignature, error) {
+	var resp SignDraftResponse
+	if err := c.do(ctx, http.MethodPost, PathSignDraft, d, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Signatures, nil
+}
+
+// SignMessage 请求签名绑定链 ID 的消息（chainID 为空时不绑定），返回 65 字节可恢复签名
+func (c *Client) SignMessage(ctx context.Context, signer types.Address, chainID string, msg []byte) ([]byte, error) {
+	return c.sign(ctx, PathSignMessage, SignMessageRequest{
+		Signer:  signer.String(),
+		ChainID: chainID,
+		Message: hex.EncodeToString(msg),
+	})
+}
+
+// SignTransaction 请求审查并签名交易
+func (c *Client) SignTransaction(ctx context.Context, signer types.Address, tx []byte) ([]byte, error) {
+	return c.sign(ctx, PathSignTransaction, SignTransactionRequest{
+		Signer: signer.String(),
+		Tx:     hex.EncodeToString(tx),
+	})
+}
+
+func (c *Client) sign(ctx context.Context, path string, req interface{}) ([]byte, error) {
+	var resp SignatureResponse
+	if err := c.do(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(resp.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode signature failed: %w", err)
+	}
+	return sig, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, req, resp interface{}) error {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("marshal request failed: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("signer request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, maxRequestBody))
+	if err != nil {
+		return fmt.Errorf("read signer response failed: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var e errorBody
+		_ = json.Unmarshal(respBody, &e)
+		if e.Error == "" {
+			e.Error = http.StatusText(httpResp.StatusCode)
+		}
+		switch httpResp.StatusCode {
+		case http.StatusUnauthorized:
+			return ErrUnauthorized
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: %s", policy.ErrPolicyViolation, strings.TrimPrefix(e.Error, policy.ErrPolicyViolation.Error()+": "))
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrUnknownSigner, strings.TrimPrefix(e.Error, ErrUnknownSigner.Error()+": "))
+		}
+		return fmt.Errorf("signer returned HTTP %d: %s", httpResp.StatusCode, e.Error)
+	}
+
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return fmt.Errorf("decode signer response failed: %w", err)
+	}
+	return nil
+}
+
+// Wallet 返回签名服务中指定地址的远程钱包
+func (c *Client) Wallet(ctx context.Context, address types.Address) (*Wallet, error) {
+	keys, err := c.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if !strings.EqualFold(k.Hex, hex.EncodeToString(address[:])) {
+			continue
+		}
+		pubBytes, err := hex.DecodeString(strings.TrimPrefix(k.Pubkey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pubkey from signer: %w", err)
+		}
+		pub, err := ethcrypto.DecompressPubkey(pubBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pubkey from signer: %w", err)
+		}
+		if derived, err := wallet.AddressFromPubKey(pubBytes); err != nil || !bytes.Equal(derived, address[:]) {
+			return nil, fmt.Errorf("signer pubkey does not match address %s", address)
+		}
+		return &Wallet{
+			client:  c,
+			address: address,
+			pub:     pub,
+			pending: make(map[string][]byte),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSigner, address)
+}
+
+// Wallet 远程钱包（实现 wallet.Wallet 与 wallet.DraftReviewer）
+//
+// 业务服务经 wallet.RequireSigner 调用 ReviewDraft 时，把未签名草稿发给签名服务审查并签名，
+// 随后的 SignHash 返回签名服务给出的签名（每个哈希只能取一次）；未经草稿审查的哈希一律拒绝。
+// PrivateKey 只返回公钥（D 为 0）。
+type Wallet struct {
+	client  *Client
+	address types.Address
+	pub     *ecdsa.PublicKey
+
+	mu      sync.Mutex
+	pending map[string][]byte // 签名哈希（hex） → 签名服务返回的 r || s
+}
+
+// ReviewDraft 实现 wallet.DraftReviewer：请求签名服务签名草稿，并校验返回的签名
+func (w *Wallet) ReviewDraft(draftJSON []byte, sighash *utils.DraftSighash, inputIndices []uint32) error {
+	if sighash == nil {
+		return fmt.Errorf("sighash is nil")
+	}
+	signatures, err := w.client.SignDraft(context.Background(), wallet.NewUnsignedDraft(w.address[:], draftJSON, sighash, inputIndices))
+	if err != nil {
+		return err
+	}
+
+	got := make(map[uint32][]byte, len(signatures))
+	for _, s := range signatures {
+		sig, err := hex.DecodeString(strings.TrimPrefix(s.Signature, "0x"))
+		if err != nil {
+			return fmt.Errorf("decode signature for input %d: %w", s.InputIndex, err)
+		}
+		got[s.InputIndex] = sig
+	}
+	pub := ethcrypto.CompressPubkey(w.pub)
+	pending := make(map[string][]byte, len(inputIndices))
+	for _, idx := range inputIndices {
+		hash := sighash.Hash(idx)
+		sig, ok := got[idx]
+		if !ok {
+			return fmt.Errorf("signer returned no signature for input %d", idx)
+		}
+		if !wallet.VerifySignature(pub, hash, sig) {
+			return fmt.Errorf("signer returned an invalid signature for input %d", idx)
+		}
+		pending[hex.EncodeToString(hash)] = sig
+	}
+
+	w.mu.Lock()
+	for k, v := range pending {
+		w.pending[k] = v
+	}
+	w.mu.Unlock()
+	return nil
+}
+
+// take 取出（并作废）签名服务已返回的签名
+func (w *Wallet) take(hash []byte) ([]byte, error) {
+	key := hex.EncodeToString(hash)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sig, ok := w.pending[key]
+	if !ok {
+		return nil, policy.ErrNotReviewed
+	}
+	delete(w.pending, key)
+	return sig, nil
+}
+
+// Address 获取钱包地址
+func (w *Wallet) Address() []byte {
+	return append([]byte(nil), w.address[:]...)
+}
+
+// SignHash 返回签名服务为该哈希给出的签名（须先经 ReviewDraft）
+func (w *Wallet) SignHash(hash []byte) ([]byte, error) {
+	return w.take(hash)
+}
+
+// SignHashRecoverable 返回签名服务为该哈希给出的签名，并补上恢复位
+func (w *Wallet) SignHashRecoverable(hash []byte) ([]byte, error) {
+	sig, err := w.take(hash)
+	if err != nil {
+		return nil, err
+	}
+	want := ethcrypto.FromECDSAPub(w.pub)
+	for v := byte(0); v < 2; v++ {
+		recoverable := append(append([]byte(nil), sig...), v)
+		if pub, err := ethcrypto.Ecrecover(hash, recoverable); err == nil && bytes.Equal(pub, want) {
+			return recoverable, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot derive recovery id for signer signature")
+}
+
+// SignTransaction 请求签名服务审查并签名交易
+func (w *Wallet) SignTransaction(tx []byte) ([]byte, error) {
+	return w.client.SignTransaction(context.Background(), w.address, tx)
+}
+
+// SignMessage 请求签名服务签名消息（需签名服务策略允许消息签名）
+func (w *Wallet) SignMessage(msg []byte) ([]byte, error) {
+	return w.SignMessageForChain("", msg)
+}
+
+// SignMessageForChain 请求签名服务签名绑定链 ID 的消息
+func (w *Wallet) SignMessageForChain(chainID string, msg []byte) ([]byte, error) {
+	return w.client.SignMessage(context.Background(), w.address, chainID, msg)
+}
+
+// PrivateKey 返回只含公钥的副本（D 为 0），业务服务据此取公钥
+func (w *Wallet) PrivateKey() *ecdsa.PrivateKey {
+	return &ecdsa.PrivateKey{PublicKey: *w.pub, D: new(big.Int)}
+}
@@ -0,0 +1,122 @@
+// Package remote 提供远程签名服务（wes-signer 守护进程）的 HTTP 协议、服务端与 wallet.Wallet 客户端
+//
+// 签名服务持有密钥并对每个请求执行签名策略（wallet/policy）；业务进程只持有 remote.Wallet，
+// 各业务服务照常使用，签名步骤把未签名草稿（wallet.UnsignedDraft）发给签名服务：
+//
+//	c, _ := remote.NewClient("unix:/run/wes-signer.sock", token, nil)
+//	w, _ := c.Wallet(ctx, address)
+//	tokenService := token.NewServiceWithWallet(cli, w)
+//
+// 签名服务只监听 Unix socket 或本机回环地址，所有请求需携带 Authorization: Bearer <token>。
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/wallet"
+)
+
+// API 路径
+const (
+	PathKeys            = "/v1/keys"
+	PathSignDraft       = "/v1/sign/draft"
+	PathSignMessage     = "/v1/sign/message"
+	PathSignTransaction = "/v1/sign/transaction"
+)
+
+// maxRequestBody 请求体大小上限
+const maxRequestBody = 4 << 20
+
+var (
+	// ErrUnauthorized 令牌缺失或错误
+	ErrUnauthorized = errors.New("signer: unauthorized")
+
+	// ErrUnknownSigner 签名服务未加载该地址的密钥
+	ErrUnknownSigner = errors.New("signer: unknown signer")
+)
+
+// KeyInfo 签名服务加载的密钥
+type KeyInfo struct {
+	Address string `json:"address"` // Base58 地址
+	Hex     string `json:"hex"`     // 20 字节地址 hex
+	Pubkey  string `json:"pubkey"`  // 0x 前缀压缩公钥
+}
+
+// KeysResponse GET /v1/keys 响应
+type KeysResponse struct {
+	Keys []KeyInfo `json:"keys"`
+}
+
+// SignDraftResponse POST /v1/sign/draft 响应（请求体为 wallet.UnsignedDraft）
+type SignDraftResponse struct {
+	Signatures []wallet.DraftSignature `json:"signatures"`
+}
+
+// SignMessageRequest POST /v1/sign/message 请求
+type SignMessageRequest struct {
+	Signer  string `json:"signer"`             // 签名方地址（Base58 / hex）
+	ChainID string `json:"chain_id,omitempty"` // 为空时不绑定链 ID
+	Message string `json:"message"`            // 消息 hex
+}
+
+// SignTransactionRequest POST /v1/sign/transaction 请求
+type SignTransactionRequest struct {
+	Signer string `json:"signer"` // 签名方地址（Base58 / hex）
+	Tx     string `json:"tx"`     // 未签名交易 hex
+}
+
+// SignatureResponse 消息 / 交易签名响应
+type SignatureResponse struct {
+	Signature string `json:"signature"` // 0x 前缀签名 hex
+}
+
+// errorBody HTTP 错误响应体
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// Listen 监听签名服务地址
+//
+// "unix:/path/to.sock" 创建权限为 0600 的 Unix socket（删除残留的旧 socket 文件）；
+// 其他地址按 TCP "host:port" 处理，host 必须是回环地址（localhost / 127.0.0.1 / ::1）。
+func Listen(address string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(path)
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("listen on %s: %w", address, err)
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("chmod socket: %w", err)
+		}
+		return l, nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listen address %q: %w", address, err)
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("signer must listen on a loopback address, got %q", host)
+	}
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", address, err)
+	}
+	return l, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,194 @@
+package remote
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/internal/testnode"
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+	"github.com/weisyn/client-sdk-go/wallet/policy"
+)
+
+// draftMockClient 模拟节点：按草稿构造未签名交易并返回签名哈希，wes_getUTXO 返回 testDraft 花费的输入（金额 100）
+type draftMockClient struct{}
+
+func (m *draftMockClient) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case "wes_computeSignatureHashFromDraft":
+		return testnode.ComputeSignatureHashFromDraft(params)
+	case "wes_getUTXO":
+		return map[string]interface{}{"utxos": []interface{}{
+			map[string]interface{}{"outpoint": strings.Repeat("ab", 32) + ":0", "amount": "100"},
+		}}, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (m *draftMockClient) SendRawTransaction(ctx context.Context, signedTxHex string) (*client.SendTxResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *draftMockClient) Subscribe(ctx context.Context, filter *client.EventFilter) (<-chan *client.Event, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *draftMockClient) Close() error { return nil }
+
+func testDraft(to []byte, amount string) []byte {
+	return []byte(fmt.Sprintf(`{"inputs":[{"tx_hash":"%s","output_index":0}],"outputs":[{"type":"asset","owner":"%x","amount":"%s"}]}`,
+		strings.Repeat("ab", 32), to, amount))
+}
+
+// startSigner 在 Unix socket 上启动签名服务
+func startSigner(t *testing.T, inner wallet.Wallet, p *policy.Policy) string {
+	t.Helper()
+	pw, err := policy.NewWallet(inner, p, policy.Options{Client: &draftMockClient{}})
+	if err != nil {
+		t.Fatalf("policy.NewWallet: %v", err)
+	}
+	server, err := NewServer("secret-token", pw)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	// Unix socket 路径长度有限，不使用 t.TempDir()
+	dir, err := os.MkdirTemp("", "wes-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	endpoint := "unix:" + filepath.Join(dir, "signer.sock")
+	l, err := Listen(endpoint)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	hs := &http.Server{Handler: server.Handler()}
+	go hs.Serve(l)
+	t.Cleanup(func() { hs.Close() })
+	return endpoint
+}
+
+func TestRemoteWallet(t *testing.T) {
+	ctx := context.Background()
+	inner, _ := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + "01")
+	to, _ := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + "02")
+	endpoint := startSigner(t, inner, &policy.Policy{
+		PerTxLimit: map[string]types.Amount{policy.NativeToken: types.NewAmount(100)},
+	})
+
+	c, err := NewClient(endpoint, "secret-token", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	w, err := c.Wallet(ctx, types.MustAddressFromBytes(inner.Address()))
+	if err != nil {
+		t.Fatalf("Wallet: %v", err)
+	}
+	var _ wallet.Wallet = w
+	if w.PrivateKey().D.Sign() != 0 || !w.PrivateKey().PublicKey.Equal(&inner.PrivateKey().PublicKey) {
+		t.Fatalf("PrivateKey should carry only the public key")
+	}
+
+	// 业务服务的签名流程：计算签名哈希 → RequireSigner → SignHash
+	draftJSON := testDraft(to.Address(), "100")
+	sighash, err := utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err != nil {
+		t.Fatalf("ComputeDraftSighash: %v", err)
+	}
+	if _, err := w.SignHash(sighash.Hash(0)); !errors.Is(err, policy.ErrNotReviewed) {
+		t.Fatalf("expected ErrNotReviewed before review, got %v", err)
+	}
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{0}); err != nil {
+		t.Fatalf("RequireSigner: %v", err)
+	}
+	sig, err := w.SignHash(sighash.Hash(0))
+	if err != nil {
+		t.Fatalf("SignHash = %x, %v", sig, err)
+	}
+	want, _ := inner.SignHash(sighash.Hash(0))
+	if hex.EncodeToString(sig) != hex.EncodeToString(want) {
+		t.Fatalf("remote signature differs from local signature")
+	}
+
+	// 策略拒绝
+	draftJSON = testDraft(to.Address(), "101")
+	sighash, _ = utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	if err := wallet.RequireSigner(w, draftJSON, sighash, []uint32{0}); !errors.Is(err, policy.ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation, got %v", err)
+	}
+
+	// 消息签名默认拒绝
+	if _, err := w.SignMessage([]byte("hello")); !errors.Is(err, policy.ErrPolicyViolation) {
+		t.Fatalf("expected message signing to be denied, got %v", err)
+	}
+
+	bad, _ := NewClient(endpoint, "wrong-token", nil)
+	if _, err := bad.Keys(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if _, err := c.Wallet(ctx, types.MustAddressFromBytes(to.Address())); !errors.Is(err, ErrUnknownSigner) {
+		t.Fatalf("expected ErrUnknownSigner, got %v", err)
+	}
+}
+
+func TestServerRejectsMismatchedSighash(t *testing.T) {
+	ctx := context.Background()
+	inner, _ := wallet.NewWalletFromPrivateKey(strings.Repeat("0", 62) + "01")
+	pw, _ := policy.NewWallet(inner, nil, policy.Options{})
+	server, _ := NewServer("secret-token", pw)
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	draftJSON := testDraft(inner.Address(), "1")
+	sighash, _ := utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	d := wallet.NewUnsignedDraft(inner.Address(), draftJSON, sighash, []uint32{0})
+	d.Inputs[0].Sighash = strings.Repeat("11", 32)
+
+	c, _ := NewClient(ts.URL, "secret-token", nil)
+	if _, err := c.SignDraft(ctx, d); err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+		t.Fatalf("expected HTTP 400 for mismatched sighash, got %v", err)
+	}
+	if _, err := Listen("0.0.0.0:0"); err == nil {
+		t.Fatalf("expected error for non-loopback listen address")
+	}
+	if _, err := NewServer("", pw); err == nil {
+		t.Fatalf("expected error for empty token")
+	}
+}
+
+func TestServerWithLockedWallet(t *testing.T) {
+	ctx := context.Background()
+	inner, _ := wallet.NewEphemeralWallet(0)
+	pw, _ := policy.NewWallet(inner, nil, policy.Options{})
+	server, err := NewServer("secret-token", pw)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	pubkey := server.Keys()[0].Pubkey
+
+	inner.Lock()
+	if keys := server.Keys(); len(keys) != 1 || keys[0].Pubkey != pubkey {
+		t.Fatalf("Keys() after lock = %+v, want pubkey %s", keys, pubkey)
+	}
+
+	draftJSON := testDraft(inner.Address(), "1")
+	sighash, _ := utils.ComputeDraftSighash(ctx, &draftMockClient{}, draftJSON, []uint32{0}, txcodec.SighashAll)
+	d := wallet.NewUnsignedDraft(inner.Address(), draftJSON, sighash, []uint32{0})
+	if _, err := server.SignDraft(d); !errors.Is(err, wallet.ErrWalletLocked) {
+		t.Fatalf("SignDraft() after lock error = %v, want ErrWalletLocked", err)
+	}
+	if _, err := NewServer("secret-token", pw); !errors.Is(err, wallet.ErrWalletLocked) {
+		t.Fatalf("NewServer() with locked wallet error = %v, want ErrWalletLocked", err)
+	}
+}
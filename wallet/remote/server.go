@@ -0,0 +1,276 @@
+package remote
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/weisyn/client-sdk-go/txcodec"
+	"github.com/weisyn/client-sdk-go/types"
+	"github.com/weisyn/client-sdk-go/utils"
+	"github.com/weisyn/client-sdk-go/wallet"
+	"github.com/weisyn/client-sdk-go/wallet/policy"
+)
+
+// Server 签名服务：按地址持有策略钱包，处理签名请求
+type Server struct {
+	token   string
+	signers map[types.Address]*policy.Wallet
+	pubkeys map[types.Address]string // 压缩公钥（0x 前缀），创建时取出，内层钱包之后锁定也可用
+}
+
+// NewServer 创建签名服务（token 不能为空；每个密钥都以策略钱包包装）
+//
+// 创建时各钱包必须处于解锁状态（以读取公钥），否则返回 wallet.ErrWalletLocked。
+func NewServer(token string, signers ...*policy.Wallet) (*Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("auth token is required")
+	}
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("at least one signer is required")
+	}
+	s := &Server{
+		token:   token,
+		signers: make(map[types.Address]*policy.Wallet, len(signers)),
+		pubkeys: make(map[types.Address]string, len(signers)),
+	}
+	for _, pw := range signers {
+		address, err := types.NewAddressFromBytes(pw.Address())
+		if err != nil {
+			return nil, err
+		}
+		priv := pw.PrivateKey()
+		if priv == nil {
+			return nil, fmt.Errorf("signer %s: %w", address, wallet.ErrWalletLocked)
+		}
+		s.signers[address] = pw
+		s.pubkeys[address] = "0x" + hex.EncodeToString(ethcrypto.CompressPubkey(&priv.PublicKey))
+	}
+	return s, nil
+}
+
+// Keys 返回已加载的密钥（按地址排序）
+func (s *Server) Keys() []KeyInfo {
+	keys := make([]KeyInfo, 0, len(s.signers))
+	for address := range s.signers {
+		keys = append(keys, KeyInfo{
+			Address: address.String(),
+			Hex:     hex.EncodeToString(address[:]),
+			Pubkey:  s.pubkeys[address],
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Hex < keys[j].Hex })
+	return keys
+}
+
+// SignDraft 审查并签名未签名草稿
+//
+// 签名哈希由未签名交易在本地重新计算，并核对未签名交易与草稿一致；请求中的 sighash 仅用于比对，
+// 不一致时返回 utils.ErrSighashMismatch。草稿经策略钱包审查（wallet.RequireSigner）后逐个输入签名。
+func (s *Server) SignDraft(d *wallet.UnsignedDraft) ([]wallet.DraftSignature, error) {
+	pw, err := s.signer(d.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Inputs) == 0 {
+		return nil, fmt.Errorf("%w: no inputs to sign", errBadRequest)
+	}
+	sighashType, err := txcodec.ParseSighashType(d.Inputs[0].SighashType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	indices := make([]uint32, len(d.Inputs))
+	for i, in := range d.Inputs {
+		if in.SighashType != d.Inputs[0].SighashType {
+			return nil, fmt.Errorf("%w: mixed sighash types in one draft are not supported", errBadRequest)
+		}
+		indices[i] = in.InputIndex
+	}
+
+	sighash, err := utils.DraftSighashFromUnsignedTx(d.Draft, d.UnsignedTx, indices, sighashType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBadRequest, err)
+	}
+	for _, in := range d.Inputs {
+		if in.Sighash == "" {
+			continue
+		}
+		claimed, err := hex.DecodeString(strings.TrimPrefix(in.Sighash, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: input %d: invalid sighash: %v", errBadRequest, in.InputIndex, err)
+		}
+		if subtle.ConstantTimeCompare(claimed, sighash.Hash(in.InputIndex)) != 1 {
+			return nil, &utils.SighashMismatchError{InputIndex: in.InputIndex, Reason: "requested sighash does not match unsignedTx"}
+		}
+	}
+
+	if err := wallet.RequireSigner(pw, d.Draft, sighash, indices); err != nil {
+		return nil, err
+	}
+	pubkey := s.pubkeys[types.MustAddressFromBytes(pw.Address())]
+	signatures := make([]wallet.DraftSignature, 0, len(indices))
+	for _, idx := range indices {
+		sig, err := pw.SignHash(sighash.Hash(idx))
+		if err != nil {
+			return nil, fmt.Errorf("sign input %d: %w", idx, err)
+		}
+		signatures = append(signatures, wallet.DraftSignature{
+			InputIndex:  idx,
+			SighashType: sighashType.String(),
+			Pubkey:      pubkey,
+			Signature:   "0x" + hex.EncodeToString(sig),
+		})
+	}
+	return signatures, nil
+}
+
+// SignMessage 签名消息（需策略允许消息签名）
+func (s *Server) SignMessage(req *SignMessageRequest) ([]byte, error) {
+	pw, err := s.signer(req.Signer)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := hex.DecodeString(strings.TrimPrefix(req.Message, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid message hex: %v", errBadRequest, err)
+	}
+	return pw.SignMessageForChain(req.ChainID, msg)
+}
+
+// SignTransaction 审查并签名交易
+func (s *Server) SignTransaction(req *SignTransactionRequest) ([]byte, error) {
+	pw, err := s.signer(req.Signer)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := hex.DecodeString(strings.TrimPrefix(req.Tx, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid tx hex: %v", errBadRequest, err)
+	}
+	return pw.SignTransaction(tx)
+}
+
+func (s *Server) signer(address string) (*policy.Wallet, error) {
+	a, err := types.ParseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signer: %v", errBadRequest, err)
+	}
+	pw, ok := s.signers[a]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigner, a)
+	}
+	return pw, nil
+}
+
+// Handler 返回签名服务 HTTP 处理器
+//
+// 令牌错误返回 401，策略拒绝返回 403，未知签名方返回 404，请求格式错误或签名哈希不一致返回 400，
+// 其他错误返回 500。错误响应体为 {"error": "..."}。
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathKeys, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, errorBody{Error: "method not allowed"})
+			return
+		}
+		writeJSON(w, http.StatusOK, KeysResponse{Keys: s.Keys()})
+	})
+	mux.HandleFunc(PathSignDraft, post(func(r *http.Request) (interface{}, error) {
+		var d wallet.UnsignedDraft
+		if err := decodeRequest(r, &d); err != nil {
+			return nil, err
+		}
+		signatures, err := s.SignDraft(&d)
+		if err != nil {
+			return nil, err
+		}
+		return SignDraftResponse{Signatures: signatures}, nil
+	}))
+	mux.HandleFunc(PathSignMessage, post(func(r *http.Request) (interface{}, error) {
+		var req SignMessageRequest
+		if err := decodeRequest(r, &req); err != nil {
+			return nil, err
+		}
+		sig, err := s.SignMessage(&req)
+		if err != nil {
+			return nil, err
+		}
+		return SignatureResponse{Signature: "0x" + hex.EncodeToString(sig)}, nil
+	}))
+	mux.HandleFunc(PathSignTransaction, post(func(r *http.Request) (interface{}, error) {
+		var req SignTransactionRequest
+		if err := decodeRequest(r, &req); err != nil {
+			return nil, err
+		}
+		sig, err := s.SignTransaction(&req)
+		if err != nil {
+			return nil, err
+		}
+		return SignatureResponse{Signature: "0x" + hex.EncodeToString(sig)}, nil
+	}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			writeJSON(w, http.StatusUnauthorized, errorBody{Error: ErrUnauthorized.Error()})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// errBadRequest 请求格式错误
+var errBadRequest = errors.New("invalid request")
+
+func decodeRequest(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBody)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+func post(handle func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, errorBody{Error: "method not allowed"})
+			return
+		}
+		resp, err := handle(r)
+		if err != nil {
+			writeJSON(w, statusOf(err), errorBody{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, policy.ErrPolicyViolation), errors.Is(err, policy.ErrNotReviewed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrUnknownSigner):
+		return http.StatusNotFound
+	case errors.Is(err, errBadRequest), errors.Is(err, utils.ErrSighashMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
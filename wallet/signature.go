@@ -0,0 +1,160 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+const (
+	// SignatureLength 紧凑签名长度：r || s
+	SignatureLength = 64
+
+	// RecoverableSignatureLength 可恢复签名长度：r || s || v（v ∈ {0, 1}）
+	RecoverableSignatureLength = 65
+)
+
+var (
+	secp256k1N     = ethcrypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// signHashRecoverable 使用 RFC 6979 确定性 nonce 签名哈希
+// 返回 65 字节 r || s || v，s 已规范化为 low-S
+func signHashRecoverable(privateKey *ecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("invalid hash length: expected 32 bytes, got %d", len(hash))
+	}
+	sig, err := ethcrypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1 sign: %w", err)
+	}
+	return sig, nil
+}
+
+// VerifySignature 验证 secp256k1 签名
+//
+// pubkey 支持 33 字节压缩或 65 字节未压缩格式；sig 支持 64 字节 r || s
+// 或 65 字节 r || s || v（恢复位会被忽略）。high-S 签名视为非规范签名，验证失败。
+func VerifySignature(pubkey, hash, sig []byte) bool {
+	if len(hash) != 32 {
+		return false
+	}
+	switch len(sig) {
+	case SignatureLength:
+	case RecoverableSignatureLength:
+		sig = sig[:SignatureLength]
+	default:
+		return false
+	}
+	return ethcrypto.VerifySignature(pubkey, hash, sig)
+}
+
+// RecoverPubKey 从 65 字节可恢复签名中恢复公钥（33 字节压缩格式）
+func RecoverPubKey(hash, sig []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("invalid hash length: expected 32 bytes, got %d", len(hash))
+	}
+	if len(sig) != RecoverableSignatureLength {
+		return nil, fmt.Errorf("invalid signature length: expected %d bytes, got %d", RecoverableSignatureLength, len(sig))
+	}
+	if !IsLowS(sig) {
+		return nil, fmt.Errorf("non-canonical signature: s is not in lower half of curve order")
+	}
+
+	// 兼容以太坊风格的 v = 27/28
+	normalized := make([]byte, RecoverableSignatureLength)
+	copy(normalized, sig)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+
+	pub, err := ethcrypto.SigToPub(hash, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("recover public key: %w", err)
+	}
+	return ethcrypto.CompressPubkey(pub), nil
+}
+
+// RecoverAddress 从 65 字节可恢复签名中恢复签名者地址（20 字节）
+func RecoverAddress(hash, sig []byte) ([]byte, error) {
+	pubkey, err := RecoverPubKey(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	return AddressFromPubKey(pubkey)
+}
+
+// AddressFromPubKey 从公钥计算地址：HASH160(compressed_pubkey)
+// pubkey 支持 33 字节压缩或 65 字节未压缩格式
+func AddressFromPubKey(pubkey []byte) ([]byte, error) {
+	compressed, err := compressPubKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return hash160(compressed), nil
+}
+
+// compressPubKey 解析公钥并返回 33 字节压缩格式
+func compressPubKey(pubkey []byte) ([]byte, error) {
+	var pub *ecdsa.PublicKey
+	var err error
+	switch len(pubkey) {
+	case 33:
+		pub, err = ethcrypto.DecompressPubkey(pubkey)
+	case 65:
+		pub, err = ethcrypto.UnmarshalPubkey(pubkey)
+	default:
+		return nil, fmt.Errorf("invalid public key length: %d", len(pubkey))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	return ethcrypto.CompressPubkey(pub), nil
+}
+
+// IsLowS 判断签名的 s 值是否位于曲线阶的下半部分
+func IsLowS(sig []byte) bool {
+	if len(sig) < SignatureLength {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	return s.Sign() > 0 && s.Cmp(secp256k1HalfN) <= 0
+}
+
+// NormalizeLowS 将签名规范化为 low-S 形式（s' = N - s）
+// 对 65 字节签名会同时翻转恢复位；返回新的切片，不修改入参
+func NormalizeLowS(sig []byte) ([]byte, error) {
+	if len(sig) != SignatureLength && len(sig) != RecoverableSignatureLength {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	out := make([]byte, len(sig))
+	copy(out, sig)
+
+	s := new(big.Int).SetBytes(sig[32:64])
+	if s.Sign() == 0 || s.Cmp(secp256k1N) >= 0 {
+		return nil, fmt.Errorf("invalid signature: s out of range")
+	}
+	if s.Cmp(secp256k1HalfN) <= 0 {
+		return out, nil
+	}
+
+	s.Sub(secp256k1N, s)
+	s.FillBytes(out[32:64])
+	if len(out) == RecoverableSignatureLength {
+		out[64] ^= 1
+	}
+	return out, nil
+}
+
+// hash160 计算 RIPEMD160(SHA256(data))
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	r := ripemd160.New()
+	_, _ = r.Write(sha[:])
+	return r.Sum(nil)
+}
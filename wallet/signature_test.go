@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// RFC 6979 secp256k1 测试向量：私钥 = 1，消息 = "Satoshi Nakamoto"
+const (
+	vectorPrivateKey = "0000000000000000000000000000000000000000000000000000000000000001"
+	vectorSignature  = "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5" + "01"
+	vectorAddress = "751e76e8199196d454941c45d1b3a323f1433bd6"
+)
+
+func vectorWallet(t *testing.T) (Wallet, []byte) {
+	t.Helper()
+	w, err := NewWalletFromPrivateKey(vectorPrivateKey)
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey() error = %v", err)
+	}
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	return w, hash[:]
+}
+
+func TestSignHashDeterministic(t *testing.T) {
+	w, hash := vectorWallet(t)
+
+	sig, err := w.SignHashRecoverable(hash)
+	if err != nil {
+		t.Fatalf("SignHashRecoverable() error = %v", err)
+	}
+	if got := hex.EncodeToString(sig); got != vectorSignature {
+		t.Errorf("SignHashRecoverable() = %s, want %s", got, vectorSignature)
+	}
+
+	compact, err := w.SignHash(hash)
+	if err != nil {
+		t.Fatalf("SignHash() error = %v", err)
+	}
+	if !bytes.Equal(compact, sig[:SignatureLength]) {
+		t.Errorf("SignHash() should equal first 64 bytes of recoverable signature")
+	}
+	if !IsLowS(compact) {
+		t.Errorf("SignHash() produced high-S signature")
+	}
+
+	if _, err := w.SignHash(hash[:31]); err == nil {
+		t.Errorf("SignHash() error = nil for short hash")
+	}
+}
+
+func TestVerifyAndRecover(t *testing.T) {
+	w, hash := vectorWallet(t)
+	sig, _ := w.SignHashRecoverable(hash)
+	pubkey := ethcrypto.CompressPubkey(&w.PrivateKey().PublicKey)
+
+	if !VerifySignature(pubkey, hash, sig) {
+		t.Errorf("VerifySignature() = false for 65-byte signature")
+	}
+	if !VerifySignature(pubkey, hash, sig[:SignatureLength]) {
+		t.Errorf("VerifySignature() = false for 64-byte signature")
+	}
+	if !VerifySignature(ethcrypto.FromECDSAPub(&w.PrivateKey().PublicKey), hash, sig) {
+		t.Errorf("VerifySignature() = false for uncompressed public key")
+	}
+
+	tampered := append([]byte(nil), hash...)
+	tampered[0] ^= 1
+	if VerifySignature(pubkey, tampered, sig) {
+		t.Errorf("VerifySignature() = true for wrong hash")
+	}
+
+	recovered, err := RecoverPubKey(hash, sig)
+	if err != nil {
+		t.Fatalf("RecoverPubKey() error = %v", err)
+	}
+	if !bytes.Equal(recovered, pubkey) {
+		t.Errorf("RecoverPubKey() = %x, want %x", recovered, pubkey)
+	}
+
+	addr, err := RecoverAddress(hash, sig)
+	if err != nil {
+		t.Fatalf("RecoverAddress() error = %v", err)
+	}
+	if got := hex.EncodeToString(addr); got != vectorAddress {
+		t.Errorf("RecoverAddress() = %s, want %s", got, vectorAddress)
+	}
+	if !bytes.Equal(addr, w.Address()) {
+		t.Errorf("RecoverAddress() does not match wallet address")
+	}
+
+	// 以太坊风格 v = 27/28 同样可恢复
+	legacy := append([]byte(nil), sig...)
+	legacy[64] += 27
+	if addr2, err := RecoverAddress(hash, legacy); err != nil || !bytes.Equal(addr2, addr) {
+		t.Errorf("RecoverAddress() with v+27 = %x, %v", addr2, err)
+	}
+}
+
+func TestLowSEnforcement(t *testing.T) {
+	w, hash := vectorWallet(t)
+	sig, _ := w.SignHashRecoverable(hash)
+	pubkey := ethcrypto.CompressPubkey(&w.PrivateKey().PublicKey)
+
+	// 构造 high-S 形式：s' = N - s，v 翻转
+	highS := append([]byte(nil), sig...)
+	s := new(big.Int).SetBytes(sig[32:64])
+	new(big.Int).Sub(secp256k1N, s).FillBytes(highS[32:64])
+	highS[64] ^= 1
+
+	if IsLowS(highS) {
+		t.Errorf("IsLowS() = true for high-S signature")
+	}
+	if VerifySignature(pubkey, hash, highS) {
+		t.Errorf("VerifySignature() = true for high-S signature")
+	}
+	if _, err := RecoverPubKey(hash, highS); err == nil {
+		t.Errorf("RecoverPubKey() error = nil for high-S signature")
+	}
+
+	normalized, err := NormalizeLowS(highS)
+	if err != nil {
+		t.Fatalf("NormalizeLowS() error = %v", err)
+	}
+	if !bytes.Equal(normalized, sig) {
+		t.Errorf("NormalizeLowS() = %x, want %x", normalized, sig)
+	}
+}
+
+func TestAddressFromPubKey(t *testing.T) {
+	w, _ := vectorWallet(t)
+	pub := &w.PrivateKey().PublicKey
+
+	for name, pubkey := range map[string][]byte{
+		"compressed":   ethcrypto.CompressPubkey(pub),
+		"uncompressed": ethcrypto.FromECDSAPub(pub),
+	} {
+		addr, err := AddressFromPubKey(pubkey)
+		if err != nil {
+			t.Fatalf("%s: AddressFromPubKey() error = %v", name, err)
+		}
+		if !bytes.Equal(addr, w.Address()) {
+			t.Errorf("%s: AddressFromPubKey() = %x, want %x", name, addr, w.Address())
+		}
+	}
+
+	if _, err := AddressFromPubKey([]byte{0x02, 0x01}); err == nil {
+		t.Errorf("AddressFromPubKey() error = nil for invalid public key")
+	}
+}
@@ -0,0 +1,456 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// DomainTypeName 结构化数据签名的域类型名称
+const DomainTypeName = "WESDomain"
+
+// TypedDataField 结构化类型中的字段定义
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataDomain 签名域
+//
+// 仅非空字段参与域分隔哈希；ChainID 为节点 wes_chainId 返回的链 ID。
+type TypedDataDomain struct {
+	Name              string `json:"name,omitempty"`
+	Version           string `json:"version,omitempty"`
+	ChainID           string `json:"chainId,omitempty"`
+	VerifyingContract string `json:"verifyingContract,omitempty"` // Base58 或 hex 地址
+	Salt              string `json:"salt,omitempty"`              // 32 字节 hex
+}
+
+// TypedData 结构化数据（EIP-712 风格）
+//
+// **JSON 示例**：
+//
+//	{
+//	  "types": {
+//	    "Order": [{"name": "maker", "type": "address"}, {"name": "amount", "type": "uint256"}]
+//	  },
+//	  "primaryType": "Order",
+//	  "domain": {"name": "DEX", "version": "1", "chainId": "0x1"},
+//	  "message": {"maker": "Cf1...", "amount": "1000"}
+//	}
+//
+// **支持的类型**：address、bool、string、bytes、bytes1..bytes32、
+// uint8..uint256、int8..int256、自定义结构体，以及以上类型的数组（T[]）。
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// ParseTypedData 从 JSON 解析结构化数据
+func ParseTypedData(data []byte) (*TypedData, error) {
+	var td TypedData
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&td); err != nil {
+		return nil, fmt.Errorf("parse typed data: %w", err)
+	}
+	return &td, nil
+}
+
+// Hash 计算结构化数据的签名哈希
+//
+//	SHA-256( 0x19 || 0x01 || domainSeparator || hashStruct(primaryType, message) )
+func (td *TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	if td.PrimaryType == "" {
+		return nil, fmt.Errorf("primaryType is required")
+	}
+	if td.PrimaryType == DomainTypeName {
+		return nil, fmt.Errorf("primaryType cannot be %s", DomainTypeName)
+	}
+	messageHash, err := td.hashStruct(td.PrimaryType, td.Message, 0)
+	if err != nil {
+		return nil, fmt.Errorf("hash message: %w", err)
+	}
+
+	preimage := make([]byte, 0, 2+64)
+	preimage = append(preimage, 0x19, 0x01)
+	preimage = append(preimage, domainSeparator...)
+	preimage = append(preimage, messageHash...)
+	hash := sha256.Sum256(preimage)
+	return hash[:], nil
+}
+
+// DomainSeparator 计算域分隔哈希
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	fields, values := td.Domain.fields()
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("domain must have at least one field")
+	}
+
+	// 域类型由非空字段推导，不读取 Types 中的同名定义
+	domainTypes := &TypedData{Types: map[string][]TypedDataField{DomainTypeName: fields}}
+	hash, err := domainTypes.hashStruct(DomainTypeName, values, 0)
+	if err != nil {
+		return nil, fmt.Errorf("hash domain: %w", err)
+	}
+	return hash, nil
+}
+
+func (d TypedDataDomain) fields() ([]TypedDataField, map[string]interface{}) {
+	var fields []TypedDataField
+	values := make(map[string]interface{})
+	add := func(name, typ, value string) {
+		if value == "" {
+			return
+		}
+		fields = append(fields, TypedDataField{Name: name, Type: typ})
+		values[name] = value
+	}
+	add("name", "string", d.Name)
+	add("version", "string", d.Version)
+	add("chainId", "string", d.ChainID)
+	add("verifyingContract", "address", d.VerifyingContract)
+	add("salt", "bytes32", d.Salt)
+	return fields, values
+}
+
+// maxTypedDataDepth 防止自引用类型导致无限递归
+const maxTypedDataDepth = 32
+
+// hashStruct 计算 SHA-256(typeHash || encodeData(data))
+func (td *TypedData) hashStruct(typeName string, data map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxTypedDataDepth {
+		return nil, fmt.Errorf("typed data nested too deeply")
+	}
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %s", typeName)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("missing data for type %s", typeName)
+	}
+
+	encType, err := td.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	typeHash := sha256.Sum256([]byte(encType))
+
+	buf := make([]byte, 0, 32*(len(fields)+1))
+	buf = append(buf, typeHash[:]...)
+	for _, f := range fields {
+		value, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s: missing value", typeName, f.Name)
+		}
+		enc, err := td.encodeValue(f.Type, value, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, f.Name, err)
+		}
+		buf = append(buf, enc...)
+	}
+	if len(data) != len(fields) {
+		return nil, fmt.Errorf("%s: unexpected extra fields in data", typeName)
+	}
+
+	hash := sha256.Sum256(buf)
+	return hash[:], nil
+}
+
+// EncodeType 返回类型编码字符串，如 "Order(address maker,Asset asset)Asset(bytes32 id)"
+// 主类型在前，其引用的类型按名称排序追加
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	deps := make(map[string]bool)
+	if err := td.collectDeps(typeName, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typeName)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range append([]string{typeName}, names...) {
+		sb.WriteString(name)
+		sb.WriteByte('(')
+		for i, f := range td.Types[name] {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(f.Type)
+			sb.WriteByte(' ')
+			sb.WriteString(f.Name)
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
+
+func (td *TypedData) collectDeps(typeName string, deps map[string]bool) error {
+	if deps[typeName] {
+		return nil
+	}
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return fmt.Errorf("unknown type: %s", typeName)
+	}
+	deps[typeName] = true
+	for _, f := range fields {
+		base := strings.TrimSuffix(f.Type, "[]")
+		if _, isStruct := td.Types[base]; isStruct {
+			if err := td.collectDeps(base, deps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	intTypePattern   = regexp.MustCompile(`^(u?)int(\d*)$`)
+	bytesTypePattern = regexp.MustCompile(`^bytes(\d+)$`)
+)
+
+// encodeValue 将单个字段值编码为 32 字节
+func (td *TypedData) encodeValue(typ string, value interface{}, depth int) ([]byte, error) {
+	// 数组：SHA-256(各元素编码拼接)
+	if strings.HasSuffix(typ, "[]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array for %s", typ)
+		}
+		elemType := strings.TrimSuffix(typ, "[]")
+		buf := make([]byte, 0, 32*len(items))
+		for i, item := range items {
+			enc, err := td.encodeValue(elemType, item, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			buf = append(buf, enc...)
+		}
+		hash := sha256.Sum256(buf)
+		return hash[:], nil
+	}
+
+	// 结构体：hashStruct
+	if _, isStruct := td.Types[typ]; isStruct {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s", typ)
+		}
+		return td.hashStruct(typ, m, depth+1)
+	}
+
+	switch typ {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string")
+		}
+		hash := sha256.Sum256([]byte(s))
+		return hash[:], nil
+
+	case "bytes":
+		b, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(b)
+		return hash[:], nil
+
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool")
+		}
+		out := make([]byte, 32)
+		if b {
+			out[31] = 1
+		}
+		return out, nil
+
+	case "address":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address string")
+		}
+		addr, err := parseTypedDataAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 32)
+		copy(out[12:], addr)
+		return out, nil
+	}
+
+	if m := bytesTypePattern.FindStringSubmatch(typ); m != nil {
+		size, _ := strconv.Atoi(m[1])
+		if size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type: %s", typ)
+		}
+		b, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+		}
+		out := make([]byte, 32)
+		copy(out, b)
+		return out, nil
+	}
+
+	if m := intTypePattern.FindStringSubmatch(typ); m != nil {
+		bits := 256
+		if m[2] != "" {
+			bits, _ = strconv.Atoi(m[2])
+		}
+		if bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("invalid type: %s", typ)
+		}
+		return encodeTypedDataInt(value, bits, m[1] == "")
+	}
+
+	return nil, fmt.Errorf("unsupported type: %s", typ)
+}
+
+// encodeTypedDataInt 将整数编码为 32 字节大端（有符号数使用二进制补码）
+func encodeTypedDataInt(value interface{}, bits int, signed bool) ([]byte, error) {
+	var n *big.Int
+	switch v := value.(type) {
+	case string:
+		var ok bool
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			n, ok = new(big.Int).SetString(v[2:], 16)
+		} else {
+			n, ok = new(big.Int).SetString(v, 10)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %q", v)
+		}
+	case json.Number:
+		var ok bool
+		n, ok = new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %s", v)
+		}
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer: %v", v)
+		}
+		n = big.NewInt(int64(v))
+	case int:
+		n = big.NewInt(int64(v))
+	case int64:
+		n = big.NewInt(v)
+	case uint64:
+		n = new(big.Int).SetUint64(v)
+	case *big.Int:
+		n = new(big.Int).Set(v)
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", value)
+	}
+
+	var min, max *big.Int
+	if signed {
+		max = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		min = new(big.Int).Neg(max)
+		max.Sub(max, big.NewInt(1))
+	} else {
+		min = big.NewInt(0)
+		max = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		max.Sub(max, big.NewInt(1))
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		return nil, fmt.Errorf("integer %s out of range for %d bits", n, bits)
+	}
+
+	if n.Sign() < 0 {
+		// 256 位二进制补码
+		n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	out := make([]byte, 32)
+	n.FillBytes(out)
+	return out, nil
+}
+
+// typedDataBytes 解析 hex 字符串（可带 0x 前缀）
+func typedDataBytes(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected hex string")
+	}
+	b, err := hex.DecodeString(hexRemovePrefix(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return b, nil
+}
+
+// parseTypedDataAddress 解析 Base58 或 40 位 hex 地址
+func parseTypedDataAddress(s string) ([]byte, error) {
+	if h := hexRemovePrefix(s); len(h) == 40 {
+		if b, err := hex.DecodeString(h); err == nil {
+			return b, nil
+		}
+	}
+	addr, err := utils.AddressBase58ToBytes(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+	return addr, nil
+}
+
+// SignTypedData 使用钱包对结构化数据签名，返回 65 字节可恢复签名
+func SignTypedData(w Wallet, td *TypedData) ([]byte, error) {
+	if w == nil {
+		return nil, fmt.Errorf("wallet is nil")
+	}
+	if td == nil {
+		return nil, fmt.Errorf("typed data is nil")
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return w.SignHashRecoverable(hash)
+}
+
+// RecoverTypedDataSigner 从结构化数据签名中恢复签名者地址（20 字节）
+func RecoverTypedDataSigner(td *TypedData, sig []byte) ([]byte, error) {
+	if td == nil {
+		return nil, fmt.Errorf("typed data is nil")
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return RecoverAddress(hash, sig)
+}
+
+// VerifyTypedData 验证结构化数据签名是否由指定地址签出
+func VerifyTypedData(address []byte, td *TypedData, sig []byte) (bool, error) {
+	signer, err := RecoverTypedDataSigner(td, sig)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(signer, address), nil
+}
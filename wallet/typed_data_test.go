@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const orderTypedDataJSON = `{
+  "types": {
+    "Order": [
+      {"name": "maker", "type": "address"},
+      {"name": "sell", "type": "Asset"},
+      {"name": "buy", "type": "Asset"},
+      {"name": "expiry", "type": "uint64"},
+      {"name": "tags", "type": "string[]"}
+    ],
+    "Asset": [
+      {"name": "token", "type": "bytes32"},
+      {"name": "amount", "type": "uint256"}
+    ]
+  },
+  "primaryType": "Order",
+  "domain": {"name": "WES DEX", "version": "1", "chainId": "0x1"},
+  "message": {
+    "maker": "751e76e8199196d454941c45d1b3a323f1433bd6",
+    "sell": {"token": "0x0000000000000000000000000000000000000000000000000000000000000000", "amount": "1000"},
+    "buy": {"token": "0x1111111111111111111111111111111111111111111111111111111111111111", "amount": 25},
+    "expiry": 1700000000,
+    "tags": ["limit", "gtc"]
+  }
+}`
+
+func TestTypedDataEncodeType(t *testing.T) {
+	td, err := ParseTypedData([]byte(orderTypedDataJSON))
+	if err != nil {
+		t.Fatalf("ParseTypedData() error = %v", err)
+	}
+	got, err := td.EncodeType("Order")
+	if err != nil {
+		t.Fatalf("EncodeType() error = %v", err)
+	}
+	want := "Order(address maker,Asset sell,Asset buy,uint64 expiry,string[] tags)Asset(bytes32 token,uint256 amount)"
+	if got != want {
+		t.Errorf("EncodeType() = %s, want %s", got, want)
+	}
+}
+
+func TestSignAndVerifyTypedData(t *testing.T) {
+	w, _ := vectorWallet(t)
+	td, err := ParseTypedData([]byte(orderTypedDataJSON))
+	if err != nil {
+		t.Fatalf("ParseTypedData() error = %v", err)
+	}
+
+	sig, err := SignTypedData(w, td)
+	if err != nil {
+		t.Fatalf("SignTypedData() error = %v", err)
+	}
+	ok, err := VerifyTypedData(w.Address(), td, sig)
+	if err != nil || !ok {
+		t.Fatalf("VerifyTypedData() = %v, %v", ok, err)
+	}
+
+	// 修改消息内容后验证失败
+	tampered, _ := ParseTypedData([]byte(strings.Replace(orderTypedDataJSON, `"amount": "1000"`, `"amount": "1001"`, 1)))
+	if ok, _ := VerifyTypedData(w.Address(), tampered, sig); ok {
+		t.Errorf("VerifyTypedData() = true for tampered message")
+	}
+
+	// 修改链 ID 后验证失败
+	otherChain, _ := ParseTypedData([]byte(orderTypedDataJSON))
+	otherChain.Domain.ChainID = "0x2"
+	if ok, _ := VerifyTypedData(w.Address(), otherChain, sig); ok {
+		t.Errorf("VerifyTypedData() = true for different chain")
+	}
+
+	// 结构化签名不能当作普通消息签名
+	if VerifyMessage(w.Address(), "0x1", []byte(orderTypedDataJSON), sig) {
+		t.Errorf("typed data signature verified as personal message")
+	}
+}
+
+func TestTypedDataHashErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{name: "missing field", replace: [2]string{`"expiry": 1700000000,`, ``}},
+		{name: "uint overflow", replace: [2]string{`"expiry": 1700000000`, `"expiry": "18446744073709551616"`}},
+		{name: "bad bytes32", replace: [2]string{`"token": "0x0000000000000000000000000000000000000000000000000000000000000000"`, `"token": "0x00"`}},
+		{name: "bad address", replace: [2]string{`"751e76e8199196d454941c45d1b3a323f1433bd6"`, `"not-an-address"`}},
+		{name: "unknown type", replace: [2]string{`"type": "uint64"`, `"type": "float"`}},
+		{name: "missing domain", replace: [2]string{`{"name": "WES DEX", "version": "1", "chainId": "0x1"}`, `{}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td, err := ParseTypedData([]byte(strings.Replace(orderTypedDataJSON, tt.replace[0], tt.replace[1], 1)))
+			if err != nil {
+				t.Fatalf("ParseTypedData() error = %v", err)
+			}
+			if _, err := td.Hash(); err == nil {
+				t.Errorf("Hash() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestEncodeTypedDataSignedInt(t *testing.T) {
+	got, err := encodeTypedDataInt("-1", 8, true)
+	if err != nil {
+		t.Fatalf("encodeTypedDataInt() error = %v", err)
+	}
+	if hex.EncodeToString(got) != strings.Repeat("ff", 32) {
+		t.Errorf("encodeTypedDataInt(-1) = %x", got)
+	}
+	if _, err := encodeTypedDataInt("-129", 8, true); err == nil {
+		t.Errorf("encodeTypedDataInt(-129, int8) error = nil")
+	}
+	if _, err := encodeTypedDataInt("-1", 8, false); err == nil {
+		t.Errorf("encodeTypedDataInt(-1, uint8) error = nil")
+	}
+}
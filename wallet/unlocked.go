@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrWalletLocked 钱包已锁定（解锁时长已到或已手动锁定）
+var ErrWalletLocked = errors.New("wallet is locked")
+
+// UnlockedWallet 限时解锁的钱包（由 KeystoreManager.Unlock 创建）
+//
+// 到期或调用 Lock 后私钥被清零，此后签名方法返回 ErrWalletLocked，PrivateKey 返回 nil；
+// Address 始终可用。
+type UnlockedWallet struct {
+	address []byte
+
+	mu     sync.RWMutex
+	inner  *SimpleWallet
+	timer  *time.Timer
+	expiry time.Time
+}
+
+func newUnlockedWallet(inner *SimpleWallet, ttl time.Duration) *UnlockedWallet {
+	w := &UnlockedWallet{address: inner.Address(), inner: inner}
+	if ttl > 0 {
+		w.expiry = time.Now().Add(ttl)
+		// 定时器可能在赋值前触发，赋值需与 Lock 互斥
+		w.mu.Lock()
+		w.timer = time.AfterFunc(ttl, w.Lock)
+		w.mu.Unlock()
+	}
+	return w
+}
+
+// NewEphemeralWallet 生成随机私钥的临时钱包，ttl 后自动锁定（ttl <= 0 表示不自动锁定）
+//
+// 私钥只存在于内存中，适合会话密钥等短期用途。
+func NewEphemeralWallet(ttl time.Duration) (*UnlockedWallet, error) {
+	w, err := NewWallet()
+	if err != nil {
+		return nil, err
+	}
+	return newUnlockedWallet(w.(*SimpleWallet), ttl), nil
+}
+
+// Lock 立即锁定并清零私钥（可重复调用）
+func (w *UnlockedWallet) Lock() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if w.inner != nil {
+		w.inner.privateKey.D.SetInt64(0)
+		w.inner = nil
+	}
+}
+
+// IsLocked 是否已锁定
+func (w *UnlockedWallet) IsLocked() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.inner == nil
+}
+
+// ExpiresAt 自动锁定时间（零值表示不自动锁定）
+func (w *UnlockedWallet) ExpiresAt() time.Time {
+	return w.expiry
+}
+
+// Address 获取钱包地址
+func (w *UnlockedWallet) Address() []byte {
+	return w.address
+}
+
+// SignTransaction 签名交易
+func (w *UnlockedWallet) SignTransaction(tx []byte) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.inner == nil {
+		return nil, ErrWalletLocked
+	}
+	return w.inner.SignTransaction(tx)
+}
+
+// SignMessage 签名消息
+func (w *UnlockedWallet) SignMessage(msg []byte) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.inner == nil {
+		return nil, ErrWalletLocked
+	}
+	return w.inner.SignMessage(msg)
+}
+
+// SignHash 签名哈希值
+func (w *UnlockedWallet) SignHash(hash []byte) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.inner == nil {
+		return nil, ErrWalletLocked
+	}
+	return w.inner.SignHash(hash)
+}
+
+// SignHashRecoverable 签名哈希值，输出 r || s || v
+func (w *UnlockedWallet) SignHashRecoverable(hash []byte) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.inner == nil {
+		return nil, ErrWalletLocked
+	}
+	return w.inner.SignHashRecoverable(hash)
+}
+
+// PrivateKey 获取私钥（锁定后返回 nil）
+func (w *UnlockedWallet) PrivateKey() *ecdsa.PrivateKey {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.inner == nil {
+		return nil
+	}
+	return w.inner.PrivateKey()
+}
@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/weisyn/client-sdk-go/client"
+	"github.com/weisyn/client-sdk-go/utils"
+)
+
+// UnsignedDraft 待外部签名的草稿（可 JSON 序列化后交给离线签名方 / 硬件钱包）
+type UnsignedDraft struct {
+	Signer     string          `json:"signer"` // 签名方地址（hex）
+	Draft      json.RawMessage `json:"draft"`
+	UnsignedTx string          `json:"unsignedTx"`
+	Inputs     []UnsignedInput `json:"inputs"`
+}
+
+// UnsignedInput 待签名的输入及其签名哈希
+type UnsignedInput struct {
+	InputIndex  uint32 `json:"input_index"`
+	SighashType string `json:"sighash_type"`
+	Sighash     string `json:"sighash"` // hex
+}
+
+// DraftSignature 外部签名方返回的输入签名（字段与 wes_finalizeTransactionFromDraft 一致）
+type DraftSignature struct {
+	InputIndex  uint32 `json:"input_index"`
+	SighashType string `json:"sighash_type"`
+	Pubkey      string `json:"pubkey"`    // 0x 前缀压缩公钥
+	Signature   string `json:"signature"` // 0x 前缀 r || s
+}
+
+// UnsignedDraftError 签名步骤遇到观察钱包时返回的错误，携带可导出的未签名草稿
+//
+// errors.Is(err, ErrWatchOnly) 为 true；使用 AsUnsignedDraft 取出草稿。
+type UnsignedDraftError struct {
+	Draft *UnsignedDraft
+}
+
+func (e *UnsignedDraftError) Error() string {
+	return fmt.Sprintf("%v: draft requires external signature by %s", ErrWatchOnly, e.Draft.Signer)
+}
+
+// Unwrap 返回 ErrWatchOnly
+func (e *UnsignedDraftError) Unwrap() error {
+	return ErrWatchOnly
+}
+
+// AsUnsignedDraft 从错误链中取出未签名草稿
+func AsUnsignedDraft(err error) (*UnsignedDraft, bool) {
+	var e *UnsignedDraftError
+	if errors.As(err, &e) {
+		return e.Draft, true
+	}
+	return nil, false
+}
+
+// UnsignedTxError 对节点返回的原始未签名交易签名时（如合约调用）遇到观察钱包返回的错误
+//
+// errors.Is(err, ErrWatchOnly) 为 true；使用 AsUnsignedTx 取出未签名交易，交给外部签名方
+// 调用 SignTransaction 后自行提交。
+type UnsignedTxError struct {
+	Signer     string // 签名方地址（hex）
+	UnsignedTx string // 未签名交易（hex）
+}
+
+func (e *UnsignedTxError) Error() string {
+	return fmt.Sprintf("%v: transaction requires external signature by %s", ErrWatchOnly, e.Signer)
+}
+
+// Unwrap 返回 ErrWatchOnly
+func (e *UnsignedTxError) Unwrap() error {
+	return ErrWatchOnly
+}
+
+// AsUnsignedTx 从错误链中取出未签名交易
+func AsUnsignedTx(err error) (*UnsignedTxError, bool) {
+	var e *UnsignedTxError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// RequireTxSigner 在对原始未签名交易调用 SignTransaction 前检查钱包
+//
+// 观察钱包返回携带未签名交易的 *UnsignedTxError；其他钱包返回 nil
+// （策略钱包在 SignTransaction 中自行审查）。
+func RequireTxSigner(w Wallet, unsignedTx []byte) error {
+	if !IsWatchOnly(w) {
+		return nil
+	}
+	var signer []byte
+	if w != nil {
+		signer = w.Address()
+	}
+	return &UnsignedTxError{Signer: hex.EncodeToString(signer), UnsignedTx: hex.EncodeToString(unsignedTx)}
+}
+
+// DraftReviewer 签名前需要审查草稿的钱包（如 wallet/policy 的策略钱包）
+//
+// 各业务服务在计算签名哈希后、调用 SignHash 前经由 RequireSigner 调用 ReviewDraft；
+// 返回错误时放弃签名。
+type DraftReviewer interface {
+	ReviewDraft(draftJSON []byte, sighash *utils.DraftSighash, inputIndices []uint32) error
+}
+
+// RequireSigner 在签名前检查钱包
+//
+// 观察钱包返回携带未签名草稿的 *UnsignedDraftError；实现 DraftReviewer 的钱包先审查草稿。
+func RequireSigner(w Wallet, draftJSON []byte, sighash *utils.DraftSighash, inputIndices []uint32) error {
+	if r, ok := w.(DraftReviewer); ok {
+		return r.ReviewDraft(draftJSON, sighash, inputIndices)
+	}
+	if !IsWatchOnly(w) {
+		return nil
+	}
+	var signer []byte
+	if w != nil {
+		signer = w.Address()
+	}
+	return &UnsignedDraftError{Draft: NewUnsignedDraft(signer, draftJSON, sighash, inputIndices)}
+}
+
+// NewUnsignedDraft 由草稿及其签名哈希构造待外部签名的草稿
+func NewUnsignedDraft(signer []byte, draftJSON []byte, sighash *utils.DraftSighash, inputIndices []uint32) *UnsignedDraft {
+	d := &UnsignedDraft{
+		Signer:     hex.EncodeToString(signer),
+		Draft:      append(json.RawMessage(nil), draftJSON...),
+		UnsignedTx: sighash.UnsignedTx,
+	}
+	for _, idx := range inputIndices {
+		d.Inputs = append(d.Inputs, UnsignedInput{
+			InputIndex:  idx,
+			SighashType: sighash.SighashType.String(),
+			Sighash:     hex.EncodeToString(sighash.Hash(idx)),
+		})
+	}
+	return d
+}
+
+// Submit 使用外部签名方返回的签名 finalize 并提交草稿，返回交易哈希
+func (d *UnsignedDraft) Submit(ctx context.Context, c client.Client, signatures []DraftSignature) (string, error) {
+	signed := make(map[uint32]bool, len(signatures))
+	for _, sig := range signatures {
+		signed[sig.InputIndex] = true
+	}
+	for _, in := range d.Inputs {
+		if !signed[in.InputIndex] {
+			return "", fmt.Errorf("missing signature for input %d", in.InputIndex)
+		}
+	}
+
+	finalizeParams := map[string]interface{}{
+		"draft":      d.Draft,
+		"unsignedTx": d.UnsignedTx,
+		"signatures": signatures,
+	}
+	finalResult, err := c.Call(ctx, "wes_finalizeTransactionFromDraft", finalizeParams)
+	if err != nil {
+		return "", fmt.Errorf("finalize transaction from draft failed: %w", err)
+	}
+	finalMap, ok := finalResult.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from wes_finalizeTransactionFromDraft")
+	}
+	txHex, ok := finalMap["tx"].(string)
+	if !ok || txHex == "" {
+		return "", fmt.Errorf("missing tx in wes_finalizeTransactionFromDraft response")
+	}
+
+	sendResult, err := c.SendRawTransaction(ctx, txHex)
+	if err != nil {
+		return "", fmt.Errorf("send raw transaction failed: %w", err)
+	}
+	if !sendResult.Accepted {
+		return "", fmt.Errorf("transaction rejected: %s", sendResult.Reason)
+	}
+	return sendResult.TxHash, nil
+}
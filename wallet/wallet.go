@@ -2,14 +2,12 @@ package wallet
 
 import (
 	"crypto/ecdsa"
-	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"time"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
-	"golang.org/x/crypto/ripemd160"
 )
 
 // Wallet 钱包接口
@@ -20,12 +18,16 @@ type Wallet interface {
 	// SignTransaction 签名交易
 	SignTransaction(tx []byte) ([]byte, error)
 
-	// SignMessage 签名消息
+	// SignMessage 签名消息（带 MessagePrefix 前缀，不绑定链 ID），返回 65 字节可恢复签名
+	// 需要绑定链 ID 时使用 SignMessageForChain
 	SignMessage(msg []byte) ([]byte, error)
 
-	// SignHash 签名给定哈希（供高级调用方使用）
+	// SignHash 签名给定哈希（供高级调用方使用），返回 64 字节 r || s
 	SignHash(hash []byte) ([]byte, error)
 
+	// SignHashRecoverable 签名给定哈希，返回 65 字节 r || s || v，可用于恢复公钥
+	SignHashRecoverable(hash []byte) ([]byte, error)
+
 	// PrivateKey 获取私钥（谨慎使用）
 	PrivateKey() *ecdsa.PrivateKey
 }
@@ -100,31 +102,24 @@ func (w *SimpleWallet) SignTransaction(tx []byte) ([]byte, error) {
 }
 
 // SignHash 签名哈希值（参考 client/core/wallet/keystore.go）
+// 使用 RFC 6979 确定性 nonce，s 规范化为 low-S，输出 r || s (64字节)
 func (w *SimpleWallet) SignHash(hash []byte) ([]byte, error) {
-	// 使用ECDSA签名
-	r, s, err := ecdsa.Sign(rand.Reader, w.privateKey, hash)
+	sig, err := signHashRecoverable(w.privateKey, hash)
 	if err != nil {
-		return nil, fmt.Errorf("ecdsa sign: %w", err)
+		return nil, err
 	}
+	return sig[:SignatureLength], nil
+}
 
-	// 序列化签名: r || s (64字节)
-	// 确保r和s都是32字节（补齐前导零）
-	rBytes := make([]byte, 32)
-	sBytes := make([]byte, 32)
-	r.FillBytes(rBytes)
-	s.FillBytes(sBytes)
-
-	signature := append(rBytes, sBytes...)
-	return signature, nil
+// SignHashRecoverable 签名哈希值，输出 r || s || v (65字节)
+func (w *SimpleWallet) SignHashRecoverable(hash []byte) ([]byte, error) {
+	return signHashRecoverable(w.privateKey, hash)
 }
 
 // SignMessage 签名消息
+// 消息哈希带域分隔前缀（见 HashMessage），与交易签名哈希不可混用
 func (w *SimpleWallet) SignMessage(msg []byte) ([]byte, error) {
-	// 1. 计算消息哈希
-	hash := sha256.Sum256(msg)
-
-	// 2. 签名哈希
-	return w.SignHash(hash[:])
+	return SignMessageForChain(w, "", msg)
 }
 
 // PrivateKey 获取私钥
@@ -136,14 +131,8 @@ func (w *SimpleWallet) PrivateKey() *ecdsa.PrivateKey {
 // 使用 secp256k1 公钥的 HASH160(compressed_pubkey) 作为 20 字节地址
 // 与链上 AddressManager 的语义保持一致
 func deriveAddress(privateKey *ecdsa.PrivateKey) []byte {
-	// 压缩公钥
-	compressed := ethcrypto.CompressPubkey(&privateKey.PublicKey)
-
-	// 计算 HASH160(compressed_pubkey)
-	sha := sha256.Sum256(compressed)
-	r := ripemd160.New()
-	_, _ = r.Write(sha[:])
-	return r.Sum(nil) // 20 字节
+	// 计算 HASH160(compressed_pubkey)，20 字节
+	return hash160(ethcrypto.CompressPubkey(&privateKey.PublicKey))
 }
 
 // parsePrivateKey 解析私钥（参考 client/core/transfer/service.go）
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+)
+
+// ErrWatchOnly 观察钱包不持有私钥，无法签名
+var ErrWatchOnly = errors.New("watch-only wallet cannot sign")
+
+// WatchOnlyWallet 观察钱包：仅有地址（及可选的公钥），用于只读查询与构建待签名草稿
+//
+// 签名方法均返回 ErrWatchOnly，PrivateKey 返回 nil。各业务服务在签名步骤检测到观察钱包时
+// 返回 *UnsignedDraftError，可从中导出未签名草稿交给外部签名方。
+type WatchOnlyWallet struct {
+	address []byte
+	pubkey  []byte // 33 字节压缩公钥（可选）
+}
+
+// NewWatchOnlyWallet 从 20 字节地址创建观察钱包
+func NewWatchOnlyWallet(address []byte) (*WatchOnlyWallet, error) {
+	if len(address) != 20 {
+		return nil, fmt.Errorf("address must be 20 bytes, got %d", len(address))
+	}
+	return &WatchOnlyWallet{address: append([]byte(nil), address...)}, nil
+}
+
+// NewWatchOnlyWalletFromPubKey 从公钥（33 字节压缩或 65 字节未压缩）创建观察钱包
+func NewWatchOnlyWalletFromPubKey(pubkey []byte) (*WatchOnlyWallet, error) {
+	address, err := AddressFromPubKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := compressPubKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return &WatchOnlyWallet{address: address, pubkey: compressed}, nil
+}
+
+// IsWatchOnly 判断钱包是否为观察钱包（nil 视为观察钱包）
+func IsWatchOnly(w Wallet) bool {
+	if w == nil {
+		return true
+	}
+	_, ok := w.(*WatchOnlyWallet)
+	return ok
+}
+
+// Address 获取钱包地址
+func (w *WatchOnlyWallet) Address() []byte {
+	return w.address
+}
+
+// PublicKey 压缩公钥（仅从地址创建时为 nil）
+func (w *WatchOnlyWallet) PublicKey() []byte {
+	return w.pubkey
+}
+
+// SignTransaction 返回 ErrWatchOnly
+func (w *WatchOnlyWallet) SignTransaction(tx []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignMessage 返回 ErrWatchOnly
+func (w *WatchOnlyWallet) SignMessage(msg []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignHash 返回 ErrWatchOnly
+func (w *WatchOnlyWallet) SignHash(hash []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignHashRecoverable 返回 ErrWatchOnly
+func (w *WatchOnlyWallet) SignHashRecoverable(hash []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// PrivateKey 观察钱包没有私钥，返回 nil
+func (w *WatchOnlyWallet) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
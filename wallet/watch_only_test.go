@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestWatchOnlyWallet(t *testing.T) {
+	w, err := NewWalletFromPrivateKey("0x" + strings.Repeat("0", 62) + "01")
+	if err != nil {
+		t.Fatalf("NewWalletFromPrivateKey: %v", err)
+	}
+	pub := ethcrypto.FromECDSAPub(&w.PrivateKey().PublicKey)
+
+	watch, err := NewWatchOnlyWalletFromPubKey(pub)
+	if err != nil {
+		t.Fatalf("NewWatchOnlyWalletFromPubKey: %v", err)
+	}
+	if !bytes.Equal(watch.Address(), w.Address()) || len(watch.PublicKey()) != 33 {
+		t.Fatalf("watch-only wallet should derive the same address and a compressed pubkey")
+	}
+	if _, err := NewWatchOnlyWallet(w.Address()[:19]); err == nil {
+		t.Fatalf("expected error for short address")
+	}
+
+	var iface Wallet = watch
+	if !IsWatchOnly(iface) || IsWatchOnly(w) || !IsWatchOnly(nil) {
+		t.Fatalf("unexpected IsWatchOnly result")
+	}
+	if _, err := iface.SignHash(make([]byte, 32)); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("expected ErrWatchOnly, got %v", err)
+	}
+	if _, err := iface.SignTransaction([]byte{1}); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("expected ErrWatchOnly, got %v", err)
+	}
+	if iface.PrivateKey() != nil {
+		t.Fatalf("watch-only wallet must not expose a private key")
+	}
+}
+
+func TestRequireTxSigner(t *testing.T) {
+	w, _ := NewWalletFromPrivateKey("0x" + strings.Repeat("0", 62) + "01")
+	if err := RequireTxSigner(w, []byte{0x01}); err != nil {
+		t.Fatalf("signing wallet should pass, got %v", err)
+	}
+	watch, _ := NewWatchOnlyWallet(w.Address())
+	err := RequireTxSigner(watch, []byte{0xab, 0xcd})
+	if !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("expected ErrWatchOnly, got %v", err)
+	}
+	unsigned, ok := AsUnsignedTx(err)
+	if !ok || unsigned.UnsignedTx != "abcd" || unsigned.Signer != hex.EncodeToString(w.Address()) {
+		t.Fatalf("unexpected unsigned tx %+v", unsigned)
+	}
+}